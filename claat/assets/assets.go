@@ -0,0 +1,384 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package assets optimizes images of exported codelabs.
+//
+// PNG and JPEG images are recompressed, and those wider than
+// Options.MaxWidth are resized to 1x and 2x variants. Images of more than
+// MaxPixels pixels, and JPEG images rotated by an EXIF orientation tag,
+// are left intact. Variants in other
+// formats, WebP and AVIF, are converted with the cwebp and avifenc tools,
+// which are skipped with a warning if not installed.
+// Variants are recorded in types.ImageNode.Variants, from which renderers
+// emit responsive markup, such as <picture> and srcset in HTML.
+package assets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Defaults of Options.
+const (
+	DefaultMaxWidth    = 800
+	DefaultJPEGQuality = 85
+)
+
+// MaxPixels is the number of pixels of the largest image optimized,
+// bounding the memory used to decode it.
+const MaxPixels = 50000000
+
+// Formats are the alternate image formats, most efficient first,
+// keyed by name.
+var Formats = []*Format{
+	{Name: "avif", Type: "image/avif", Ext: ".avif", Tool: "avifenc", Args: []string{"--speed", "6", "{in}", "{out}"}},
+	{Name: "webp", Type: "image/webp", Ext: ".webp", Tool: "cwebp", Args: []string{"-quiet", "-q", "80", "{in}", "-o", "{out}"}},
+}
+
+// Format is an alternate image format, converted to with an external tool.
+type Format struct {
+	Name string   // Format name, e.g. "webp"
+	Type string   // MIME type
+	Ext  string   // File extension
+	Tool string   // Converting tool
+	Args []string // Tool arguments, where {in} and {out} are the input and output files
+}
+
+// LookupFormat returns the alternate format of name, or nil if unknown.
+func LookupFormat(name string) *Format {
+	for _, f := range Formats {
+		if f.Name == strings.ToLower(name) {
+			return f
+		}
+	}
+	return nil
+}
+
+// Options configure the optimization of images.
+type Options struct {
+	// MaxWidth is the width of 1x variants, in pixels: wider images are
+	// resized to this width, and to twice this width or their own for 2x.
+	// DefaultMaxWidth is used if zero.
+	MaxWidth int
+	// JPEGQuality is the quality of recompressed JPEG images, from 1 to 100.
+	// DefaultJPEGQuality is used if zero.
+	JPEGQuality int
+	// Formats are names of the alternate formats to convert images to,
+	// see LookupFormat.
+	Formats []string
+	// Warn, if not nil, is called with issues which do not fail
+	// the optimization, such as a missing converting tool.
+	Warn func(format string, args ...interface{})
+}
+
+// Optimize optimizes images of nodes stored in directory dir, with a Src
+// relative to dir, and sets their Variants. Other images, such as GIF, SVG,
+// remote or placeholder images, are left intact.
+// Variants are written alongside each image. Resized images are replaced
+// with their 1x variant, so that the full-size original is not published
+// and other references to it, such as a hero image, still resolve.
+func Optimize(dir string, nodes []*types.ImageNode, opts Options) error {
+	if opts.MaxWidth <= 0 {
+		opts.MaxWidth = DefaultMaxWidth
+	}
+	if opts.JPEGQuality <= 0 {
+		opts.JPEGQuality = DefaultJPEGQuality
+	}
+	var formats []*Format
+	for _, name := range opts.Formats {
+		f := LookupFormat(name)
+		if f == nil {
+			return fmt.Errorf("unknown image format %q", name)
+		}
+		if _, err := exec.LookPath(f.Tool); err != nil {
+			opts.warnf("%s not found; images are not converted to %s", f.Tool, f.Name)
+			continue
+		}
+		formats = append(formats, f)
+	}
+	// images used several times are optimized once
+	done := make(map[string]*optimized)
+	for _, n := range nodes {
+		if n.Src == "" || !isLocal(n.Src) || imageType(n.Src) == "" {
+			continue
+		}
+		o, ok := done[n.Src]
+		if !ok {
+			var err error
+			if o, err = optimize(dir, n.Src, formats, opts); err != nil {
+				return fmt.Errorf("%s: %v", n.Src, err)
+			}
+			done[n.Src] = o
+		}
+		if o == nil {
+			continue
+		}
+		n.Src = o.src
+		n.Variants = o.variants
+	}
+	return nil
+}
+
+// optimized is the result of optimizing an image.
+type optimized struct {
+	src      string
+	variants []*types.ImageVariant
+}
+
+// optimize optimizes image src of dir, converting it to formats.
+// It returns nil if the image is left intact.
+func optimize(dir, src string, formats []*Format, opts Options) (*optimized, error) {
+	name := filepath.Join(dir, src)
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if int64(c.Width)*int64(c.Height) > MaxPixels {
+		opts.warnf("%s: %dx%d image is too large to optimize", src, c.Width, c.Height)
+		return nil, nil
+	}
+	// decoded images lose their orientation tag, and would appear rotated
+	if o := jpegOrientation(b); o > 1 {
+		opts.warnf("%s: image with EXIF orientation %d is not optimized", src, o)
+		return nil, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	typ := imageType(src)
+	w := img.Bounds().Dx()
+
+	// sizes of the original format, 1x first
+	sizes := []*types.ImageVariant{{Src: src, Type: typ, Width: w}}
+	if w > opts.MaxWidth {
+		ext := filepath.Ext(src)
+		base := strings.TrimSuffix(src, ext)
+		w2 := w
+		if w2 > 2*opts.MaxWidth {
+			w2 = 2 * opts.MaxWidth
+		}
+		sizes = []*types.ImageVariant{
+			{Src: src, Type: typ, Width: opts.MaxWidth},
+			{Src: base + "@2x" + ext, Type: typ, Width: w2},
+		}
+	}
+	for _, v := range sizes {
+		m := img
+		if v.Width != w {
+			m = resize(img, v.Width)
+		}
+		if err := encode(filepath.Join(dir, v.Src), m, typ, b, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	var variants []*types.ImageVariant
+	for _, f := range formats {
+		for _, v := range sizes {
+			fv := &types.ImageVariant{Src: strings.TrimSuffix(v.Src, filepath.Ext(v.Src)) + f.Ext, Type: f.Type, Width: v.Width}
+			if err := convert(f, filepath.Join(dir, v.Src), filepath.Join(dir, fv.Src)); err != nil {
+				opts.warnf("%s: no %s variant: %v", src, f.Name, err)
+				break
+			}
+			variants = append(variants, fv)
+		}
+	}
+	return &optimized{src: sizes[0].Src, variants: append(variants, sizes...)}, nil
+}
+
+// encode writes image m of MIME type typ to file name, recompressed.
+// If m is the original image, of contents orig, the smallest of both
+// is written.
+func encode(name string, m image.Image, typ string, orig []byte, opts Options) error {
+	var buf bytes.Buffer
+	var err error
+	switch typ {
+	case "image/png":
+		enc := &png.Encoder{CompressionLevel: png.BestCompression}
+		err = enc.Encode(&buf, m)
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, m, &jpeg.Options{Quality: opts.JPEGQuality})
+	}
+	if err != nil {
+		return err
+	}
+	b := buf.Bytes()
+	if m.Bounds().Dx() == imageWidth(orig) && len(orig) <= len(b) {
+		b = orig
+	}
+	return ioutil.WriteFile(name, b, 0644)
+}
+
+// imageWidth returns the width of encoded image b, or -1 if unknown.
+func imageWidth(b []byte) int {
+	c, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return -1
+	}
+	return c.Width
+}
+
+// jpegOrientation returns the EXIF orientation tag of JPEG image b,
+// from 1 to 8, or 0 if b is not a JPEG image or has no such tag.
+func jpegOrientation(b []byte) int {
+	if len(b) < 2 || b[0] != 0xff || b[1] != 0xd8 {
+		return 0
+	}
+	b = b[2:]
+	// segments up to the image data
+	for len(b) >= 4 && b[0] == 0xff && b[1] != 0xda {
+		n := int(binary.BigEndian.Uint16(b[2:4]))
+		if n < 2 || len(b) < 2+n {
+			return 0
+		}
+		if seg := b[4 : 2+n]; b[1] == 0xe1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return exifOrientation(seg[6:])
+		}
+		b = b[2+n:]
+	}
+	return 0
+}
+
+// exifOrientation returns the orientation tag of the first IFD
+// of TIFF header t, of an EXIF segment, or 0 if none.
+func exifOrientation(t []byte) int {
+	if len(t) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	off := int(order.Uint32(t[4:8]))
+	if off < 8 || off+2 > len(t) {
+		return 0
+	}
+	n := int(order.Uint16(t[off:]))
+	for i := 0; i < n; i++ {
+		e := off + 2 + 12*i
+		if e+12 > len(t) {
+			return 0
+		}
+		if order.Uint16(t[e:]) == 0x0112 {
+			if o := int(order.Uint16(t[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// convert converts image file in to file out with the tool of format f.
+func convert(f *Format, in, out string) error {
+	r := strings.NewReplacer("{in}", in, "{out}", out)
+	args := make([]string, len(f.Args))
+	for i, a := range f.Args {
+		args[i] = r.Replace(a)
+	}
+	b, err := exec.Command(f.Tool, args...).CombinedOutput()
+	if err != nil {
+		if s := strings.TrimSpace(string(b)); s != "" {
+			return fmt.Errorf("%v: %s", err, s)
+		}
+		return err
+	}
+	if _, err := os.Stat(out); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resize scales m down to width w, keeping its aspect ratio,
+// averaging the source pixels covered by each destination pixel.
+func resize(m image.Image, w int) image.Image {
+	sb := m.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	h := sh * w / sw
+	if h < 1 {
+		h = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := sb.Min.Y+y*sh/h, sb.Min.Y+(y+1)*sh/h
+		if y1 == y0 {
+			y1++
+		}
+		for x := 0; x < w; x++ {
+			x0, x1 := sb.Min.X+x*sw/w, sb.Min.X+(x+1)*sw/w
+			if x1 == x0 {
+				x1++
+			}
+			// alpha-premultiplied, so that transparent pixels add no color
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := m.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// imageType returns the MIME type of optimized image file name,
+// or an empty string if it is not optimized.
+func imageType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png":
+		return "image/png"
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	}
+	return ""
+}
+
+// isLocal reports whether src is a path rather than a URL.
+func isLocal(src string) bool {
+	return !strings.Contains(src, ":")
+}
+
+func (opts Options) warnf(format string, args ...interface{}) {
+	if opts.Warn != nil {
+		opts.Warn(format, args...)
+	}
+}
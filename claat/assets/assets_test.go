@@ -0,0 +1,194 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func writePNG(t *testing.T, name string, w, h int) {
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			m.Set(x, y, color.RGBA{uint8(x), uint8(y), 0x80, 0xff})
+		}
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, m); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func imageSize(t *testing.T, name string) (int, int) {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	c, _, err := image.DecodeConfig(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.Width, c.Height
+}
+
+func TestOptimize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestOptimize-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "img"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writePNG(t, filepath.Join(dir, "img", "small.png"), 40, 20)
+	writePNG(t, filepath.Join(dir, "img", "wide.png"), 250, 100)
+	if err := ioutil.WriteFile(filepath.Join(dir, "img", "anim.gif"), []byte("GIF89a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	small := types.NewImageNode(filepath.Join("img", "small.png"))
+	wide := types.NewImageNode(filepath.Join("img", "wide.png"))
+	again := types.NewImageNode(filepath.Join("img", "wide.png"))
+	gif := types.NewImageNode(filepath.Join("img", "anim.gif"))
+	remote := types.NewImageNode("https://example.com/a.png")
+	nodes := []*types.ImageNode{small, wide, again, gif, remote}
+	if err := Optimize(dir, nodes, Options{MaxWidth: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*types.ImageVariant{{Src: filepath.Join("img", "small.png"), Type: "image/png", Width: 40}}
+	if !reflect.DeepEqual(small.Variants, want) {
+		t.Errorf("small.Variants = %+v; want %+v", small.Variants, want)
+	}
+	want = []*types.ImageVariant{
+		{Src: filepath.Join("img", "wide.png"), Type: "image/png", Width: 100},
+		{Src: filepath.Join("img", "wide@2x.png"), Type: "image/png", Width: 200},
+	}
+	for _, n := range []*types.ImageNode{wide, again} {
+		if n.Src != want[0].Src {
+			t.Errorf("n.Src = %q; want %q", n.Src, want[0].Src)
+		}
+		if !reflect.DeepEqual(n.Variants, want) {
+			t.Errorf("n.Variants = %+v; want %+v", n.Variants, want)
+		}
+	}
+	// the 1x variant replaces the original
+	if w, h := imageSize(t, filepath.Join(dir, "img", "wide.png")); w != 100 || h != 40 {
+		t.Errorf("wide.png is %dx%d; want 100x40", w, h)
+	}
+	if w, h := imageSize(t, filepath.Join(dir, "img", "wide@2x.png")); w != 200 || h != 80 {
+		t.Errorf("wide@2x.png is %dx%d; want 200x80", w, h)
+	}
+	if gif.Variants != nil || remote.Variants != nil {
+		t.Errorf("gif.Variants = %v, remote.Variants = %v; want none", gif.Variants, remote.Variants)
+	}
+}
+
+func TestOptimizeIntact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestOptimizeIntact-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// PNG header of a 100000x100000 image
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	binary.BigEndian.PutUint32(b[16:], 100000)
+	binary.BigEndian.PutUint32(b[20:], 100000)
+	binary.BigEndian.PutUint32(b[29:], crc32.ChecksumIEEE(b[12:29]))
+	if err := ioutil.WriteFile(filepath.Join(dir, "huge.png"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// JPEG image rotated by 90 degrees
+	buf.Reset()
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 200, 100)), nil); err != nil {
+		t.Fatal(err)
+	}
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+	app1 := append([]byte{0xff, 0xe1, 0, byte(len(exif) + 2)}, exif...)
+	b = append(append(buf.Bytes()[:2:2], app1...), buf.Bytes()[2:]...)
+	if o := jpegOrientation(b); o != 6 {
+		t.Errorf("jpegOrientation = %d; want 6", o)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "rotated.jpg"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings int
+	huge, rotated := types.NewImageNode("huge.png"), types.NewImageNode("rotated.jpg")
+	opts := Options{MaxWidth: 100, Warn: func(string, ...interface{}) { warnings++ }}
+	if err := Optimize(dir, []*types.ImageNode{huge, rotated}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if huge.Src != "huge.png" || huge.Variants != nil || rotated.Src != "rotated.jpg" || rotated.Variants != nil {
+		t.Errorf("images = %+v, %+v; want them intact", huge, rotated)
+	}
+	if warnings != 2 {
+		t.Errorf("warnings = %d; want 2", warnings)
+	}
+	if got, _ := ioutil.ReadFile(filepath.Join(dir, "rotated.jpg")); !bytes.Equal(got, b) {
+		t.Error("rotated.jpg is rewritten")
+	}
+}
+
+func TestOptimizeUnknownFormat(t *testing.T) {
+	if err := Optimize(".", nil, Options{Formats: []string{"heic"}}); err == nil {
+		t.Error("Optimize with format heic = nil error; want an error")
+	}
+}
+
+func TestResize(t *testing.T) {
+	m := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		// left half opaque white, right half transparent
+		if x < 2 {
+			m.Set(x, 0, color.White)
+			m.Set(x, 1, color.White)
+		}
+	}
+	r := resize(m, 2)
+	if b := r.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+		t.Fatalf("resize bounds = %v; want 2x1", b)
+	}
+	if c := color.RGBAModel.Convert(r.At(0, 0)); c != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("r.At(0, 0) = %v; want white", c)
+	}
+	if c := color.RGBAModel.Convert(r.At(1, 0)); c != (color.RGBA{}) {
+		t.Errorf("r.At(1, 0) = %v; want transparent", c)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// CmdExportOptions.A11yCheck values.
+const (
+	a11yCheckWarn = "warn"
+	a11yCheckFail = "fail"
+)
+
+// minContrast is the WCAG AA minimum contrast ratio of normal text.
+const minContrast = 4.5
+
+// a11yContrast maps theme color roles to the color of text or background
+// they are rendered against in the html format.
+var a11yContrast = map[string]struct{ against, desc string }{
+	"primary": {"#3c4043", "title bar text"},
+	"link":    {"#ffffff", "step background"},
+}
+
+// checkA11y audits clab, exported from src in the html format with theme t,
+// if requested by opts.A11yCheck. Each violation is logged.
+// It returns an error if some were found and opts.A11yCheck is "fail".
+func checkA11y(src string, clab *types.Codelab, t *render.Theme, opts CmdExportOptions) error {
+	if opts.A11yCheck == "" {
+		return nil
+	}
+	vv := a11yAudit(clab, t)
+	sink := opts.warnings.sink(src)
+	for _, v := range vv {
+		sink(v)
+	}
+	if len(vv) > 0 && opts.A11yCheck == a11yCheckFail {
+		return fmt.Errorf("%d accessibility violations", len(vv))
+	}
+	return nil
+}
+
+// a11yAudit returns accessibility violations of clab and theme t, which may
+// be nil: images with no alt text, empty or skipped heading levels, iframes
+// with no title and theme colors of insufficient contrast.
+// Node violations are in document order, at the source position of the node
+// or, if unknown, with the step number in the message. Theme ones follow.
+func a11yAudit(clab *types.Codelab, t *render.Theme) []*parser.Warning {
+	var vv []*parser.Warning
+	for i, st := range clab.Steps {
+		step := i + 1
+		add := func(n types.Node, format string, args ...interface{}) {
+			var pos types.Position
+			if n != nil {
+				pos = n.Pos()
+			}
+			msg := fmt.Sprintf(format, args...)
+			if !pos.IsValid() {
+				msg = fmt.Sprintf("step %d: %s", step, msg)
+			}
+			vv = append(vv, &parser.Warning{Pos: pos, Message: "a11y: " + msg})
+		}
+		if strings.TrimSpace(st.Title) == "" {
+			add(nil, "step has no title")
+		}
+		level := 2 // step titles are h2
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			switch n := n.(type) {
+			case *types.ImageNode:
+				if n.Alt == "" && n.Placeholder == "" {
+					add(n, "image %q has no alt text", n.Src)
+				}
+			case *types.IframeNode:
+				if strings.TrimSpace(n.Title) == "" {
+					add(n, "iframe of %s has no title", n.URL)
+				}
+			case *types.HeaderNode:
+				switch {
+				case n.Level <= 2:
+					add(n, "heading level %d is not below the step title, h2", n.Level)
+				case n.Level > level+1:
+					add(n, "heading level %d skips level %d", n.Level, level+1)
+				}
+				if n.Empty() {
+					add(n, "heading has no text")
+				}
+				level = n.Level
+			}
+			return true
+		})
+	}
+	if t == nil {
+		return vv
+	}
+	roles := make([]string, 0, len(a11yContrast))
+	for role := range a11yContrast {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		c, ok := parseColor(t.Colors[role])
+		if !ok {
+			continue
+		}
+		against := a11yContrast[role]
+		bg, _ := parseColor(against.against)
+		if r := contrastRatio(c, bg); r < minContrast {
+			vv = append(vv, &parser.Warning{Message: fmt.Sprintf(
+				"a11y: theme color %s %s has a contrast ratio of %.2f:1 with the %s, below %.1f:1",
+				role, t.Colors[role], r, against.desc, minContrast)})
+		}
+	}
+	return vv
+}
+
+// rgbColorRegexp matches rgb() and rgba() CSS colors, capturing their
+// red, green and blue components.
+var rgbColorRegexp = regexp.MustCompile(`^rgba?\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*(?:,[^)]*)?\)$`)
+
+// parseColor returns the red, green and blue components of CSS color s
+// in #rgb, #rrggbb or rgb() notation, with alpha, if any, ignored.
+// It returns false for other colors, such as named ones.
+func parseColor(s string) ([3]uint8, bool) {
+	var c [3]uint8
+	s = strings.ToLower(strings.TrimSpace(s))
+	if m := rgbColorRegexp.FindStringSubmatch(s); m != nil {
+		for i := range c {
+			v, err := strconv.Atoi(m[i+1])
+			if err != nil || v > 255 {
+				return c, false
+			}
+			c[i] = uint8(v)
+		}
+		return c, true
+	}
+	if !strings.HasPrefix(s, "#") {
+		return c, false
+	}
+	h := s[1:]
+	switch len(h) {
+	case 3, 4:
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	case 6, 8:
+		h = h[:6]
+	default:
+		return c, false
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return c, false
+	}
+	return [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+}
+
+// contrastRatio returns the WCAG contrast ratio of colors a and b,
+// from 1 to 21.
+func contrastRatio(a, b [3]uint8) float64 {
+	la, lb := luminance(a), luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// luminance returns the WCAG relative luminance of color c.
+func luminance(c [3]uint8) float64 {
+	var rgb [3]float64
+	for i, v := range c {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			rgb[i] = s / 12.92
+		} else {
+			rgb[i] = math.Pow((s+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2]
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestA11yAudit(t *testing.T) {
+	clab := types.NewCodelab()
+	st := clab.NewStep("One")
+	img := types.NewImageNode("cat.png")
+	img.MutatePos(types.Position{Line: 7, Column: 1})
+	alt := types.NewImageNode("dog.png")
+	alt.Alt = "A dog"
+	frame := types.NewIframeNode("https://example.com/demo")
+	titled := types.NewIframeNode("https://example.com/other")
+	titled.Title = "Other demo"
+	st.Content.Append(
+		img, alt, types.NewPlaceholderNode("login screen"),
+		types.NewHeaderNode(3, types.NewTextNode("Setup")),
+		types.NewHeaderNode(5, types.NewTextNode("Deep")),
+		frame, titled,
+	)
+	clab.NewStep(" ").Content.Append(types.NewHeaderNode(2, types.NewTextNode("Top")), types.NewHeaderNode(3))
+
+	theme := &render.Theme{Colors: map[string]string{"primary": "#fff", "link": "#ccc", "accent": "#eee"}}
+	var got []string
+	for _, w := range a11yAudit(clab, theme) {
+		got = append(got, w.String())
+	}
+	want := []string{
+		`7:1: a11y: image "cat.png" has no alt text`,
+		"a11y: step 1: heading level 5 skips level 4",
+		"a11y: step 1: iframe of https://example.com/demo has no title",
+		"a11y: step 2: step has no title",
+		"a11y: step 2: heading level 2 is not below the step title, h2",
+		"a11y: step 2: heading has no text",
+		"a11y: theme color link #ccc has a contrast ratio of 1.61:1 with the step background, below 4.5:1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("a11yAudit:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCheckA11y(t *testing.T) {
+	clab := types.NewCodelab()
+	clab.NewStep("One").Content.Append(types.NewImageNode("cat.png"))
+	if err := checkA11y("src", clab, nil, CmdExportOptions{}); err != nil {
+		t.Errorf("disabled: checkA11y = %v", err)
+	}
+	if err := checkA11y("src", clab, nil, CmdExportOptions{A11yCheck: a11yCheckWarn}); err != nil {
+		t.Errorf("warn: checkA11y = %v", err)
+	}
+	if err := checkA11y("src", clab, nil, CmdExportOptions{A11yCheck: a11yCheckFail}); err == nil {
+		t.Error("fail: checkA11y = nil; want error")
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"#000", "#ffffff", 21},
+		{"rgb(255, 255, 255)", "#FFF", 1},
+		{"#4F7DC9", "#3c4043", 2.55},
+		{"#1a73e8ff", "white", 0}, // named colors are not parsed
+	}
+	for _, test := range tests {
+		a, ok := parseColor(test.a)
+		b, ok2 := parseColor(test.b)
+		if !ok || !ok2 {
+			if test.want != 0 {
+				t.Errorf("parseColor(%q, %q) failed", test.a, test.b)
+			}
+			continue
+		}
+		if r := contrastRatio(a, b); math.Abs(r-test.want) > 0.01 {
+			t.Errorf("contrastRatio(%q, %q) = %.2f; want %.2f", test.a, test.b, r, test.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// writeBadges stores SVG badges of clab duration, last update date
+// and number of steps in dir, named badge-duration.svg, badge-updated.svg
+// and badge-steps.svg respectively.
+func writeBadges(dir string, clab *types.Codelab, ctx *types.Context) error {
+	d := time.Duration(clab.Duration) * time.Minute
+	if d == 0 {
+		for _, s := range clab.Steps {
+			d += s.Duration
+		}
+	}
+	badges := []struct{ name, label, value string }{
+		{"duration", "duration", fmt.Sprintf("%d min", int(d.Round(time.Minute)/time.Minute))},
+		{"updated", "updated", time.Time(*ctx.Updated).Format("2006-01-02")},
+		{"steps", "steps", fmt.Sprint(len(clab.Steps))},
+	}
+	for _, b := range badges {
+		var buf bytes.Buffer
+		if err := render.Badge(&buf, b.label, b.value, render.BadgeColor); err != nil {
+			return err
+		}
+		f := filepath.Join(dir, "badge-"+b.name+".svg")
+		if err := ioutil.WriteFile(f, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCodelabCanceled(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestExportCodelabCanceled-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Step\n\n![image](https://example.com/image.png)\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(tmp, "out")
+	dir := filepath.Join(out, "lab")
+
+	// interrupted while downloading images, after the codelab dir is created
+	export := func() error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, ctx.Err()
+		})
+		opts := CmdExportOptions{Context: ctx, Output: out, Tmplout: "html"}
+		_, err := ExportCodelab(src, rt, opts)
+		return err
+	}
+	if err := export(); err == nil {
+		t.Fatal("ExportCodelab = nil error; want a canceled export")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("canceled export left %s behind: %v", dir, err)
+	}
+
+	// a previous export is not removed
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := export(); err == nil {
+		t.Fatal("ExportCodelab = nil error; want a canceled export")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("canceled export removed the previous export: %v", err)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/schema"
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+// Options type to make the CmdCheckRender signature succinct.
+type CmdCheckRenderOptions struct {
+	// AuthToken is the token to use for the Drive API.
+	AuthToken string
+	// Expenv is the codelab environment to render.
+	Expenv string
+	// ExtraVars is extra template variables.
+	ExtraVars map[string]string
+	// MDParser is the underlying Markdown parser to use.
+	MDParser parser.MarkdownParser
+	// PassMetadata are the extra metadata fields to pass along.
+	PassMetadata map[string]bool
+	// ServiceAccount is a service account JSON key file to use for the Drive API.
+	ServiceAccount string
+	// Srcs is the sources to check.
+	Srcs []string
+	// Vars are values of {{var "key"}} references in codelab sources.
+	Vars map[string]string
+}
+
+// CmdCheckRender is the "claat check-render ..." subcommand.
+// It renders each source to all known formats and reports content
+// missing from any of them.
+// It returns a process exit code, which is non-zero if at least
+// one format loses content or a source could not be processed.
+func CmdCheckRender(opts CmdCheckRenderOptions) int {
+	if len(opts.Srcs) == 0 {
+		logging.Fatalf("Need at least one source. Try '-h' for options.")
+	}
+	po := CmdExportOptions{
+		MDParser:     opts.MDParser,
+		PassMetadata: opts.PassMetadata,
+		Vars:         opts.Vars,
+	}.parserOptions()
+	f, err := fetch.NewFetcher(opts.AuthToken, po, nil, authOptions(opts.ServiceAccount)...)
+	if err != nil {
+		logging.Fatalf("%v", err)
+	}
+	var exitCode int
+	for _, src := range util.Unique(opts.Srcs) {
+		var id string
+		slurp := func() (*types.Codelab, error) {
+			clab, err := f.SlurpCodelab(src)
+			if err != nil {
+				return nil, err
+			}
+			id = clab.ID
+			return clab.Codelab, nil
+		}
+		issues, err := checkRender(slurp, render.Formats(), opts)
+		if err != nil {
+			logging.Errorf(reportErr, src, err)
+			exitCode = 1
+			continue
+		}
+		log, prefix := sourceLogger(src, types.Position{})
+		for _, is := range issues {
+			log.Warnf("%s%s", prefix, is)
+		}
+		if len(issues) > 0 {
+			exitCode = 1
+			continue
+		}
+		logging.Infof(reportOk, id)
+	}
+	return exitCode
+}
+
+// renderIssue is content of a codelab missing from a rendered format,
+// or rendered though excluded from the environment.
+type renderIssue struct {
+	Format string
+	Probe  renderProbe
+	Extra  bool // Probe is content of another environment
+}
+
+func (is *renderIssue) String() string {
+	if is.Extra {
+		return fmt.Sprintf("%s: %s %q of another environment", is.Format, is.Probe.Kind, is.Probe.Values[0])
+	}
+	return fmt.Sprintf("%s: missing %s %q", is.Format, is.Probe.Kind, is.Probe.Values[0])
+}
+
+// renderProbe is a piece of codelab content expected in every format.
+// The probe is found if output contains any of its values.
+type renderProbe struct {
+	Kind   string
+	Values []string
+	// Folded probes are compared on letters and digits only, see fold,
+	// since formats wrap and mark up text differently.
+	Folded bool
+}
+
+// checkRender renders the codelab returned by parse in each of formats
+// and looks for content of steps, links and block nodes in the outputs,
+// as well as titles of steps excluded from the environment.
+// Formats which require a single step are rendered once per step.
+// Renderers may mutate nodes, so the codelab is parsed again for each format.
+func checkRender(parse func() (*types.Codelab, error), formats []string, opts CmdCheckRenderOptions) ([]*renderIssue, error) {
+	var (
+		probes []renderProbe
+		others []renderProbe
+		issues []*renderIssue
+	)
+	for i, format := range formats {
+		clab, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			probes, others = renderProbes(clab, opts.Expenv)
+		}
+		var buf bytes.Buffer
+		if err := renderPages(&buf, clab, format, opts); err != nil {
+			return nil, fmt.Errorf("%s: %v", format, err)
+		}
+		out := renderedText(buf.Bytes())
+		folded := fold(out)
+		for _, p := range probes {
+			if !p.foundIn(out, folded) {
+				issues = append(issues, &renderIssue{Format: format, Probe: p})
+			}
+		}
+		for _, p := range others {
+			if p.foundIn(out, folded) {
+				issues = append(issues, &renderIssue{Format: format, Probe: p, Extra: true})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// renderPages writes all pages of clab rendered in format to w,
+// the way writeCodelab stores them on disk.
+func renderPages(w *bytes.Buffer, clab *types.Codelab, format string, opts CmdCheckRenderOptions) error {
+	data := &struct {
+		render.Context
+		Current *types.Step
+		StepNum int
+		Prev    bool
+		Next    bool
+	}{Context: render.Context{
+		Env:    opts.Expenv,
+		Format: format,
+		Meta:   &clab.Meta,
+		Steps:  clab.Steps,
+		Extra:  opts.ExtraVars,
+		Schema: schema.Latest, // json v1 is metadata only
+	}}
+	switch format {
+	case "obsidian":
+		var steps []*types.Step
+		for _, st := range clab.Steps {
+			if stepInEnv(st, opts.Expenv) {
+				steps = append(steps, st)
+			}
+		}
+		data.Steps = steps
+		for i := 0; i <= len(steps); i++ {
+			data.Current = nil
+			if i > 0 {
+				data.Current = steps[i-1]
+			}
+			data.StepNum = i
+			data.Prev = i > 1
+			data.Next = i > 0 && i < len(steps)
+			if err := render.Execute(w, format, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "offline":
+		for i, st := range clab.Steps {
+			data.Current = st
+			data.StepNum = i + 1
+			data.Prev = i > 0
+			data.Next = i < len(clab.Steps)-1
+			if err := render.Execute(w, format, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return render.Execute(w, format, data)
+}
+
+// ansiRegexp matches terminal escape sequences of the term format.
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// pdfTextRegexp matches text operators of PDF content streams,
+// capturing the shown string.
+var pdfTextRegexp = regexp.MustCompile(`\(((?:[^()\\]|\\.)*)\) Tj`)
+
+// renderedText returns the text of rendered output b to look for probes in.
+// Zip archives, such as docx, are searched in all of their files,
+// and PDF documents also in the strings of their text operators.
+func renderedText(b []byte) string {
+	if bytes.HasPrefix(b, []byte("PK\x03\x04")) {
+		if zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b))); err == nil {
+			var all bytes.Buffer
+			for _, zf := range zr.File {
+				r, err := zf.Open()
+				if err != nil {
+					continue
+				}
+				c, _ := ioutil.ReadAll(r)
+				r.Close()
+				all.Write(c)
+			}
+			b = all.Bytes()
+		}
+	}
+	if bytes.HasPrefix(b, []byte("%PDF-")) {
+		all := []rune(string(b)) // link annotations
+		for _, m := range pdfTextRegexp.FindAllSubmatch(b, -1) {
+			for i := 0; i < len(m[1]); i++ {
+				if m[1][i] == '\\' && i+1 < len(m[1]) {
+					i++
+				}
+				all = append(all, rune(m[1][i])) // Latin-1 part of Windows-1252
+			}
+			all = append(all, '\n')
+		}
+		return string(all)
+	}
+	return html.UnescapeString(ansiRegexp.ReplaceAllString(string(b), ""))
+}
+
+// fold returns the letters and digits of s, lowercased.
+func fold(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// foundIn reports whether rendered text s, folded to folded, contains p.
+func (p renderProbe) foundIn(s, folded string) bool {
+	if p.Folded {
+		s = folded
+	}
+	for _, v := range p.Values {
+		if p.Folded {
+			v = fold(v)
+		}
+		if strings.Contains(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderProbes collects content of clab expected in every format:
+// step titles, absolute link URLs, code blocks, headers, images and
+// text of paragraphs, list items and table cells. Steps and nodes
+// excluded from the env environment are skipped, and the titles of
+// such steps returned as others, which no format is expected to contain,
+// unless found in the content of the environment.
+func renderProbes(clab *types.Codelab, env string) (probes, others []renderProbe) {
+	add := func(kind string, values ...string) {
+		var nonEmpty []string
+		for _, v := range values {
+			if v != "" {
+				nonEmpty = append(nonEmpty, v)
+			}
+		}
+		if len(nonEmpty) > 0 {
+			probes = append(probes, renderProbe{Kind: kind, Values: nonEmpty})
+		}
+	}
+	var content []string // text of steps of env
+	for _, st := range clab.Steps {
+		if !stepInEnv(st, env) {
+			continue
+		}
+		content = append(content, st.Title, render.PlainText(st.Content.Nodes))
+		add("step", st.Title)
+		kinds := make(map[*types.TextNode]string) // of text in lists and tables
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			if !inEnv(n, env) {
+				return false
+			}
+			switch n := n.(type) {
+			case *types.URLNode:
+				if u, err := url.Parse(n.URL); err == nil && u.IsAbs() {
+					add("link", n.URL)
+				}
+			case *types.CodeNode:
+				add("code", firstLine(n.Value))
+			case *types.HeaderNode:
+				add("header", render.PlainText(n.Content.Nodes))
+			case *types.ImageNode:
+				if n.Placeholder != "" {
+					add("image", n.Placeholder)
+					break
+				}
+				// text formats show images by their alt text
+				add("image", n.Src, n.Alt, path.Base(n.Src))
+			case *types.ItemsListNode:
+				for _, it := range n.Items {
+					markText(kinds, it.Nodes, "list item")
+				}
+			case *types.GridNode:
+				for _, r := range n.Rows {
+					for _, c := range r {
+						markText(kinds, c.Content.Nodes, "table cell")
+					}
+				}
+			case *types.TextNode:
+				if fold(n.Value) == "" {
+					break
+				}
+				kind := kinds[n]
+				if kind == "" {
+					kind = "text"
+				}
+				probes = append(probes, renderProbe{Kind: kind, Values: []string{n.Value}, Folded: true})
+			}
+			return true
+		})
+	}
+	all := fold(strings.Join(content, "\n"))
+	for _, st := range clab.Steps {
+		if !stepInEnv(st, env) && fold(st.Title) != "" && !strings.Contains(all, fold(st.Title)) {
+			others = append(others, renderProbe{Kind: "step", Values: []string{st.Title}, Folded: true})
+		}
+	}
+	return probes, others
+}
+
+// markText maps text nodes of nodes to kind, overriding any kind
+// of an enclosing list or table.
+func markText(kinds map[*types.TextNode]string, nodes []types.Node, kind string) {
+	types.Walk(nodes, func(n types.Node) bool {
+		if t, ok := n.(*types.TextNode); ok {
+			kinds[t] = kind
+		}
+		return true
+	})
+}
+
+// inEnv reports whether node n is rendered in environment env.
+func inEnv(n types.Node, env string) bool {
+	e := n.Env()
+	if len(e) == 0 || env == "" {
+		return true
+	}
+	i := sort.SearchStrings(e, env)
+	return i < len(e) && e[i] == env
+}
+
+// firstLine returns the first non-blank line of s, without surrounding space.
+func firstLine(s string) string {
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			return l
+		}
+	}
+	return ""
+}
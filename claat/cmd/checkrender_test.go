@@ -0,0 +1,96 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// titlesRenderer renders step titles only, dropping all content.
+type titlesRenderer struct{}
+
+func (titlesRenderer) Render(w io.Writer, ctx *render.Context) error {
+	for _, st := range ctx.Steps {
+		if _, err := fmt.Fprintln(w, st.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (titlesRenderer) Ext() string { return "txt" }
+
+func TestCheckRender(t *testing.T) {
+	render.Register("test-titles", titlesRenderer{})
+
+	var parsed []*types.Codelab
+	parse := func() (*types.Codelab, error) {
+		clab := types.NewCodelab()
+		clab.Title = "Lab"
+		st := clab.NewStep("Setup & run")
+		st.Content.Append(types.NewTextNode("See "))
+		st.Content.Append(types.NewURLNode("https://example.com/?a=1&b=2", types.NewTextNode("docs")))
+		st.Content.Append(types.NewCodeNode("\n  go run .\n", false, "go"))
+		web := types.NewCodeNode("web only", false, "")
+		web.MutateEnv([]string{"web"})
+		st.Content.Append(web)
+		list := types.NewItemsListNode("", 0)
+		list.NewItem().Append(types.NewTextNode("Install the SDK"))
+		st.Content.Append(list)
+		st.Content.Append(types.NewGridNode([]*types.GridCell{
+			{Content: types.NewListNode(types.NewTextNode("Region"))},
+			{Content: types.NewListNode(types.NewTextNode("europe-west1"))},
+		}))
+		other := clab.NewStep("Deploy to the web")
+		other.Tags = []string{"web"}
+		other.Content.Append(types.NewTextNode("Run firebase deploy."))
+		parsed = append(parsed, clab)
+		return clab, nil
+	}
+
+	opts := CmdCheckRenderOptions{Expenv: "kiosk"}
+	formats := []string{"html", "md", "text", "docx", "term", "pdf", "obsidian", "test-titles"}
+	issues, err := checkRender(parse, formats, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// renderers may mutate nodes, so each format gets its own codelab
+	if len(parsed) != len(formats) {
+		t.Errorf("parsed %d times; want %d", len(parsed), len(formats))
+	}
+	var got []string
+	for _, is := range issues {
+		got = append(got, is.String())
+	}
+	want := []string{
+		`test-titles: missing text "See "`,
+		`test-titles: missing link "https://example.com/?a=1&b=2"`,
+		`test-titles: missing text "docs"`,
+		`test-titles: missing code "go run ."`,
+		`test-titles: missing list item "Install the SDK"`,
+		`test-titles: missing table cell "Region"`,
+		`test-titles: missing table cell "europe-west1"`,
+		`test-titles: step "Deploy to the web" of another environment`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkRender = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+	"gopkg.in/yaml.v2"
+)
+
+// CmdCourseOptions type to make the CmdCourse signature succinct.
+type CmdCourseOptions struct {
+	// Export configures exporting the codelabs of the course.
+	// Its Output is the directory of the codelabs and the landing page,
+	// and its Srcs are replaced by the sources of the manifest.
+	Export CmdExportOptions
+	// Manifest is the course manifest file.
+	Manifest string
+}
+
+// courseManifest is a course manifest file, such as:
+//
+//	id: go-course
+//	title: Go from zero
+//	summary: Write, test and ship your first Go program.
+//	codelabs:
+//	  - go-basics.md
+//	  - go-testing.md
+//
+// Local codelab sources are relative to the directory of the manifest,
+// other sources are Google Doc IDs or URLs.
+type courseManifest struct {
+	ID       string   `yaml:"id"`
+	Title    string   `yaml:"title"`
+	Summary  string   `yaml:"summary,omitempty"`
+	Codelabs []string `yaml:"codelabs"`
+}
+
+// courseEntry is a codelab of a course, in order.
+type courseEntry struct {
+	Src   string
+	Meta  *types.Meta
+	Start int // minutes of the course before this codelab
+}
+
+// courseNav is what links a codelab to the other codelabs of its course.
+type courseNav struct {
+	ID, Title  string       // course
+	Prev, Next *courseEntry // adjacent codelabs, if any
+}
+
+// fetchedCodelab is a codelab fetched and parsed ahead of its export,
+// such as the codelabs of a course, which are linked by their metadata.
+type fetchedCodelab struct {
+	*types.Codelab
+	Mod       time.Time     // last modified timestamp
+	Rev       string        // source revision, if known
+	ParseTime time.Duration // time spent parsing the source
+	Warnings  []string      // parser warnings, logged as the source was parsed
+}
+
+// CmdCourse is the "claat course" subcommand with action "build".
+//
+// Build exports the codelabs of a course manifest, in order, with links
+// to the previous and next codelabs and to the course appended to their
+// last step, and writes a landing page listing them with cumulative
+// durations to the course ID directory of the output.
+// Codelabs are fetched once, before the export, and the course ID
+// must not be that of a codelab directory.
+//
+// It returns a process exit code.
+func CmdCourse(action string, opts CmdCourseOptions) int {
+	if action != "build" {
+		logging.Errorf("course: unknown action %q; want build", action)
+		return 1
+	}
+	if opts.Manifest == "" {
+		logging.Errorf("course build: missing course manifest")
+		return 1
+	}
+	if isStdout(opts.Export.Output) {
+		logging.Errorf("course build: need an output directory")
+		return 1
+	}
+	man, err := readCourseManifest(opts.Manifest)
+	if err != nil {
+		logging.Errorf("course build: %v", err)
+		return 1
+	}
+
+	var (
+		exitCode int
+		entries  []*courseEntry
+		start    int
+	)
+	fetched := make(map[string]*fetchedCodelab, len(man.Codelabs))
+	for _, src := range man.Codelabs {
+		clab, err := fetchCodelab(src, opts.Export)
+		if err != nil {
+			logging.Errorf(reportErr, errSource(src, err), err)
+			exitCode = 1
+			continue
+		}
+		if clab.ID == man.ID {
+			logging.Errorf("course build: course ID %q is also the ID of codelab %s", man.ID, src)
+			return 1
+		}
+		fetched[src] = clab
+		entries = append(entries, &courseEntry{Src: src, Meta: &clab.Meta, Start: start})
+		start += clab.Duration
+	}
+	dir := filepath.Join(opts.Export.Output, man.ID)
+	if _, err := os.Stat(filepath.Join(dir, metaFilename)); err == nil {
+		logging.Errorf("course build: course ID %q is the directory of codelab %s", man.ID, dir)
+		return 1
+	}
+	navs := make(map[string]*courseNav, len(entries))
+	srcs := make([]string, len(entries))
+	for i, e := range entries {
+		nav := &courseNav{ID: man.ID, Title: man.Title}
+		if i > 0 {
+			nav.Prev = entries[i-1]
+		}
+		if i < len(entries)-1 {
+			nav.Next = entries[i+1]
+		}
+		navs[e.Src] = nav
+		srcs[i] = e.Src
+	}
+	if len(srcs) > 0 {
+		opts.Export.Srcs = srcs
+		opts.Export.course = navs
+		opts.Export.fetched = fetched
+		if CmdExport(opts.Export) != 0 {
+			exitCode = 1
+		}
+	}
+
+	if err := writeCourseIndex(dir, man, entries, start); err != nil {
+		logging.Errorf(reportErr, man.ID, err)
+		return 1
+	}
+	logging.Infof(reportOk, man.ID)
+	return exitCode
+}
+
+// readCourseManifest reads and validates the course manifest file name,
+// resolving local codelab sources against its directory.
+func readCourseManifest(name string) (*courseManifest, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	man := &courseManifest{}
+	if err := yaml.UnmarshalStrict(b, man); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if !types.ValidID(man.ID) {
+		return nil, fmt.Errorf("%s: invalid course ID %q: want lowercase letters and digits separated by - or _", name, man.ID)
+	}
+	if man.Title == "" {
+		man.Title = man.ID
+	}
+	if len(man.Codelabs) == 0 {
+		return nil, fmt.Errorf("%s: no codelabs", name)
+	}
+	for i, src := range man.Codelabs {
+		if filepath.IsAbs(src) {
+			continue
+		}
+		// Sources which are not local files are Drive docs or URLs.
+		p := filepath.Join(filepath.Dir(name), src)
+		if _, err := os.Stat(p); err == nil {
+			man.Codelabs[i] = p
+		}
+	}
+	return man, nil
+}
+
+// fetchCodelab fetches and parses codelab src for its export with opts.
+func fetchCodelab(src string, opts CmdExportOptions) (*fetchedCodelab, error) {
+	w := &warnings{}
+	po := opts.parserOptions()
+	po.WarningSink = w.sink(src)
+	f, err := opts.newFetcher(po, nil, logging.With("src", src))
+	if err != nil {
+		return nil, err
+	}
+	clab, err := f.SlurpCodelab(src)
+	if err != nil {
+		return nil, err
+	}
+	return &fetchedCodelab{
+		Codelab:   clab.Codelab,
+		Mod:       clab.Mod,
+		Rev:       clab.Rev,
+		ParseTime: clab.ParseTime,
+		Warnings:  w.list,
+	}, nil
+}
+
+// addCourseNav appends links to the course of nav and to the previous
+// and next codelabs of the course to the last step of clab,
+// labeled in locale lang.
+// It does nothing if nav is nil or clab has no steps.
+func addCourseNav(clab *types.Codelab, nav *courseNav, lang string) {
+	if nav == nil || len(clab.Steps) == 0 {
+		return
+	}
+	para := func(nodes ...types.Node) *types.ListNode {
+		p := types.NewListNode()
+		for _, n := range nodes {
+			if t, ok := n.(*types.TextNode); !ok || t.Value != "" {
+				p.Append(n)
+			}
+		}
+		p.MutateBlock(true)
+		return p
+	}
+	link := func(title, id string) *types.URLNode {
+		return types.NewURLNode("../"+id+"/", types.NewTextNode(title))
+	}
+	st := clab.Steps[len(clab.Steps)-1]
+	// the course title is linked in place of {course}
+	before, after := render.Msg(lang, render.MsgCourse), ""
+	if i := strings.Index(before, "{course}"); i >= 0 {
+		before, after = before[:i], before[i+len("{course}"):]
+	}
+	st.Content.Append(para(types.NewTextNode(before), link(nav.Title, nav.ID), types.NewTextNode(after)))
+	if e := nav.Prev; e != nil {
+		label := render.Msg(lang, render.MsgPreviousCodelab) + ": "
+		st.Content.Append(para(types.NewTextNode(label), link(e.Meta.Title, e.Meta.ID)))
+	}
+	if e := nav.Next; e != nil {
+		label := render.Msg(lang, render.MsgNextCodelab) + ": "
+		st.Content.Append(para(types.NewTextNode(label), link(e.Meta.Title, e.Meta.ID)))
+	}
+}
+
+// courseIndexTemplate is the landing page of a course: its codelabs
+// in order, with their durations and the time into the course they start.
+var courseIndexTemplate = template.Must(template.New("course").Funcs(template.FuncMap{
+	"inc": func(n int) int { return n + 1 },
+}).Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Roboto, Arial, sans-serif; margin: 0; background: #f5f5f5; color: #212121; }
+header { background: #4F7DC9; color: #fff; padding: 16px 24px; }
+header h1 { margin: 0; font-weight: 400; }
+header p { margin: 8px 0 0; }
+main { max-width: 800px; margin: 0 auto; padding: 16px; }
+ol { list-style: none; padding: 0; }
+li { background: #fff; margin: 8px 0; padding: 16px; border-radius: 2px; box-shadow: 0 1px 3px rgba(0,0,0,.3); }
+li h2 { font-size: 18px; margin: 0 0 8px; }
+li a { color: #4F7DC9; text-decoration: none; }
+li p { margin: 0 0 8px; }
+.meta { font-size: 13px; color: #757575; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+{{with .Summary}}<p>{{.}}</p>{{end}}
+<p>{{len .Entries}} codelabs &middot; {{.Duration}} min</p>
+</header>
+<main>
+<ol>
+{{range $i, $e := .Entries}}<li>
+<h2>{{inc $i}}. <a href="../{{.Meta.ID}}/">{{.Meta.Title}}</a></h2>
+{{with .Meta.Summary}}<p>{{.}}</p>{{end}}
+<div class="meta">{{.Meta.Duration}} min &middot; starts at {{.Start}} of {{$.Duration}} min</div>
+<div class="meta progress" data-progress="{{.Meta.ID}}" hidden></div>
+</li>
+{{end}}</ol>
+</main>
+` + progressIndexScript + `
+</body>
+</html>
+`))
+
+// writeCourseIndex writes the landing page of course man, with entries
+// totalling duration minutes, to index.html of dir.
+func writeCourseIndex(dir string, man *courseManifest, entries []*courseEntry, duration int) error {
+	data := struct {
+		Title, Summary string
+		Entries        []*courseEntry
+		Duration       int
+	}{man.Title, man.Summary, entries, duration}
+	var b bytes.Buffer
+	if err := courseIndexTemplate.Execute(&b, data); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "index.html"), b.Bytes(), 0644)
+}
@@ -0,0 +1,163 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdCourse(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdCourse-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"course/course.yaml": "id: go-course\ntitle: Go from zero\ncodelabs:\n  - ../labs/a.md\n  - ../labs/b.md\n  - ../labs/c.md\n",
+		"labs/a.md":          "id: lab-a\n\n# Basics\n\n## Step\nDuration: 10:00\n\nText.\n",
+		"labs/b.md":          "id: lab-b\n\n# Testing\n\n## Step\nDuration: 20:00\n\nText.\n",
+		"labs/c.md":          "id: lab-c\n\n# Shipping\n\n## One\nDuration: 5:00\n\nText.\n\n## Two\nDuration: 5:00\n\nDone.\n",
+	}
+	for name, content := range files {
+		f := path.Join(tmp, name)
+		if err := os.MkdirAll(path.Dir(f), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdCourse("build", cmd.CmdCourseOptions{
+		Export:   cmd.CmdExportOptions{Output: out, Tmplout: "md"},
+		Manifest: path.Join(tmp, "course/course.yaml"),
+	})
+	if code != 0 {
+		t.Fatalf("CmdCourse = %d; want 0", code)
+	}
+
+	tests := []struct {
+		file       string
+		want, miss []string
+	}{
+		{
+			file: "lab-a/index.md",
+			want: []string{"[Go from zero](../go-course/)", "Next codelab:", "[Testing](../lab-b/)"},
+			miss: []string{"Previous codelab"},
+		},
+		{
+			file: "lab-c/index.md",
+			want: []string{"Previous codelab:", "[Testing](../lab-b/)"},
+			miss: []string{"Next codelab"},
+		},
+		{
+			file: "go-course/index.html",
+			want: []string{
+				"<title>Go from zero</title>",
+				"3 codelabs &middot; 40 min",
+				`<h2>2. <a href="../lab-b/">Testing</a></h2>`,
+				"20 min &middot; starts at 10 of 40 min",
+				`<div class="meta progress" data-progress="lab-b" hidden></div>`,
+				`localStorage.getItem("claat-progress-" + els[i].dataset.progress)`,
+			},
+		},
+	}
+	for _, test := range tests {
+		b, err := ioutil.ReadFile(path.Join(out, test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range test.want {
+			if !strings.Contains(string(b), s) {
+				t.Errorf("%s does not contain %q:\n%s", test.file, s, b)
+			}
+		}
+		for _, s := range test.miss {
+			if strings.Contains(string(b), s) {
+				t.Errorf("%s contains %q", test.file, s)
+			}
+		}
+	}
+	b, err := ioutil.ReadFile(path.Join(out, "lab-c/index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i := strings.Index(string(b), "Previous codelab"); i < strings.Index(string(b), "Done.") {
+		t.Errorf("course links are not in the last step:\n%s", b)
+	}
+
+	// links are labeled in the locale of the viewer chrome
+	out = path.Join(tmp, "out-fr")
+	code = cmd.CmdCourse("build", cmd.CmdCourseOptions{
+		Export:   cmd.CmdExportOptions{Output: out, Tmplout: "md", Locale: "fr"},
+		Manifest: path.Join(tmp, "course/course.yaml"),
+	})
+	if code != 0 {
+		t.Fatalf("CmdCourse(fr) = %d; want 0", code)
+	}
+	b, err = ioutil.ReadFile(path.Join(out, "lab-a/index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"Cet atelier fait partie du cours", "Atelier suivant:"} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("lab-a/index.md does not contain %q:\n%s", s, b)
+		}
+	}
+}
+
+func TestCmdCourseErrors(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdCourseErrors-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	if err := ioutil.WriteFile(path.Join(tmp, "a.md"), []byte("id: lab-a\n\n# A\n\n## Step\n\nText.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"bad-id.yaml":  "id: Go Course\ncodelabs: [a.md]\n",
+		"empty.yaml":   "id: go-course\n",
+		"unknown.yaml": "id: go-course\ncodelabs: [a.md]\nauthor: me\n",
+		"codelab.yaml": "id: lab-a\ncodelabs: [a.md]\n",
+	} {
+		f := path.Join(tmp, name)
+		if err := ioutil.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		opts := cmd.CmdCourseOptions{Export: cmd.CmdExportOptions{Output: tmp}, Manifest: f}
+		if code := cmd.CmdCourse("build", opts); code == 0 {
+			t.Errorf("%s: CmdCourse = 0; want 1", name)
+		}
+	}
+	if _, err := os.Stat(path.Join(tmp, "lab-a")); !os.IsNotExist(err) {
+		t.Errorf("codelab of a course of the same ID is exported: %v", err)
+	}
+	if code := cmd.CmdCourse("publish", cmd.CmdCourseOptions{Manifest: "course.yaml"}); code == 0 {
+		t.Error("unknown action: CmdCourse = 0; want 1")
+	}
+}
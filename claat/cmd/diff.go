@@ -0,0 +1,139 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/schema"
+)
+
+// CmdDiffOptions type to make the CmdDiff signature succinct.
+type CmdDiffOptions struct {
+	// Expenv is the codelab environment to compare,
+	// or all environments if empty.
+	Expenv string
+	// MDParser is the underlying Markdown parser to use.
+	MDParser parser.MarkdownParser
+	// New is the new version of the codelab.
+	New string
+	// Old is the old version of the codelab.
+	Old string
+	// Vars are values of {{var "key"}} references in codelab sources.
+	Vars map[string]string
+}
+
+// CmdDiff is the "claat diff old new" subcommand.
+//
+// It compares two versions of a codelab, local Markdown sources or
+// exports of the json format in the v2 schema, and prints the changes
+// of metadata and steps to stdout: steps added, removed and renamed,
+// duration changes and content nodes added, removed and changed.
+//
+// Like diff(1), it returns exit code 0 if the versions are the same,
+// 1 if they differ and 2 if one could not be read.
+func CmdDiff(opts CmdDiffOptions) int {
+	a, err := readDiffCodelab(opts.Old, opts)
+	if err != nil {
+		logging.Errorf(reportErr, opts.Old, err)
+		return 2
+	}
+	b, err := readDiffCodelab(opts.New, opts)
+	if err != nil {
+		logging.Errorf(reportErr, opts.New, err)
+		return 2
+	}
+	d := schema.Compare(a, b)
+	if d.Empty() {
+		return 0
+	}
+	writeDiff(os.Stdout, opts.Old, opts.New, d)
+	return 1
+}
+
+// readDiffCodelab reads codelab src to compare, an index.json export in
+// the v2 schema if it has a .json extension, or a Markdown source.
+func readDiffCodelab(src string, opts CmdDiffOptions) (*schema.Codelab, error) {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(src) == ".json" {
+		c := &schema.Codelab{}
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, err
+		}
+		if c.Schema != schema.V2 {
+			return nil, fmt.Errorf("not a codelab in the v2 schema; export it with -f json -schema v2")
+		}
+		return c, nil
+	}
+	popts := *parser.NewOptions(opts.MDParser)
+	popts.Vars = opts.Vars
+	clab, err := parser.Parse("md", bytes.NewReader(b), popts)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Convert(&clab.Meta, clab.Steps, opts.Expenv, ""), nil
+}
+
+// writeDiff writes changes d from codelab old to new in a text format,
+// one change per line, with "+" for added, "-" for removed and "~" for
+// renamed or changed steps and nodes.
+func writeDiff(w io.Writer, old, new string, d *schema.Diff) {
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", old, new)
+	for _, f := range d.Meta {
+		fmt.Fprintf(w, "~ %s: %q -> %q\n", f.Field, f.Old, f.New)
+	}
+	for _, s := range d.Steps {
+		switch s.Change {
+		case schema.Added:
+			fmt.Fprintf(w, "+ step %d %q, %d min\n", s.NewIndex, s.NewTitle, s.NewDuration)
+			continue
+		case schema.Removed:
+			fmt.Fprintf(w, "- step %d %q, %d min\n", s.OldIndex, s.OldTitle, s.OldDuration)
+			continue
+		case schema.Renamed:
+			fmt.Fprintf(w, "~ step %d %q renamed to %q", s.OldIndex, s.OldTitle, s.NewTitle)
+		default:
+			fmt.Fprintf(w, "~ step %d %q", s.OldIndex, s.OldTitle)
+		}
+		if s.OldIndex != s.NewIndex {
+			fmt.Fprintf(w, ", now step %d", s.NewIndex)
+		}
+		fmt.Fprintln(w)
+		if s.OldDuration != s.NewDuration {
+			fmt.Fprintf(w, "    duration: %d -> %d min\n", s.OldDuration, s.NewDuration)
+		}
+		for _, n := range s.Nodes {
+			switch n.Change {
+			case schema.Added:
+				fmt.Fprintf(w, "    + %s: %s\n", n.Type, n.New)
+			case schema.Removed:
+				fmt.Fprintf(w, "    - %s: %s\n", n.Type, n.Old)
+			default:
+				fmt.Fprintf(w, "    ~ %s: %q -> %q\n", n.Type, n.Old, n.New)
+			}
+		}
+	}
+}
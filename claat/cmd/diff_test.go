@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/schema"
+)
+
+func TestCmdDiff(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdDiff-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"old.md":   "id: lab\n\n# Lab\n\n## Intro\nDuration: 2:00\n\nHello.\n\n## Set up\nDuration: 5:00\n\nInstall.\n\n```\ngo get\n```\n\nDone.\n",
+		"new.md":   "id: lab\n\n# Lab\n\n## Intro\nDuration: 2:00\n\nHello.\n\n## Setup\nDuration: 10:00\n\nInstall.\n\n```\ngo install\n```\n\nDone.\n\n## Deploy\nDuration: 5:00\n\nShip it.\n",
+		"v1.json":  `{"id": "lab", "title": "Lab"}`,
+		"new.json": `{"schema": "v2", "id": "lab", "title": "Lab", "steps": []}`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(path.Join(tmp, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	old, new := path.Join(tmp, "old.md"), path.Join(tmp, "new.md")
+	if code := CmdDiff(CmdDiffOptions{Old: old, New: old}); code != 0 {
+		t.Errorf("CmdDiff of the same codelab = %d; want 0", code)
+	}
+	if code := CmdDiff(CmdDiffOptions{Old: old, New: path.Join(tmp, "new.json")}); code != 1 {
+		t.Errorf("CmdDiff of md and json = %d; want 1", code)
+	}
+	if code := CmdDiff(CmdDiffOptions{Old: path.Join(tmp, "v1.json"), New: new}); code != 2 {
+		t.Errorf("CmdDiff of a v1 json = %d; want 2", code)
+	}
+
+	a, err := readDiffCodelab(old, CmdDiffOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := readDiffCodelab(new, CmdDiffOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	writeDiff(&out, "old.md", "new.md", schema.Compare(a, b))
+	want := `--- old.md
++++ new.md
+~ duration: "7" -> "17"
+~ step 2 "Set up" renamed to "Setup"
+    duration: 5 -> 10 min
+    ~ code: "go get" -> "go install"
++ step 3 "Deploy", 5 min
+`
+	if out.String() != want {
+		t.Errorf("writeDiff:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
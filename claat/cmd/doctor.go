@@ -0,0 +1,383 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/config"
+	"github.com/googlecodelabs/tools/claat/fetch/drive/auth"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+// releasesURL is where released binaries of claat are downloaded from.
+const releasesURL = "https://github.com/googlecodelabs/tools/releases"
+
+// CmdDoctorOptions type to make the CmdDoctor signature succinct.
+// Its fields are the unvalidated values of the flags and configuration
+// other commands would use.
+type CmdDoctorOptions struct {
+	// Analytics are the -analytics provider specs.
+	Analytics []string
+	// AuthToken is the -auth access token, if any.
+	AuthToken string
+	// Config is the configuration, nil if it could not be read.
+	Config *config.Config
+	// ConfigErr is the error reading or applying the configuration, if any.
+	ConfigErr error
+	// ConfigFiles are the configuration files read, if any.
+	ConfigFiles []string
+	// FeedbackWidget is the -feedback-widget backend.
+	FeedbackWidget string
+	// Locale is the -locale of the viewer chrome.
+	Locale string
+	// Output is the -o output directory.
+	Output string
+	// ServiceAccount is the -service-account JSON key file, if any.
+	ServiceAccount string
+	// SiteURL is the -site-url of the output directory.
+	SiteURL string
+	// Srcs are the sources to check, files, directories of Markdown
+	// sources or Google Doc IDs. If empty, the current directory is checked.
+	Srcs []string
+	// Template is the -template file of the html format, if any.
+	Template string
+	// Theme is the -theme bundle of the html format, if any.
+	Theme string
+	// Tmplout is the -f output format.
+	Tmplout string
+	// Version is the claat version, empty for development builds.
+	Version string
+}
+
+// doctor reports the results of CmdDoctor checks as they are made.
+type doctor struct {
+	problems, warnings int
+}
+
+func (d *doctor) ok(check, format string, args ...interface{}) {
+	logging.Infof("ok\t%s: %s", check, fmt.Sprintf(format, args...))
+}
+
+// warn reports a misconfiguration which does not prevent claat from
+// working, with a fix.
+func (d *doctor) warn(check, fix, format string, args ...interface{}) {
+	d.warnings++
+	logging.Warnf("warn\t%s: %s\n\tfix: %s", check, fmt.Sprintf(format, args...), fix)
+}
+
+// fail reports a problem which makes claat fail, with a fix.
+func (d *doctor) fail(check, fix, format string, args ...interface{}) {
+	d.problems++
+	logging.Errorf("err\t%s: %s\n\tfix: %s", check, fmt.Sprintf(format, args...), fix)
+}
+
+// CmdDoctor is the "claat doctor" subcommand.
+//
+// It diagnoses the environment and sources claat runs with: the binary
+// and Go versions, configuration, Google Drive credentials, output format
+// and templates, themes and locales, flag values, the output directory and
+// the layout and sources of opts.Srcs. Each problem is printed with
+// an actionable fix. Checks are made offline.
+//
+// It returns a process exit code, non-zero if a problem was found.
+// Warnings alone don't fail.
+func CmdDoctor(opts CmdDoctorOptions) int {
+	d := &doctor{}
+	if opts.Version == "" {
+		d.warn("claat", "install a release from "+releasesURL,
+			"development build, %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	} else {
+		d.ok("claat", "version %s, %s %s/%s", opts.Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	}
+
+	d.checkConfig(opts)
+	srcs, docs := d.checkSources(opts)
+	d.checkCredentials(opts, docs)
+	d.checkFormat(opts)
+	d.checkFlags(opts)
+	d.checkOutput(opts.Output)
+	for _, src := range srcs {
+		d.checkSource(src, opts)
+	}
+
+	switch {
+	case d.problems > 0:
+		logging.Errorf("%d problem(s), %d warning(s) found", d.problems, d.warnings)
+		return 1
+	case d.warnings > 0:
+		logging.Warnf("no problems, %d warning(s) found", d.warnings)
+	default:
+		logging.Infof("no problems found")
+	}
+	return 0
+}
+
+func (d *doctor) checkConfig(opts CmdDoctorOptions) {
+	switch {
+	case opts.ConfigErr != nil:
+		d.fail("config", "correct the configuration file, see 'claat -h' for its format", "%v", opts.ConfigErr)
+	case len(opts.ConfigFiles) == 0:
+		d.ok("config", "no claat.yaml found, using defaults")
+	default:
+		d.ok("config", "%s", strings.Join(opts.ConfigFiles, ", "))
+	}
+}
+
+// checkSources checks the layout of opts.Srcs and returns the local
+// Markdown sources they contain and the number of Google Docs.
+func (d *doctor) checkSources(opts CmdDoctorOptions) ([]string, int) {
+	in := opts.Srcs
+	if len(in) == 0 {
+		in = []string{"."}
+	}
+	var (
+		srcs []string
+		docs int
+	)
+	for _, src := range util.Unique(in) {
+		fi, err := os.Stat(src)
+		switch {
+		case err == nil && !fi.IsDir():
+			if filepath.Ext(src) != ".md" {
+				d.warn("sources", "rename it with a .md extension, which lint and serve look for",
+					"%s is not a .md file", src)
+			}
+			srcs = append(srcs, src)
+			continue
+		case err == nil:
+			files, err := lintSources([]string{src})
+			if err != nil {
+				d.fail("sources", "check the permissions of the directory", "%s: %v", src, err)
+				continue
+			}
+			if len(files) == 0 {
+				d.warn("sources", "create a codelab with 'claat new my-codelab'",
+					"no Markdown sources in %s", src)
+				continue
+			}
+			d.ok("sources", "%d Markdown source(s) in %s", len(files), src)
+			srcs = append(srcs, files...)
+		case strings.ContainsAny(src, `/\`) || filepath.Ext(src) != "":
+			d.fail("sources", "check the path, relative to the current directory", "%s not found", src)
+		default:
+			d.ok("sources", "%s is a Google Doc ID", src)
+			docs++
+		}
+	}
+	return srcs, docs
+}
+
+// checkCredentials checks Google Drive credentials, which are needed
+// to export Google Docs.
+func (d *doctor) checkCredentials(opts CmdDoctorOptions, docs int) {
+	const check = "credentials"
+	if opts.AuthToken != "" {
+		d.ok(check, "access token given with -auth")
+		return
+	}
+	if opts.ServiceAccount != "" {
+		email, err := auth.ServiceAccountEmail(opts.ServiceAccount)
+		if err != nil {
+			d.fail(check, "download a JSON key of the service account from the Cloud Console", "%v", err)
+			return
+		}
+		d.ok(check, "service account %s; share the Docs with it", email)
+		return
+	}
+	tok, file, err := auth.CachedToken(auth.ProviderGoogle)
+	switch {
+	case os.IsNotExist(err) && docs == 0:
+		d.ok(check, "no cached Google Drive credentials, only needed to export Google Docs")
+	case os.IsNotExist(err):
+		d.fail(check, "export a Google Doc once to authorize claat, or use -service-account on machines without a browser",
+			"no cached Google Drive credentials")
+	case err != nil:
+		d.fail(check, fmt.Sprintf("remove %s and authorize claat again", file), "%v", err)
+	case tok.RefreshToken == "" && !tok.Valid():
+		d.fail(check, fmt.Sprintf("remove %s and authorize claat again", file),
+			"cached Google Drive credentials in %s expired and cannot be refreshed", file)
+	default:
+		d.ok(check, "cached Google Drive credentials in %s", file)
+	}
+}
+
+// checkFormat checks the output format, templates, theme and locale.
+func (d *doctor) checkFormat(opts CmdDoctorOptions) {
+	fix := "use a built-in format, such as html, md or offline, or a Go template file"
+	if err := render.CheckTemplate(opts.Tmplout); err != nil {
+		d.fail("format", fix, "%v", err)
+	} else {
+		d.ok("format", "%s", opts.Tmplout)
+	}
+	if opts.Template != "" {
+		if err := render.CheckTemplate(opts.Template); err != nil {
+			d.fail("template", "correct the template file or remove -template to use the built-in one", "%v", err)
+		} else if opts.Tmplout != "html" {
+			d.warn("template", "export with -f html, or remove -template",
+				"%s only renders the html format, not %s", opts.Template, opts.Tmplout)
+		} else {
+			d.ok("template", "%s", opts.Template)
+		}
+	}
+	var themes map[string]*render.Theme
+	if opts.Config != nil {
+		themes = opts.Config.Themes
+		var locales []string
+		for l := range opts.Config.Locales {
+			locales = append(locales, l)
+		}
+		sort.Strings(locales)
+		for _, l := range locales {
+			if err := render.RegisterLocale(l, opts.Config.Locales[l]); err != nil {
+				d.fail("locale", "correct the locales section of the configuration", "%q: %v", l, err)
+			}
+		}
+	}
+	if opts.Theme != "" {
+		if themes[opts.Theme] == nil {
+			d.fail("theme", "add the theme to the themes section of claat.yaml", "unknown theme %q", opts.Theme)
+		} else {
+			d.ok("theme", "%s", opts.Theme)
+		}
+	}
+	if opts.Locale != "" {
+		if !render.HasLocale(opts.Locale) {
+			d.fail("locale", "use one of "+strings.Join(render.Locales(), ", ")+" or add it to claat.yaml",
+				"unknown locale %q", opts.Locale)
+		} else {
+			d.ok("locale", "%s", opts.Locale)
+		}
+	}
+}
+
+// checkFlags checks values of flags which other commands validate
+// when they start.
+func (d *doctor) checkFlags(opts CmdDoctorOptions) {
+	for _, spec := range opts.Analytics {
+		if _, err := render.ParseAnalytics(spec); err != nil {
+			d.fail("flags", "correct -analytics, see 'claat -h'", "%v", err)
+		}
+	}
+	if opts.FeedbackWidget != "" {
+		if err := render.ValidateFeedbackWidget(opts.FeedbackWidget); err != nil {
+			d.fail("flags", "use an http(s) URL or github:owner/repo", "-feedback-widget: %v", err)
+		}
+	}
+	if opts.SiteURL != "" {
+		if err := checkSiteURL(opts.SiteURL); err != nil {
+			d.fail("flags", "use the absolute URL of the output directory, e.g. https://example.com/codelabs", "-site-url: %v", err)
+		}
+	}
+}
+
+// checkOutput checks whether the output directory can be written to.
+func (d *doctor) checkOutput(dir string) {
+	if dir == "-" {
+		d.ok("output", "stdout")
+		return
+	}
+	fi, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		d.ok("output", "%s will be created", dir)
+		return
+	}
+	if err == nil && !fi.IsDir() {
+		d.fail("output", "remove the file or choose another directory with -o", "%s is not a directory", dir)
+		return
+	}
+	if err == nil {
+		var f *os.File
+		if f, err = ioutil.TempFile(dir, ".claat-doctor-*"); err == nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		d.fail("output", "check the permissions of the directory or choose another one with -o", "%v", err)
+		return
+	}
+	d.ok("output", "%s", dir)
+}
+
+// checkSource checks a local Markdown source for problems preventing its
+// export: parse errors, an invalid ID, and missing images and templates.
+// Content issues are left to the lint command.
+func (d *doctor) checkSource(src string, opts CmdDoctorOptions) {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		d.fail(src, "check the permissions of the file", "%v", err)
+		return
+	}
+	popts := *parser.NewOptions(parser.Blackfriday)
+	popts.WarningSink = func(*parser.Warning) {}
+	clab, err := parser.Parse("md", bytes.NewReader(b), popts)
+	if err != nil {
+		d.fail(src, "correct the source, see FORMAT-GUIDE.md", "%v", err)
+		return
+	}
+	problems := d.problems
+	if !types.ValidID(clab.Meta.ID) {
+		d.fail(src, "set the id metadata to lowercase letters and digits separated by - or _",
+			"invalid codelab ID %q", clab.Meta.ID)
+	}
+	dir := filepath.Dir(src)
+	imgs := map[string]bool{}
+	if clab.Meta.HeroImage != "" {
+		imgs[clab.Meta.HeroImage] = true
+	}
+	for _, st := range clab.Steps {
+		for _, img := range types.ImageNodes(st.Content.Nodes) {
+			if img.Src != "" {
+				imgs[img.Src] = true
+			}
+		}
+	}
+	var missing []string
+	for img := range imgs {
+		if u, err := url.Parse(img); err != nil || u.Scheme != "" || u.Host != "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(img))); err != nil {
+			missing = append(missing, img)
+		}
+	}
+	sort.Strings(missing)
+	for _, img := range missing {
+		d.fail(src, "add the image, relative to the directory of the source, or correct its path",
+			"image %s not found", img)
+	}
+	if clab.Meta.Template != "" {
+		t := codelabTemplate(src, &clab.Meta, opts.Template, "html")
+		if err := render.CheckTemplate(t); err != nil {
+			d.fail(src, "correct the template metadata, relative to the directory of the source", "%v", err)
+		}
+	}
+	if d.problems == problems {
+		d.ok(src, "codelab %s, %d step(s); run 'claat lint' to check its content", clab.Meta.ID, len(clab.Steps))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdDoctor(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdDoctor-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", tmp)
+	files := map[string]string{
+		"labs/good.md":      "id: good-lab\n\n# Good\n\n## Step\nDuration: 5:00\n\n![Logo](img/logo.png)\n",
+		"labs/img/logo.png": "png",
+		"labs/bad.md":       "id: Bad Lab\n\n# Bad\n\n## Step\nDuration: 5:00\n\n![Chart](img/chart.png)\n",
+	}
+	for name, content := range files {
+		f := path.Join(tmp, name)
+		if err := os.MkdirAll(path.Dir(f), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+	opts := cmd.CmdDoctorOptions{
+		Output:  path.Join(tmp, "out"),
+		Srcs:    []string{path.Join(tmp, "labs/good.md")},
+		Tmplout: "html",
+		Version: "1.0",
+	}
+	if code := cmd.CmdDoctor(opts); code != 0 {
+		t.Fatalf("CmdDoctor = %d; want 0\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "codelab good-lab, 1 step(s)") {
+		t.Errorf("CmdDoctor output:\n%s\nwant good-lab checked", out.String())
+	}
+
+	out.Reset()
+	opts.Srcs = []string{path.Join(tmp, "labs"), "1aBcDeF", "missing.md"}
+	opts.Template = path.Join(tmp, "missing.html")
+	opts.Theme = "dark"
+	opts.Analytics = []string{"ga4:UA-1"}
+	if code := cmd.CmdDoctor(opts); code == 0 {
+		t.Fatalf("CmdDoctor = 0; want 1\n%s", out.String())
+	}
+	for _, want := range []string{
+		"ok\tsources: 2 Markdown source(s)",
+		"ok\tsources: 1aBcDeF is a Google Doc ID",
+		"err\tsources: missing.md not found",
+		"err\tcredentials: no cached Google Drive credentials",
+		"err\ttemplate: ",
+		"err\ttheme: unknown theme \"dark\"",
+		"err\tflags: analytics \"ga4:UA-1\"",
+		"bad.md: invalid codelab ID \"Bad Lab\"",
+		"bad.md: image img/chart.png not found",
+		"\tfix: ",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("CmdDoctor output:\n%s\nwant %q", out.String(), want)
+		}
+	}
+}
@@ -15,80 +15,551 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/googlecodelabs/tools/claat/assets"
 	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/i18n"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/metrics"
 	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/patch"
 	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/schema"
+	"github.com/googlecodelabs/tools/claat/transform"
 	"github.com/googlecodelabs/tools/claat/types"
 	"github.com/googlecodelabs/tools/claat/util"
 )
 
 // Options type to make the CmdExport signature succinct.
 type CmdExportOptions struct {
+	// A11yCheck audits each codelab for accessibility issues, such as
+	// images with no alt text or low-contrast theme colors, logging them
+	// if "warn" and failing the export if "fail". Nothing is audited if empty.
+	A11yCheck string
+	// A11yNav adds a skip link, aria-current step navigation, focus
+	// management and n/p keyboard shortcuts to the html format.
+	// It is off by default so that exports stay unchanged.
+	A11yNav bool
+	// Analytics are providers which the html format sends step view,
+	// duration and completion events to, along with those of the
+	// analytics providers metadata of each codelab.
+	Analytics []*render.Analytics
 	// AuthToken is the token to use for the Drive API.
 	AuthToken string
+	// CheckIDs fails exporting codelabs with an ID which is not a valid slug,
+	// see types.ValidID, or which another source of the export already uses.
+	CheckIDs bool
+	// CheckLinks requests external links and images of each codelab,
+	// logging broken ones if "warn" and failing the export if "fail".
+	// Links are not checked if empty.
+	CheckLinks string
+	// ChipDateLayout is the Go time layout of dates of Google Docs,
+	// such as date smart chips, see parser.ChipOptions.
+	ChipDateLayout string
+	// ChipMailto links people smart chips of Google Docs to their email.
+	ChipMailto bool
+	// Context cancels the export once done, e.g. on an interrupt signal:
+	// sources not started yet are skipped, and the others stop requesting
+	// and rendering, leaving no partial output. Never canceled if nil.
+	Context context.Context
+	// Badges stores SVG badges of duration, last update and step count
+	// alongside each exported codelab.
+	Badges bool
+	// BaseURL is used to resolve relative links and images, if not empty.
+	BaseURL string
+	// BaseURLExclude are path patterns of relative references to leave intact.
+	BaseURLExclude []string
+	// BuildTime, if not zero, is the update time of codelabs without updated
+	// metadata, instead of the modification time of their source, so that
+	// identical sources are exported to byte-identical output.
+	BuildTime time.Time
+	// DefaultLang is the locale of codelab sources with no locale suffix,
+	// when exported along with their locale variants, e.g. "foo.fr.md".
+	DefaultLang string
+	// DrawingFormat is the image format of Google Drawings embedded in
+	// Google Docs, "png" or "svg", see parser.Options.DrawingFormat.
+	DrawingFormat string
+	// DriveFolder is a Drive folder ID or URL. All Google Docs in the folder
+	// are exported along with Srcs, and indexed in a combined codelabs.json.
+	DriveFolder string
+	// DryRun parses and renders codelabs in memory without writing any file,
+	// and reports statistics of each codelab instead, such as its number
+	// of steps, words and images.
+	DryRun bool
 	// Expenv is the codelab environment to export to.
 	Expenv string
+	// ExternalLinks adds target="_blank" and rel="noopener" to external links.
+	ExternalLinks bool
 	// ExtraVars is extra template variables.
 	ExtraVars map[string]string
+	// FeedbackWidget adds thumbs up and down and comment widgets to each
+	// step of the html format, sent to this backend, if not empty.
+	// See render.ValidateFeedbackWidget.
+	FeedbackWidget string
+	// Fingerprint gives content-hashed names to images of each codelab,
+	// and moves inline styles and scripts of the html format to
+	// content-hashed files, so that exports can be served with long
+	// cache lifetimes. Such files of previous exports are removed.
+	Fingerprint bool
 	// GlobalGA is the global Google Analytics account to use.
 	GlobalGA string
+	// Glossary appends a step listing all glossary terms.
+	Glossary bool
+	// HTTP configures retries, timeouts and the proxy of network requests,
+	// such as fetching Google Docs, imports and images, and checking links.
+	HTTP fetch.HTTPOptions
+	// IframeAllowlist are domains allowed to be embedded as iframes.
+	// If nil, the default types.IframeWhitelist is used.
+	// If empty, no iframes are allowed.
+	IframeAllowlist []string
+	// ImageFormats are the alternate formats images are converted to
+	// with OptimizeImages, see assets.LookupFormat.
+	ImageFormats []string
+	// ImportCache is the directory remote imports of codelabs are cached in,
+	// see fetch.ImportCache. Defaults to fetch.DefaultImportCacheDir
+	// with CmdExport. Imports are not cached if empty.
+	ImportCache string
+	// ImportCredentials authenticate requests of remote imports,
+	// see fetch.Fetcher.SetImportCredentials.
+	ImportCredentials []*fetch.ImportCredential
+	// ImportRoot confines local imports of codelabs to this directory,
+	// see fetch.Fetcher.SetImportRoot. Defaults to the current directory.
+	ImportRoot string
+	// ImportTTL is how long cached imports are used without fetching them.
+	// Imports are fetched on every export if zero, but still cached for Offline.
+	ImportTTL time.Duration
+	// Jobs is the maximum number of codelabs exported concurrently.
+	// If zero, the number of CPUs is used.
+	Jobs int
+	// LinkAllowlist are hosts and URL prefixes never requested by CheckLinks,
+	// see fetch.LinkChecker.
+	LinkAllowlist []string
+	// Locale is the locale of the viewer chrome of the html format,
+	// such as button labels, for codelabs which are not locale variants.
+	// English is used if empty, see render.Msg.
+	Locale string
 	// MDParser is the underlying Markdown parser to use.
 	MDParser parser.MarkdownParser
+	// MetaRules constrain metadata of parsed codelabs,
+	// see parser.Options.MetaRules.
+	MetaRules map[string]*parser.MetaRule
+	// Metrics records timing and size metrics of each exported codelab,
+	// if not nil.
+	Metrics metrics.Recorder
+	// MetricsFile is a file CmdExport writes metrics of all exported
+	// codelabs to, in the Prometheus text format, if not empty.
+	MetricsFile string
+	// MetricsPush is the URL of a Prometheus Pushgateway group CmdExport
+	// pushes metrics of all exported codelabs to, if not empty.
+	MetricsPush string
+	// NotebookOutputs includes outputs of Jupyter notebook code cells.
+	NotebookOutputs bool
+	// Offline never fetches remote imports: cached copies are used,
+	// whatever their age, and codelabs importing uncached ones fail.
+	Offline bool
+	// OnError is what happens when a source fails to export:
+	// "continue", the default, exports the remaining sources;
+	// "fail-fast" skips sources not being exported yet.
+	// Either way, the exit code is non-zero.
+	OnError string
+	// OptimizeImages recompresses PNG and JPEG images of each codelab,
+	// resizes wide ones to 1x and 2x variants and converts them to
+	// ImageFormats, see package assets. Renderers emit responsive markup
+	// of the variants, such as <picture> in the html format.
+	OptimizeImages bool
 	// Output is the output directory, or "-" for stdout.
 	Output string
 	// PassMetadata are the extra metadata fields to pass along.
 	PassMetadata map[string]bool
+	// Patch is a JSON Patch file applied to each parsed codelab, if not empty.
+	Patch string
 	// Prefix is a URL prefix to prepend when using HTML format.
 	Prefix string
+	// ProgressURL is the endpoint which the html format posts reading
+	// progress to, in addition to storing it in localStorage, if not empty.
+	ProgressURL string
+	// PWA makes codelabs of the html format installable web apps, readable
+	// offline: a web app manifest and a service worker precaching the pages,
+	// images, styles and scripts of each codelab are written alongside it.
+	PWA bool
+	// PWAScope is what PWA makes installable: each codelab, with
+	// "codelab" or if empty, or all codelabs of Output as a single app
+	// with "dir", whose manifest, service worker and start page listing
+	// them are written to Output, precaching every codelab directory.
+	PWAScope string
+	// QR writes QR code images of the published URL of each codelab,
+	// under SiteURL, which is then required: qr.svg and qr.png. The pdf
+	// format shows the QR code on its title page.
+	QR bool
+	// ReadingWPM estimates durations of steps with none from their length,
+	// read at this many words per minute. Durations are not estimated if zero,
+	// see parser.Options.ReadingWPM.
+	ReadingWPM int
+	// RefreshImports fetches all remote imports again, ignoring ImportTTL.
+	RefreshImports bool
+	// Report is a file to store a JSON report of the export in, if not empty,
+	// with the status, warnings and error of each source.
+	Report string
+	// Review is how unresolved comments and suggestions are handled.
+	Review parser.ReviewMode
+	// Revision stamps codelabs without revision metadata with this source
+	// revision, shown with their last update date in the html format.
+	// If "auto", local sources are stamped with their last git commit and
+	// Google Docs with their version. Codelabs are not stamped if empty.
+	Revision string
+	// Schema is the JSON export schema version of the json format,
+	// see package schema. Defaults to schema.V1.
+	Schema string
+	// ServiceAccount is a service account JSON key file to use for the Drive API.
+	ServiceAccount string
+	// SiteURL is the URL the output directory is hosted at. If not empty,
+	// a sitemap and an Atom feed of recently updated codelabs are written
+	// to the output directory.
+	SiteURL string
 	// Srcs is the sources to export codelabs from.
 	Srcs []string
+	// Strict fails exporting a source if the parser drops or ignores
+	// any of its content, see parser.Options.Strict.
+	Strict bool
+	// Template is a local template file rendering codelabs of the html format,
+	// unless overridden by the template metadata of a codelab.
+	// The built-in html template is used if empty.
+	Template string
+	// Theme is the theme bundle of Themes rendering codelabs of the html
+	// format, unless the theme metadata of a codelab names another one.
+	Theme string
+	// Themes are named theme bundles of the html format.
+	Themes map[string]*render.Theme
 	// Tmplout is the output format.
 	Tmplout string
+	// Transforms are node transformation rules applied to each parsed codelab.
+	Transforms []*transform.Rule
+	// UTMSource, if not empty, adds UTM query parameters to external links.
+	UTMSource string
+	// Vars are values of {{var "key"}} references in codelab sources.
+	Vars map[string]string
+	// Version is the claat version, checked against codelab requirements.
+	Version string
+	// VideoDurations adds running time of embedded videos to step durations.
+	VideoDurations bool
+	// YouTubeAPIKey is the YouTube Data API key used with VideoDurations.
+	YouTubeAPIKey string
+
+	// ids records codelab IDs of an export with CheckIDs.
+	ids *idRegistry
+	// locale is set for sources exported as one of several locale variants.
+	locale *locale
+	// translation is the catalog of "claat i18n apply" translating sources.
+	translation *i18n.Catalog
+	// course links codelabs of "claat course build", keyed by source.
+	course map[string]*courseNav
+	// fetched are codelabs fetched ahead of the export, keyed by source.
+	fetched map[string]*fetchedCodelab
+	// links is shared by all codelabs of an export to cache checked URLs.
+	links *fetch.LinkChecker
+	// warnings collects warnings of the exported source, if not nil.
+	warnings *warnings
+	// stats receives statistics of the source exported in a dry run, if not nil.
+	stats *codelabStats
 }
 
 // CmdExport is the "claat export ..." subcommand.
 // It returns a process exit code.
 func CmdExport(opts CmdExportOptions) int {
 	var exitCode int
-	if len(opts.Srcs) == 0 {
-		log.Fatalf("Need at least one source. Try '-h' for options.")
+	if len(opts.Srcs) == 0 && opts.DriveFolder == "" {
+		logging.Fatalf("Need at least one source. Try '-h' for options.")
+	}
+	if opts.Schema != "" && !schema.Valid(opts.Schema) {
+		logging.Fatalf("Unknown schema version %q; known versions: %s", opts.Schema, strings.Join(schema.Versions, ", "))
+	}
+	switch opts.CheckLinks {
+	case "", checkLinksWarn, checkLinksFail:
+	default:
+		logging.Fatalf("Unknown check-links value %q; want %q or %q", opts.CheckLinks, checkLinksWarn, checkLinksFail)
+	}
+	switch opts.A11yCheck {
+	case "", a11yCheckWarn, a11yCheckFail:
+	default:
+		logging.Fatalf("Unknown a11y-check value %q; want %q or %q", opts.A11yCheck, a11yCheckWarn, a11yCheckFail)
+	}
+	switch opts.DrawingFormat {
+	case "", "png", "svg":
+	default:
+		logging.Fatalf("Unknown drawing format %q; want png or svg", opts.DrawingFormat)
+	}
+	switch opts.OnError {
+	case "", onErrorContinue, onErrorFailFast:
+	default:
+		logging.Fatalf("Unknown on-error value %q; want %q or %q", opts.OnError, onErrorContinue, onErrorFailFast)
+	}
+	for _, f := range opts.ImageFormats {
+		if opts.OptimizeImages && assets.LookupFormat(f) == nil {
+			logging.Fatalf("Unknown image format %q; known formats: %s", f, imageFormatNames())
+		}
+	}
+	switch opts.PWAScope {
+	case "", pwaScopeCodelab, pwaScopeDir:
+	default:
+		logging.Fatalf("Unknown pwa-scope value %q; want %q or %q", opts.PWAScope, pwaScopeCodelab, pwaScopeDir)
+	}
+	if opts.SiteURL != "" {
+		if err := checkSiteURL(opts.SiteURL); err != nil {
+			logging.Fatalf("Invalid -site-url: %v", err)
+		}
+	}
+	for _, src := range opts.Srcs {
+		if src == fetch.Stdin && (len(opts.Srcs) > 1 || opts.DriveFolder != "") {
+			logging.Fatalf("Source - (stdin) cannot be exported along with other sources")
+		}
+	}
+	if isStdout(opts.Output) && (opts.Tmplout == "offline" || opts.Tmplout == "obsidian") {
+		logging.Fatalf("Format %q writes several files, which cannot be written to stdout; use -o", opts.Tmplout)
+	}
+	if opts.MetricsPush != "" {
+		if err := checkPushURL(opts.MetricsPush); err != nil {
+			logging.Fatalf("Invalid -metrics-push: %v", err)
+		}
+	}
+	if opts.QR && opts.SiteURL == "" {
+		logging.Fatalf("-qr needs -site-url, the URL codelabs are published under")
+	}
+	if opts.Offline && opts.RefreshImports {
+		logging.Fatalf("-offline and -refresh-imports cannot be used together")
+	}
+	if opts.ImportCache == "" {
+		d, err := fetch.DefaultImportCacheDir()
+		if err != nil && opts.Offline {
+			logging.Fatalf("No import cache for -offline: %v", err)
+		}
+		opts.ImportCache = d
+	}
+	opts.HTTP = opts.httpOptions()
+	rt, err := opts.HTTP.Transport(nil)
+	if err != nil {
+		logging.Fatalf("Invalid -proxy: %v", err)
+	}
+	if opts.CheckLinks != "" {
+		opts.links = newLinkChecker(rt, opts)
+	}
+	var prom *metrics.Prometheus
+	if opts.MetricsFile != "" || opts.MetricsPush != "" {
+		prom = &metrics.Prometheus{}
+		opts.Metrics = metrics.Multi(opts.Metrics, prom)
+	}
+	if opts.CheckIDs {
+		opts.ids = newIDRegistry()
+	}
+	var report exportReport
+	if opts.DriveFolder != "" {
+		docs, err := folderDocs(rt, opts)
+		if err != nil {
+			logging.Errorf(reportErr, opts.DriveFolder, err)
+			report.add(&sourceReport{Src: opts.DriveFolder, Status: statusError, Error: newReportError(err)})
+			writeReport(&report, opts.Report)
+			return 1
+		}
+		opts.Srcs = append(opts.Srcs, docs...)
 	}
 	type result struct {
-		src  string
-		meta *types.Meta
-		err  error
+		src      string
+		meta     *types.Meta
+		err      error
+		skipped  bool
+		warnings []string
+		stats    *codelabStats
+	}
+	if opts.Tmplout == "term" {
+		// terminal previews are never stored on disk
+		opts.Output = "-"
 	}
 	srcs := util.Unique(opts.Srcs)
-	ch := make(chan *result, len(srcs))
-	for _, src := range srcs {
-		go func(src string) {
-			meta, err := ExportCodelab(src, nil, opts)
-			ch <- &result{src, meta, err}
-		}(src)
-	}
-	for range srcs {
+	locales := localeVariants(srcs, opts.DefaultLang)
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	sem := make(chan struct{}, jobs)
+	var abort int32 // set with fail-fast after the first failure
+	chs := make([]chan *result, len(srcs))
+	for i, src := range srcs {
+		chs[i] = make(chan *result, 1)
+		// sources are started in order, so fail-fast
+		// and interrupts skip the last ones
+		sem <- struct{}{}
+		if atomic.LoadInt32(&abort) != 0 || opts.context().Err() != nil {
+			<-sem
+			chs[i] <- &result{src: src, skipped: true}
+			continue
+		}
+		opts.locale = locales[src]
+		go func(src string, opts CmdExportOptions, ch chan<- *result) {
+			defer func() { <-sem }()
+			opts.warnings = &warnings{}
+			if opts.DryRun {
+				opts.stats = &codelabStats{}
+			}
+			meta, err := ExportCodelab(src, rt, opts)
+			if err != nil && opts.OnError == onErrorFailFast {
+				atomic.StoreInt32(&abort, 1)
+			}
+			ch <- &result{src, meta, err, false, opts.warnings.list, opts.stats}
+		}(src, opts, chs[i])
+	}
+	// results are reported in order of srcs, regardless of completion order
+	features := map[string]int{}
+	var metas []*types.Meta
+	total := &codelabStats{}
+	for _, ch := range chs {
 		res := <-ch
-		if res.err != nil {
+		sr := &sourceReport{Src: res.src, Status: statusOK, Warnings: res.warnings}
+		switch {
+		case res.skipped:
+			sr.Status = statusSkipped
+		case res.err != nil:
+			exitCode = 1
+			sr.Status = statusError
+			sr.Error = newReportError(res.err)
+			logSourceErr(res.src, res.err)
+		case opts.DryRun:
+			sr.ID = res.meta.ID
+			sr.Stats = res.stats
+			total.add(res.stats)
+			logSourceOK(res.src, res.meta.ID+": "+res.stats.String())
+		default:
+			sr.ID = res.meta.ID
+			if !isStdout(opts.Output) {
+				logSourceOK(res.src, res.meta.ID)
+				for _, f := range res.meta.Features {
+					features[f]++
+				}
+				metas = append(metas, res.meta)
+			}
+		}
+		report.add(sr)
+	}
+	if report.Failed > 0 && len(srcs) > 1 {
+		logging.Errorf("%d of %d codelabs failed to export", report.Failed, len(srcs))
+	}
+	interrupted := opts.context().Err() != nil
+	switch {
+	case report.Skipped > 0 && interrupted:
+		logging.Warnf("%d codelabs skipped after an interrupt", report.Skipped)
+	case report.Skipped > 0:
+		logging.Warnf("%d codelabs skipped after a failure", report.Skipped)
+	}
+	if opts.DryRun && report.OK > 1 {
+		logging.Infof("%d codelabs: %v", report.OK, total)
+	}
+	if !writeReport(&report, opts.Report) {
+		exitCode = 1
+	}
+	reportFeatures(features)
+	if prom != nil && !writeMetrics(prom, opts) {
+		exitCode = 1
+	}
+	if interrupted {
+		// the index and site files would list only part of the codelabs
+		logging.Warnf("Export interrupted; index and site files not written")
+		return 1
+	}
+	if opts.DriveFolder != "" && !isStdout(opts.Output) && !opts.DryRun {
+		if err := writeIndex(filepath.Join(opts.Output, indexFilename), metas); err != nil {
+			logging.Errorf(reportErr, indexFilename, err)
+			exitCode = 1
+		}
+	}
+	if opts.PWA && opts.PWAScope == pwaScopeDir && opts.Tmplout == "html" && !isStdout(opts.Output) && !opts.DryRun && len(metas) > 0 {
+		// last, to precache all other files
+		if err := writeDirPWA(opts.Output, metas); err != nil {
+			logging.Errorf(reportErr, swFilename, err)
+			exitCode = 1
+		}
+	}
+	if opts.SiteURL != "" && !isStdout(opts.Output) && !opts.DryRun {
+		if err := writeSiteFiles(opts.Output, opts.SiteURL, opts.BuildTime); err != nil {
+			logging.Errorf(reportErr, sitemapFilename, err)
 			exitCode = 1
-			log.Printf(reportErr, res.src, res.err)
-		} else if !isStdout(opts.Output) {
-			log.Printf(reportOk, res.meta.ID)
 		}
 	}
 	return exitCode
 }
 
+// writeReport stores report in file name, if not empty.
+// It returns false if the report could not be written.
+func writeReport(report *exportReport, name string) bool {
+	if name == "" {
+		return true
+	}
+	if err := report.write(name); err != nil {
+		logging.Errorf(reportErr, name, err)
+		return false
+	}
+	return true
+}
+
+// folderDocs returns IDs of Google Docs in opts.DriveFolder,
+// sending requests with rt.
+func folderDocs(rt http.RoundTripper, opts CmdExportOptions) ([]string, error) {
+	f, err := fetch.NewFetcher(opts.AuthToken, opts.parserOptions(), rt, authOptions(opts.ServiceAccount)...)
+	if err != nil {
+		return nil, err
+	}
+	f.SetHTTPOptions(opts.httpOptions())
+	docs, err := f.FolderDocs(opts.DriveFolder)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no Google Docs in folder")
+	}
+	return docs, nil
+}
+
+// writeIndex stores metadata of all exported codelabs, sorted by ID,
+// in a single JSON file at path.
+func writeIndex(path string, metas []*types.Meta) error {
+	sort.Slice(metas, func(i, j int) bool { return metas[i].ID < metas[j].ID })
+	if metas == nil {
+		metas = []*types.Meta{}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(metas, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// reportFeatures logs the number of exported codelabs using each feature.
+func reportFeatures(features map[string]int) {
+	names := make([]string, 0, len(features))
+	for f := range features {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	for _, f := range names {
+		logging.Infof(reportFeature, f, features[f])
+	}
+}
+
 // ExportCodelab fetches codelab src from either local disk or remote,
 // parses and stores the results on disk, in a dir ancestored by output.
 //
@@ -100,63 +571,580 @@ func CmdExport(opts CmdExportOptions) int {
 // is printed to stdout.
 //
 // An alternate http.RoundTripper may be specified if desired. Leave null for default.
-func ExportCodelab(src string, rt http.RoundTripper, opts CmdExportOptions) (*types.Meta, error) {
-	f, err := fetch.NewFetcher(opts.AuthToken, opts.PassMetadata, rt, opts.MDParser)
+//
+// Once opts.Context is done, the export stops and removes the codelab
+// dir, unless it existed before.
+func ExportCodelab(src string, rt http.RoundTripper, opts CmdExportOptions) (_ *types.Meta, err error) {
+	log, start := logging.With("src", src), time.Now()
+	m := &metrics.Export{Src: src}
+	if opts.Metrics != nil {
+		defer func() {
+			m.Err = err
+			m.Total = time.Since(start)
+			opts.Metrics.Record(m)
+		}()
+	}
+	po := opts.parserOptions()
+	po.WarningSink = opts.warnings.sink(src)
+	f, err := opts.newFetcher(po, rt, log)
 	if err != nil {
 		return nil, err
 	}
-	clab, err := f.SlurpCodelab(src)
+	clab, err := opts.slurp(f, src)
 	if err != nil {
+		m.Fetch = time.Since(start)
+		return nil, err
+	}
+	m.ID = clab.ID
+	m.Parse = clab.ParseTime
+	m.Fetch = time.Since(start) - clab.ParseTime
+	if err := translateCodelab(src, clab.Codelab, opts); err != nil {
+		return nil, err
+	}
+	applyLocale(clab.Codelab, opts.locale, opts.DefaultLang)
+	addCourseNav(clab.Codelab, opts.course[src], codelabLocale(&clab.Meta, opts.Locale))
+	if err := patchCodelab(clab.Codelab, opts.Patch); err != nil {
+		return nil, err
+	}
+	if err := transform.Apply(clab.Codelab, opts.Transforms); err != nil {
+		return nil, err
+	}
+	if err := checkLinks(src, clab.Codelab, rt, opts); err != nil {
+		return nil, err
+	}
+	decorateLinks(clab.Codelab, opts)
+	if err := addVideoDurations(clab.Codelab, rt, opts); err != nil {
+		return nil, err
+	}
+	if err := checkPlaceholders(clab.Codelab); err != nil {
 		return nil, err
 	}
 
 	// codelab export context
-	lastmod := types.ContextTime(clab.Mod)
 	clab.Meta.Source = src
 	meta := &clab.Meta
+	meta.Revision = codelabRevision(src, meta, clab.Rev, opts)
+	lastmod := codelabUpdated(src, meta, clab.Mod, opts)
+	metaDate(src, "published", meta.Published, opts)
 	ctx := &types.Context{
 		Env:     opts.Expenv,
 		Format:  opts.Tmplout,
 		Prefix:  opts.Prefix,
 		MainGA:  opts.GlobalGA,
 		Updated: &lastmod,
+		Schema:  opts.Schema,
+	}
+
+	if err := checkID(src, meta.ID, opts); err != nil {
+		return nil, err
+	}
+	if meta.Template != "" && ctx.Format == "html" {
+		if _, err := metaTemplate(src, meta.Template); err != nil {
+			opts.warnings.warnf(src, "%v; using the default template", err)
+		}
+	}
+	lk := codelabLook(src, meta, opts, ctx.Format)
+	if err := checkA11y(src, clab.Codelab, lk.theme, opts); err != nil {
+		return nil, err
 	}
 
 	dir := opts.Output // output dir or stdout
+	if opts.DryRun {
+		// rendered like to stdout, and discarded
+		dir = stdout
+		if opts.stats != nil {
+			*opts.stats = *newCodelabStats(clab.Codelab, opts.Expenv)
+		}
+	}
+	if err := opts.context().Err(); err != nil {
+		return nil, err
+	}
 	if !isStdout(dir) {
 		dir = codelabDir(dir, meta)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			// a canceled export leaves no partial output behind
+			defer func() {
+				if err != nil && opts.context().Err() != nil {
+					os.RemoveAll(dir)
+				}
+			}()
+		}
 		// download or copy codelab assets to disk, and rewrite image URLs
 		mdir := filepath.Join(dir, util.ImgDirname)
+		t := time.Now()
 		if _, err := f.SlurpImages(src, mdir, clab.Steps); err != nil {
 			return nil, err
 		}
+		m.Fetch += time.Since(t)
+		if opts.OptimizeImages {
+			if err := optimizeImages(src, dir, clab.Codelab, opts); err != nil {
+				return nil, err
+			}
+		}
+		// remote hero images stay absolute for link previews
+		if u, err := url.Parse(clab.HeroImage); err == nil && clab.HeroImage != "" && u.Scheme == "" {
+			t := time.Now()
+			if clab.HeroImage, err = f.SlurpImage(src, mdir, clab.HeroImage); err != nil {
+				return nil, fmt.Errorf("hero image: %v", err)
+			}
+			m.Fetch += time.Since(t)
+			if lk.url == "" && ctx.Format == "html" {
+				opts.warnings.warnf(src, "hero image %s is left out of link previews, which need absolute URLs; use -site-url", clab.HeroImage)
+			}
+		}
+	}
+	var fp *fingerprinter
+	if opts.Fingerprint && !isStdout(dir) {
+		fp = newFingerprinter(dir)
+		if err := fp.images(clab.Codelab); err != nil {
+			return nil, err
+		}
+		lk.asset = fp.asset
+	}
+	lk.warn = func(format string, args ...interface{}) {
+		opts.warnings.warnf(src, format, args...)
 	}
 	// write codelab and its metadata to disk
-	return meta, writeCodelab(dir, clab.Codelab, opts.ExtraVars, ctx)
+	t := time.Now()
+	if err := writeCodelab(dir, clab.Codelab, opts.ExtraVars, ctx, lk); err != nil {
+		return nil, err
+	}
+	m.Render = time.Since(t)
+	if opts.Badges && !isStdout(dir) {
+		if err := writeBadges(dir, clab.Codelab, ctx); err != nil {
+			return nil, err
+		}
+	}
+	if lk.qr != "" && !isStdout(dir) {
+		if err := writeQR(dir, lk.qr); err != nil {
+			return nil, fmt.Errorf("QR code: %v", err)
+		}
+	}
+	if fp != nil {
+		if err := fp.removeStale(ctx.Format == "html"); err != nil {
+			return nil, err
+		}
+	}
+	if lk.pwa && lk.pwaRoot == "" && !isStdout(dir) {
+		// last, to precache all other files
+		if err := writePWA(dir, clab.Codelab, lk); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Metrics != nil && !isStdout(dir) {
+		m.Bytes = dirSize(dir)
+	}
+	log.Debugf("exported %s in %v", meta.ID, time.Since(start).Round(time.Millisecond))
+	return meta, nil
+}
+
+// slurp returns codelab src fetched and parsed by f, unless it was
+// fetched ahead of the export, whose parser warnings are then recorded.
+func (opts CmdExportOptions) slurp(f *fetch.Fetcher, src string) (*fetchedCodelab, error) {
+	if clab := opts.fetched[src]; clab != nil {
+		if opts.warnings != nil {
+			opts.warnings.list = append(opts.warnings.list, clab.Warnings...)
+		}
+		return clab, nil
+	}
+	clab, err := f.SlurpCodelab(src)
+	if err != nil {
+		return nil, err
+	}
+	return &fetchedCodelab{Codelab: clab.Codelab, Mod: clab.Mod, Rev: clab.Rev, ParseTime: clab.ParseTime}, nil
 }
 
 func ExportCodelabMemory(src io.ReadCloser, w io.Writer, opts CmdExportOptions) (*types.Meta, error) {
-	m := fetch.NewMemoryFetcher(opts.PassMetadata, opts.MDParser)
+	m := fetch.NewMemoryFetcher(opts.parserOptions())
 	clab, err := m.SlurpCodelab(src)
 	if err != nil {
 		return nil, err
 	}
+	if err := patchCodelab(clab.Codelab, opts.Patch); err != nil {
+		return nil, err
+	}
+	if err := transform.Apply(clab.Codelab, opts.Transforms); err != nil {
+		return nil, err
+	}
+	if err := checkLinks(clab.ID, clab.Codelab, nil, opts); err != nil {
+		return nil, err
+	}
+	decorateLinks(clab.Codelab, opts)
+	if err := addVideoDurations(clab.Codelab, nil, opts); err != nil {
+		return nil, err
+	}
+	if err := checkPlaceholders(clab.Codelab); err != nil {
+		return nil, err
+	}
 
 	// codelab export context
-	lastmod := types.ContextTime(clab.Mod)
 	meta := &clab.Meta
+	meta.Revision = codelabRevision("", meta, "", opts)
+	lastmod := codelabUpdated(clab.ID, meta, clab.Mod, opts)
+	metaDate(clab.ID, "published", meta.Published, opts)
 	ctx := &types.Context{
 		Env:     opts.Expenv,
 		Format:  opts.Tmplout,
 		Prefix:  opts.Prefix,
 		MainGA:  opts.GlobalGA,
 		Updated: &lastmod,
+		Schema:  opts.Schema,
+	}
+
+	lk := codelabLook("", meta, opts, ctx.Format)
+	if err := checkA11y(clab.ID, clab.Codelab, lk.theme, opts); err != nil {
+		return nil, err
+	}
+	return meta, writeCodelabWriter(w, clab.Codelab, opts.ExtraVars, ctx, lk)
+}
+
+// codelabUpdated returns the update time of codelab m of src, whose
+// source was last modified at mod: its updated metadata, if valid,
+// opts.BuildTime, if set, or mod.
+func codelabUpdated(src string, m *types.Meta, mod time.Time, opts CmdExportOptions) types.ContextTime {
+	if t, ok := metaDate(src, "updated", m.LastUpdated, opts); ok {
+		return types.ContextTime(t)
+	}
+	if !opts.BuildTime.IsZero() {
+		return types.ContextTime(opts.BuildTime)
+	}
+	return types.ContextTime(mod)
+}
+
+// context returns opts.Context, or the background context if nil.
+func (opts CmdExportOptions) context() context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+// httpOptions returns opts.HTTP, canceled with opts.Context
+// unless it has a context of its own.
+func (opts CmdExportOptions) httpOptions() fetch.HTTPOptions {
+	o := opts.HTTP
+	if o.Context == nil {
+		o.Context = opts.Context
+	}
+	return o
+}
+
+// newFetcher returns a fetcher of codelab sources with the HTTP and import
+// options of opts, parsing them with po and sending requests with rt,
+// or a transport of the HTTP options if nil.
+// Requests are logged to log, or the default logger if nil.
+func (opts CmdExportOptions) newFetcher(po parser.Options, rt http.RoundTripper, log *logging.Logger) (*fetch.Fetcher, error) {
+	ho := opts.httpOptions()
+	if rt == nil {
+		var err error
+		if rt, err = ho.Transport(nil); err != nil {
+			return nil, err
+		}
+	}
+	f, err := fetch.NewFetcher(opts.AuthToken, po, rt, authOptions(opts.ServiceAccount)...)
+	if err != nil {
+		return nil, err
+	}
+	ho.Logger = log
+	f.SetHTTPOptions(ho)
+	f.SetImportCredentials(opts.ImportCredentials)
+	f.SetImportRoot(opts.ImportRoot)
+	if opts.ImportCache != "" {
+		f.SetImportCache(&fetch.ImportCache{
+			Dir:     opts.ImportCache,
+			TTL:     opts.ImportTTL,
+			Offline: opts.Offline,
+			Refresh: opts.RefreshImports,
+		})
+	}
+	return f, nil
+}
+
+// parserOptions returns codelab source parsing options derived from opts.
+func (opts CmdExportOptions) parserOptions() parser.Options {
+	po := *parser.NewOptions(opts.MDParser)
+	po.PassMetadata = opts.PassMetadata
+	po.BaseURL = opts.BaseURL
+	po.BaseURLExclude = opts.BaseURLExclude
+	po.Glossary = opts.Glossary
+	po.Vars = opts.Vars
+	po.Version = opts.Version
+	po.NotebookOutputs = opts.NotebookOutputs
+	po.Review = opts.Review
+	po.Strict = opts.Strict
+	po.MetaRules = opts.MetaRules
+	po.ReadingWPM = opts.ReadingWPM
+	po.DrawingFormat = opts.DrawingFormat
+	po.Chips = parser.ChipOptions{PersonLinks: opts.ChipMailto, DateLayout: opts.ChipDateLayout}
+	if opts.IframeAllowlist != nil {
+		po.IframeAllowlist = opts.IframeAllowlist
+	}
+	return po
+}
+
+// imageFormatNames returns the comma-separated names of assets.Formats.
+func imageFormatNames() string {
+	var names []string
+	for _, f := range assets.Formats {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkPlaceholders returns an error if clab is published
+// while some of its images are still placeholders.
+func checkPlaceholders(clab *types.Codelab) error {
+	if clab.Status == nil {
+		return nil
+	}
+	var published bool
+	for _, s := range *clab.Status {
+		published = published || strings.EqualFold(s, "published")
+	}
+	if !published {
+		return nil
+	}
+	var n int
+	for _, st := range clab.Steps {
+		n += len(types.PlaceholderNodes(st.Content.Nodes))
+	}
+	if n > 0 {
+		return fmt.Errorf("published codelab has %d placeholder images", n)
+	}
+	return nil
+}
+
+// optimizeImages optimizes images of codelab clab of src, exported to dir,
+// see CmdExportOptions.OptimizeImages.
+func optimizeImages(src, dir string, clab *types.Codelab, opts CmdExportOptions) error {
+	var imgs []*types.ImageNode
+	for _, st := range clab.Steps {
+		imgs = append(imgs, types.ImageNodes(st.Content.Nodes)...)
+	}
+	return assets.Optimize(dir, imgs, assets.Options{
+		Formats: opts.ImageFormats,
+		Warn: func(format string, args ...interface{}) {
+			opts.warnings.warnf(src, format, args...)
+		},
+	})
+}
+
+// patchCodelab applies JSON Patch operations stored in file to clab.
+// It is a noop if file is empty.
+func patchCodelab(clab *types.Codelab, file string) error {
+	if file == "" {
+		return nil
+	}
+	ops, err := patch.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return patch.Apply(clab, ops)
+}
+
+// addVideoDurations adds running time of videos embedded in clab
+// to its durations, if requested by opts.VideoDurations.
+// YouTube videos are only included when opts.YouTubeAPIKey is provided.
+func addVideoDurations(clab *types.Codelab, rt http.RoundTripper, opts CmdExportOptions) error {
+	if !opts.VideoDurations {
+		return nil
 	}
+	client := &http.Client{Transport: rt}
+	srcs := []fetch.DurationSource{&fetch.VimeoDurations{Client: client, HTTP: opts.HTTP}}
+	if opts.YouTubeAPIKey != "" {
+		srcs = append(srcs, &fetch.YouTubeDurations{APIKey: opts.YouTubeAPIKey, Client: client, HTTP: opts.HTTP})
+	}
+	return fetch.AddMediaDurations(clab, srcs...)
+}
+
+// look is how a codelab is rendered, see codelabLook.
+type look struct {
+	tmpl     string        // template to execute, see codelabTemplate
+	theme    *render.Theme // theme bundle of the html format, if any
+	locale   string        // locale of the viewer chrome, see render.Msg
+	nav      bool          // accessible navigation of the html format
+	progress string        // endpoint reading progress is posted to, if any
+	// analytics are providers of the html format, see codelabAnalytics.
+	analytics []*render.Analytics
+	feedback  string // feedback widget backend of the html format, if any
+	discard   bool   // content rendered to stdout is discarded, in a dry run
+	pwa       bool   // web app manifest and service worker of the html format
+	pwaRoot   string // directory of the app of pwa, relative to pages, if not the codelab
+	url       string // published URL of the codelab, if known
+	qr        string // published URL encoded as a QR code, if any
+	// asset stores inline styles and scripts of the html format,
+	// if not nil, see render.Context.Asset.
+	asset func(content, ext string) (string, error)
+	// warn reports issues of rendered output, see render.Context.Warn.
+	warn func(format string, args ...interface{})
+	// done stops rendering once done, if not nil.
+	done context.Context
+}
+
+// context returns lk.done, or the background context if nil.
+func (lk look) context() context.Context {
+	if lk.done == nil {
+		return context.Background()
+	}
+	return lk.done
+}
+
+// codelabLook returns how codelab m exported from src in format is rendered
+// with opts.
+func codelabLook(src string, m *types.Meta, opts CmdExportOptions, format string) look {
+	return look{
+		tmpl:      codelabTemplate(src, m, opts.Template, format),
+		theme:     codelabTheme(m, opts.Theme, opts.Themes, format),
+		locale:    codelabLocale(m, opts.Locale),
+		nav:       opts.A11yNav,
+		progress:  opts.ProgressURL,
+		analytics: codelabAnalytics(src, m, opts, format),
+		feedback:  opts.FeedbackWidget,
+		discard:   opts.DryRun,
+		pwa:       opts.PWA && format == "html",
+		pwaRoot:   codelabPWARoot(opts),
+		url:       codelabPublishedURL(m, opts),
+		qr:        codelabQR(m, opts),
+		done:      opts.context(),
+	}
+}
+
+// codelabPWARoot returns the directory of the web app manifest and
+// service worker of codelabs exported with opts, relative to their pages,
+// or "" for the codelab directory, see render.Context.PWARoot.
+func codelabPWARoot(opts CmdExportOptions) string {
+	if opts.PWAScope != pwaScopeDir {
+		return ""
+	}
+	return "../"
+}
+
+// codelabPublishedURL returns the published URL of codelab m under
+// opts.SiteURL, or "" if unknown.
+func codelabPublishedURL(m *types.Meta, opts CmdExportOptions) string {
+	if opts.SiteURL == "" {
+		return ""
+	}
+	return codelabURL(opts.SiteURL, m)
+}
+
+// codelabQR returns the published URL of codelab m to encode as a QR code
+// with opts, if any.
+func codelabQR(m *types.Meta, opts CmdExportOptions) string {
+	if !opts.QR || opts.SiteURL == "" {
+		return ""
+	}
+	return codelabURL(opts.SiteURL, m)
+}
+
+// codelabAnalytics returns the analytics providers of codelab m exported
+// from src in format: those of opts, followed by those of the metadata of m.
+// Invalid providers of m are logged and skipped.
+func codelabAnalytics(src string, m *types.Meta, opts CmdExportOptions, format string) []*render.Analytics {
+	if format != "html" {
+		return nil
+	}
+	res := append([]*render.Analytics(nil), opts.Analytics...)
+	for _, spec := range m.Analytics {
+		a, err := render.ParseAnalytics(spec)
+		if err != nil {
+			opts.warnings.warnf(src, "%v", err)
+			continue
+		}
+		dup := false
+		for _, b := range res {
+			dup = dup || *a == *b
+		}
+		if !dup {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// codelabLocale returns the locale of the viewer chrome of codelab m:
+// that of m as a locale variant, if any, or def otherwise.
+func codelabLocale(m *types.Meta, def string) string {
+	if m.Lang != "" {
+		return m.Lang
+	}
+	return def
+}
+
+// codelabTheme returns the theme bundle of codelab m in format:
+// for the html format, that named by the theme of m, if any,
+// or def otherwise. Themes derived from categories of Google Docs
+// need not name a bundle.
+func codelabTheme(m *types.Meta, def string, themes map[string]*render.Theme, format string) *render.Theme {
+	if format != "html" {
+		return nil
+	}
+	if t, ok := themes[m.Theme]; ok {
+		return t
+	}
+	return themes[def]
+}
+
+// codelabTemplate returns the template rendering codelab m exported from src
+// in format: for the html format, the template metadata of m, if usable
+// as described in metaTemplate, or def otherwise.
+// If the template is still empty, it is the built-in template of format.
+func codelabTemplate(src string, m *types.Meta, def, format string) string {
+	if format != "html" {
+		return format
+	}
+	t := def
+	if m.Template != "" {
+		if p, err := metaTemplate(src, m.Template); err == nil {
+			t = p
+		}
+	}
+	if t == "" {
+		return format
+	}
+	return t
+}
 
-	return meta, writeCodelabWriter(w, clab.Codelab, opts.ExtraVars, ctx)
+// metaTemplate returns the path of t, the template metadata of codelab src.
+// Only local sources may set a template: an .html file relative to the
+// directory of src, and within it.
+func metaTemplate(src, t string) (string, error) {
+	if fi, err := os.Stat(src); src == "" || src == fetch.Stdin || err != nil || fi.IsDir() {
+		return "", fmt.Errorf("template %q: only local sources may set a template", t)
+	}
+	for _, f := range render.Formats() {
+		if t == f {
+			return "", fmt.Errorf("template %q is a built-in format, not a file", t)
+		}
+	}
+	name := filepath.Clean(filepath.FromSlash(t))
+	if filepath.IsAbs(t) || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("template %q is not within the directory of the source", t)
+	}
+	if filepath.Ext(name) != ".html" {
+		return "", fmt.Errorf("template %q is not an .html file", t)
+	}
+	dir, err := filepath.Abs(filepath.Dir(src))
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(dir, name)
+	// symlinks must not lead out of the directory either
+	if rp, err := filepath.EvalSymlinks(p); err == nil {
+		rdir, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return "", err
+		}
+		if rel, err := filepath.Rel(rdir, rp); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("template %q is not within the directory of the source", t)
+		}
+	}
+	return p, nil
 }
 
-func writeCodelabWriter(w io.Writer, clab *types.Codelab, extraVars map[string]string, ctx *types.Context) error {
+// writeCodelabWriter renders codelab main content in ctx.Format into w,
+// the way lk describes.
+func writeCodelabWriter(w io.Writer, clab *types.Codelab, extraVars map[string]string, ctx *types.Context, lk look) error {
 	// main content file(s)
 	data := &struct {
 		render.Context
@@ -165,27 +1153,48 @@ func writeCodelabWriter(w io.Writer, clab *types.Codelab, extraVars map[string]s
 		Prev    bool
 		Next    bool
 	}{Context: render.Context{
-		Env:      ctx.Env,
-		Prefix:   ctx.Prefix,
-		Format:   ctx.Format,
-		GlobalGA: ctx.MainGA,
-		Updated:  time.Time(*ctx.Updated).Format(time.RFC3339),
-		Meta:     &clab.Meta,
-		Steps:    clab.Steps,
-		Extra:    extraVars,
+		Env:            ctx.Env,
+		Prefix:         ctx.Prefix,
+		Format:         ctx.Format,
+		GlobalGA:       ctx.MainGA,
+		Updated:        time.Time(*ctx.Updated).Format(time.RFC3339),
+		Meta:           &clab.Meta,
+		Steps:          clab.Steps,
+		Extra:          extraVars,
+		Schema:         ctx.Schema,
+		Theme:          lk.theme,
+		Locale:         lk.locale,
+		A11yNav:        lk.nav,
+		ProgressURL:    lk.progress,
+		Analytics:      lk.analytics,
+		FeedbackWidget: lk.feedback,
+		PWA:            lk.pwa,
+		PWARoot:        lk.pwaRoot,
+		URL:            lk.url,
+		QRURL:          lk.qr,
+		Warn:           lk.warn,
 	}}
 
-	if ctx.Format == "offline" {
-		return errors.New("exporting codelab offline is not supported for In-Memory Export")
+	if ctx.Format == "offline" || ctx.Format == "obsidian" {
+		return fmt.Errorf("exporting codelab %s is not supported for In-Memory Export", ctx.Format)
 	}
 
-	return render.Execute(w, ctx.Format, data)
+	return render.ExecuteContext(lk.context(), w, lk.tmpl, data)
+}
+
+// stdout returns where content rendered to stdout is written.
+func (lk look) stdout() io.Writer {
+	if lk.discard {
+		return ioutil.Discard
+	}
+	return os.Stdout
 }
 
 // writeCodelab stores codelab main content in ctx.Format and its metadata
 // in JSON format on disk.
 // extraVars is extra variables to pass into the template context.
-func writeCodelab(dir string, clab *types.Codelab, extraVars map[string]string, ctx *types.Context) error {
+// A single-file format is rendered the way lk describes.
+func writeCodelab(dir string, clab *types.Codelab, extraVars map[string]string, ctx *types.Context, lk look) error {
 	// output to stdout does not include metadata
 	if !isStdout(dir) {
 		// make sure codelab dir exists
@@ -208,20 +1217,75 @@ func writeCodelab(dir string, clab *types.Codelab, extraVars map[string]string,
 		Prev    bool
 		Next    bool
 	}{Context: render.Context{
-		Env:      ctx.Env,
-		Prefix:   ctx.Prefix,
-		Format:   ctx.Format,
-		GlobalGA: ctx.MainGA,
-		Updated:  time.Time(*ctx.Updated).Format(time.RFC3339),
-		Meta:     &clab.Meta,
-		Steps:    clab.Steps,
-		Extra:    extraVars,
+		Env:            ctx.Env,
+		Prefix:         ctx.Prefix,
+		Format:         ctx.Format,
+		GlobalGA:       ctx.MainGA,
+		Updated:        time.Time(*ctx.Updated).Format(time.RFC3339),
+		Meta:           &clab.Meta,
+		Steps:          clab.Steps,
+		Extra:          extraVars,
+		Schema:         ctx.Schema,
+		Theme:          lk.theme,
+		Locale:         lk.locale,
+		A11yNav:        lk.nav,
+		ProgressURL:    lk.progress,
+		Analytics:      lk.analytics,
+		FeedbackWidget: lk.feedback,
+		PWA:            lk.pwa,
+		PWARoot:        lk.pwaRoot,
+		URL:            lk.url,
+		QRURL:          lk.qr,
+		Asset:          lk.asset,
+		Warn:           lk.warn,
 	}}
+	if !isStdout(dir) {
+		data.Dir = dir
+	}
+	if ctx.Format == "obsidian" {
+		// one note per step of the environment, numbered and linked
+		// among them, and an index note linking to them
+		var steps []*types.Step
+		for _, st := range clab.Steps {
+			if stepInEnv(st, ctx.Env) {
+				steps = append(steps, st)
+			}
+		}
+		data.Steps = steps
+		notes := []string{render.ObsidianIndexNote(&clab.Meta)}
+		for i := range steps {
+			notes = append(notes, render.ObsidianStepNote(&clab.Meta, steps, i+1))
+		}
+		for i, name := range notes {
+			data.Current = nil
+			if i > 0 {
+				data.Current = steps[i-1]
+			}
+			data.StepNum = i
+			data.Prev = i > 1
+			data.Next = i > 0 && i < len(steps)
+			w := lk.stdout()
+			if !isStdout(dir) {
+				f, err := os.Create(filepath.Join(dir, name+".md"))
+				if err != nil {
+					return err
+				}
+				w = f
+				defer f.Close()
+			}
+			if err := render.ExecuteContext(lk.context(), w, ctx.Format, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	if ctx.Format != "offline" {
-		w := os.Stdout
+		w := lk.stdout()
 		if !isStdout(dir) {
 			ext := ctx.Format
-			if ext != "md" {
+			if r := render.Lookup(ctx.Format); r != nil {
+				ext = r.Ext()
+			} else if ext != "md" {
 				ext = "html"
 			}
 			f, err := os.Create(filepath.Join(dir, "index."+ext))
@@ -231,14 +1295,14 @@ func writeCodelab(dir string, clab *types.Codelab, extraVars map[string]string,
 			w = f
 			defer f.Close()
 		}
-		return render.Execute(w, ctx.Format, data)
+		return render.ExecuteContext(lk.context(), w, lk.tmpl, data)
 	}
 	for i, step := range clab.Steps {
 		data.Current = step
 		data.StepNum = i + 1
 		data.Prev = i > 0
 		data.Next = i < len(clab.Steps)-1
-		w := os.Stdout
+		w := lk.stdout()
 		if !isStdout(dir) {
 			name := "index.html"
 			if i > 0 {
@@ -251,7 +1315,7 @@ func writeCodelab(dir string, clab *types.Codelab, extraVars map[string]string,
 			w = f
 			defer f.Close()
 		}
-		if err := render.Execute(w, ctx.Format, data); err != nil {
+		if err := render.ExecuteContext(lk.context(), w, ctx.Format, data); err != nil {
 			return err
 		}
 	}
@@ -17,15 +17,22 @@ package cmd_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"log"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googlecodelabs/tools/claat/cmd"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/render"
 )
 
 func TestExportCodelabMemory(t *testing.T) {
@@ -138,3 +145,611 @@ func filterIgnoredLinePrefix(content string) string {
 
 	return strings.Join(processedContent, "\n")
 }
+
+func TestCmdExportJobs(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportJobs-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	var srcs []string
+	for _, id := range []string{"c", "bad", "a", "b"} {
+		src := path.Join(tmp, id+".md")
+		content := "id: lab-" + id + "\n\n# Lab " + id + "\n\n## Step\n\nText.\n"
+		if id == "bad" {
+			content += "{{var \"undefined\"}}\n"
+		}
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    2,
+		Output:  path.Join(tmp, "out"),
+		Srcs:    srcs,
+		Tmplout: "md",
+	})
+	if code != 1 {
+		t.Errorf("CmdExport = %d; want 1 for an invalid source", code)
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"ok\tlab-c",
+		"err\t" + srcs[1] + `:8:1 undefined variable "undefined"`,
+		"ok\tlab-a",
+		"ok\tlab-b",
+		"1 of 4 codelabs failed to export",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("log:\n%s\nwant:\n%s", buf.String(), strings.Join(want, "\n"))
+	}
+}
+
+func TestCmdExportCheckIDs(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportCheckIDs-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	var srcs []string
+	for _, f := range []struct{ name, id string }{
+		{"a.md", "lab-a"},
+		{"a.fr.md", "lab-a"},
+		{"b.md", "lab-a"},
+		{"c.md", "Lab C"},
+	} {
+		src := path.Join(tmp, f.name)
+		content := "id: " + f.id + "\n\n# Lab\n\n## Step\n\nText.\n"
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		CheckIDs: true,
+		Jobs:     1,
+		Output:   path.Join(tmp, "out"),
+		Srcs:     srcs,
+		Tmplout:  "md",
+	})
+	if code != 1 {
+		t.Errorf("CmdExport = %d; want 1 for invalid IDs", code)
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"ok\tlab-a",
+		"ok\tlab-a-fr",
+		"err\t" + srcs[2] + ` codelab ID "lab-a" is also used by ` + srcs[0],
+		"err\t" + srcs[3] + ` invalid codelab ID "Lab C": want lowercase letters and digits separated by - or _`,
+		"2 of 4 codelabs failed to export",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("log:\n%s\nwant:\n%s", buf.String(), strings.Join(want, "\n"))
+	}
+}
+
+func TestCmdExportReport(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportReport-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	var srcs []string
+	for _, id := range []string{"a", "bad", "b"} {
+		src := path.Join(tmp, id+".md")
+		content := "id: lab-" + id + "\n\n# Lab " + id + "\n\n## Step\n\nText.\n"
+		switch id {
+		case "a":
+			content += "\nPress <kbd>Enter</kbd>.\n"
+		case "bad":
+			content += "See {{var \"undefined\"}}.\n"
+		}
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	report := path.Join(tmp, "report.json")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    1,
+		OnError: "fail-fast",
+		Output:  path.Join(tmp, "out"),
+		Report:  report,
+		Srcs:    srcs,
+		Tmplout: "md",
+	})
+	if code != 1 {
+		t.Errorf("CmdExport = %d; want 1", code)
+	}
+	b, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type reportError struct {
+		Message      string
+		Line, Column int
+	}
+	var got struct {
+		OK, Failed, Skipped int
+		Sources             []struct {
+			Src, Status, ID string
+			Warnings        []string
+			Error           *reportError
+		}
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.OK != 1 || got.Failed != 1 || got.Skipped != 1 || len(got.Sources) != 3 {
+		t.Fatalf("report:\n%s\nwant 1 ok, 1 failed and 1 skipped source", b)
+	}
+	for i, want := range []string{"ok", "error", "skipped"} {
+		if s := got.Sources[i]; s.Src != srcs[i] || s.Status != want {
+			t.Errorf("%d: %s = %q; want %s = %q", i, s.Src, s.Status, srcs[i], want)
+		}
+	}
+	if id := got.Sources[0].ID; id != "lab-a" {
+		t.Errorf("ID = %q; want lab-a", id)
+	}
+	warnings := []string{"9:1: unsupported HTML element <kbd> ignored"}
+	if w := got.Sources[0].Warnings; !reflect.DeepEqual(w, warnings) {
+		t.Errorf("warnings = %q; want %q", w, warnings)
+	}
+	want := &reportError{Message: `undefined variable "undefined"`, Line: 8, Column: 5}
+	if e := got.Sources[1].Error; e == nil || *e != *want {
+		t.Errorf("error = %+v; want %+v", e, want)
+	}
+}
+
+func TestCmdExportTemplate(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportTemplate-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"run.html":      "<h1>Run: {{.Meta.Title}}</h1>\n",
+		"tmpl/lab.html": "<h1>Lab: {{.Meta.Title}} {{len .Steps}}</h1>\n",
+		"a.md":          "id: lab-a\n\n# A & B\n\n## Step\n\nText.\n",
+		"b.md":          "id: lab-b\ntemplate: tmpl/lab.html\n\n# B\n\n## Step\n\nText.\n",
+		"src/c.md":      "id: lab-c\ntemplate: ../tmpl/lab.html\n\n# C\n\n## Step\n\nText.\n",
+		"d.md":          "id: lab-d\ntemplate: /etc/passwd\n\n# D\n\n## Step\n\nText.\n",
+		"e.md":          "id: lab-e\ntemplate: md\n\n# E\n\n## Step\n\nText.\n",
+	}
+	for name, content := range files {
+		f := path.Join(tmp, name)
+		if err := os.MkdirAll(path.Dir(f), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:     1,
+		Output:   out,
+		Srcs:     []string{path.Join(tmp, "a.md"), path.Join(tmp, "b.md"), path.Join(tmp, "src/c.md"), path.Join(tmp, "d.md"), path.Join(tmp, "e.md")},
+		Template: path.Join(tmp, "run.html"),
+		Tmplout:  "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	for id, want := range map[string]string{
+		"lab-a": "<h1>Run: A &amp; B</h1>\n",
+		"lab-b": "<h1>Lab: B 1</h1>\n",
+		// templates outside the source dir and format names are ignored
+		"lab-c": "<h1>Run: C</h1>\n",
+		"lab-d": "<h1>Run: D</h1>\n",
+		"lab-e": "<h1>Run: E</h1>\n",
+	} {
+		b, err := ioutil.ReadFile(path.Join(out, id, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != want {
+			t.Errorf("%s: index.html = %q; want %q", id, b, want)
+		}
+	}
+}
+
+func TestCmdExportAnalytics(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportAnalytics-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "a.md")
+	content := "id: lab-a\nanalytics providers: ga4:G-ABC123, plausible:example.com, matomo:1\n\n# A\n\n## Step\n\nText.\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Analytics: []*render.Analytics{{Provider: render.AnalyticsGA4, ID: "G-ABC123"}, {Provider: render.AnalyticsGTM, ID: "GTM-XYZ9"}},
+		Jobs:      1,
+		Output:    out,
+		Srcs:      []string{src},
+		Tmplout:   "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	b, err := ioutil.ReadFile(path.Join(out, "lab-a", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(b)
+	if n := strings.Count(html, "gtag/js?id=G-ABC123"); n != 1 {
+		t.Errorf("index.html loads G-ABC123 %d times; want 1", n)
+	}
+	for _, want := range []string{"gtm.js?id=GTM-XYZ9", `data-domain="example.com"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("index.html does not contain %q", want)
+		}
+	}
+	if strings.Contains(html, "matomo") {
+		t.Errorf("index.html contains the invalid matomo provider")
+	}
+}
+
+func TestCmdExportHeroImage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportHeroImage-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"a.md":     "id: lab-a\nhero image: hero.png\n\n# A\n\n## Step\n\nText.\n",
+		"hero.png": "\x89PNG\r\n\x1a\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(path.Join(tmp, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    1,
+		Output:  out,
+		SiteURL: "https://codelabs.example.com/",
+		Srcs:    []string{path.Join(tmp, "a.md")},
+		Tmplout: "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	b, err := ioutil.ReadFile(path.Join(out, "lab-a", "codelab.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta struct {
+		HeroImage string `json:"hero_image"`
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(meta.HeroImage, "img/") {
+		t.Fatalf("hero_image = %q; want an img/ path", meta.HeroImage)
+	}
+	if _, err := os.Stat(path.Join(out, "lab-a", meta.HeroImage)); err != nil {
+		t.Error(err)
+	}
+	b, err = ioutil.ReadFile(path.Join(out, "lab-a", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<meta property="og:url" content="https://codelabs.example.com/lab-a/">`,
+		`<meta property="og:image" content="https://codelabs.example.com/lab-a/` + meta.HeroImage + `">`,
+		`<meta name="twitter:image" content="https://codelabs.example.com/lab-a/` + meta.HeroImage + `">`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("index.html does not contain %q", want)
+		}
+	}
+
+	// relative hero images are left out without a site URL
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	out = path.Join(tmp, "out-nosite")
+	code = cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    1,
+		Output:  out,
+		Srcs:    []string{path.Join(tmp, "a.md")},
+		Tmplout: "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport without a site URL = %d; want 0", code)
+	}
+	b, err = ioutil.ReadFile(path.Join(out, "lab-a", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "og:image") || strings.Contains(string(b), "og:url") {
+		t.Error("index.html without a site URL has a relative og:image or an og:url")
+	}
+	if !strings.Contains(logs.String(), "-site-url") {
+		t.Errorf("no warning about -site-url:\n%s", logs.String())
+	}
+}
+
+func TestCmdExportDryRun(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportDryRun-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Intro\nDuration: 2:00\n\nRead [the docs](https://example.com/docs) and [the next step](#1).\n\n" +
+		"![Diagram](img/diagram.png)\n\n## Code\nDuration: 3:00\n\nRun it:\n\n```\ngo run .\n```\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+	report := path.Join(tmp, "report.json")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		DryRun:  true,
+		Output:  path.Join(tmp, "out"),
+		Report:  report,
+		Srcs:    []string{src},
+		Tmplout: "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0\n%s", code, out.String())
+	}
+	if _, err := os.Stat(path.Join(tmp, "out")); !os.IsNotExist(err) {
+		t.Errorf("output directory: %v; want not exist", err)
+	}
+	stats := "2 steps, 10 words, 1 images, 1 code blocks, 1 external links, 5 min"
+	if !strings.Contains(out.String(), "ok\tlab: "+stats) {
+		t.Errorf("CmdExport output = %q; want %q", out.String(), stats)
+	}
+	b, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"code_blocks": 1`) {
+		t.Errorf("report:\n%s\nwant stats", b)
+	}
+}
+
+func TestCmdExportBuildTime(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportBuildTime-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\ntags: beta, kiosk\nenvironments: web\n\n# Lab\n\n## Step\nDuration: 5:00\n\nText.\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	build := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	var outputs []map[string]string
+	for i, mod := range []time.Time{build.Add(time.Hour), build.Add(48 * time.Hour)} {
+		if err := os.Chtimes(src, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+		out := path.Join(tmp, fmt.Sprintf("out%d", i))
+		code := cmd.CmdExport(cmd.CmdExportOptions{
+			BuildTime: build,
+			Output:    out,
+			SiteURL:   "https://example.com/codelabs",
+			Srcs:      []string{src},
+			Tmplout:   "html",
+		})
+		if code != 0 {
+			t.Fatalf("CmdExport = %d; want 0", code)
+		}
+		files := map[string]string{}
+		err := filepath.Walk(out, func(p string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() {
+				return err
+			}
+			b, err := ioutil.ReadFile(p)
+			files[strings.TrimPrefix(p, out)] = string(b)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, files)
+	}
+	if diff := cmp.Diff(outputs[0], outputs[1]); diff != "" {
+		t.Errorf("exports of the same source differ (-first +second):\n%s", diff)
+	}
+	if meta := outputs[0]["/lab/codelab.json"]; !strings.Contains(meta, `"updated": "2019-05-01T10:00:00Z"`) {
+		t.Errorf("codelab.json:\n%s\nwant updated at build time", meta)
+	}
+}
+
+func TestCmdExportRevision(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportRevision-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	labs := map[string]string{
+		"flag.md": "id: flag\n\n# Flag\n\n## Step\n\nText.\n",
+		"meta.md": "id: meta\nrevision: v1.2\n\n# Meta\n\n## Step\n\nText.\n",
+	}
+	var srcs []string
+	for name, content := range labs {
+		src := path.Join(tmp, name)
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		BuildTime: time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC),
+		Output:    out,
+		Revision:  "abc1234",
+		Srcs:      srcs,
+		Tmplout:   "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	for id, rev := range map[string]string{"flag": "abc1234", "meta": "v1.2"} {
+		b, err := ioutil.ReadFile(path.Join(out, id, "codelab.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf(`"revision": %q`, rev); !strings.Contains(string(b), want) {
+			t.Errorf("%s/codelab.json:\n%s\nwant %s", id, b, want)
+		}
+		b, err = ioutil.ReadFile(path.Join(out, id, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := fmt.Sprintf(`<time datetime="2019-05-01T10:00:00Z">Last updated 2019-05-01</time> · Revision <code>%s</code>`, rev)
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s/index.html has no stamp %s", id, want)
+		}
+	}
+}
+
+func TestCmdExportObsidianEnv(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportObsidianEnv-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Android\nEnvironment: android\n\nGradle.\n\n## Web\nEnvironment: web\n\nnpm.\n\n## Done\n\nBye.\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Expenv:  "web",
+		Output:  out,
+		Srcs:    []string{src},
+		Tmplout: "obsidian",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	var notes []string
+	files, err := ioutil.ReadDir(path.Join(out, "lab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range files {
+		if strings.HasSuffix(fi.Name(), ".md") {
+			notes = append(notes, fi.Name())
+		}
+	}
+	want := []string{"Lab 01 Web.md", "Lab 02 Done.md", "Lab.md"}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("notes = %q; want %q", notes, want)
+	}
+	b, err := ioutil.ReadFile(path.Join(out, "lab", "Lab 01 Web.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); strings.Contains(s, "Previous:") || !strings.Contains(s, "Next: [[Lab 02 Done]]") {
+		t.Errorf("Lab 01 Web.md:\n%s\nwant a link to the next note only", s)
+	}
+}
+
+func TestCmdExportLogJSON(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportLogJSON-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	var srcs []string
+	for id, extra := range map[string]string{"a": "Press <kbd>Enter</kbd>.\n", "bad": "See {{var \"undefined\"}}.\n"} {
+		src := path.Join(tmp, id+".md")
+		content := "id: lab-" + id + "\n\n# Lab " + id + "\n\n## Step\n\nText.\n" + extra
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	var buf bytes.Buffer
+	l, err := logging.New(&buf, logging.Info, logging.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logging.SetDefault(logging.Default())
+	logging.SetDefault(l)
+	cmd.CmdExport(cmd.CmdExportOptions{
+		Output:  path.Join(tmp, "out"),
+		Srcs:    srcs,
+		Tmplout: "md",
+	})
+
+	entries := map[string]map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e map[string]string
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		entries[e["level"]+" "+path.Base(e["src"])] = e
+	}
+	want := map[string]map[string]string{
+		"warn a.md":    {"msg": "unsupported HTML element <kbd> ignored", "pos": "7:1"},
+		"info a.md":    {"msg": "exported lab-a"},
+		"error bad.md": {"msg": "export failed", "pos": "8:5", "error": `undefined variable "undefined"`},
+	}
+	for k, fields := range want {
+		e := entries[k]
+		if e == nil {
+			t.Errorf("no %s entry in:\n%s", k, buf.String())
+			continue
+		}
+		for f, v := range fields {
+			if e[f] != v {
+				t.Errorf("%s entry: %s = %q; want %q", k, f, e[f], v)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"hash/crc64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+var (
+	// crcTable is the table of content hashes, as in fetch.Fetcher,
+	// so that copied images keep their name.
+	crcTable = crc64.MakeTable(crc64.ECMA)
+	// fingerprintRegexp matches names of content-hashed styles and scripts.
+	fingerprintRegexp = regexp.MustCompile(`^[0-9a-f]+\.(css|js)$`)
+)
+
+// fingerprinter gives content-hashed names to files of a codelab exported
+// to dir, so that they can be served with long cache lifetimes: a file
+// name never refers to other content. See CmdExportOptions.Fingerprint.
+type fingerprinter struct {
+	dir   string
+	files map[string]bool // content-hashed files, relative to dir
+}
+
+func newFingerprinter(dir string) *fingerprinter {
+	return &fingerprinter{dir: dir, files: make(map[string]bool)}
+}
+
+// images renames local images of codelab clab after a hash of their
+// content, unless they already are, such as those copied by fetch.Fetcher
+// which were not modified since, and updates the references of clab.
+// Images with the same content are merged.
+func (fp *fingerprinter) images(clab *types.Codelab) error {
+	renames := make(map[string]string)
+	rename := func(src string) (string, error) {
+		if name, ok := renames[src]; ok {
+			return name, nil
+		}
+		if filepath.Dir(src) != util.ImgDirname {
+			return src, nil
+		}
+		b, err := ioutil.ReadFile(filepath.Join(fp.dir, src))
+		if err != nil {
+			return "", err
+		}
+		name := filepath.Join(util.ImgDirname, fingerprintName(b, filepath.Ext(src)))
+		if name != src {
+			if err := os.Rename(filepath.Join(fp.dir, src), filepath.Join(fp.dir, name)); err != nil {
+				return "", err
+			}
+		}
+		renames[src] = name
+		fp.files[name] = true
+		return name, nil
+	}
+	var err error
+	for _, st := range clab.Steps {
+		for _, n := range types.ImageNodes(st.Content.Nodes) {
+			if n.Src, err = rename(n.Src); err != nil {
+				return err
+			}
+			for _, v := range n.Variants {
+				if v.Src, err = rename(v.Src); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	clab.HeroImage, err = rename(clab.HeroImage)
+	return err
+}
+
+// asset writes an inline style or script to a file named after a hash
+// of its content, and returns the name, see render.Context.Asset.
+func (fp *fingerprinter) asset(content, ext string) (string, error) {
+	name := fingerprintName([]byte(content), ext)
+	if !fp.files[name] {
+		if err := ioutil.WriteFile(filepath.Join(fp.dir, name), []byte(content), 0644); err != nil {
+			return "", err
+		}
+		fp.files[name] = true
+	}
+	return name, nil
+}
+
+// removeStale removes images and content-hashed files of previous exports,
+// which the export did not reference. Styles and scripts are only removed
+// if assets, since only exports moving them to files reference them.
+func (fp *fingerprinter) removeStale(assets bool) error {
+	imgdir := filepath.Join(fp.dir, util.ImgDirname)
+	files, err := ioutil.ReadDir(imgdir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, fi := range files {
+		if !fi.IsDir() && !fp.files[filepath.Join(util.ImgDirname, fi.Name())] {
+			if err := os.Remove(filepath.Join(imgdir, fi.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	if !assets {
+		return nil
+	}
+	if files, err = ioutil.ReadDir(fp.dir); err != nil {
+		return err
+	}
+	for _, fi := range files {
+		if !fi.IsDir() && fingerprintRegexp.MatchString(fi.Name()) && !fp.files[fi.Name()] {
+			if err := os.Remove(filepath.Join(fp.dir, fi.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fingerprintName returns the content-hashed name of file contents b
+// with extension ext.
+func fingerprintName(b []byte, ext string) string {
+	return fmt.Sprintf("%x%s", crc64.Checksum(b, crcTable), ext)
+}
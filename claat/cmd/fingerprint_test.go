@@ -0,0 +1,176 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"fmt"
+	"hash/crc64"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdExportFingerprint(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportFingerprint-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	f, err := os.Create(path.Join(tmp, "wide.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewGray(image.Rect(0, 0, 1000, 10))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Step\n\n![wide](wide.png)\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Fingerprint:    true,
+		OptimizeImages: true,
+		Output:         out,
+		Srcs:           []string{src},
+		Tmplout:        "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	dir := path.Join(out, "lab")
+	b, err := ioutil.ReadFile(path.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(b)
+	if strings.Contains(page, "<style") {
+		t.Error("index.html has inline styles")
+	}
+	if !strings.Contains(page, `<script type="application/ld+json">`) {
+		t.Error("index.html has no inline JSON-LD")
+	}
+
+	// all local references are content-hashed files
+	table := crc64.MakeTable(crc64.ECMA)
+	refs := regexp.MustCompile(`(?:href|src)="([^":/]+\.(?:css|js))"|img/[^" ,]+`).FindAllStringSubmatch(page, -1)
+	seen := map[string]bool{}
+	exts := map[string]int{}
+	for _, m := range refs {
+		ref := m[0]
+		if m[1] != "" {
+			ref = m[1]
+		}
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		b, err := ioutil.ReadFile(filepath.Join(dir, ref))
+		if err != nil {
+			t.Errorf("%s: %v", ref, err)
+			continue
+		}
+		if want := fmt.Sprintf("%x%s", crc64.Checksum(b, table), filepath.Ext(ref)); filepath.Base(ref) != want {
+			t.Errorf("%s is not named after its content hash, %s", ref, want)
+		}
+		exts[filepath.Ext(ref)]++
+	}
+	if exts[".css"] == 0 || exts[".js"] == 0 || exts[".png"] != 2 {
+		t.Errorf("index.html references %d styles, %d scripts and %d images; want some styles and scripts, and 2 images:\n%s",
+			exts[".css"], exts[".js"], exts[".png"], page)
+	}
+}
+
+func TestCmdExportFingerprintStale(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportFingerprintStale-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Step\n\n![img](img.png)\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	dir := path.Join(out, "lab")
+	for i, nav := range []bool{false, true} {
+		// the image and styles change between exports
+		f, err := os.Create(path.Join(tmp, "img.png"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := png.Encode(f, image.NewGray(image.Rect(0, 0, 10+i, 10))); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if i > 0 {
+			// other files of the codelab directory are kept
+			if err := ioutil.WriteFile(path.Join(dir, "notes.css"), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		code := cmd.CmdExport(cmd.CmdExportOptions{
+			A11yNav:     nav,
+			Fingerprint: true,
+			Output:      out,
+			Srcs:        []string{src},
+			Tmplout:     "html",
+		})
+		if code != 0 {
+			t.Fatalf("CmdExport = %d; want 0", code)
+		}
+	}
+
+	b, err := ioutil.ReadFile(path.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(b)
+	for _, d := range []string{dir, path.Join(dir, "img")} {
+		files, err := ioutil.ReadDir(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fi := range files {
+			ext := filepath.Ext(fi.Name())
+			if fi.Name() == "notes.css" || (ext != ".css" && ext != ".js" && ext != ".png") {
+				continue
+			}
+			if !strings.Contains(page, fi.Name()) {
+				t.Errorf("%s of a previous export was not removed", fi.Name())
+			}
+		}
+	}
+	if _, err := os.Stat(path.Join(dir, "notes.css")); err != nil {
+		t.Errorf("notes.css: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/i18n"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+// CmdI18nOptions type to make the CmdI18n signature succinct.
+type CmdI18nOptions struct {
+	// Export configures parsing sources, and exporting translated codelabs
+	// with apply. Its Output is where extract writes catalogs.
+	Export CmdExportOptions
+	// Format is the catalog format of extract, i18n.XLIFF or i18n.PO,
+	// unless Export.Output is a file with a catalog extension.
+	Format string
+	// SourceLang is the locale of sources which are not locale variants.
+	SourceLang string
+	// TargetLang is the locale of the translations, stored in extracted catalogs.
+	TargetLang string
+	// Translation is the translated catalog file applied with apply.
+	Translation string
+}
+
+// CmdI18n is the "claat i18n" subcommand with action "extract" or "apply".
+//
+// Extract writes translatable text of the sources to a catalog for
+// translators: a file per codelab, named after its ID, if the output is
+// a directory, a single file if it has a catalog extension, or stdout.
+// Apply exports the sources with text replaced by translations of the
+// catalog, as locale variants of the target language.
+//
+// It returns a process exit code.
+func CmdI18n(action string, opts CmdI18nOptions) int {
+	switch action {
+	case "extract":
+		return cmdI18nExtract(opts)
+	case "apply":
+		if opts.Translation == "" {
+			logging.Errorf("i18n apply: missing -translation catalog")
+			return 1
+		}
+		c, err := i18n.ReadFile(opts.Translation)
+		if err != nil {
+			logging.Errorf("i18n apply: %v", err)
+			return 1
+		}
+		if c.TargetLang == "" {
+			logging.Errorf("i18n apply: %s: unknown target language", opts.Translation)
+			return 1
+		}
+		if !i18n.ValidLang(c.TargetLang) {
+			logging.Errorf("i18n apply: %s: invalid target language %q", opts.Translation, c.TargetLang)
+			return 1
+		}
+		opts.Export.translation = c
+		return CmdExport(opts.Export)
+	}
+	logging.Errorf("i18n: unknown action %q; want extract or apply", action)
+	return 1
+}
+
+func cmdI18nExtract(opts CmdI18nOptions) int {
+	out := opts.Export.Output
+	format := opts.Format
+	single := isStdout(out)
+	if f, err := i18n.FormatOf(out); err == nil {
+		format = f
+		single = true
+	}
+	if format != i18n.XLIFF && format != i18n.PO {
+		logging.Errorf("i18n extract: unknown catalog format %q", format)
+		return 1
+	}
+	if opts.TargetLang != "" && !i18n.ValidLang(opts.TargetLang) {
+		logging.Errorf("i18n extract: invalid target language %q", opts.TargetLang)
+		return 1
+	}
+
+	var (
+		exitCode int
+		all      = &i18n.Catalog{SourceLang: opts.SourceLang, TargetLang: opts.TargetLang}
+	)
+	for _, src := range util.Unique(opts.Export.Srcs) {
+		clab, err := slurpCodelab(src, opts.Export)
+		if err != nil {
+			logging.Errorf(reportErr, errSource(src, err), err)
+			exitCode = 1
+			continue
+		}
+		cl := i18n.Extract(clab)
+		if single {
+			all.Codelabs = append(all.Codelabs, cl)
+			logging.Infof(reportOk, clab.ID)
+			continue
+		}
+		c := &i18n.Catalog{SourceLang: opts.SourceLang, TargetLang: opts.TargetLang, Codelabs: []*i18n.Codelab{cl}}
+		if clab.Lang != "" {
+			c.SourceLang = clab.Lang
+		}
+		name := filepath.Join(out, clab.ID+i18n.Ext(format))
+		if err := writeCatalog(name, format, c); err != nil {
+			logging.Errorf(reportErr, src, err)
+			exitCode = 1
+			continue
+		}
+		logging.Infof(reportOk, clab.ID)
+	}
+	if !single {
+		return exitCode
+	}
+	if err := writeCatalog(out, format, all); err != nil {
+		logging.Errorf("i18n extract: %v", err)
+		return 1
+	}
+	return exitCode
+}
+
+// slurpCodelab fetches and parses the codelab of src with opts.
+func slurpCodelab(src string, opts CmdExportOptions) (*types.Codelab, error) {
+	f, err := opts.newFetcher(opts.parserOptions(), nil, logging.With("src", src))
+	if err != nil {
+		return nil, err
+	}
+	clab, err := f.SlurpCodelab(src)
+	if err != nil {
+		return nil, err
+	}
+	return clab.Codelab, nil
+}
+
+// writeCatalog stores catalog c in format to file name, or stdout.
+func writeCatalog(name, format string, c *i18n.Catalog) error {
+	if isStdout(name) {
+		return i18n.Write(os.Stdout, format, c)
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := i18n.Write(f, format, c); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// translateCodelab replaces text of clab exported from src with
+// translations of opts.translation, if any, making it a locale variant
+// of the target language. Untranslated text is reported as warnings.
+func translateCodelab(src string, clab *types.Codelab, opts CmdExportOptions) error {
+	c := opts.translation
+	if c == nil {
+		return nil
+	}
+	cl := c.Lookup(clab.ID)
+	if cl == nil {
+		return fmt.Errorf("codelab %q is not in the translation catalog", clab.ID)
+	}
+	// the target language becomes part of the codelab ID and output path
+	id := clab.ID + "-" + strings.ToLower(c.TargetLang)
+	if !i18n.ValidLang(c.TargetLang) || !types.ValidID(id) {
+		return fmt.Errorf("invalid target language %q of the translation catalog", c.TargetLang)
+	}
+	_, msgs := i18n.Apply(clab, cl.Units)
+	for _, m := range msgs {
+		opts.warnings.warnf(src, "translation %s", m)
+	}
+	clab.Group = clab.ID
+	clab.ID = id
+	clab.URL = clab.ID
+	clab.Lang = c.TargetLang
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// idRegistry records sources of codelab IDs exported in a single
+// invocation, where codelabs with the same ID overwrite each other.
+type idRegistry struct {
+	mu  sync.Mutex
+	ids map[string]string // codelab ID => source
+}
+
+func newIDRegistry() *idRegistry {
+	return &idRegistry{ids: make(map[string]string)}
+}
+
+// claim records codelab id as exported from src. It returns an error
+// if another source has already claimed the id.
+func (r *idRegistry) claim(id, src string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if other, ok := r.ids[id]; ok && other != src {
+		return fmt.Errorf("codelab ID %q is also used by %s", id, other)
+	}
+	r.ids[id] = src
+	return nil
+}
+
+// checkID returns an error if id of the codelab exported from src
+// is not a valid slug, or is used by another source of the same export.
+// It does nothing unless opts.CheckIDs is set.
+func checkID(src, id string, opts CmdExportOptions) error {
+	if !opts.CheckIDs {
+		return nil
+	}
+	if !types.ValidID(id) {
+		return fmt.Errorf("invalid codelab ID %q: want lowercase letters and digits separated by - or _", id)
+	}
+	if opts.ids == nil {
+		return nil
+	}
+	return opts.ids.claim(id, src)
+}
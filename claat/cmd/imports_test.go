@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestExportCodelabOffline(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestExportCodelabOffline-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Step\n\n<<https://example.com/frag.md>>\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cmd.ExportCodelab(src, nil, cmd.CmdExportOptions{
+		ImportCache: path.Join(tmp, "cache"),
+		Offline:     true,
+		Output:      path.Join(tmp, "out"),
+		Tmplout:     "html",
+	})
+	if err == nil || !strings.Contains(err.Error(), "https://example.com/frag.md is not in the import cache") {
+		t.Errorf("ExportCodelab err = %v; want uncached import error", err)
+	}
+}
+
+func TestExportCodelabRelativeImports(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestExportCodelabRelativeImports-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	// fragments are relative to the source, not the current directory
+	opts := cmd.CmdExportOptions{
+		ImportRoot: "testdata",
+		Output:     tmp,
+		Tmplout:    "md",
+	}
+	meta, err := cmd.ExportCodelab("testdata/import-test.md", nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path.Join(tmp, meta.ID, "index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "content 1") {
+		t.Errorf("export does not contain imported fragment:\n%s", b)
+	}
+
+	opts.ImportRoot = tmp
+	if _, err := cmd.ExportCodelab("testdata/import-test.md", nil, opts); err == nil {
+		t.Error("ExportCodelab with fragments outside the import root = nil error; want an error")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// utmMedium is the utm_medium value of decorated external links.
+const utmMedium = "codelab"
+
+// CmdExportOptions.CheckLinks values.
+const (
+	checkLinksWarn = "warn"
+	checkLinksFail = "fail"
+)
+
+// decorateLinks adds target, rel and UTM query parameters to external links
+// of clab, as requested by opts.ExternalLinks and opts.UTMSource.
+//
+// A link is external if it has an http or https scheme and its host
+// differs from that of opts.BaseURL.
+// Existing UTM parameters of a link are never overwritten.
+func decorateLinks(clab *types.Codelab, opts CmdExportOptions) {
+	if !opts.ExternalLinks && opts.UTMSource == "" {
+		return
+	}
+	var host string
+	if u, err := url.Parse(opts.BaseURL); err == nil {
+		host = u.Host
+	}
+	utm := url.Values{
+		"utm_source":   {opts.UTMSource},
+		"utm_medium":   {utmMedium},
+		"utm_campaign": {clab.ID},
+	}
+	for _, st := range clab.Steps {
+		for _, n := range types.URLNodes(st.Content.Nodes) {
+			u, err := url.Parse(n.URL)
+			if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == host {
+				continue
+			}
+			if opts.ExternalLinks {
+				n.Target = "_blank"
+				n.Rel = "noopener"
+			}
+			if opts.UTMSource == "" {
+				continue
+			}
+			q := u.Query()
+			for k, v := range utm {
+				if _, ok := q[k]; !ok && v[0] != "" {
+					q[k] = v
+				}
+			}
+			u.RawQuery = q.Encode()
+			n.URL = u.String()
+		}
+	}
+}
+
+// newLinkChecker returns a link checker of opts, sending requests with rt.
+func newLinkChecker(rt http.RoundTripper, opts CmdExportOptions) *fetch.LinkChecker {
+	return &fetch.LinkChecker{
+		Client:    &http.Client{Transport: rt},
+		Allowlist: opts.LinkAllowlist,
+		Retries:   opts.HTTP.Retries,
+		Backoff:   opts.HTTP.Backoff,
+		Timeout:   opts.HTTP.Timeout,
+		Context:   opts.httpOptions().Context,
+	}
+}
+
+// checkLinks requests external links and images of clab, exported from src,
+// if requested by opts.CheckLinks. Each broken link is logged.
+// It returns an error if some links are broken and opts.CheckLinks is "fail".
+func checkLinks(src string, clab *types.Codelab, rt http.RoundTripper, opts CmdExportOptions) error {
+	if opts.CheckLinks == "" {
+		return nil
+	}
+	lc := opts.links
+	if lc == nil {
+		lc = newLinkChecker(rt, opts)
+	}
+	broken := lc.Check(clab)
+	for _, b := range broken {
+		opts.warnings.warnf(src, "broken link: %s", b)
+	}
+	if len(broken) > 0 && opts.CheckLinks == checkLinksFail {
+		return fmt.Errorf("%d broken links", len(broken))
+	}
+	return nil
+}
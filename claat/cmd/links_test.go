@@ -0,0 +1,103 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestDecorateLinks(t *testing.T) {
+	tests := []struct {
+		url, want, rel string
+	}{
+		{"https://golang.org/doc", "https://golang.org/doc?utm_campaign=clab&utm_medium=codelab&utm_source=site", "noopener"},
+		{"https://golang.org/?utm_source=mine", "https://golang.org/?utm_campaign=clab&utm_medium=codelab&utm_source=mine", "noopener"},
+		{"https://example.com/other/", "https://example.com/other/", ""},
+		{"#anchor", "#anchor", ""},
+		{"mailto:me@example.com", "mailto:me@example.com", ""},
+	}
+	opts := CmdExportOptions{
+		BaseURL:       "https://example.com/codelabs/",
+		ExternalLinks: true,
+		UTMSource:     "site",
+	}
+	for i, test := range tests {
+		clab := types.NewCodelab()
+		clab.ID = "clab"
+		n := types.NewURLNode(test.url, types.NewTextNode("link"))
+		clab.NewStep("one").Content.Append(n)
+		decorateLinks(clab, opts)
+		if n.URL != test.want {
+			t.Errorf("%d: n.URL = %q; want %q", i, n.URL, test.want)
+		}
+		if n.Rel != test.rel {
+			t.Errorf("%d: n.Rel = %q; want %q", i, n.Rel, test.rel)
+		}
+	}
+}
+
+func TestCheckPlaceholders(t *testing.T) {
+	clab := types.NewCodelab()
+	st := clab.NewStep("Step")
+	st.Content.Append(types.NewPlaceholderNode("login screen"))
+	if err := checkPlaceholders(clab); err != nil {
+		t.Errorf("draft: checkPlaceholders = %v", err)
+	}
+	clab.Status = &types.LegacyStatus{"Published"}
+	if err := checkPlaceholders(clab); err == nil {
+		t.Error("published: checkPlaceholders = nil; want error")
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		code := http.StatusOK
+		if r.URL.Path == "/gone" {
+			code = http.StatusGone
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+	clab := types.NewCodelab()
+	st := clab.NewStep("Step")
+	st.Content.Append(types.NewURLNode("https://example.com/gone"))
+
+	if err := checkLinks("src", clab, rt, CmdExportOptions{}); err != nil {
+		t.Errorf("disabled: checkLinks = %v", err)
+	}
+	if err := checkLinks("src", clab, rt, CmdExportOptions{CheckLinks: checkLinksWarn}); err != nil {
+		t.Errorf("warn: checkLinks = %v", err)
+	}
+	if err := checkLinks("src", clab, rt, CmdExportOptions{CheckLinks: checkLinksFail}); err == nil {
+		t.Error("fail: checkLinks = nil; want error")
+	}
+	opts := CmdExportOptions{CheckLinks: checkLinksFail, LinkAllowlist: []string{"example.com"}}
+	if err := checkLinks("src", clab, rt, opts); err != nil {
+		t.Errorf("allowlisted: checkLinks = %v", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
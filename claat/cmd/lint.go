@@ -0,0 +1,203 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/googlecodelabs/tools/claat/lint"
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+// Options type to make the CmdLint signature succinct.
+type CmdLintOptions struct {
+	// Config configures lint rules. If nil, all rules are checked
+	// with default settings.
+	Config *lint.Config
+	// Fix writes corrections back to the source files.
+	Fix bool
+	// Format is the report format: "text", the default, or "sarif".
+	Format string
+	// MDParser is the underlying Markdown parser to use.
+	MDParser parser.MarkdownParser
+	// MetaRules constrain metadata of checked codelabs,
+	// see parser.Options.MetaRules.
+	MetaRules map[string]*parser.MetaRule
+	// ReadingWPM estimates durations of steps with none, reported
+	// under lint.RuleMissingDuration, see parser.Options.ReadingWPM.
+	ReadingWPM int
+	// Srcs is the local Markdown sources to check.
+	// Directories are searched for .md files recursively.
+	Srcs []string
+	// Vars are values of {{var "key"}} references in codelab sources.
+	Vars map[string]string
+	// Version is the claat version, reported in SARIF logs.
+	Version string
+}
+
+// CmdLint is the "claat lint ..." subcommand.
+// It returns a process exit code, which is non-zero if at least
+// one issue remains unfixed or a source could not be processed.
+func CmdLint(opts CmdLintOptions) int {
+	if len(opts.Srcs) == 0 {
+		logging.Fatalf("Need at least one source. Try '-h' for options.")
+	}
+	if opts.Format != "" && opts.Format != "text" && opts.Format != "sarif" {
+		logging.Fatalf("Unknown lint format %q; want text or sarif", opts.Format)
+	}
+	srcs, err := lintSources(opts.Srcs)
+	if err != nil {
+		logging.Fatalf("%v", err)
+	}
+	var (
+		exitCode int
+		reports  []*lint.Report
+		parsed   []*lint.Source
+		checked  []*lint.Report // reports of parsed sources
+	)
+	popts := *parser.NewOptions(opts.MDParser)
+	popts.Vars = opts.Vars
+	popts.MetaRules = opts.MetaRules
+	popts.ReadingWPM = opts.ReadingWPM
+	for _, src := range srcs {
+		rep, s, err := lintFile(src, opts, popts)
+		if rep != nil {
+			reports = append(reports, rep)
+		}
+		if err != nil {
+			logging.Errorf(reportErr, errSource(src, err), err)
+			exitCode = 1
+			continue
+		}
+		parsed = append(parsed, s)
+		checked = append(checked, rep)
+	}
+	for i, s := range parsed {
+		checked[i].Issues = append(checked[i].Issues, lint.Check(s, parsed, opts.Config)...)
+	}
+	for _, rep := range reports {
+		var placeholders int
+		for _, is := range rep.Issues {
+			if !is.Fixed {
+				exitCode = 1
+			}
+			if is.Rule == lint.RulePlaceholder {
+				placeholders++
+			}
+			if opts.Format == "sarif" {
+				continue
+			}
+			if log, _ := sourceLogger(rep.File, types.Position{Line: is.Line, Column: is.Column}); log.JSON() {
+				log.With("rule", is.Rule).Warnf("%s", is.Message)
+			} else {
+				log.Warnf("%s", lintIssue(rep.File, is))
+			}
+		}
+		if placeholders > 0 && opts.Format != "sarif" {
+			log, prefix := sourceLogger(rep.File, types.Position{})
+			log.Warnf("%s%d outstanding placeholder images", prefix, placeholders)
+		}
+	}
+	if opts.Format == "sarif" {
+		if err := lint.WriteSARIF(os.Stdout, opts.Version, reports); err != nil {
+			logging.Errorf("SARIF: %v", err)
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
+
+// lintIssue formats issue is of file src as "src:line:column: message".
+func lintIssue(src string, is *lint.Issue) string {
+	if is.Line > 0 {
+		return src + ":" + is.String()
+	}
+	return src + ": " + is.String()
+}
+
+// lintSources returns srcs with directories replaced by all .md files
+// they contain, recursively.
+func lintSources(srcs []string) ([]string, error) {
+	var res []string
+	for _, src := range util.Unique(srcs) {
+		fi, err := os.Stat(src)
+		if err != nil || !fi.IsDir() {
+			res = append(res, src)
+			continue
+		}
+		var files []string
+		err = filepath.Walk(src, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() && filepath.Ext(p) == ".md" {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		res = append(res, files...)
+	}
+	return util.Unique(res), nil
+}
+
+// lintFile checks a local Markdown file src for issues of the source text
+// and parses it for lint.Check. If opts.Fix is true, the corrected content
+// is written back to src and parsed instead.
+// If parsing fails, the report of the source text is returned with the error.
+func lintFile(src string, opts CmdLintOptions, popts parser.Options) (*lint.Report, *lint.Source, error) {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, fixed := lint.Markdown(b, opts.Fix, opts.Config)
+	if opts.Config.Enabled(lint.RulePlaceholder) {
+		issues = append(issues, lint.Placeholders(fixed)...)
+	}
+	if opts.Fix && len(issues) > 0 {
+		if err := ioutil.WriteFile(src, fixed, fi.Mode()); err != nil {
+			return nil, nil, err
+		}
+	}
+	var warnings []*parser.Warning
+	popts.WarningSink = func(w *parser.Warning) { warnings = append(warnings, w) }
+	rep := &lint.Report{File: src, Issues: issues}
+	clab, err := parser.Parse("md", bytes.NewReader(fixed), popts)
+	if err != nil {
+		return rep, nil, err
+	}
+	base := src
+	if b, _, ok := localeSuffix(src); ok {
+		if _, err := os.Stat(b); err == nil {
+			base = b
+		}
+	}
+	s := &lint.Source{Name: src, Base: base, Content: fixed, Codelab: clab, Warnings: warnings}
+	return rep, s, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/lint"
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+// TestLintFileParseError makes sure issues of the source text are reported
+// along with the error of a source failing to parse.
+func TestLintFileParseError(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestLintFileParseError-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "lab.md")
+	if err := ioutil.WriteFile(src, []byte("# Lab\n\n### Intro\n\n> > > deep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	popts := *parser.NewOptions(parser.Blackfriday)
+	popts.MaxDepth = 3
+	rep, s, err := lintFile(src, CmdLintOptions{}, popts)
+	if _, ok := err.(*parser.ErrTooDeep); !ok {
+		t.Fatalf("lintFile: err = %v; want *parser.ErrTooDeep", err)
+	}
+	if s != nil {
+		t.Errorf("lintFile: source = %+v; want nil", s)
+	}
+	if rep == nil || len(rep.Issues) != 1 || rep.Issues[0].Rule != lint.RuleHeadingLevel {
+		t.Errorf("lintFile: report = %+v; want one %s issue", rep, lint.RuleHeadingLevel)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/i18n"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// localeSuffix splits a locale-suffixed Markdown source name,
+// e.g. "foo.fr.md" or "foo.pt-BR.md", into its base name "foo.md"
+// and the locale. The suffix must be a BCP 47 language tag, so that
+// names such as "foo.v2.md" are not mistaken for locale variants.
+func localeSuffix(src string) (base, lang string, ok bool) {
+	name := strings.TrimSuffix(src, ".md")
+	ext := filepath.Ext(name)
+	if name == src || ext == "" || !i18n.ValidLang(ext[1:]) {
+		return "", "", false
+	}
+	return strings.TrimSuffix(name, ext) + ".md", ext[1:], true
+}
+
+// locale is a locale variant of a codelab and its siblings.
+type locale struct {
+	lang  string   // locale of the variant
+	langs []string // locales of all variants of the codelab, sorted
+}
+
+// localeVariants groups local Markdown srcs which are locale variants of
+// the same codelab, such as "foo.md", "foo.fr.md" and "foo.ja.md".
+// The unsuffixed source is assumed to be written in defLang.
+//
+// The returned map is keyed by source. Only sources of a group with
+// the unsuffixed base source, such as "foo.md", are included.
+func localeVariants(srcs []string, defLang string) map[string]*locale {
+	isSrc := make(map[string]bool)
+	for _, src := range srcs {
+		isSrc[src] = true
+	}
+	groups := make(map[string][]string) // base source => srcs
+	langs := make(map[string]string)    // src => locale
+	for _, src := range srcs {
+		if base, lang, ok := localeSuffix(src); ok && isSrc[base] {
+			groups[base] = append(groups[base], src)
+			langs[src] = lang
+		}
+	}
+	for base, g := range groups {
+		groups[base] = append(g, base)
+		langs[base] = defLang
+	}
+
+	res := make(map[string]*locale)
+	for _, g := range groups {
+		var all []string
+		seen := make(map[string]bool)
+		for _, src := range g {
+			if l := langs[src]; !seen[l] {
+				seen[l] = true
+				all = append(all, l)
+			}
+		}
+		sort.Strings(all)
+		for _, src := range g {
+			res[src] = &locale{lang: langs[src], langs: all}
+		}
+	}
+	return res
+}
+
+// applyLocale marks clab as locale variant l and lists its sibling variants.
+// The ID of all but the defLang variant is suffixed with the variant locale.
+// Variants are expected to share the same codelab ID in their metadata.
+func applyLocale(clab *types.Codelab, l *locale, defLang string) {
+	if l == nil {
+		return
+	}
+	group := clab.ID
+	id := func(lang string) string {
+		if lang == defLang {
+			return group
+		}
+		return group + "-" + strings.ToLower(lang)
+	}
+	clab.ID = id(l.lang)
+	clab.URL = clab.ID
+	clab.Lang = l.lang
+	clab.Group = group
+	clab.Translations = nil
+	for _, lang := range l.langs {
+		clab.Translations = append(clab.Translations, &types.Translation{Lang: lang, ID: id(lang)})
+	}
+}
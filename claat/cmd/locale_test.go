@@ -0,0 +1,67 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestLocaleVariants(t *testing.T) {
+	srcs := []string{"a/foo.md", "a/foo.fr.md", "a/foo.pt-BR.md", "bar.md", "bar.v2.md", "baz.ja.md", "1abc"}
+	got := localeVariants(srcs, "en")
+	want := map[string]*locale{
+		"a/foo.md":       {lang: "en", langs: []string{"en", "fr", "pt-BR"}},
+		"a/foo.fr.md":    {lang: "fr", langs: []string{"en", "fr", "pt-BR"}},
+		"a/foo.pt-BR.md": {lang: "pt-BR", langs: []string{"en", "fr", "pt-BR"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("localeVariants(%q) = %+v; want %+v", srcs, got, want)
+	}
+}
+
+func TestLocaleSuffix(t *testing.T) {
+	tests := []struct {
+		src, base, lang string
+	}{
+		{"a/foo.fr.md", "a/foo.md", "fr"},
+		{"foo.zh-Hant-TW.md", "foo.md", "zh-Hant-TW"},
+		{"foo.v2.md", "", ""},
+		{"foo.pt_BR.md", "", ""},
+		{"foo.md", "", ""},
+		{"foo.fr.txt", "", ""},
+	}
+	for _, tc := range tests {
+		base, lang, ok := localeSuffix(tc.src)
+		if base != tc.base || lang != tc.lang || ok != (tc.lang != "") {
+			t.Errorf("localeSuffix(%q) = %q, %q, %v; want %q, %q", tc.src, base, lang, ok, tc.base, tc.lang)
+		}
+	}
+}
+
+func TestApplyLocale(t *testing.T) {
+	clab := types.NewCodelab()
+	clab.ID = "foo"
+	applyLocale(clab, &locale{lang: "pt-BR", langs: []string{"en", "pt-BR"}}, "en")
+	if clab.ID != "foo-pt-br" || clab.Group != "foo" || clab.Lang != "pt-BR" {
+		t.Errorf("clab.ID, Group, Lang = %q, %q, %q; want foo-pt-br, foo, pt-BR", clab.ID, clab.Group, clab.Lang)
+	}
+	want := []*types.Translation{{Lang: "en", ID: "foo"}, {Lang: "pt-BR", ID: "foo-pt-br"}}
+	if !reflect.DeepEqual(clab.Translations, want) {
+		t.Errorf("clab.Translations = %+v; want %+v", clab.Translations, want)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/metrics"
+)
+
+// writeMetrics writes metrics of prom to opts.MetricsFile and pushes them
+// to opts.MetricsPush, if not empty. Metrics are pushed even if the export
+// was interrupted. It returns false if they could not be written or pushed.
+func writeMetrics(prom *metrics.Prometheus, opts CmdExportOptions) bool {
+	ok := true
+	if opts.MetricsFile != "" {
+		if err := prom.WriteFile(opts.MetricsFile); err != nil {
+			logging.Errorf(reportErr, opts.MetricsFile, err)
+			ok = false
+		}
+	}
+	if opts.MetricsPush != "" {
+		ho := opts.HTTP
+		ho.Context = nil
+		rt, err := ho.Transport(nil)
+		if err == nil {
+			err = prom.Push(&http.Client{Transport: rt}, opts.MetricsPush)
+		}
+		if err != nil {
+			logging.Errorf(reportErr, opts.MetricsPush, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// checkPushURL returns an error if u is not an absolute http(s) URL.
+func checkPushURL(u string) error {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if (pu.Scheme != "http" && pu.Scheme != "https") || pu.Host == "" {
+		return fmt.Errorf("%q is not an absolute http(s) URL", u)
+	}
+	return nil
+}
+
+// dirSize returns the total size of files in dir and its subdirectories.
+func dirSize(dir string) int64 {
+	var n int64
+	filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err == nil && fi.Mode().IsRegular() {
+			n += fi.Size()
+		}
+		return nil
+	})
+	return n
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/metrics"
+)
+
+func TestExportCodelabMetrics(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestExportCodelabMetrics-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "lab.md")
+	if err := ioutil.WriteFile(src, []byte("id: lab\n\n# Lab\n\n## Step\n\nHello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var got []*metrics.Export
+	opts := CmdExportOptions{
+		Metrics: metrics.RecorderFunc(func(e *metrics.Export) {
+			mu.Lock()
+			got = append(got, e)
+			mu.Unlock()
+		}),
+		Output:  filepath.Join(tmp, "out"),
+		Tmplout: "html",
+	}
+	if _, err := ExportCodelab(src, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExportCodelab(filepath.Join(tmp, "missing.md"), nil, opts); err == nil {
+		t.Fatal("ExportCodelab of a missing source = nil error")
+	}
+	if len(got) != 2 {
+		t.Fatalf("%d exports recorded; want 2", len(got))
+	}
+	m := got[0]
+	if m.Src != src || m.ID != "lab" || m.Err != nil {
+		t.Errorf("Src, ID, Err = %q, %q, %v; want %q, lab, nil", m.Src, m.ID, m.Err, src)
+	}
+	if m.Render <= 0 || m.Total < m.Fetch+m.Parse+m.Render {
+		t.Errorf("Fetch, Parse, Render, Total = %v, %v, %v, %v; want rendering timed within the total", m.Fetch, m.Parse, m.Render, m.Total)
+	}
+	if m.Bytes != dirSize(filepath.Join(tmp, "out", "lab")) || m.Bytes == 0 {
+		t.Errorf("Bytes = %d; want the size of the codelab dir", m.Bytes)
+	}
+	if m := got[1]; m.Err == nil || m.ID != "" {
+		t.Errorf("failed export recorded as ID %q, Err %v", m.ID, m.Err)
+	}
+
+	// CmdExport writes the textfile of all codelabs
+	opts.Metrics = nil
+	opts.MetricsFile = filepath.Join(tmp, "claat.prom")
+	opts.Srcs = []string{src}
+	if code := CmdExport(opts); code != 0 {
+		t.Fatalf("CmdExport = %d", code)
+	}
+	b, err := ioutil.ReadFile(opts.MetricsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `claat_export_success{src="`+src+`",codelab="lab"} 1`) {
+		t.Errorf("metrics file does not report the export:\n%s", b)
+	}
+}
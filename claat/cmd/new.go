@@ -0,0 +1,264 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// DefaultStarter is the starter template of CmdNew if none is given.
+const DefaultStarter = "basic"
+
+// CmdNewOptions type to make the CmdNew signature succinct.
+type CmdNewOptions struct {
+	// ID is the ID of the new codelab, also the name of its directory
+	// and Markdown source.
+	ID string
+	// Output is the directory to create the codelab directory in.
+	Output string
+	// Starter is the name of the starter template, one of Starters.
+	// If empty, DefaultStarter is used.
+	Starter string
+}
+
+// starter is a starter template of CmdNew.
+type starter struct {
+	Summary      string
+	Categories   string
+	Environments string
+	// Setup is the Markdown of the steps between the overview
+	// and the survey, with the codelab ID and title as {{.ID}}
+	// and {{.Title}}.
+	Setup string
+}
+
+// starters are the starter templates of CmdNew, by name.
+var starters = map[string]*starter{
+	"basic": {
+		Summary:      "Learn the basics of writing a codelab.",
+		Categories:   "getting-started",
+		Environments: "web",
+		Setup: "## Write your first step\nDuration: 5:00\n\n" +
+			"Each step starts with a level 2 heading and its duration in minutes.\n" +
+			"Text supports **bold**, *italic*, `inline code` and [links](https://github.com/googlecodelabs/tools).\n\n" +
+			"![The {{.Title}} logo](img/hero.svg)\n\n" +
+			"Code blocks keep their language for highlighting:\n\n" +
+			"```console\n$ claat export {{.ID}}.md\n```\n\n" +
+			"Negative\n: Negative info boxes warn readers about common mistakes.\n",
+	},
+	"gcp": {
+		Summary:      "Deploy your first service on Google Cloud.",
+		Categories:   "cloud",
+		Environments: "web",
+		Setup: "## Set up your environment\nDuration: 5:00\n\n" +
+			"1. Sign in to the [Google Cloud console](https://console.cloud.google.com/) and create a project.\n" +
+			"2. Enable billing for the project.\n" +
+			"3. Open [Cloud Shell](https://shell.cloud.google.com/) and set the project:\n\n" +
+			"```console\n$ gcloud config set project PROJECT_ID\n```\n\n" +
+			"Negative\n: Running through this codelab may incur costs. Clean up the resources at the end to avoid further charges.\n\n" +
+			"## Enable the APIs\nDuration: 2:00\n\n" +
+			"Enable the APIs used in this codelab:\n\n" +
+			"```console\n$ gcloud services enable run.googleapis.com\n```\n\n" +
+			"## Deploy\nDuration: 10:00\n\n" +
+			"![The {{.Title}} architecture](img/hero.svg)\n\n" +
+			"Deploy the sample service:\n\n" +
+			"```console\n$ gcloud run deploy {{.ID}} --source . --region us-central1\n```\n\n" +
+			"## Clean up\nDuration: 2:00\n\n" +
+			"Delete the project to stop all charges:\n\n" +
+			"```console\n$ gcloud projects delete PROJECT_ID\n```\n",
+	},
+	"web": {
+		Summary:      "Build your first web app.",
+		Categories:   "web",
+		Environments: "web",
+		Setup: "## Set up the project\nDuration: 5:00\n\n" +
+			"Create a directory for the app and initialize it:\n\n" +
+			"```console\n$ mkdir {{.ID}} && cd {{.ID}}\n$ npm init -y\n```\n\n" +
+			"## Create the page\nDuration: 10:00\n\n" +
+			"![The {{.Title}} page](img/hero.svg)\n\n" +
+			"Add an `index.html` file:\n\n" +
+			"```html\n<!doctype html>\n<html lang=\"en\">\n  <head>\n    <title>{{.Title}}</title>\n  </head>\n  <body>\n    <h1>Hello, world!</h1>\n  </body>\n</html>\n```\n\n" +
+			"Serve it locally and open http://localhost:8080:\n\n" +
+			"```console\n$ npx http-server -p 8080\n```\n",
+	},
+}
+
+// Starters returns the names of the starter templates of CmdNew, sorted.
+func Starters() []string {
+	var names []string
+	for name := range starters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// starterSource is the Markdown source of a new codelab.
+// Its setup template is defined by the starter.
+const starterSource = `authors: Your Name
+summary: {{.Starter.Summary}}
+id: {{.ID}}
+categories: {{.Starter.Categories}}
+environments: {{.Starter.Environments}}
+status: draft
+feedback link: https://github.com/your-org/your-repo/issues
+hero image: img/hero.svg
+
+# {{.Title}}
+
+## Overview
+Duration: 2:00
+
+Describe what readers build in this codelab and why.
+
+### What you'll learn
+- How to write steps
+- How to add code, images and info boxes
+
+### What you'll need
+- A text editor
+- The claat tool
+
+Positive
+: Positive info boxes hold tips and best practices.
+
+{{template "setup" .}}
+## Check your understanding
+Duration: 2:00
+
+<form>
+  <name>Which command turns this file into a codelab?</name>
+  <input value="claat export" checked>
+  <input value="claat serve">
+</form>
+
+## Congratulations
+Duration: 1:00
+
+You have completed {{.Title}}.
+
+<button>
+  [Download the sample code](https://github.com/your-org/your-repo/archive/main.zip)
+</button>
+
+### Further reading
+- [Codelab format guide](https://github.com/googlecodelabs/tools/blob/main/FORMAT-GUIDE.md)
+`
+
+// starterHero is the hero image of a new codelab, img/hero.svg.
+const starterHero = `<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630" viewBox="0 0 1200 630">
+  <rect width="1200" height="630" fill="#1a73e8"/>
+  <text x="600" y="330" fill="#fff" font-family="sans-serif" font-size="64" text-anchor="middle">{{.Title}}</text>
+</svg>
+`
+
+// CmdNew is the "claat new" subcommand.
+//
+// It creates a directory named after the codelab ID in the output
+// directory, with a starter Markdown source of the template, <id>.md,
+// and an img directory of assets, which the source refers to.
+// Existing directories are never overwritten.
+//
+// It returns a process exit code.
+func CmdNew(opts CmdNewOptions) int {
+	if !types.ValidID(opts.ID) {
+		logging.Errorf(reportErr, opts.ID, "invalid codelab ID; want lowercase letters and digits separated by - or _")
+		return 1
+	}
+	name := opts.Starter
+	if name == "" {
+		name = DefaultStarter
+	}
+	st, ok := starters[name]
+	if !ok {
+		logging.Errorf(reportErr, opts.ID, fmt.Sprintf("unknown starter %q; want %s", name, strings.Join(Starters(), ", ")))
+		return 1
+	}
+	dir := filepath.Join(opts.Output, opts.ID)
+	if _, err := os.Stat(dir); err == nil {
+		logging.Errorf(reportErr, opts.ID, fmt.Sprintf("%s already exists", dir))
+		return 1
+	}
+	files, err := newCodelabFiles(opts.ID, st)
+	if err != nil {
+		logging.Errorf(reportErr, opts.ID, err)
+		return 1
+	}
+	for _, f := range []string{opts.ID + ".md", filepath.Join("img", "hero.svg")} {
+		p := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			logging.Errorf(reportErr, opts.ID, err)
+			return 1
+		}
+		if err := ioutil.WriteFile(p, files[f], 0644); err != nil {
+			logging.Errorf(reportErr, opts.ID, err)
+			return 1
+		}
+	}
+	logging.Infof(reportOk, filepath.Join(dir, opts.ID+".md"))
+	return 0
+}
+
+// newCodelabFiles returns the files of a new codelab id of starter st,
+// by their path in the codelab directory.
+func newCodelabFiles(id string, st *starter) (map[string][]byte, error) {
+	data := struct {
+		ID, Title string
+		Starter   *starter
+	}{id, starterTitle(id), st}
+	t, err := template.New("source").Parse(starterSource)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.New("setup").Parse(st.Setup); err != nil {
+		return nil, err
+	}
+	var src bytes.Buffer
+	if err := t.Execute(&src, data); err != nil {
+		return nil, err
+	}
+	hero, err := template.New("hero").Parse(starterHero)
+	if err != nil {
+		return nil, err
+	}
+	var img bytes.Buffer
+	if err := hero.Execute(&img, data); err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		id + ".md":                       src.Bytes(),
+		filepath.Join("img", "hero.svg"): img.Bytes(),
+	}, nil
+}
+
+// starterTitle returns a title of codelab id, e.g. "My Codelab"
+// of "my-codelab".
+func starterTitle(id string) string {
+	words := strings.FieldsFunc(id, func(r rune) bool { return r == '-' || r == '_' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
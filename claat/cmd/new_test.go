@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdNew(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdNew-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, name := range cmd.Starters() {
+		id := "my-" + name + "-codelab"
+		if code := cmd.CmdNew(cmd.CmdNewOptions{ID: id, Output: tmp, Starter: name}); code != 0 {
+			t.Fatalf("%s: CmdNew = %d; want 0", name, code)
+		}
+		src := path.Join(tmp, id, id+".md")
+		if _, err := os.Stat(path.Join(tmp, id, "img", "hero.svg")); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if code := cmd.CmdLint(cmd.CmdLintOptions{Srcs: []string{src}}); code != 0 {
+			t.Errorf("%s: CmdLint = %d; want 0", name, code)
+		}
+		out := path.Join(tmp, "out")
+		if code := cmd.CmdExport(cmd.CmdExportOptions{Output: out, Srcs: []string{src}, Tmplout: "html"}); code != 0 {
+			t.Fatalf("%s: CmdExport = %d; want 0", name, code)
+		}
+		b, err := ioutil.ReadFile(path.Join(out, id, "codelab.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), `"id": "`+id+`"`) {
+			t.Errorf("%s: codelab.json = %s; want id %q", name, b, id)
+		}
+		b, err = ioutil.ReadFile(path.Join(out, id, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"<google-codelab-survey", "<paper-button", `class="special"`} {
+			if !strings.Contains(string(b), want) {
+				t.Errorf("%s: index.html does not contain %q", name, want)
+			}
+		}
+	}
+
+	if code := cmd.CmdNew(cmd.CmdNewOptions{ID: "my-basic-codelab", Output: tmp}); code == 0 {
+		t.Errorf("CmdNew of an existing codelab = 0; want 1")
+	}
+	if code := cmd.CmdNew(cmd.CmdNewOptions{ID: "My Codelab", Output: tmp}); code == 0 {
+		t.Errorf("CmdNew of an invalid ID = 0; want 1")
+	}
+	if code := cmd.CmdNew(cmd.CmdNewOptions{ID: "other", Output: tmp, Starter: "nope"}); code == 0 {
+		t.Errorf("CmdNew of an unknown template = 0; want 1")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// previewServer renders codelabs of Markdown sources in a directory
+// on each request, without exporting them first.
+//
+// Rendered codelabs are cached in memory until their source file changes.
+// Other files of a codelab, such as images, are served from the directory
+// of its source, where relative references of the source point to.
+type previewServer struct {
+	dir  string
+	opts CmdExportOptions
+
+	mu    sync.Mutex
+	cache map[string]*preview // source file => rendered codelab
+}
+
+// preview is a codelab rendered from source file src modified at mod.
+type preview struct {
+	src  string
+	mod  time.Time
+	meta *types.Meta // nil if err is set
+	err  error
+	page []byte
+}
+
+func newPreviewServer(dir string, opts CmdExportOptions) *previewServer {
+	if opts.Tmplout == "" {
+		opts.Tmplout = "html"
+	}
+	return &previewServer{
+		dir:   dir,
+		opts:  opts,
+		cache: make(map[string]*preview),
+	}
+}
+
+func (ps *previewServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := path.Clean("/" + r.URL.Path)
+	previews := ps.previews()
+	if p == "/" {
+		entries := make([]*indexEntry, len(previews))
+		for i, pv := range previews {
+			entries[i] = &indexEntry{Src: pv.src, Err: pv.err}
+			if pv.err == nil {
+				entries[i].URL = "/" + pv.meta.ID + "/"
+				entries[i].Meta = pv.meta
+			}
+		}
+		serveIndex(w, entries, false)
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 2)
+	for _, pv := range previews {
+		if pv.err != nil || pv.meta.ID != parts[0] {
+			continue
+		}
+		if len(parts) == 1 && !strings.HasSuffix(r.URL.Path, "/") {
+			http.Redirect(w, r, "/"+pv.meta.ID+"/", http.StatusFound)
+			return
+		}
+		if len(parts) == 2 && parts[1] != "index.html" {
+			http.ServeFile(w, r, filepath.Join(filepath.Dir(pv.src), filepath.FromSlash(parts[1])))
+			return
+		}
+		w.Header().Set("Cache-Control", "no-cache")
+		http.ServeContent(w, r, "index.html", pv.mod, bytes.NewReader(pv.page))
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// previews returns codelabs of all Markdown sources in ps.dir, recursively,
+// sorted by source file name. Hidden and exported codelab directories
+// are skipped. Sources modified since they were last
+// rendered are rendered again.
+func (ps *previewServer) previews() []*preview {
+	var srcs []string
+	filepath.Walk(ps.dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if fi.IsDir() && p != ps.dir && strings.HasPrefix(fi.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if fi.IsDir() {
+			// skip exported codelabs, e.g. of the md format
+			if _, err := os.Stat(filepath.Join(p, metaFilename)); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		if !fi.IsDir() && filepath.Ext(p) == ".md" {
+			srcs = append(srcs, p)
+		}
+		return nil
+	})
+	sort.Strings(srcs)
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	res := make([]*preview, 0, len(srcs))
+	for _, src := range srcs {
+		fi, err := os.Stat(src)
+		if err != nil {
+			continue
+		}
+		pv := ps.cache[src]
+		if pv == nil || !pv.mod.Equal(fi.ModTime()) {
+			pv = ps.render(src, fi.ModTime())
+			ps.cache[src] = pv
+		}
+		res = append(res, pv)
+	}
+	return res
+}
+
+// render renders codelab src, modified at mod, in ps.opts.Tmplout format.
+func (ps *previewServer) render(src string, mod time.Time) *preview {
+	pv := &preview{src: src, mod: mod}
+	f, err := os.Open(src)
+	if err != nil {
+		pv.err = err
+		return pv
+	}
+	var b bytes.Buffer
+	pv.meta, pv.err = ExportCodelabMemory(f, &b, ps.opts)
+	pv.page = b.Bytes()
+	return pv
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	_ "github.com/googlecodelabs/tools/claat/parser/md"
+)
+
+func TestPreviewServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-preview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "lab.md")
+	write := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("lab.md", "id: my-lab\n\n# My Lab\n\n## Step One\n\nFirst version.\n")
+	write(filepath.Join("img", "a.png"), "png")
+
+	ps := newPreviewServer(dir, CmdExportOptions{MDParser: parser.Blackfriday, Tmplout: "md"})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ps.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	if body := get("/").Body.String(); !strings.Contains(body, `<a href="/my-lab/">My Lab</a>`) {
+		t.Errorf("index = %q; want a link to my-lab", body)
+	}
+	if rec := get("/my-lab"); rec.Code != http.StatusFound {
+		t.Errorf("/my-lab: code = %d; want redirect", rec.Code)
+	}
+	if body := get("/my-lab/").Body.String(); !strings.Contains(body, "First version.") {
+		t.Errorf("/my-lab/ = %q; want rendered codelab", body)
+	}
+	if body := get("/my-lab/img/a.png").Body.String(); body != "png" {
+		t.Errorf("/my-lab/img/a.png = %q; want source image", body)
+	}
+	if rec := get("/other/"); rec.Code != http.StatusNotFound {
+		t.Errorf("/other/: code = %d; want 404", rec.Code)
+	}
+
+	write("lab.md", "id: my-lab\n\n# My Lab\n\n## Step One\n\nSecond version.\n")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(src, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if body := get("/my-lab/").Body.String(); !strings.Contains(body, "Second version.") {
+		t.Errorf("modified /my-lab/ = %q; want re-rendered codelab", body)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc64"
+	htmlTemplate "html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	textTemplate "text/template"
+	"unicode"
+
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Files of installable codelabs, see CmdExportOptions.PWA.
+const (
+	manifestFilename = "manifest.webmanifest"
+	swFilename       = "sw.js"
+	iconFilename     = "icon.svg"
+)
+
+// Scopes of installable codelabs, see CmdExportOptions.PWAScope.
+const (
+	// pwaScopeCodelab makes each codelab an app of its own.
+	pwaScopeCodelab = "codelab"
+	// pwaScopeDir makes all codelabs of the output directory a single app,
+	// whose start page lists them.
+	pwaScopeDir = "dir"
+)
+
+// remoteRefRegexp matches scripts and stylesheets of a page loaded
+// from other origins, with their URL as a submatch.
+var remoteRefRegexp = regexp.MustCompile(`<(?:script\b|link rel="stylesheet")[^>]*?\b(?:src|href)="((?:https?:)?//[^"]+)"`)
+
+// webManifest is a web app manifest, see https://www.w3.org/TR/appmanifest/.
+type webManifest struct {
+	Name            string             `json:"name"`
+	ShortName       string             `json:"short_name"`
+	Description     string             `json:"description,omitempty"`
+	Lang            string             `json:"lang,omitempty"`
+	StartURL        string             `json:"start_url"`
+	Scope           string             `json:"scope"`
+	Display         string             `json:"display"`
+	BackgroundColor string             `json:"background_color"`
+	ThemeColor      string             `json:"theme_color"`
+	Icons           []*webManifestIcon `json:"icons"`
+}
+
+type webManifestIcon struct {
+	Src   string `json:"src"`
+	Sizes string `json:"sizes"`
+	Type  string `json:"type"`
+}
+
+// writePWA writes the web app manifest, icon and service worker
+// of codelab clab exported to dir, rendered with look lk.
+// The service worker precaches all files of dir, and scripts and
+// stylesheets which its pages load from other origins.
+func writePWA(dir string, clab *types.Codelab, lk look) error {
+	color := render.ThemeColor(lk.theme)
+	m := &webManifest{
+		Name:            clab.Title,
+		ShortName:       clab.Title,
+		Description:     clab.Summary,
+		Lang:            clab.Lang,
+		StartURL:        "./",
+		Scope:           "./",
+		Display:         "standalone",
+		BackgroundColor: "#ffffff",
+		ThemeColor:      color,
+		Icons:           []*webManifestIcon{{Src: iconFilename, Sizes: "any", Type: "image/svg+xml"}},
+	}
+	return writePWAFiles(dir, clab.ID, m)
+}
+
+// writeDirPWA writes the web app manifest, icon, service worker and
+// start page of the single app of codelabs metas exported to dir,
+// with scope pwaScopeDir. The service worker precaches all files of dir,
+// including those of each codelab directory.
+func writeDirPWA(dir string, metas []*types.Meta) error {
+	metas = append([]*types.Meta(nil), metas...)
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Title < metas[j].Title })
+	var page strings.Builder
+	if err := pwaStartPage.Execute(&page, metas); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte(page.String()), 0644); err != nil {
+		return err
+	}
+	m := &webManifest{
+		Name:            "Codelabs",
+		ShortName:       "Codelabs",
+		StartURL:        "./",
+		Scope:           "./",
+		Display:         "standalone",
+		BackgroundColor: "#ffffff",
+		ThemeColor:      render.ThemeColor(nil),
+		Icons:           []*webManifestIcon{{Src: iconFilename, Sizes: "any", Type: "image/svg+xml"}},
+	}
+	return writePWAFiles(dir, pwaScopeDir, m)
+}
+
+// writePWAFiles writes manifest m, its icon, and the service worker
+// of the app of directory dir, whose cache is named after id.
+func writePWAFiles(dir, id string, m *webManifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, manifestFilename), append(b, '\n'), 0644); err != nil {
+		return err
+	}
+	var icon strings.Builder
+	if err := pwaIcon.Execute(&icon, struct{ Color, Initial string }{m.ThemeColor, titleInitial(m.Name)}); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, iconFilename), []byte(icon.String()), 0644); err != nil {
+		return err
+	}
+
+	files, remote, err := pwaFiles(dir)
+	if err != nil {
+		return err
+	}
+	var sw strings.Builder
+	err = pwaWorker.Execute(&sw, struct {
+		Cache  string
+		Files  []string
+		Remote []string
+	}{pwaCacheName(id, dir, files, remote), files, remote})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, swFilename), []byte(sw.String()), 0644)
+}
+
+// pwaFiles returns the files of dir which its service worker precaches,
+// relative to dir, and the remote URLs loaded by its pages.
+// Directories with an index.html page are precached as well,
+// such as "./" and "my-codelab/".
+func pwaFiles(dir string) (files, remote []string, err error) {
+	seen := make(map[string]bool)
+	err = filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if path.Base(rel) == swFilename {
+			// workers are not precached, nor those left
+			// in codelab directories by other scopes
+			return nil
+		}
+		if path.Base(rel) == "index.html" {
+			files = append(files, path.Dir(rel)+"/")
+		}
+		files = append(files, rel)
+		if filepath.Ext(p) != ".html" {
+			return nil
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		for _, m := range remoteRefRegexp.FindAllStringSubmatch(string(b), -1) {
+			u := htmlUnescape(m[1])
+			if strings.HasPrefix(u, "//") {
+				u = "https:" + u
+			}
+			if !seen[u] {
+				seen[u] = true
+				remote = append(remote, u)
+			}
+		}
+		return nil
+	})
+	sort.Strings(remote)
+	return files, remote, err
+}
+
+// pwaCacheName returns the name of the service worker cache of codelab id,
+// which changes with the content of its files, relative to dir,
+// and remote URLs, so that updated codelabs replace stale caches.
+func pwaCacheName(id, dir string, files, remote []string) string {
+	h := crc64.New(crcTable)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\n", f)
+		h.Write(b)
+	}
+	for _, u := range remote {
+		fmt.Fprintf(h, "%s\n", u)
+	}
+	return fmt.Sprintf("claat-%s-%x", id, h.Sum64())
+}
+
+// titleInitial returns the first letter or digit of title, uppercase,
+// or "C" if there is none.
+func titleInitial(title string) string {
+	for _, r := range title {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return string(unicode.ToUpper(r))
+		}
+	}
+	return "C"
+}
+
+// htmlUnescape unescapes the entities html/template escapes in URLs.
+func htmlUnescape(s string) string {
+	if !strings.Contains(s, "&") {
+		return s
+	}
+	return strings.NewReplacer("&amp;", "&", "&#43;", "+", "&#39;", "'", "&#34;", `"`).Replace(s)
+}
+
+// pwaIcon is the icon of installed codelabs, their initial
+// on their theme color.
+var pwaIcon = htmlTemplate.Must(htmlTemplate.New("icon").Parse(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 512 512">
+  <rect width="512" height="512" rx="96" fill="{{.Color}}"/>
+  <text x="256" y="340" font-family="Roboto, Arial, sans-serif" font-size="260" font-weight="500" fill="#fff" text-anchor="middle">{{.Initial}}</text>
+</svg>
+`))
+
+// pwaStartPage is the start page of the single app of the codelabs
+// of a directory, linking to each of them.
+var pwaStartPage = htmlTemplate.Must(htmlTemplate.New("start").Parse(`<!doctype html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Codelabs</title>
+  <link rel="manifest" href="manifest.webmanifest">
+  <style>
+    body { font-family: Roboto, Arial, sans-serif; margin: 0 auto; max-width: 48em; padding: 1em; }
+    li { margin: 1em 0; }
+  </style>
+</head>
+<body>
+  <h1>Codelabs</h1>
+  <ul>
+  {{- range .}}
+    <li><a href="{{.ID}}/">{{.Title}}</a>{{with .Summary}}<br>{{.}}{{end}}</li>
+  {{- end}}
+  </ul>
+  <script>
+    if ('serviceWorker' in navigator) {
+      navigator.serviceWorker.register('sw.js');
+    }
+  </script>
+</body>
+</html>
+`))
+
+// pwaWorker is the service worker of installable codelabs.
+// Files, remote scripts and stylesheets, and the fonts of stylesheets
+// are precached on install, stale caches of the app removed on
+// activation, and requests answered from the cache first, caching other
+// responses as they are fetched. Pages match regardless of their query.
+var pwaWorker = textTemplate.Must(textTemplate.New("sw").Funcs(textTemplate.FuncMap{
+	"js": func(v interface{}) (string, error) {
+		b, err := json.MarshalIndent(v, "", "  ")
+		return string(b), err
+	},
+}).Parse(`// Service worker of an installable codelab, generated by claat.
+const CACHE = {{js .Cache}};
+const FILES = {{js .Files}};
+const REMOTE = {{js .Remote}};
+
+// precache caches url, a remote script or stylesheet, and the fonts
+// which a stylesheet loads, such as woff2 files of fonts.gstatic.com,
+// so that icons and text render offline.
+const precache = (cache, url) => fetch(url, {mode: 'cors'})
+  .then((res) => {
+    if (!res.ok) {
+      throw new Error(url + ': ' + res.status);
+    }
+    const css = (res.headers.get('content-type') || '').startsWith('text/css');
+    return cache.put(url, res.clone()).then(() => css ? res.text() : '');
+  })
+  .then((css) => Promise.all(Array.from(css.matchAll(/url\((['"]?)([^'")]+)\1\)/g))
+    .map((m) => new URL(m[2], url).href)
+    // fonts are requested with CORS, which opaque responses fail
+    .map((font) => fetch(font, {mode: 'cors'})
+      .then((res) => res.ok && cache.put(font, res))
+      .catch(() => {}))))
+  // other origins may not allow CORS; opaque responses are fine
+  .catch(() => fetch(new Request(url, {mode: 'no-cors'}))
+    .then((res) => cache.put(url, res))
+    .catch(() => {}));
+
+self.addEventListener('install', (event) => {
+  event.waitUntil(caches.open(CACHE).then((cache) => Promise.all([
+    cache.addAll(FILES),
+    ...REMOTE.map((url) => precache(cache, url)),
+  ])).then(() => self.skipWaiting()));
+});
+
+self.addEventListener('activate', (event) => {
+  const prefix = CACHE.replace(/-[0-9a-f]+$/, '-');
+  event.waitUntil(caches.keys().then((keys) => Promise.all(keys
+    .filter((key) => key !== CACHE && key.replace(/-[0-9a-f]+$/, '-') === prefix)
+    .map((key) => caches.delete(key))
+  )).then(() => self.clients.claim()));
+});
+
+self.addEventListener('fetch', (event) => {
+  if (event.request.method !== 'GET') {
+    return;
+  }
+  event.respondWith(caches.open(CACHE).then((cache) =>
+    // pages are cached without their query, such as ?index=..%2F..index
+    cache.match(event.request, {ignoreSearch: event.request.mode === 'navigate'}).then((cached) => cached ||
+      fetch(event.request).then((res) => {
+        if (res.ok || res.type === 'opaque') {
+          cache.put(event.request, res.clone());
+        }
+        return res;
+      }))));
+});
+`))
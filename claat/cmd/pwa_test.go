@@ -0,0 +1,203 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdExportPWA(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportPWA-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\nsummary: Offline lab\n\n# Lab\n\n## Step\n\n![logo](logo.png)\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(tmp, "logo.png"), []byte("\x89PNG"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Fingerprint: true,
+		Output:      out,
+		PWA:         true,
+		Prefix:      "https://cdn.example.com",
+		Srcs:        []string{src},
+		Tmplout:     "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	dir := path.Join(out, "lab")
+
+	b, err := ioutil.ReadFile(path.Join(dir, "manifest.webmanifest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m struct {
+		StartURL string `json:"start_url"`
+		Icons    []struct{ Src string }
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.StartURL != "./" || len(m.Icons) != 1 {
+		t.Errorf("manifest.webmanifest:\n%s\nwant start_url ./ and an icon", b)
+	}
+	if _, err := os.Stat(path.Join(dir, m.Icons[0].Src)); err != nil {
+		t.Errorf("icon: %v", err)
+	}
+
+	b, err = ioutil.ReadFile(path.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `<link rel="manifest" href="manifest.webmanifest">`) {
+		t.Error("index.html has no manifest link")
+	}
+	b, err = ioutil.ReadFile(path.Join(dir, "sw.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := string(b)
+	for _, want := range []string{
+		`const CACHE = "claat-lab-`,
+		`"index.html"`,
+		`"codelab.json"`,
+		`"img/`,
+		`"https://cdn.example.com/codelab-elements/codelab-elements.js"`,
+		`"https://fonts.googleapis.com/icon?family=Material+Icons"`,
+		`"./"`,
+		"ignoreSearch: event.request.mode === 'navigate'",
+		"css.matchAll(",
+	} {
+		if !strings.Contains(sw, want) {
+			t.Errorf("sw.js has no %s:\n%s", want, sw)
+		}
+	}
+	// the page registering the worker is a fingerprinted script
+	var registered bool
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range files {
+		if !strings.HasSuffix(fi.Name(), ".js") || fi.Name() == "sw.js" {
+			continue
+		}
+		b, err := ioutil.ReadFile(path.Join(dir, fi.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), "serviceWorker.register('sw.js')") {
+			registered = true
+			if !strings.Contains(sw, `"`+fi.Name()+`"`) {
+				t.Errorf("sw.js does not precache %s", fi.Name())
+			}
+		}
+	}
+	if !registered {
+		t.Error("no script registers sw.js")
+	}
+}
+
+func TestCmdExportPWADir(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportPWADir-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	var srcs []string
+	for _, id := range []string{"lab1", "lab2"} {
+		src := path.Join(tmp, id+".md")
+		content := "id: " + id + "\nsummary: Offline " + id + "\n\n# Title " + id + "\n\n## Step\n\nText.\n"
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Output:   out,
+		PWA:      true,
+		PWAScope: "dir",
+		Srcs:     srcs,
+		Tmplout:  "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+
+	b, err := ioutil.ReadFile(path.Join(out, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<link rel="manifest" href="manifest.webmanifest">`, `<a href="lab1/">Title lab1</a>`, `<a href="lab2/">Title lab2</a>`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("index.html has no %s:\n%s", want, b)
+		}
+	}
+	if _, err := os.Stat(path.Join(out, "manifest.webmanifest")); err != nil {
+		t.Error(err)
+	}
+	b, err = ioutil.ReadFile(path.Join(out, "sw.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := string(b)
+	for _, want := range []string{`const CACHE = "claat-dir-`, `"./"`, `"index.html"`, `"lab1/"`, `"lab1/index.html"`, `"lab2/codelab.json"`} {
+		if !strings.Contains(sw, want) {
+			t.Errorf("sw.js has no %s:\n%s", want, sw)
+		}
+	}
+
+	for _, id := range []string{"lab1", "lab2"} {
+		dir := path.Join(out, id)
+		if _, err := os.Stat(path.Join(dir, "sw.js")); !os.IsNotExist(err) {
+			t.Errorf("%s/sw.js: %v; want no worker of the codelab", id, err)
+		}
+		b, err := ioutil.ReadFile(path.Join(dir, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := string(b)
+		for _, want := range []string{`<link rel="manifest" href="../manifest.webmanifest">`, `sw.js');`} {
+			if !strings.Contains(page, want) {
+				t.Errorf("%s/index.html has no %s", id, want)
+			}
+		}
+		if strings.Contains(page, "register('sw.js')") {
+			t.Errorf("%s/index.html registers a worker of the codelab", id)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/qr"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// QR code images of the published URL of codelabs, see CmdExportOptions.QR.
+const (
+	qrSVGFilename = "qr.svg"
+	qrPNGFilename = "qr.png"
+)
+
+// qrScale is the width of QR code modules in qr.png, in pixels.
+const qrScale = 10
+
+// codelabURL returns the URL of codelab m, hosted under site URL site.
+func codelabURL(site string, m *types.Meta) string {
+	return strings.TrimSuffix(site, "/") + "/" + m.ID + "/"
+}
+
+// writeQR writes QR code images of url to dir.
+func writeQR(dir, url string) error {
+	c, err := qr.Encode(url)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, qrSVGFilename), []byte(c.SVG()+"\n"), 0644); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, qrPNGFilename))
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, c.Image(qrScale)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
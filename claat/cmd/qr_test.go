@@ -0,0 +1,78 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdExportQR(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportQR-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "lab.md")
+	content := "id: lab\n\n# Lab\n\n## Step\n\nText.\n"
+	if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Output:  out,
+		QR:      true,
+		SiteURL: "https://codelabs.example.com",
+		Srcs:    []string{src},
+		Tmplout: "pdf",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	dir := path.Join(out, "lab")
+
+	b, err := ioutil.ReadFile(path.Join(dir, "qr.svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "<svg ") {
+		t.Errorf("qr.svg:\n%s", b)
+	}
+	b, err = ioutil.ReadFile(path.Join(dir, "qr.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := png.Decode(bytes.NewReader(b)); err != nil {
+		t.Errorf("qr.png: %v", err)
+	}
+	b, err = ioutil.ReadFile(path.Join(dir, "index.pdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "/URI (https://codelabs.example.com/lab/)") {
+		t.Error("index.pdf does not link to the published codelab")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// CmdExportOptions.OnError values.
+const (
+	// onErrorContinue exports all sources regardless of failures.
+	onErrorContinue = "continue"
+	// onErrorFailFast stops exporting sources after the first failure.
+	onErrorFailFast = "fail-fast"
+)
+
+// Export report statuses of a source.
+const (
+	statusOK      = "ok"
+	statusError   = "error"
+	statusSkipped = "skipped"
+)
+
+// exportReport is the machine-readable outcome of an export,
+// written with the -report option.
+type exportReport struct {
+	OK      int             `json:"ok"`
+	Failed  int             `json:"failed"`
+	Skipped int             `json:"skipped"`
+	Sources []*sourceReport `json:"sources"`
+}
+
+// sourceReport is the export outcome of a single source.
+type sourceReport struct {
+	Src      string       `json:"src"`
+	Status   string       `json:"status"`
+	ID       string       `json:"id,omitempty"`
+	Warnings []string     `json:"warnings,omitempty"`
+	Error    *reportError `json:"error,omitempty"`
+	// Stats are statistics of the codelab, reported by a dry run.
+	Stats *codelabStats `json:"stats,omitempty"`
+}
+
+// reportError is an export error, located in the source if possible.
+type reportError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// positioner is implemented by errors located in a source,
+// such as parser.ErrUndefinedVar.
+type positioner interface {
+	Position() (line, column int)
+}
+
+// newReportError returns the report of err, locating it if it or any
+// error it wraps is a positioner.
+func newReportError(err error) *reportError {
+	re := &reportError{Message: err.Error()}
+	var p positioner
+	if errors.As(err, &p) {
+		re.Line, re.Column = p.Position()
+	}
+	return re
+}
+
+// errPos returns the position of err in its source, if err or any error
+// it wraps is a positioner, or the zero position.
+func errPos(err error) types.Position {
+	var p positioner
+	if !errors.As(err, &p) {
+		return types.Position{}
+	}
+	line, col := p.Position()
+	return types.Position{Line: line, Column: col}
+}
+
+// errSource returns src suffixed with ":line:column" of err,
+// if err or any error it wraps is located in the source.
+func errSource(src string, err error) string {
+	if pos := errPos(err); pos.IsValid() {
+		return src + ":" + pos.String()
+	}
+	return src
+}
+
+// sourceLogger returns the logger of entries about src, at pos if valid,
+// and the prefix of their messages, such as "lab.md:3:1: ". JSON entries
+// have src and pos fields instead, and no prefix.
+func sourceLogger(src string, pos types.Position) (*logging.Logger, string) {
+	log, name := logging.With("src", src), src
+	if pos.IsValid() {
+		log = log.With("pos", pos.String())
+		name += ":" + pos.String()
+	}
+	if log.JSON() {
+		return log, ""
+	}
+	return log, name + ": "
+}
+
+// logSourceErr logs the failure of src with err: as a reportErr line
+// of text, or with src, pos and error fields in JSON.
+func logSourceErr(src string, err error) {
+	log, _ := sourceLogger(src, errPos(err))
+	if log.JSON() {
+		log.With("error", err).Errorf("export failed")
+		return
+	}
+	log.Errorf(reportErr, errSource(src, err), err)
+}
+
+// logSourceOK logs the success of src, exported as msg, such as
+// its codelab ID: as a reportOk line of text, or with a src field in JSON.
+func logSourceOK(src, msg string) {
+	log := logging.With("src", src)
+	if log.JSON() {
+		log.Infof("exported %s", msg)
+		return
+	}
+	log.Infof(reportOk, msg)
+}
+
+// add records the outcome of exporting src, counting it by status.
+func (r *exportReport) add(sr *sourceReport) {
+	switch sr.Status {
+	case statusOK:
+		r.OK++
+	case statusError:
+		r.Failed++
+	case statusSkipped:
+		r.Skipped++
+	}
+	r.Sources = append(r.Sources, sr)
+}
+
+// write stores r as indented JSON in file name.
+func (r *exportReport) write(name string) error {
+	if r.Sources == nil {
+		r.Sources = []*sourceReport{}
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if dir := filepath.Dir(name); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(name, b, 0644)
+}
+
+// warnings collects warnings of exporting a single source.
+// A nil *warnings only logs them.
+type warnings struct {
+	list []string
+}
+
+// sink returns a parser warning sink which logs warnings of src,
+// located as "src:line:column" in text, and records them in w.
+func (w *warnings) sink(src string) parser.WarningSink {
+	return func(pw *parser.Warning) {
+		log, prefix := sourceLogger(src, pw.Pos)
+		log.Warnf("%s%s", prefix, pw.Message)
+		if w != nil {
+			w.list = append(w.list, pw.String())
+		}
+	}
+}
+
+// warnf logs a warning about src and records it in w.
+func (w *warnings) warnf(src, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log, prefix := sourceLogger(src, types.Position{})
+	log.Warnf("%s%s", prefix, msg)
+	if w != nil {
+		w.list = append(w.list, msg)
+	}
+}
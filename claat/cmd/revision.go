@@ -0,0 +1,77 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// revisionAuto is the CmdExportOptions.Revision value stamping codelabs
+// with the revision of their source: the last git commit of local files,
+// or the version of Google Docs.
+const revisionAuto = "auto"
+
+// codelabRevision returns the source revision of codelab m of src, whose
+// fetched source is at revision rev, if known: its revision metadata,
+// if any, or one derived from opts.Revision. Local sources are only
+// looked up in git if src is not empty.
+func codelabRevision(src string, m *types.Meta, rev string, opts CmdExportOptions) string {
+	if m.Revision != "" || opts.Revision == "" {
+		return m.Revision
+	}
+	if opts.Revision != revisionAuto {
+		return opts.Revision
+	}
+	if rev != "" || src == "" {
+		return rev
+	}
+	if _, err := os.Stat(src); err != nil {
+		// remote sources other than Google Docs have no revision
+		return ""
+	}
+	rev, err := gitRevision(src)
+	if err != nil {
+		opts.warnings.warnf(src, "no git revision: %v", err)
+	}
+	return rev
+}
+
+// gitRevision returns the abbreviated hash of the last git commit
+// of local file name, or an error if it is not committed.
+func gitRevision(name string) (string, error) {
+	dir, base := filepath.Split(name)
+	if dir == "" {
+		dir = "."
+	}
+	cmd := exec.Command("git", "-C", dir, "log", "-1", "--format=%h", "--", base)
+	b, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", err
+	}
+	rev := strings.TrimSpace(string(b))
+	if rev == "" {
+		return "", fmt.Errorf("%s is not committed", name)
+	}
+	return rev, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/schema"
+)
+
+// CmdSchema is the "claat schema" subcommand.
+// It prints the JSON Schema definition of the export schema version,
+// schema.Latest if empty, to stdout.
+// It returns a process exit code.
+func CmdSchema(version string) int {
+	if version == "" {
+		version = schema.Latest
+	}
+	def, err := schema.Definition(version)
+	if err != nil {
+		logging.Errorf("%v", err)
+		return 1
+	}
+	fmt.Print(def)
+	return 0
+}
@@ -15,27 +15,341 @@
 package cmd
 
 import (
-	"log"
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+const (
+	// reloadPath is the URL path of the live reload event stream.
+	reloadPath = "/_claat/reload"
+	// watchInterval is how often sources are polled for changes.
+	watchInterval = 250 * time.Millisecond
 )
 
+// reloadScript is injected into served HTML pages in watch mode.
+// It reloads the page when a codelab is re-exported, if the page
+// is a part of that codelab or the codelabs index.
+const reloadScript = `<script>
+new EventSource("` + reloadPath + `").onmessage = function(e) {
+  var p = location.pathname;
+  if (p === "/" || p.indexOf("/" + e.data + "/") === 0) location.reload();
+};
+</script>
+`
+
+// Options type to make the CmdServe signature succinct.
+type CmdServeOptions struct {
+	// Addr is the hostname and port to bind the web server to.
+	Addr string
+	// Export is used to export Export.Srcs in watch mode.
+	// If there are no sources, the current directory is served as is.
+	Export CmdExportOptions
+	// Src is a directory of Markdown sources which are rendered
+	// on each request, in Export.Tmplout format, instead of being exported.
+	Src string
+}
+
 // CmdServe is the "claat serve ..." subcommand.
-// addr is the hostname and port to bind the web server to.
 // It returns a process exit code.
-func CmdServe(addr string) int {
-	http.Handle("/", http.FileServer(http.Dir(".")))
-	log.Printf("Serving codelabs on %s, opening browser tab now...", addr)
+//
+// With sources in opts.Export.Srcs, serve exports them to opts.Export.Output,
+// watches the sources and their directories for changes, re-exports
+// the changed codelabs and reloads them in connected browsers.
+// With opts.Src, codelabs are rendered from sources on each request.
+func CmdServe(opts CmdServeOptions) int {
+	root := "."
+	switch {
+	case opts.Src != "":
+		if len(opts.Export.Srcs) > 0 {
+			logging.Fatalf("claat serve: -src cannot be used with src arguments")
+		}
+		http.Handle("/", newPreviewServer(opts.Src, opts.Export))
+		logging.Infof("Rendering codelabs of %s on request", opts.Src)
+	case len(opts.Export.Srcs) > 0:
+		if isStdout(opts.Export.Output) || opts.Export.Tmplout == "term" {
+			logging.Fatalf("claat serve: cannot watch sources exported to stdout")
+		}
+		root = opts.Export.Output
+		lw := newLiveWatcher(opts.Export)
+		lw.exportAll()
+		go lw.watch(watchInterval)
+		http.Handle(reloadPath, lw.reloader)
+		http.Handle("/", &codelabFileServer{root: root, reload: true})
+		logging.Infof("Watching %d sources for changes", len(lw.srcs))
+	default:
+		http.Handle("/", &codelabFileServer{root: root})
+	}
+	logging.Infof("Serving codelabs on %s, opening browser tab now...", opts.Addr)
 	ch := make(chan error, 1)
 	go func() {
-		ch <- http.ListenAndServe(addr, nil)
+		ch <- http.ListenAndServe(opts.Addr, nil)
 	}()
-	openBrowser("http://" + addr)
-	log.Fatalf("claat serve: %v", <-ch)
+	openBrowser("http://" + opts.Addr)
+	logging.Fatalf("claat serve: %v", <-ch)
 	return 0
 }
 
+// liveWatcher re-exports codelabs when their sources change.
+type liveWatcher struct {
+	opts     CmdExportOptions
+	srcs     []string
+	locales  map[string]*locale
+	reloader *reloader
+	mod      map[string]time.Time // last snapshot of watched files
+}
+
+func newLiveWatcher(opts CmdExportOptions) *liveWatcher {
+	srcs := util.Unique(opts.Srcs)
+	if opts.CheckLinks != "" {
+		rt, err := opts.httpOptions().Transport(nil)
+		if err != nil {
+			logging.Fatalf("Invalid -proxy: %v", err)
+		}
+		opts.links = newLinkChecker(rt, opts)
+	}
+	if opts.CheckIDs {
+		opts.ids = newIDRegistry()
+	}
+	return &liveWatcher{
+		opts:     opts,
+		srcs:     srcs,
+		locales:  localeVariants(srcs, opts.DefaultLang),
+		reloader: &reloader{clients: make(map[chan string]bool)},
+	}
+}
+
+// exportAll exports all sources and takes the initial snapshot.
+func (lw *liveWatcher) exportAll() {
+	lw.mod = snapshot(lw.dirs(), lw.opts.Output)
+	for _, src := range lw.srcs {
+		lw.export(src)
+	}
+}
+
+// watch polls watched files every interval, re-exporting changed codelabs.
+// It never returns.
+func (lw *liveWatcher) watch(interval time.Duration) {
+	for range time.Tick(interval) {
+		mod := snapshot(lw.dirs(), lw.opts.Output)
+		changed := lw.changed(lw.mod, mod)
+		lw.mod = mod
+		for _, src := range changed {
+			start := time.Now()
+			if id, ok := lw.export(src); ok {
+				logging.Infof("Re-exported %s in %v", id, time.Since(start).Round(time.Millisecond))
+				lw.reloader.reload(id)
+			}
+		}
+	}
+}
+
+// export exports src, returning the codelab ID and true on success.
+func (lw *liveWatcher) export(src string) (string, bool) {
+	opts := lw.opts
+	opts.locale = lw.locales[src]
+	meta, err := ExportCodelab(src, nil, opts)
+	if err != nil {
+		logging.Errorf(reportErr, errSource(src, err), err)
+		return "", false
+	}
+	return meta.ID, true
+}
+
+// dirs returns directories of local sources, which contain their assets.
+// Remote sources are not watched.
+func (lw *liveWatcher) dirs() []string {
+	var dirs []string
+	for _, src := range lw.srcs {
+		if _, err := os.Stat(src); err == nil {
+			dirs = append(dirs, filepath.Dir(src))
+		}
+	}
+	return util.Unique(dirs)
+}
+
+// changed returns sources to re-export given snapshots before and after
+// a change, in order of lw.srcs. A changed source is re-exported alone,
+// while any other changed file, such as an image or imported Markdown,
+// re-exports all sources in its directory or above.
+func (lw *liveWatcher) changed(before, after map[string]time.Time) []string {
+	var files []string
+	for f, t := range after {
+		if bt, ok := before[f]; !ok || !bt.Equal(t) {
+			files = append(files, f)
+		}
+	}
+	for f := range before {
+		if _, ok := after[f]; !ok {
+			files = append(files, f)
+		}
+	}
+	res := make(map[string]bool)
+	for _, f := range files {
+		for _, src := range lw.srcs {
+			if f == filepath.Clean(src) || isUnder(f, filepath.Dir(src)) && !lw.isSrc(f) {
+				res[src] = true
+			}
+		}
+	}
+	var srcs []string
+	for _, src := range lw.srcs {
+		if res[src] {
+			srcs = append(srcs, src)
+		}
+	}
+	return srcs
+}
+
+// isSrc reports whether file f is one of the watched sources.
+func (lw *liveWatcher) isSrc(f string) bool {
+	for _, src := range lw.srcs {
+		if filepath.Clean(src) == f {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnder reports whether file f is in dir or any of its subdirectories.
+func isUnder(f, dir string) bool {
+	rel, err := filepath.Rel(dir, f)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// snapshot returns modification times of files in dirs, recursively.
+// The output directory, hidden directories and exported codelab
+// directories, those containing a codelab.json file, are skipped.
+func snapshot(dirs []string, output string) map[string]time.Time {
+	mod := make(map[string]time.Time)
+	output = filepath.Clean(output)
+	for _, dir := range dirs {
+		filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if fi.IsDir() {
+				if p != dir && (strings.HasPrefix(fi.Name(), ".") || filepath.Clean(p) == output) {
+					return filepath.SkipDir
+				}
+				if _, err := os.Stat(filepath.Join(p, metaFilename)); err == nil {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			mod[filepath.Clean(p)] = fi.ModTime()
+			return nil
+		})
+	}
+	return mod
+}
+
+// reloader streams IDs of re-exported codelabs to connected browsers
+// as server-sent events.
+type reloader struct {
+	mu      sync.Mutex
+	clients map[chan string]bool
+}
+
+func (rl *reloader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan string, 1)
+	rl.mu.Lock()
+	rl.clients[ch] = true
+	rl.mu.Unlock()
+	defer func() {
+		rl.mu.Lock()
+		delete(rl.clients, ch)
+		rl.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fl.Flush()
+	for {
+		select {
+		case id := <-ch:
+			fmt.Fprintf(w, "data: %s\n\n", id)
+			fl.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// reload notifies connected browsers that codelab id was re-exported.
+// Browsers still busy with a previous event skip this one.
+func (rl *reloader) reload(id string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for ch := range rl.clients {
+		select {
+		case ch <- id:
+		default:
+		}
+	}
+}
+
+// codelabFileServer serves files of root like http.FileServer,
+// and a landing page listing exported codelabs if root has no index.html.
+// If reload is true, reloadScript is injected into HTML pages.
+type codelabFileServer struct {
+	root   string
+	reload bool
+}
+
+func (fs *codelabFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := filepath.Join(fs.root, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+	if strings.HasSuffix(r.URL.Path, "/") {
+		name = filepath.Join(name, "index.html")
+	}
+	fi, err := os.Stat(name)
+	if err != nil && r.URL.Path == "/" {
+		serveIndex(w, exportedEntries(fs.root), fs.reload)
+		return
+	}
+	if err != nil || fi.IsDir() || filepath.Ext(name) != ".html" || !fs.reload {
+		http.FileServer(http.Dir(fs.root)).ServeHTTP(w, r)
+		return
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, name, fi.ModTime(), bytes.NewReader(injectReload(b)))
+}
+
+// injectReload adds reloadScript to HTML page b, before its closing body tag.
+func injectReload(b []byte) []byte {
+	i := bytes.LastIndex(bytes.ToLower(b), []byte("</body>"))
+	if i < 0 {
+		return append(b, reloadScript...)
+	}
+	res := make([]byte, 0, len(b)+len(reloadScript))
+	res = append(res, b[:i]...)
+	res = append(res, reloadScript...)
+	return append(res, b[i:]...)
+}
+
 // openBrowser tries to open the URL in a browser.
 func openBrowser(url string) error {
 	var args []string
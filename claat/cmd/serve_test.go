@@ -0,0 +1,166 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLiveWatcherChanged(t *testing.T) {
+	a := filepath.Join("labs", "a.md")
+	b := filepath.Join("labs", "b.md")
+	c := filepath.Join("other", "c.md")
+	lw := &liveWatcher{srcs: []string{a, b, c}}
+	t0 := time.Unix(0, 0)
+	t1 := t0.Add(time.Second)
+	before := map[string]time.Time{
+		a:                                     t0,
+		b:                                     t0,
+		c:                                     t0,
+		filepath.Join("labs", "img", "x.png"): t0,
+	}
+
+	tests := []struct {
+		after map[string]time.Time
+		want  []string
+	}{
+		{before, nil},
+		{map[string]time.Time{a: t0, b: t1, c: t0, filepath.Join("labs", "img", "x.png"): t0}, []string{b}},
+		{map[string]time.Time{a: t0, b: t0, c: t0, filepath.Join("labs", "img", "x.png"): t1}, []string{a, b}},
+		{map[string]time.Time{a: t0, b: t0, c: t0}, []string{a, b}},
+		{map[string]time.Time{a: t0, b: t0, c: t0, filepath.Join("labs", "img", "x.png"): t0, filepath.Join("other", "part.md"): t0}, []string{c}},
+	}
+	for i, test := range tests {
+		if got := lw.changed(before, test.after); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: changed = %v; want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := []string{
+		"a.md",
+		filepath.Join("img", "x.png"),
+		filepath.Join(".git", "HEAD"),
+		filepath.Join("a-codelab", metaFilename),
+		filepath.Join("a-codelab", "index.html"),
+		filepath.Join("out", "b-codelab", "img", "y.png"),
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	for f := range snapshot([]string{dir}, filepath.Join(dir, "out")) {
+		rel, _ := filepath.Rel(dir, f)
+		got = append(got, rel)
+	}
+	sort.Strings(got)
+	want := []string{"a.md", filepath.Join("img", "x.png")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshot = %v; want %v", got, want)
+	}
+}
+
+func TestCodelabFileServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-serve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "lab"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	html := "<html><body><p>hi</p></body></html>"
+	if err := ioutil.WriteFile(filepath.Join(dir, "lab", "index.html"), []byte(html), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"id": "lab", "title": "A Lab", "category": ["Web"], "status": ["draft"]}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "lab", "codelab.json"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := &codelabFileServer{root: dir, reload: true}
+
+	rec := httptest.NewRecorder()
+	fs.ServeHTTP(rec, httptest.NewRequest("GET", "/lab/", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, reloadPath) || !strings.HasSuffix(body, "</body></html>") {
+		t.Errorf("index.html = %q; want reload script before </body>", body)
+	}
+	rec = httptest.NewRecorder()
+	fs.ServeHTTP(rec, httptest.NewRequest("GET", "/lab/codelab.json", nil))
+	if body := rec.Body.String(); body != meta {
+		t.Errorf("codelab.json = %q; want unchanged", body)
+	}
+	rec = httptest.NewRecorder()
+	fs.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	body = rec.Body.String()
+	for _, want := range []string{`<a href="/lab/">A Lab</a>`, `<option value="web">Web</option>`, `data-status="draft"`, reloadPath} {
+		if !strings.Contains(body, want) {
+			t.Errorf("index does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestReloader(t *testing.T) {
+	rl := &reloader{clients: make(map[chan string]bool)}
+	srv := httptest.NewServer(rl)
+	defer srv.Close()
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q; want text/event-stream", ct)
+	}
+	for {
+		rl.mu.Lock()
+		n := len(rl.clients)
+		rl.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	rl.reload("my-codelab")
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line != "data: my-codelab\n" {
+		t.Errorf("event = %q; want data: my-codelab", line)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// indexEntry is a codelab listed on the landing page of claat serve.
+type indexEntry struct {
+	URL  string      // codelab page
+	Meta *types.Meta // nil if Err is set
+	Src  string      // source of a codelab which could not be rendered
+	Err  error
+}
+
+// Search returns lowercase text matched by the search box of the index.
+func (e *indexEntry) Search() string {
+	if e.Meta == nil {
+		return strings.ToLower(e.Src)
+	}
+	m := e.Meta
+	v := []string{m.ID, m.Title, m.Summary, m.Authors}
+	v = append(v, m.Categories...)
+	v = append(v, m.Tags...)
+	return strings.ToLower(strings.Join(v, " "))
+}
+
+// Status returns the codelab status as a comma-separated list.
+func (e *indexEntry) Status() string {
+	if e.Meta == nil || e.Meta.Status == nil {
+		return ""
+	}
+	return strings.Join(*e.Meta.Status, ", ")
+}
+
+// serveIndexTemplate is the landing page of claat serve, approximating
+// the production codelabs index: a card per codelab, a search box
+// and filters of categories and statuses, all applied client-side.
+var serveIndexTemplate = template.Must(template.New("index").Funcs(template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+}).Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Codelabs</title>
+<style>
+body { font-family: Roboto, Arial, sans-serif; margin: 0; background: #f5f5f5; color: #212121; }
+header { background: #4F7DC9; color: #fff; padding: 16px 24px; }
+header h1 { margin: 0 0 12px; font-weight: 400; }
+header input, header select { font-size: 16px; padding: 6px; margin-right: 8px; }
+main { display: flex; flex-wrap: wrap; padding: 16px; }
+.card { background: #fff; width: 300px; margin: 8px; padding: 16px; border-radius: 2px; box-shadow: 0 1px 3px rgba(0,0,0,.3); display: flex; flex-direction: column; }
+.card h2 { font-size: 18px; margin: 0 0 8px; }
+.card a { color: #4F7DC9; text-decoration: none; }
+.card p { flex: 1; margin: 0 0 8px; }
+.meta { font-size: 13px; color: #757575; }
+.error { color: #c62828; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<header>
+<h1>Codelabs</h1>
+<input id="search" type="search" placeholder="Search" autofocus>
+<select id="category"><option value="">All categories</option>
+{{range .Categories}}<option value="{{lower .}}">{{.}}</option>
+{{end}}</select>
+<select id="status"><option value="">All statuses</option>
+{{range .Statuses}}<option value="{{lower .}}">{{.}}</option>
+{{end}}</select>
+</header>
+<main>
+{{range .Entries}}{{if .Err}}<div class="card" data-search="{{.Search}}" data-category="" data-status="">
+<h2 class="error">{{.Src}}</h2>
+<p class="error">{{.Err}}</p>
+</div>
+{{else}}<div class="card" data-search="{{.Search}}" data-category="{{lower (join .Meta.Categories "|")}}" data-status="{{lower .Status}}">
+<h2><a href="{{.URL}}">{{.Meta.Title}}</a></h2>
+<p>{{.Meta.Summary}}</p>
+<div class="meta">{{.Meta.Duration}} min{{with .Meta.Categories}} &middot; {{join . ", "}}{{end}}{{with .Status}} &middot; {{.}}{{end}}</div>
+{{with .Meta.Tags}}<div class="meta">{{join . ", "}}</div>{{end}}
+<div class="meta progress" data-progress="{{.Meta.ID}}" hidden></div>
+</div>
+{{end}}{{end}}</main>
+<script>
+(function() {
+  var search = document.getElementById("search");
+  var category = document.getElementById("category");
+  var status = document.getElementById("status");
+  var cards = document.querySelectorAll(".card");
+  function has(list, v) {
+    return v === "" || list.split(/\s*[|,]\s*/).indexOf(v) >= 0;
+  }
+  function filter() {
+    var q = search.value.toLowerCase();
+    for (var i = 0; i < cards.length; i++) {
+      var d = cards[i].dataset;
+      var show = d.search.indexOf(q) >= 0 && has(d.category, category.value) && has(d.status, status.value);
+      cards[i].classList.toggle("hidden", !show);
+    }
+  }
+  search.addEventListener("input", filter);
+  category.addEventListener("change", filter);
+  status.addEventListener("change", filter);
+})();
+</script>
+` + progressIndexScript + `
+</body>
+</html>
+`))
+
+// progressIndexScript shows the completion percentage of each codelab
+// of an index page with a data-progress element, as stored by the html
+// format in localStorage, if the reader has started it.
+const progressIndexScript = `<script>
+(function() {
+  var els = document.querySelectorAll("[data-progress]");
+  for (var i = 0; i < els.length; i++) {
+    var p = null;
+    try {
+      p = JSON.parse(localStorage.getItem("claat-progress-" + els[i].dataset.progress));
+    } catch (e) {}
+    if (p && typeof p.percent === "number") {
+      els[i].textContent = p.percent + "% complete";
+      els[i].hidden = false;
+    }
+  }
+})();
+</script>`
+
+// serveIndex writes the landing page listing codelabs of entries to w.
+// If reload is true, the page reloads as codelabs are re-exported.
+func serveIndex(w http.ResponseWriter, entries []*indexEntry, reload bool) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].title()) < strings.ToLower(entries[j].title())
+	})
+	cats := make(map[string]bool)
+	stats := make(map[string]bool)
+	for _, e := range entries {
+		if e.Meta == nil {
+			continue
+		}
+		for _, c := range e.Meta.Categories {
+			cats[c] = true
+		}
+		if e.Meta.Status != nil {
+			for _, s := range *e.Meta.Status {
+				stats[s] = true
+			}
+		}
+	}
+	data := struct {
+		Entries    []*indexEntry
+		Categories []string
+		Statuses   []string
+	}{entries, sortedKeys(cats), sortedKeys(stats)}
+	var b bytes.Buffer
+	if err := serveIndexTemplate.Execute(&b, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	page := b.Bytes()
+	if reload {
+		page = injectReload(page)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(page)
+}
+
+// exportedEntries returns index entries of codelabs exported to root,
+// that is directories with a codelab.json metadata file.
+// Directories with unreadable metadata are listed as errors.
+func exportedEntries(root string) []*indexEntry {
+	dirs, err := walkPath(root)
+	if err != nil {
+		return []*indexEntry{{Src: root, Err: err}}
+	}
+	var entries []*indexEntry
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." {
+			continue
+		}
+		cm, err := readMeta(filepath.Join(dir, metaFilename))
+		if err != nil {
+			entries = append(entries, &indexEntry{Src: dir, Err: err})
+			continue
+		}
+		entries = append(entries, &indexEntry{
+			URL:  "/" + filepath.ToSlash(rel) + "/",
+			Meta: &cm.Meta,
+		})
+	}
+	return entries
+}
+
+func (e *indexEntry) title() string {
+	if e.Meta == nil {
+		return e.Src
+	}
+	return e.Meta.Title
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,256 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// feedEntries is the number of most recently updated codelabs
+// in the Atom feed.
+const feedEntries = 20
+
+// metaDate parses date v of metadata key of codelab src, if any.
+// Invalid dates are logged and ignored.
+func metaDate(src, key, v string, opts CmdExportOptions) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	t, ok := parseMetaDate(v)
+	if !ok {
+		opts.warnings.warnf(src, "%s date %q is neither RFC 3339 nor YYYY-MM-DD; ignored", key, v)
+	}
+	return t, ok
+}
+
+// parseMetaDate parses v, an RFC 3339 time or YYYY-MM-DD.
+func parseMetaDate(v string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// siteCodelab is an exported codelab listed in the sitemap and feed.
+type siteCodelab struct {
+	meta      *types.Meta
+	url       string
+	updated   time.Time
+	published time.Time // zero if unknown
+}
+
+// siteCodelabs returns codelabs exported to dir, those of this export
+// as well as earlier ones, hosted under site URL site, most recently
+// updated first. Hidden codelabs are left out.
+// Codelabs and their update times are read from the exported metadata
+// of each codelab.
+func siteCodelabs(dir, site string) ([]*siteCodelab, error) {
+	dirs, err := walkPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []*siteCodelab
+	for _, d := range dirs {
+		rel, err := filepath.Rel(dir, d)
+		if err != nil || rel == "." {
+			continue
+		}
+		cm, err := readMeta(filepath.Join(d, metaFilename))
+		if err != nil {
+			return nil, err
+		}
+		m := &cm.Meta
+		if m.Status != nil && hasStatus(*m.Status, "hidden") {
+			continue
+		}
+		c := &siteCodelab{meta: m, url: strings.TrimSuffix(site, "/") + "/" + filepath.ToSlash(rel) + "/"}
+		if cm.Updated != nil {
+			c.updated = time.Time(*cm.Updated)
+		}
+		// export has already logged invalid dates
+		c.published, _ = parseMetaDate(m.Published)
+		res = append(res, c)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].updated.Equal(res[j].updated) {
+			return res[i].updated.After(res[j].updated)
+		}
+		return res[i].meta.ID < res[j].meta.ID
+	})
+	return res, nil
+}
+
+func hasStatus(status types.LegacyStatus, s string) bool {
+	for _, v := range status {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSiteFiles writes the sitemap and Atom feed of codelabs exported
+// to dir, hosted under site URL site, to dir. The feed is updated at
+// build time, if not zero, when it has no entries.
+func writeSiteFiles(dir, site string, build time.Time) error {
+	codelabs, err := siteCodelabs(dir, site)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := writeXML(filepath.Join(dir, sitemapFilename), sitemap(codelabs)); err != nil {
+		return err
+	}
+	return writeXML(filepath.Join(dir, feedFilename), atomFeed(site, codelabs, build))
+}
+
+func writeXML(name string, v interface{}) error {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append([]byte(xml.Header), b...)
+	return ioutil.WriteFile(name, append(b, '\n'), 0644)
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name      `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []*sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// sitemap returns the sitemap of codelabs, which are all listed.
+func sitemap(codelabs []*siteCodelab) *sitemapURLSet {
+	s := &sitemapURLSet{}
+	for _, c := range codelabs {
+		u := &sitemapURL{Loc: c.url}
+		if !c.updated.IsZero() {
+			u.LastMod = c.updated.UTC().Format(time.RFC3339)
+		}
+		s.URLs = append(s.URLs, u)
+	}
+	return s
+}
+
+type atomFeedXML struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string       `xml:"title"`
+	ID      string       `xml:"id"`
+	Links   []*atomLink  `xml:"link"`
+	Updated string       `xml:"updated"`
+	Author  *atomAuthor  `xml:"author"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string          `xml:"title"`
+	ID         string          `xml:"id"`
+	Link       *atomLink       `xml:"link"`
+	Updated    string          `xml:"updated"`
+	Published  string          `xml:"published,omitempty"`
+	Summary    string          `xml:"summary,omitempty"`
+	Authors    []*atomAuthor   `xml:"author"`
+	Categories []*atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomFeed returns the Atom feed of the feedEntries most recently updated
+// of codelabs, hosted under site URL site. An empty feed is updated at
+// build time, or now if zero.
+func atomFeed(site string, codelabs []*siteCodelab, build time.Time) *atomFeedXML {
+	site = strings.TrimSuffix(site, "/") + "/"
+	f := &atomFeedXML{
+		Title:  "Codelabs",
+		ID:     site,
+		Links:  []*atomLink{{Href: site}, {Rel: "self", Href: site + feedFilename}},
+		Author: &atomAuthor{Name: "Codelabs"},
+	}
+	var updated time.Time
+	if len(codelabs) > feedEntries {
+		codelabs = codelabs[:feedEntries]
+	}
+	for _, c := range codelabs {
+		if c.updated.After(updated) {
+			updated = c.updated
+		}
+		e := &atomEntry{
+			Title:   c.meta.Title,
+			ID:      c.url,
+			Link:    &atomLink{Href: c.url},
+			Updated: c.updated.UTC().Format(time.RFC3339),
+			Summary: c.meta.Summary,
+		}
+		if c.meta.Description != "" {
+			e.Summary = c.meta.Description
+		}
+		if !c.published.IsZero() {
+			e.Published = c.published.UTC().Format(time.RFC3339)
+		}
+		for _, a := range strings.Split(c.meta.Authors, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				e.Authors = append(e.Authors, &atomAuthor{Name: a})
+			}
+		}
+		for _, t := range c.meta.Categories {
+			e.Categories = append(e.Categories, &atomCategory{Term: t})
+		}
+		f.Entries = append(f.Entries, e)
+	}
+	if updated.IsZero() {
+		updated = build
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	f.Updated = updated.UTC().Format(time.RFC3339)
+	return f
+}
+
+// checkSiteURL returns an error if site is not an absolute http(s) URL.
+func checkSiteURL(site string) error {
+	if !strings.HasPrefix(site, "https://") && !strings.HasPrefix(site, "http://") {
+		return fmt.Errorf("site URL %q is not an absolute http(s) URL", site)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdExportSiteURL(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportSiteURL-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"old.md":    "id: old\nauthors: Ada, Alan\nupdated: 2019-01-02\npublished: 2018-12-01\nsummary: Old & gold\n\n# Old\n\n## Step\n\nText.\n",
+		"new.md":    "id: new\nupdated: 2019-06-01T10:00:00Z\ndescription: Brand new\n\n# New\n\n## Step\n\nText.\n",
+		"hidden.md": "id: hidden\nstatus: hidden\n\n# Hidden\n\n## Step\n\nText.\n",
+	}
+	var srcs []string
+	for name, content := range files {
+		f := path.Join(tmp, name)
+		if err := ioutil.WriteFile(f, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, f)
+	}
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	out := path.Join(tmp, "out")
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    1,
+		Output:  out,
+		SiteURL: "https://codelabs.example.com",
+		Srcs:    srcs,
+		Tmplout: "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+
+	b, err := ioutil.ReadFile(path.Join(out, "sitemap.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sitemap := string(b)
+	want := `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://codelabs.example.com/new/</loc>
+    <lastmod>2019-06-01T10:00:00Z</lastmod>
+  </url>
+  <url>
+    <loc>https://codelabs.example.com/old/</loc>
+    <lastmod>2019-01-02T00:00:00Z</lastmod>
+  </url>
+</urlset>`
+	if !strings.Contains(sitemap, want) {
+		t.Errorf("sitemap.xml:\n%s\nwant:\n%s", sitemap, want)
+	}
+
+	b, err = ioutil.ReadFile(path.Join(out, "feed.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed := string(b)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<link rel="self" href="https://codelabs.example.com/feed.xml"></link>`,
+		`<updated>2019-06-01T10:00:00Z</updated>`,
+		`<summary>Brand new</summary>`,
+		`<published>2018-12-01T00:00:00Z</published>`,
+		`<summary>Old &amp; gold</summary>`,
+		`<author>
+      <name>Alan</name>
+    </author>`,
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("feed.xml does not contain %q:\n%s", want, feed)
+		}
+	}
+	if strings.Contains(feed+sitemap, "hidden") {
+		t.Errorf("hidden codelab is listed:\n%s\n%s", sitemap, feed)
+	}
+	if strings.Index(feed, "/new/") > strings.Index(feed, "/old/") {
+		t.Errorf("feed.xml entries are not most recently updated first:\n%s", feed)
+	}
+
+	// codelabs exported before are kept
+	code = cmd.CmdExport(cmd.CmdExportOptions{
+		Jobs:    1,
+		Output:  out,
+		SiteURL: "https://codelabs.example.com",
+		Srcs:    []string{path.Join(tmp, "new.md")},
+		Tmplout: "html",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	b, err = ioutil.ReadFile(path.Join(out, "sitemap.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sitemap := string(b); !strings.Contains(sitemap, want) {
+		t.Errorf("sitemap.xml after exporting new.md:\n%s\nwant:\n%s", sitemap, want)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// codelabStats are statistics of an exported codelab,
+// reported by a dry run export.
+type codelabStats struct {
+	Steps         int `json:"steps"`
+	Words         int `json:"words"` // of text, excluding code
+	Images        int `json:"images"`
+	CodeBlocks    int `json:"code_blocks"`
+	ExternalLinks int `json:"external_links"`
+	Duration      int `json:"duration"` // minutes
+}
+
+// newCodelabStats returns statistics of the content of clab exported
+// for the env environment, or all content if env is empty.
+func newCodelabStats(clab *types.Codelab, env string) *codelabStats {
+	s := &codelabStats{Duration: clab.Duration}
+	for _, st := range clab.Steps {
+		if !stepInEnv(st, env) {
+			continue
+		}
+		s.Steps++
+		if st.Content == nil {
+			continue
+		}
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			if !inEnv(n, env) {
+				return false
+			}
+			switch n := n.(type) {
+			case *types.TextNode:
+				if !n.Code {
+					s.Words += len(strings.Fields(n.Value))
+				}
+			case *types.ImageNode:
+				s.Images++
+			case *types.CodeNode:
+				s.CodeBlocks++
+			case *types.URLNode:
+				if u, err := url.Parse(n.URL); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+					s.ExternalLinks++
+				}
+			}
+			return true
+		})
+	}
+	return s
+}
+
+// add adds statistics of o to s.
+func (s *codelabStats) add(o *codelabStats) {
+	s.Steps += o.Steps
+	s.Words += o.Words
+	s.Images += o.Images
+	s.CodeBlocks += o.CodeBlocks
+	s.ExternalLinks += o.ExternalLinks
+	s.Duration += o.Duration
+}
+
+func (s *codelabStats) String() string {
+	return fmt.Sprintf("%d steps, %d words, %d images, %d code blocks, %d external links, %d min",
+		s.Steps, s.Words, s.Images, s.CodeBlocks, s.ExternalLinks, s.Duration)
+}
+
+// stepInEnv reports whether step st is exported for environment env.
+func stepInEnv(st *types.Step, env string) bool {
+	if env == "" || len(st.Tags) == 0 {
+		return true
+	}
+	for _, t := range st.Tags {
+		if t == env {
+			return true
+		}
+	}
+	return false
+}
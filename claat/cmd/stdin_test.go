@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdExportStdin(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdExportStdin-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	in := path.Join(tmp, "in.md")
+	content := "id: piped\n\n# Piped\n\n## Step\n\nText.\n"
+	if err := ioutil.WriteFile(in, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := os.Open(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	stdout, err := os.Create(path.Join(tmp, "out.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	defer func(in, out *os.File) { os.Stdin, os.Stdout = in, out }(os.Stdin, os.Stdout)
+	os.Stdin, os.Stdout = stdin, stdout
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	code := cmd.CmdExport(cmd.CmdExportOptions{
+		Output:  "-",
+		Srcs:    []string{"-"},
+		Tmplout: "json",
+	})
+	if code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+	b, err := ioutil.ReadFile(stdout.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, b)
+	}
+	if v.ID != "piped" || v.Title != "Piped" {
+		t.Errorf("stdout = %s; want codelab piped", b)
+	}
+	if fis, _ := ioutil.ReadDir(tmp); len(fis) != 2 {
+		t.Errorf("%d files in %s; want only in.md and out.json", len(fis), tmp)
+	}
+}
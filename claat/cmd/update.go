@@ -16,17 +16,18 @@ package cmd
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/logging"
 	"github.com/googlecodelabs/tools/claat/parser"
 	"github.com/googlecodelabs/tools/claat/types"
 	"github.com/googlecodelabs/tools/claat/util"
@@ -36,71 +37,171 @@ import (
 type CmdUpdateOptions struct {
 	// AuthToken is the token to use for the Drive API.
 	AuthToken string
+	// DryRun lists codelabs which would be updated without fetching
+	// their sources or writing any file.
+	DryRun bool
 	// ExtraVars is extra template variables.
 	ExtraVars map[string]string
+	// Filters select codelabs to update by their stored metadata, keyed
+	// by filter key, see filterKeys. A codelab must match all of them.
+	Filters map[string]string
 	// GlobalGA is the global Google Analytics account to use.
 	GlobalGA string
+	// HTTP configures retries, timeouts and the proxy of network requests.
+	HTTP fetch.HTTPOptions
+	// ImportCredentials authenticate requests of remote imports,
+	// see fetch.Fetcher.SetImportCredentials.
+	ImportCredentials []*fetch.ImportCredential
+	// ImportRoot confines local imports of codelabs to this directory,
+	// see fetch.Fetcher.SetImportRoot. Defaults to the current directory.
+	ImportRoot string
+	// Jobs is the maximum number of codelabs updated concurrently.
+	// If zero, the number of CPUs is used.
+	Jobs int
 	// MDParser is the underlying Markdown parser to use.
 	MDParser parser.MarkdownParser
 	// PassMetadata are the extra metadata fields to pass along.
 	PassMetadata map[string]bool
 	// Prefix is a URL prefix to prepend when using HTML format.
 	Prefix string
+	// ReadingWPM estimates durations of steps with none from their length,
+	// read at this many words per minute. Durations are not estimated if zero,
+	// see parser.Options.ReadingWPM.
+	ReadingWPM int
+	// ServiceAccount is a service account JSON key file to use for the Drive API.
+	ServiceAccount string
+	// Srcs are the directories to look for exported codelabs in,
+	// recursively. The current directory is used if empty.
+	Srcs []string
 }
 
+// filterKeys are the keys of CmdUpdateOptions.Filters, sorted.
+var filterKeys = []string{"category", "id", "lang", "status", "tag", "theme"}
+
 // CmdUpdate is the "claat update ..." subcommand.
 // It returns a process exit code.
 func CmdUpdate(opts CmdUpdateOptions) int {
-	roots := flag.Args()
+	for k := range opts.Filters {
+		if i := sort.SearchStrings(filterKeys, k); i == len(filterKeys) || filterKeys[i] != k {
+			logging.Fatalf("Unknown filter %q; known filters: %s", k, strings.Join(filterKeys, ", "))
+		}
+	}
+	roots := opts.Srcs
 	if len(roots) == 0 {
 		roots = []string{"."}
 	}
 	dirs, err := scanPaths(roots)
 	if err != nil {
-		log.Fatalf("%v", err)
+		logging.Fatalf("%v", err)
 	}
 	if len(dirs) == 0 {
-		log.Fatalf("no codelabs found in %s", strings.Join(roots, ", "))
+		logging.Fatalf("no codelabs found in %s", strings.Join(roots, ", "))
 	}
+	sort.Strings(dirs)
 
 	type result struct {
-		dir  string
-		meta *types.Meta
-		err  error
+		dir     string
+		meta    *types.Meta
+		err     error
+		skipped bool // filtered out
+	}
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
 	}
-	ch := make(chan *result, len(dirs))
-	for _, d := range dirs {
-		go func(d string) {
+	sem := make(chan struct{}, jobs)
+	chs := make([]chan *result, len(dirs))
+	for i, d := range dirs {
+		chs[i] = make(chan *result, 1)
+		sem <- struct{}{}
+		go func(d string, ch chan<- *result) {
+			defer func() { <-sem }()
+			meta, err := readMeta(filepath.Join(d, metaFilename))
+			if err != nil {
+				ch <- &result{dir: d, err: err}
+				return
+			}
+			if !matchFilters(&meta.Meta, opts.Filters) {
+				ch <- &result{dir: d, meta: &meta.Meta, skipped: true}
+				return
+			}
+			if opts.DryRun {
+				ch <- &result{dir: d, meta: &meta.Meta}
+				return
+			}
 			// random sleep up to 1 sec
 			// to reduce number of rate limit errors
 			time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
-			meta, err := updateCodelab(d, opts)
-			ch <- &result{d, meta, err}
-		}(d)
+			m, err := updateCodelab(d, meta, opts)
+			ch <- &result{dir: d, meta: m, err: err}
+		}(d, chs[i])
 	}
 
-	var exitCode int
-	for range dirs {
+	// results are reported in order of dirs, regardless of completion order
+	var exitCode, n int
+	for _, ch := range chs {
 		res := <-ch
-		if res.err != nil {
+		switch {
+		case res.err != nil:
 			exitCode = 1
-			log.Printf(reportErr, res.dir, res.err)
-		} else {
-			log.Printf(reportOk, res.meta.ID)
+			logging.Errorf(reportErr, res.dir, res.err)
+		case res.skipped:
+			// filtered out
+		case opts.DryRun:
+			n++
+			logging.Infof(reportOk, fmt.Sprintf("%s: would update %s from %s", res.meta.ID, res.dir, res.meta.Source))
+		default:
+			n++
+			logging.Infof(reportOk, res.meta.ID)
 		}
 	}
+	if opts.DryRun {
+		logging.Infof("%d of %d codelabs would be updated", n, len(dirs))
+	} else if len(opts.Filters) > 0 {
+		logging.Infof("%d of %d codelabs matched filters", n, len(dirs))
+	}
 	return exitCode
 }
 
-// updateCodelab reads metadata from a dir/codelab.json file,
-// re-exports the codelab just like it normally would in exportCodelab,
-// and removes assets (images) which are not longer in use.
-func updateCodelab(dir string, opts CmdUpdateOptions) (*types.Meta, error) {
-	// get stored codelab metadata and fail early if we can't
-	meta, err := readMeta(filepath.Join(dir, metaFilename))
-	if err != nil {
-		return nil, err
+// matchFilters reports whether codelab metadata m matches all filters,
+// keyed by filter key. Values are compared case-insensitively.
+func matchFilters(m *types.Meta, filters map[string]string) bool {
+	for k, v := range filters {
+		var values []string
+		switch k {
+		case "category":
+			values = m.Categories
+		case "id":
+			values = []string{m.ID}
+		case "lang":
+			values = []string{m.Lang}
+		case "status":
+			if m.Status != nil {
+				values = *m.Status
+			}
+		case "tag":
+			values = m.Tags
+		case "theme":
+			values = []string{m.Theme}
+		}
+		var ok bool
+		for _, s := range values {
+			if strings.EqualFold(strings.TrimSpace(s), v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
 	}
+	return true
+}
+
+// updateCodelab re-exports the codelab of dir, whose metadata meta was
+// read from its codelab.json file, just like it normally would
+// in exportCodelab, and removes assets (images) which are not longer in use.
+func updateCodelab(dir string, meta *types.ContextMeta, opts CmdUpdateOptions) (*types.Meta, error) {
 	// override allowed options from cli
 	if opts.Prefix != "" {
 		meta.Prefix = opts.Prefix
@@ -110,10 +211,20 @@ func updateCodelab(dir string, opts CmdUpdateOptions) (*types.Meta, error) {
 	}
 
 	// fetch and parse codelab source
-	f, err := fetch.NewFetcher(opts.AuthToken, opts.PassMetadata, nil, opts.MDParser)
+	po := *parser.NewOptions(opts.MDParser)
+	po.PassMetadata = opts.PassMetadata
+	po.ReadingWPM = opts.ReadingWPM
+	rt, err := opts.HTTP.Transport(nil)
+	if err != nil {
+		return nil, err
+	}
+	f, err := fetch.NewFetcher(opts.AuthToken, po, rt, authOptions(opts.ServiceAccount)...)
 	if err != nil {
 		return nil, err
 	}
+	f.SetHTTPOptions(opts.HTTP)
+	f.SetImportCredentials(opts.ImportCredentials)
+	f.SetImportRoot(opts.ImportRoot)
 	clab, err := f.SlurpCodelab(meta.Source)
 	if err != nil {
 		return nil, err
@@ -132,7 +243,8 @@ func updateCodelab(dir string, opts CmdUpdateOptions) (*types.Meta, error) {
 	}
 
 	// write codelab and its metadata
-	if err := writeCodelab(newdir, clab.Codelab, opts.ExtraVars, &meta.Context); err != nil {
+	lk := look{tmpl: codelabTemplate(meta.Source, &clab.Meta, "", meta.Format), done: opts.HTTP.Context}
+	if err := writeCodelab(newdir, clab.Codelab, opts.ExtraVars, &meta.Context, lk); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,104 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/cmd"
+)
+
+func TestCmdUpdateFilters(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestCmdUpdateFilters-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	labs := map[string]string{
+		"fire.md":  "id: fire\ncategories: Firebase\nstatus: draft\n\n# Fire\n\n## Step\n\nText.\n",
+		"cloud.md": "id: cloud\ncategories: Cloud\nstatus: published\n\n# Cloud\n\n## Step\n\nText.\n",
+	}
+	var srcs []string
+	for name, content := range labs {
+		src := path.Join(tmp, name)
+		if err := ioutil.WriteFile(src, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		srcs = append(srcs, src)
+	}
+	out := path.Join(tmp, "out")
+	log.SetOutput(ioutil.Discard)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+	if code := cmd.CmdExport(cmd.CmdExportOptions{Output: out, Srcs: srcs, Tmplout: "html"}); code != 0 {
+		t.Fatalf("CmdExport = %d; want 0", code)
+	}
+
+	tests := []struct {
+		filters map[string]string
+		dryRun  bool
+		want    []string // log lines
+	}{
+		{
+			dryRun: true,
+			want: []string{
+				"ok\tcloud: would update " + path.Join(out, "cloud") + " from " + path.Join(tmp, "cloud.md"),
+				"ok\tfire: would update " + path.Join(out, "fire") + " from " + path.Join(tmp, "fire.md"),
+				"2 of 2 codelabs would be updated",
+			},
+		},
+		{
+			filters: map[string]string{"category": "firebase"},
+			dryRun:  true,
+			want: []string{
+				"ok\tfire: would update " + path.Join(out, "fire") + " from " + path.Join(tmp, "fire.md"),
+				"1 of 2 codelabs would be updated",
+			},
+		},
+		{
+			filters: map[string]string{"status": "published"},
+			want:    []string{"ok\tcloud", "1 of 2 codelabs matched filters"},
+		},
+		{
+			filters: map[string]string{"status": "published", "category": "firebase"},
+			want:    []string{"0 of 2 codelabs matched filters"},
+		},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		code := cmd.CmdUpdate(cmd.CmdUpdateOptions{
+			DryRun:  test.dryRun,
+			Filters: test.filters,
+			Jobs:    2,
+			Srcs:    []string{out},
+		})
+		if code != 0 {
+			t.Errorf("%d: CmdUpdate = %d; want 0", i, code)
+		}
+		if got := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+			t.Errorf("%d: CmdUpdate logged:\n%s\nwant:\n%s", i, buf.String(), strings.Join(test.want, "\n"))
+		}
+	}
+}
@@ -20,24 +20,45 @@
 package cmd
 
 import (
+	"github.com/googlecodelabs/tools/claat/fetch/drive/auth"
 
 	// allow parsers to register themselves
 	_ "github.com/googlecodelabs/tools/claat/parser/gdoc"
+	_ "github.com/googlecodelabs/tools/claat/parser/ipynb"
 	_ "github.com/googlecodelabs/tools/claat/parser/md"
+	_ "github.com/googlecodelabs/tools/claat/parser/rst"
 )
 
 const (
 	// metaFilename is codelab metadata file.
 	metaFilename = "codelab.json"
+	// indexFilename is the combined metadata file of codelabs exported
+	// from a Drive folder.
+	indexFilename = "codelabs.json"
+	// sitemapFilename and feedFilename are the sitemap and Atom feed
+	// of codelabs exported with a site URL.
+	sitemapFilename = "sitemap.xml"
+	feedFilename    = "feed.xml"
 	// stdout is a special value for -o cli arg to identify stdout writer.
 	stdout = "-"
 
 	// log report formats
-	reportErr = "err\t%s %v"
-	reportOk  = "ok\t%s"
+	reportErr     = "err\t%s %v"
+	reportOk      = "ok\t%s"
+	reportFeature = "feature\t%s: %d"
 )
 
 // isStdout reports whether filename is stdout.
 func isStdout(filename string) bool {
 	return filename == stdout
 }
+
+// authOptions returns options of obtaining Drive credentials
+// with a service account key file, if any.
+func authOptions(serviceAccount string) []auth.Option {
+	var opts []auth.Option
+	if serviceAccount != "" {
+		opts = append(opts, auth.WithServiceAccount(serviceAccount))
+	}
+	return opts
+}
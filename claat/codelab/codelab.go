@@ -0,0 +1,171 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codelab converts codelabs in-process, for Go programs embedding
+// claat: it parses codelab sources and renders them in the built-in
+// single-file output formats of the claat command, or with a custom
+// template given as a value, with no network access and no files on disk.
+//
+// Imports, code snippets and images of sources are not fetched: they are
+// left as references, which renderers output as is.
+//
+// Rendering does not depend on global state of the render package:
+// formats registered with render.Register, and template functions and
+// partials registered with render.RegisterFuncs and render.RegisterPartial,
+// are not used.
+package codelab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/parser/gdoc"
+	"github.com/googlecodelabs/tools/claat/parser/ipynb"
+	"github.com/googlecodelabs/tools/claat/parser/md"
+	"github.com/googlecodelabs/tools/claat/parser/rst"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Source formats of ParseOptions.
+const (
+	Markdown  = "md"
+	GoogleDoc = "gdoc" // HTML of a Google Doc, as exported by Drive
+	Notebook  = "ipynb"
+	RST       = "rst"
+)
+
+// DefaultPrefix is the URL prefix of assets of the html format,
+// such as its styles and scripts, if RenderOptions.Prefix is empty.
+const DefaultPrefix = "https://storage.googleapis.com"
+
+// parsers are the parsers of source formats. They are used directly,
+// whichever parsers are registered with the parser package.
+var parsers = map[string]parser.Parser{
+	Markdown:  &md.Parser{},
+	GoogleDoc: &gdoc.Parser{},
+	Notebook:  &ipynb.Parser{},
+	RST:       &rst.Parser{},
+}
+
+// ParseOptions configure Parse. The zero value parses Markdown
+// with the defaults of the claat command.
+type ParseOptions struct {
+	// Format is the source format, Markdown if empty.
+	Format string
+	// Options are further parser options, such as variables
+	// and metadata rules, see parser.Options.
+	parser.Options
+}
+
+// Parse parses the codelab source r.
+func Parse(r io.Reader, opts ParseOptions) (*types.Codelab, error) {
+	return ParseContext(context.Background(), r, opts)
+}
+
+// ParseContext is like Parse but stops reading r, and returns the error
+// of ctx, once ctx is done.
+func ParseContext(ctx context.Context, r io.Reader, opts ParseOptions) (*types.Codelab, error) {
+	format := opts.Format
+	if format == "" {
+		format = Markdown
+	}
+	p, ok := parsers[format]
+	if !ok {
+		return nil, &parser.ErrUnknownParser{Name: format}
+	}
+	return parser.ParseWithContext(ctx, p, r, opts.Options)
+}
+
+// RenderOptions configure Render. The zero value renders
+// with the defaults of the claat command.
+type RenderOptions struct {
+	// Env is the environment content is rendered for, e.g. "web".
+	// Content of all environments is rendered if empty.
+	Env string
+	// Prefix is the URL prefix of assets of the html format.
+	// Defaults to DefaultPrefix.
+	Prefix string
+	// GlobalGA is the global Google Analytics account of the html format.
+	GlobalGA string
+	// Updated is the update time of the codelab. Defaults to now.
+	Updated time.Time
+	// Extra are extra template variables.
+	Extra map[string]string
+	// Schema is the JSON export schema version of the json format,
+	// see package schema.
+	Schema string
+	// Theme is the theme bundle of the html format, if not nil.
+	Theme *render.Theme
+	// Locale is the locale of the viewer chrome of the html format,
+	// see render.Msg. English is used if empty.
+	Locale string
+	// Template, if not nil, is a custom template executed in place of
+	// a built-in format, read to the end by Render. It is parsed as an HTML
+	// template if the format given to Render is "html" or ends with
+	// ".html", and as a text template otherwise.
+	Template io.Reader
+}
+
+// Render writes codelab c in format to w. The format is one of the built-in
+// single-file output formats of the claat command, such as "html", "md",
+// "json" or "pdf", or names opts.Template. The offline and obsidian
+// formats, which write several files, are not supported.
+func Render(w io.Writer, format string, c *types.Codelab, opts RenderOptions) error {
+	return RenderContext(context.Background(), w, format, c, opts)
+}
+
+// RenderContext is like Render but stops writing to w, and returns
+// the error of ctx, once ctx is done.
+func RenderContext(ctx context.Context, w io.Writer, format string, c *types.Codelab, opts RenderOptions) error {
+	ro := []render.Option{render.Builtin()}
+	if opts.Template != nil {
+		html := format == "html" || strings.HasSuffix(format, ".html")
+		ro = append(ro, render.WithTemplate(opts.Template, html))
+	} else if format == "offline" || format == "obsidian" {
+		return fmt.Errorf("format %q writes several files and cannot be rendered to a writer", format)
+	}
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	updated := opts.Updated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	data := &struct {
+		render.Context
+		Current *types.Step
+		StepNum int
+		Prev    bool
+		Next    bool
+	}{Context: render.Context{
+		Env:      opts.Env,
+		Prefix:   prefix,
+		Format:   format,
+		GlobalGA: opts.GlobalGA,
+		Updated:  updated.Format(time.RFC3339),
+		Meta:     &c.Meta,
+		Steps:    c.Steps,
+		Extra:    opts.Extra,
+		Schema:   opts.Schema,
+		Theme:    opts.Theme,
+		Locale:   opts.Locale,
+	}}
+	return render.ExecuteContext(ctx, w, format, data, ro...)
+}
@@ -0,0 +1,159 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codelab
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+)
+
+const source = `id: lib
+summary: Converted in-process
+
+# Library Codelab
+
+## Setup
+Duration: 2:00
+
+Hello, {{var "name"}}.
+`
+
+func TestParse(t *testing.T) {
+	opts := ParseOptions{Options: parser.Options{Vars: map[string]string{"name": "gopher"}}}
+	c, err := Parse(strings.NewReader(source), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ID != "lib" || c.Title != "Library Codelab" {
+		t.Errorf("c.ID, c.Title = %q, %q; want lib, Library Codelab", c.ID, c.Title)
+	}
+	if len(c.Steps) != 1 || c.Steps[0].Duration != 2*time.Minute {
+		t.Fatalf("c.Steps = %+v; want 1 step of 2 minutes", c.Steps)
+	}
+
+	if _, err := Parse(strings.NewReader(source), ParseOptions{}); err == nil {
+		t.Error("Parse with an undefined variable = nil error; want an error")
+	}
+	_, err = Parse(strings.NewReader(source), ParseOptions{Format: "docx"})
+	if _, ok := err.(*parser.ErrUnknownParser); !ok {
+		t.Errorf("Parse of format docx err = %v; want *parser.ErrUnknownParser", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	c, err := Parse(strings.NewReader(source), ParseOptions{Options: parser.Options{Vars: map[string]string{"name": "gopher"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"md", "Hello, gopher."},
+		{"html", `<google-codelab-step label="Setup" duration="2">`},
+		{"json", `"summary": "Converted in-process"`},
+	}
+	for _, tc := range tests {
+		var b bytes.Buffer
+		if err := Render(&b, tc.format, c, RenderOptions{Updated: updated}); err != nil {
+			t.Errorf("Render(%s): %v", tc.format, err)
+			continue
+		}
+		if !strings.Contains(b.String(), tc.want) {
+			t.Errorf("Render(%s) = %s; want it to contain %q", tc.format, b.String(), tc.want)
+		}
+	}
+	for _, format := range []string{"offline", "obsidian"} {
+		if err := Render(&bytes.Buffer{}, format, c, RenderOptions{}); err == nil {
+			t.Errorf("Render(%s) = nil error; want an error", format)
+		}
+	}
+}
+
+func TestRenderBuiltin(t *testing.T) {
+	c, err := Parse(strings.NewReader(source), ParseOptions{Options: parser.Options{Vars: map[string]string{"name": "gopher"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	render.Register("codelab-test", testRenderer{})
+	render.RegisterPartial("codelab-test-partial", "partial")
+	if err := Render(&bytes.Buffer{}, "codelab-test", c, RenderOptions{}); err == nil {
+		t.Error("Render(codelab-test) = nil error; want registered formats unknown")
+	}
+	if err := Render(&bytes.Buffer{}, "codelab_test.go", c, RenderOptions{}); err == nil {
+		t.Error("Render(codelab_test.go) = nil error; want local files not read")
+	}
+
+	tests := []struct {
+		format string
+		tmpl   string
+		want   string
+	}{
+		{"custom", `{{.Meta.Title}} {{"<&>"}}`, "Library Codelab <&>"},
+		{"custom.html", `<p>{{.Meta.Title}} {{"<&>"}}</p>`, "<p>Library Codelab &lt;&amp;&gt;</p>"},
+	}
+	for _, tc := range tests {
+		var b bytes.Buffer
+		opts := RenderOptions{Template: strings.NewReader(tc.tmpl)}
+		if err := Render(&b, tc.format, c, opts); err != nil {
+			t.Errorf("Render(%s): %v", tc.format, err)
+			continue
+		}
+		if b.String() != tc.want {
+			t.Errorf("Render(%s) = %q; want %q", tc.format, b.String(), tc.want)
+		}
+	}
+	opts := RenderOptions{Template: strings.NewReader(`{{template "codelab-test-partial"}}`)}
+	if err := Render(&bytes.Buffer{}, "custom", c, opts); err == nil {
+		t.Error("Render with a registered partial = nil error; want partials not used")
+	}
+}
+
+type testRenderer struct{}
+
+func (testRenderer) Ext() string { return "test" }
+
+func (testRenderer) Render(w io.Writer, ctx *render.Context) error {
+	_, err := io.WriteString(w, "test")
+	return err
+}
+
+func TestContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := ParseOptions{Options: parser.Options{Vars: map[string]string{"name": "gopher"}}}
+	c, err := ParseContext(ctx, strings.NewReader(source), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := ParseContext(ctx, strings.NewReader(source), opts); err != context.Canceled {
+		t.Errorf("ParseContext err = %v; want %v", err, context.Canceled)
+	}
+	var b bytes.Buffer
+	if err := RenderContext(ctx, &b, "html", c, RenderOptions{}); err != context.Canceled {
+		t.Errorf("RenderContext err = %v; want %v", err, context.Canceled)
+	}
+	if b.Len() != 0 {
+		t.Errorf("RenderContext wrote %d bytes once canceled", b.Len())
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config loads claat project configuration from a YAML file.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/lint"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/transform"
+)
+
+// DefaultFile is the configuration file looked up by Discover
+// when none is specified explicitly.
+const DefaultFile = "claat.yaml"
+
+// Files are names of configuration files looked up by Discover
+// in each directory, in order of preference. JSON is a subset of YAML.
+var Files = []string{DefaultFile, "claat.json"}
+
+// PathFlags are names of flags whose values are local file paths.
+// Load resolves relative ones against the directory of the file.
+var PathFlags = map[string]bool{
+	"iframe-allowlist": true,
+	"import-cache":     true,
+	"import-root":      true,
+	"link-allowlist":   true,
+	"metrics-file":     true,
+	"o":                true,
+	"patch":            true,
+	"report":           true,
+	"service-account":  true,
+	"src":              true,
+	"template":         true,
+	"translation":      true,
+}
+
+// Config is claat project configuration.
+type Config struct {
+	// Root stops Discover from looking up configuration files
+	// in parent directories of this one.
+	Root bool `yaml:"root,omitempty" json:"root,omitempty"`
+	// Flags are default values of command-line flags, keyed by flag name,
+	// used unless the flag is specified on the command line.
+	Flags map[string]FlagValue `yaml:"flags,omitempty" json:"flags,omitempty"`
+	// Transforms are applied to each exported codelab, in order.
+	Transforms []*transform.Rule `yaml:"transforms" json:"transforms"`
+	// Lint configures rules of the lint command.
+	Lint *lint.Config `yaml:"lint,omitempty" json:"lint,omitempty"`
+	// Metadata constrains metadata of parsed codelabs, keyed by metadata key.
+	Metadata map[string]*parser.MetaRule `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	// Themes are named theme bundles of the html format,
+	// selected with the theme metadata of a codelab or the -theme flag.
+	Themes map[string]*render.Theme `yaml:"themes,omitempty" json:"themes,omitempty"`
+	// Locales add or replace messages of the viewer chrome, keyed by locale,
+	// see render.RegisterLocale.
+	Locales map[string]render.Messages `yaml:"locales,omitempty" json:"locales,omitempty"`
+	// Credentials authenticate requests of remote imports under URL prefixes,
+	// e.g. of private repositories, see fetch.ImportCredential.
+	Credentials []*fetch.ImportCredential `yaml:"credentials,omitempty" json:"credentials,omitempty"`
+}
+
+// Parse decodes and validates configuration stored in b.
+func Parse(b []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	for i, r := range c.Transforms {
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("transforms[%d]: %v", i, err)
+		}
+	}
+	if c.Lint != nil {
+		if err := c.Lint.Validate(); err != nil {
+			return nil, fmt.Errorf("lint: %v", err)
+		}
+	}
+	for k, r := range c.Metadata {
+		if r == nil {
+			continue
+		}
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("metadata %q: %v", k, err)
+		}
+	}
+	for k, t := range c.Themes {
+		if t == nil {
+			continue
+		}
+		if err := t.Validate(); err != nil {
+			return nil, fmt.Errorf("theme %q: %v", k, err)
+		}
+	}
+	for k, m := range c.Locales {
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("locale %q: %v", k, err)
+		}
+	}
+	for i, cred := range c.Credentials {
+		if cred == nil {
+			return nil, fmt.Errorf("credentials[%d]: empty credential", i)
+		}
+		if err := cred.Validate(); err != nil {
+			return nil, fmt.Errorf("credentials[%d]: %v", i, err)
+		}
+	}
+	return c, nil
+}
+
+// FlagValue is a command-line flag value in configuration:
+// a scalar, or a list of scalars which is joined with commas.
+type FlagValue string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (v *FlagValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []interface{}
+	if err := unmarshal(&list); err == nil {
+		s := make([]string, len(list))
+		for i, x := range list {
+			s[i] = scalar(x)
+		}
+		*v = FlagValue(strings.Join(s, ","))
+		return nil
+	}
+	var x interface{}
+	if err := unmarshal(&x); err != nil {
+		return err
+	}
+	if _, ok := x.(map[interface{}]interface{}); ok {
+		return fmt.Errorf("flag value must be a scalar or a list, not a map")
+	}
+	*v = FlagValue(scalar(x))
+	return nil
+}
+
+func scalar(x interface{}) string {
+	if x == nil {
+		return ""
+	}
+	return fmt.Sprint(x)
+}
+
+// merge overrides c with configuration o of a nested directory.
+// Transforms of o are applied after those of c, and credentials
+// of o take precedence over those of c with the same prefix.
+func (c *Config) merge(o *Config) {
+	c.Transforms = append(c.Transforms, o.Transforms...)
+	c.Credentials = append(append([]*fetch.ImportCredential(nil), o.Credentials...), c.Credentials...)
+	if o.Lint != nil {
+		c.Lint = o.Lint
+	}
+	for k, r := range o.Metadata {
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]*parser.MetaRule)
+		}
+		c.Metadata[k] = r
+	}
+	for k, t := range o.Themes {
+		if c.Themes == nil {
+			c.Themes = make(map[string]*render.Theme)
+		}
+		c.Themes[k] = t
+	}
+	for k, m := range o.Locales {
+		if c.Locales == nil {
+			c.Locales = make(map[string]render.Messages)
+		}
+		if c.Locales[k] == nil {
+			c.Locales[k] = make(render.Messages)
+		}
+		for id, s := range m {
+			c.Locales[k][id] = s
+		}
+	}
+	for k, v := range o.Flags {
+		if c.Flags == nil {
+			c.Flags = make(map[string]FlagValue)
+		}
+		c.Flags[k] = v
+	}
+}
+
+// Discover looks up configuration files in dir and its parent directories,
+// up to one with Root set or the file system root, and merges them.
+// Configuration of nested directories overrides that of outer ones.
+// It returns names of the merged files, outermost first,
+// and an empty configuration if there are none.
+func Discover(dir string) (*Config, []string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		confs []*Config
+		names []string
+	)
+	for {
+		name := find(dir)
+		if name != "" {
+			c, err := Load(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			confs = append([]*Config{c}, confs...)
+			names = append([]string{name}, names...)
+			if c.Root {
+				break
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	res := &Config{}
+	for _, c := range confs {
+		res.merge(c)
+	}
+	return res, names, nil
+}
+
+// find returns the first of Files existing in dir, or "" if none does.
+func find(dir string) string {
+	for _, f := range Files {
+		name := filepath.Join(dir, f)
+		if fi, err := os.Stat(name); err == nil && !fi.IsDir() {
+			return name
+		}
+	}
+	return ""
+}
+
+// Load reads configuration from a local file.
+// Relative paths of PathFlags are resolved against the file directory,
+// and "-" is left as is, standing for stdin or stdout.
+func Load(name string) (*Config, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	dir := filepath.Dir(name)
+	for k, v := range c.Flags {
+		if PathFlags[k] && v != "" && v != "-" && !filepath.IsAbs(string(v)) {
+			c.Flags[k] = FlagValue(filepath.Join(dir, string(v)))
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/render"
+)
+
+func TestParseFlags(t *testing.T) {
+	c, err := Parse([]byte(`
+flags:
+  f: md
+  glossary: true
+  jobs: 4
+  pass_metadata: [team, product]
+  prefix:
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]FlagValue{
+		"f":             "md",
+		"glossary":      "true",
+		"jobs":          "4",
+		"pass_metadata": "team,product",
+		"prefix":        "",
+	}
+	if !reflect.DeepEqual(c.Flags, want) {
+		t.Errorf("c.Flags = %v; want %v", c.Flags, want)
+	}
+	if _, err := Parse([]byte("flags:\n  extra: {env: prod}\n")); err == nil {
+		t.Error("Parse of a map flag value = nil error; want an error")
+	}
+}
+
+func TestParseThemes(t *testing.T) {
+	c, err := Parse([]byte(`
+themes:
+  cloud:
+    colors:
+      primary: "#1a73e8"
+    font: "'Google Sans', sans-serif"
+    font_url: https://fonts.example.com/css
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &render.Theme{
+		Colors:  map[string]string{"primary": "#1a73e8"},
+		Font:    "'Google Sans', sans-serif",
+		FontURL: "https://fonts.example.com/css",
+	}
+	if got := c.Themes["cloud"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("c.Themes[cloud] = %+v; want %+v", got, want)
+	}
+	if _, err := Parse([]byte("themes:\n  cloud:\n    colors:\n      primary: 'red; }'\n")); err == nil {
+		t.Error("Parse of an invalid theme = nil error; want an error")
+	}
+}
+
+func TestParseCredentials(t *testing.T) {
+	c, err := Parse([]byte(`
+credentials:
+  - prefix: gs://my-bucket/
+    value: Bearer ${GCS_TOKEN}
+  - prefix: https://wiki.example.com/
+    header: X-Api-Key
+    value: $WIKI_KEY
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*fetch.ImportCredential{
+		{Prefix: "gs://my-bucket/", Value: "Bearer ${GCS_TOKEN}"},
+		{Prefix: "https://wiki.example.com/", Header: "X-Api-Key", Value: "$WIKI_KEY"},
+	}
+	if !reflect.DeepEqual(c.Credentials, want) {
+		t.Errorf("c.Credentials = %+v; want %+v", c.Credentials, want)
+	}
+	for _, bad := range []string{
+		"credentials:\n  - prefix: ftp://example.com/\n    value: x\n",
+		"credentials:\n  - prefix: https://example.com/\n",
+		"credentials:\n  - prefix: https://example.com/\n    header: 'X: Y'\n    value: x\n",
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("Parse(%q) = nil error; want an error", bad)
+		}
+	}
+}
+
+func TestParseLocales(t *testing.T) {
+	c, err := Parse([]byte("locales:\n  nl:\n    next: Volgende\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]render.Messages{"nl": {"next": "Volgende"}}
+	if !reflect.DeepEqual(c.Locales, want) {
+		t.Errorf("c.Locales = %v; want %v", c.Locales, want)
+	}
+	if _, err := Parse([]byte("locales:\n  nl:\n    start: Start\n")); err == nil {
+		t.Error("Parse of an unknown message = nil error; want an error")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestDiscover-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	files := map[string]string{
+		"claat.yaml":             "flags: {f: md, o: outer}\n",
+		"project/claat.yaml":     "root: true\nflags: {f: html, ga: UA-1}\ntransforms: [{type: youtube, action: drop}]\n",
+		"project/sub/claat.json": `{"flags": {"o": "build"}, "transforms": [{"type": "iframe", "action": "link"}]}`,
+	}
+	for name, content := range files {
+		name = filepath.Join(tmp, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, names, err := Discover(filepath.Join(tmp, "project", "sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNames := []string{
+		filepath.Join(tmp, "project", "claat.yaml"),
+		filepath.Join(tmp, "project", "sub", "claat.json"),
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("names = %q; want %q", names, wantNames)
+	}
+	// paths are relative to the file setting them
+	wantFlags := map[string]FlagValue{"f": "html", "ga": "UA-1", "o": FlagValue(filepath.Join(tmp, "project", "sub", "build"))}
+	if !reflect.DeepEqual(c.Flags, wantFlags) {
+		t.Errorf("c.Flags = %v; want %v", c.Flags, wantFlags)
+	}
+	if len(c.Transforms) != 2 || c.Transforms[0].Type != "youtube" || c.Transforms[1].Type != "iframe" {
+		t.Errorf("c.Transforms = %+v; want youtube, then iframe", c.Transforms)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// slurpBanks adds questions of bank files referenced by survey nodes
+// found in clab. Relative file paths are resolved against the directory
+// of codelab src.
+func (f *Fetcher) slurpBanks(src string, clab *types.Codelab) error {
+	for _, st := range clab.Steps {
+		var err error
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			sn, ok := n.(*types.SurveyNode)
+			if !ok || sn.Bank == "" || err != nil {
+				return err == nil
+			}
+			if err = f.slurpBank(src, sn); err != nil {
+				err = &parser.ImportError{Path: sn.Bank, Err: err}
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slurpBank appends questions of all surveys in the Markdown bank file
+// of n to n.Groups. Banks referenced from within the bank file are ignored.
+func (f *Fetcher) slurpBank(src string, n *types.SurveyNode) error {
+	b, err := f.readRef(src, n.Bank)
+	if err != nil {
+		return err
+	}
+	nodes, err := parser.ParseFragment(string(SrcMarkdown), bytes.NewReader(b), f.parserOpts)
+	if err != nil {
+		return err
+	}
+	count := len(n.Groups)
+	types.Walk(nodes, func(bn types.Node) bool {
+		if sn, ok := bn.(*types.SurveyNode); ok {
+			n.Groups = append(n.Groups, sn.Groups...)
+		}
+		return true
+	})
+	if len(n.Groups) == count {
+		return fmt.Errorf("no questions in %s", n.Bank)
+	}
+	return nil
+}
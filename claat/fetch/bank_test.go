@@ -0,0 +1,48 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	_ "github.com/googlecodelabs/tools/claat/parser/md" // Explicitly register md parser
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestSlurpBank(t *testing.T) {
+	f, err := NewFetcher("", *parser.NewOptions(parser.Blackfriday), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := types.NewSurveyNode("quiz-1")
+	n.Bank = "bank.md"
+	src := filepath.Join("testdata", "codelab.md")
+	if err := f.slurpBank(src, n); err != nil {
+		t.Fatal(err)
+	}
+	if len(n.Groups) != 3 {
+		t.Fatalf("len(n.Groups) = %d; want 3", len(n.Groups))
+	}
+	if g := n.Groups[2]; g.Name != "Can a quiz have more than one question?" || len(g.Options) != 2 {
+		t.Errorf("n.Groups[2] = %+v", g)
+	}
+
+	n.Bank = "missing.md"
+	if err := f.slurpBank(src, n); err == nil {
+		t.Errorf("slurpBank(%q) err = nil; want error", n.Bank)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// gcsHost serves objects of Google Cloud Storage buckets over HTTPS.
+const gcsHost = "https://storage.googleapis.com/"
+
+// ImportCredential authenticates requests of remote files under a URL
+// prefix, such as imports from private repositories.
+type ImportCredential struct {
+	// Prefix is the URL prefix of the files,
+	// e.g. "https://git.example.com/docs/" or "gs://my-bucket/".
+	Prefix string `yaml:"prefix" json:"prefix"`
+	// Header is the request header to set. Defaults to Authorization.
+	Header string `yaml:"header,omitempty" json:"header,omitempty"`
+	// Value is the header value. References to environment variables,
+	// $VAR or ${VAR}, are expanded, so that secrets are not stored
+	// in configuration, e.g. "Bearer ${GITLAB_TOKEN}".
+	Value string `yaml:"value" json:"value"`
+}
+
+// Validate reports whether c is a usable credential.
+func (c *ImportCredential) Validate() error {
+	u, err := url.Parse(c.Prefix)
+	if err != nil {
+		return err
+	}
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "gs":
+		return fmt.Errorf("prefix %q is not an http(s) or gs URL", c.Prefix)
+	case u.Host == "":
+		return fmt.Errorf("prefix %q has no host", c.Prefix)
+	case strings.ContainsAny(c.Header, " :\t\r\n"):
+		return fmt.Errorf("invalid header name %q", c.Header)
+	case c.Value == "":
+		return fmt.Errorf("prefix %q has no value", c.Prefix)
+	}
+	return nil
+}
+
+// header returns the name and the value of the header set by c.
+// It returns an error if the value references unset environment variables.
+func (c *ImportCredential) header() (string, string, error) {
+	var missing []string
+	v := os.Expand(c.Value, func(k string) string {
+		s, ok := os.LookupEnv(k)
+		if !ok {
+			missing = append(missing, k)
+		}
+		return s
+	})
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("credential of %s: environment variable %s not set", c.Prefix, strings.Join(missing, ", "))
+	}
+	h := c.Header
+	if h == "" {
+		h = "Authorization"
+	}
+	return h, v, nil
+}
+
+// envCredentials authenticate well-known hosts with tokens of
+// environment variables, if set, unless a configured credential applies.
+var envCredentials = []struct {
+	env      string
+	prefixes []string
+}{
+	{"GITHUB_TOKEN", []string{"https://raw.githubusercontent.com/"}},
+	{"GOOGLE_OAUTH_ACCESS_TOKEN", []string{gcsHost}},
+}
+
+// credential returns the credential of f for fetching URL u,
+// or nil if none applies, see hasURLPrefix. The first credential
+// with the longest matching prefix wins.
+func (f *Fetcher) credential(u string) *ImportCredential {
+	var cred *ImportCredential
+	for _, c := range f.credentials {
+		p := gcsURL(c.Prefix)
+		if hasURLPrefix(u, p) && (cred == nil || len(p) > len(gcsURL(cred.Prefix))) {
+			cred = c
+		}
+	}
+	if cred != nil {
+		return cred
+	}
+	for _, e := range envCredentials {
+		if os.Getenv(e.env) == "" {
+			continue
+		}
+		for _, p := range e.prefixes {
+			if hasURLPrefix(u, p) {
+				return &ImportCredential{Prefix: p, Value: "Bearer ${" + e.env + "}"}
+			}
+		}
+	}
+	return nil
+}
+
+// hasURLPrefix reports whether URL u is under URL prefix p: both have
+// the same scheme and host, and the path of p is a prefix of the path of u
+// ending at a "/" boundary, e.g. "https://example.com/docs" matches
+// "https://example.com/docs/a.md" but not "https://example.com/docs2".
+func hasURLPrefix(u, p string) bool {
+	uu, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	pu, err := url.Parse(p)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(uu.Scheme, pu.Scheme) || !strings.EqualFold(uu.Host, pu.Host) {
+		return false
+	}
+	up, pp := uu.EscapedPath(), pu.EscapedPath()
+	if pp == "" || strings.HasSuffix(pp, "/") {
+		return strings.HasPrefix(up, pp) || up+"/" == pp
+	}
+	return up == pp || strings.HasPrefix(up, pp+"/")
+}
+
+// sameOrigin reports whether URLs a and b have the same scheme and host.
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// gcsURL returns the HTTPS URL of a gs://bucket/object URL u
+// of Google Cloud Storage. Other URLs are returned unchanged.
+func gcsURL(u string) string {
+	if !strings.HasPrefix(u, "gs://") {
+		return u
+	}
+	obj := &url.URL{Path: strings.TrimPrefix(u, "gs://")}
+	return gcsHost + obj.EscapedPath()
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+func TestFetchRemoteFileCredentials(t *testing.T) {
+	defer os.Setenv("GITHUB_TOKEN", os.Getenv("GITHUB_TOKEN"))
+	defer os.Setenv("WIKI_KEY", os.Getenv("WIKI_KEY"))
+	os.Setenv("GITHUB_TOKEN", "gh-secret")
+	os.Setenv("WIKI_KEY", "wiki-secret")
+
+	var req *http.Request
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		req = r
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("Text."))}, nil
+	}}
+	f, err := NewFetcher("", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetImportCredentials([]*ImportCredential{
+		{Prefix: "gs://bucket/", Value: "Bearer ${GCS_TOKEN_UNSET}"},
+		{Prefix: "https://wiki.example.com/", Header: "x-api-key", Value: "key $WIKI_KEY"},
+		{Prefix: "https://wiki.example.com/team/", Value: "Bearer ${WIKI_KEY}"},
+	})
+
+	tests := []struct {
+		url    string
+		reqURL string
+		header string
+		value  string
+	}{
+		{"https://wiki.example.com/a.md", "https://wiki.example.com/a.md", "X-Api-Key", "key wiki-secret"},
+		{"https://wiki.example.com/team/a.md", "https://wiki.example.com/team/a.md", "Authorization", "Bearer wiki-secret"},
+		{"https://raw.githubusercontent.com/o/r/main/a.md", "https://raw.githubusercontent.com/o/r/main/a.md", "Authorization", "Bearer gh-secret"},
+		{"gs://public/dir/a b.md", "https://storage.googleapis.com/public/dir/a%20b.md", "Authorization", ""},
+		{"https://wiki.example.com/teamx/a.md", "https://wiki.example.com/teamx/a.md", "Authorization", ""},
+		{"https://wiki.example.com.evil.net/a.md", "https://wiki.example.com.evil.net/a.md", "X-Api-Key", ""},
+		{"http://wiki.example.com/a.md", "http://wiki.example.com/a.md", "X-Api-Key", ""},
+	}
+	for _, tc := range tests {
+		req = nil
+		res, err := f.fetchRemoteFile(tc.url)
+		if err != nil {
+			t.Errorf("fetchRemoteFile(%q): %v", tc.url, err)
+			continue
+		}
+		res.body.Close()
+		if got := req.URL.String(); got != tc.reqURL {
+			t.Errorf("fetchRemoteFile(%q) requested %q; want %q", tc.url, got, tc.reqURL)
+		}
+		if got := req.Header.Get(tc.header); got != tc.value {
+			t.Errorf("fetchRemoteFile(%q) %s = %q; want %q", tc.url, tc.header, got, tc.value)
+		}
+	}
+
+	if _, err := f.fetchRemoteFile("gs://bucket/a.md"); err == nil || !strings.Contains(err.Error(), "GCS_TOKEN_UNSET") {
+		t.Errorf("fetchRemoteFile with unset variable err = %v; want it named", err)
+	}
+}
+
+func TestFetchRemoteFileCredentialsRedirect(t *testing.T) {
+	var reqs []*http.Request
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		reqs = append(reqs, r)
+		res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("Text.")), Request: r}
+		switch r.URL.Path {
+		case "/same":
+			res.StatusCode = http.StatusFound
+			res.Header.Set("Location", "/other")
+		case "/other":
+			res.StatusCode = http.StatusFound
+			res.Header.Set("Location", "https://elsewhere.example.net/a.md")
+		}
+		return res, nil
+	}}
+	f, err := NewFetcher("", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetImportCredentials([]*ImportCredential{{Prefix: "https://wiki.example.com/", Header: "x-api-key", Value: "key"}})
+
+	res, err := f.fetchRemoteFile("https://wiki.example.com/same")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.body.Close()
+	want := []string{"key", "key", ""}
+	if len(reqs) != len(want) {
+		t.Fatalf("made %d requests; want %d", len(reqs), len(want))
+	}
+	for i, r := range reqs {
+		if got := r.Header.Get("x-api-key"); got != want[i] {
+			t.Errorf("request %d to %s: x-api-key = %q; want %q", i, r.URL, got, want[i])
+		}
+	}
+}
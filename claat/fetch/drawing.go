@@ -0,0 +1,50 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// drawingExportPrefix is the prefix of export URLs of Google Drawings
+// embedded in Google Docs, followed by the drawing ID.
+const drawingExportPrefix = "https://docs.google.com/drawings/d/"
+
+// drawingTitle returns the title of the Google Drawing exported by URL u,
+// HTML-escaped like alt text of Google Docs images, if u is such a URL.
+// Titles which cannot be retrieved are left empty.
+func (f *Fetcher) drawingTitle(u string) string {
+	if f.authHelper == nil || !strings.HasPrefix(u, drawingExportPrefix) || !strings.Contains(u, "/export/") {
+		return ""
+	}
+	id := strings.SplitN(strings.TrimPrefix(u, drawingExportPrefix), "/", 2)[0]
+	q := url.Values{"fields": {"name"}, "supportsAllDrives": {"true"}}
+	res, err := f.http.get(f.authHelper.DriveClient(), fmt.Sprintf("%s/files/%s?%s", driveAPI, id, q.Encode()), nil, 3)
+	if err != nil {
+		return ""
+	}
+	defer res.Body.Close()
+	var file struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&file); err != nil {
+		return ""
+	}
+	return html.EscapeString(file.Name)
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestSlurpImagesDrawing(t *testing.T) {
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case r.URL.Host == "www.googleapis.com" && r.URL.Path == "/drive/v3/files/d1":
+			body = `{"name": "Flow <v2>"}`
+		case r.URL.Host == "docs.google.com" && r.URL.Path == "/drawings/d/d1/export/svg":
+			body = `<svg xmlns="http://www.w3.org/2000/svg"></svg>`
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	}}
+	f, err := NewFetcher("token", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.initAuth(); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "claat-drawing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	img := types.NewImageNode("https://docs.google.com/drawings/d/d1/export/svg")
+	steps := []*types.Step{{Content: types.NewListNode(img)}}
+	if _, err := f.SlurpImages("doc", dir, steps); err != nil {
+		t.Fatal(err)
+	}
+	if img.Alt != "Flow &lt;v2&gt;" {
+		t.Errorf("img.Alt = %q; want drawing title", img.Alt)
+	}
+	if filepath.Ext(img.Src) != ".svg" {
+		t.Errorf("img.Src = %q; want an .svg file", img.Src)
+	}
+}
@@ -17,13 +17,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
 	"path"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
+
+	"github.com/googlecodelabs/tools/claat/logging"
 )
 
 const (
@@ -51,10 +52,27 @@ var (
 	}
 )
 
-type authorizationHandler func(conf *oauth2.Config) (*oauth2.Token, error)
+// authorizationHandler obtains credentials of conf from the user,
+// sending requests with the oauth2.HTTPClient of ctx, if any.
+type authorizationHandler func(ctx context.Context, conf *oauth2.Config) (*oauth2.Token, error)
 
 type internalOptions struct {
-	authHandler authorizationHandler
+	authHandler    authorizationHandler
+	serviceAccount string // service account JSON key file
+}
+
+// Option configures how a Helper obtains credentials
+// when no auth token is given.
+type Option func(*internalOptions)
+
+// WithServiceAccount authenticates as the service account of a JSON key file,
+// as downloaded from the Cloud Console. The Docs need to be shared with
+// the service account email. No user interaction is required, which makes
+// it suitable for CI systems.
+func WithServiceAccount(keyFile string) Option {
+	return func(io *internalOptions) {
+		io.serviceAccount = keyFile
+	}
 }
 
 type Helper struct {
@@ -64,10 +82,14 @@ type Helper struct {
 	opts      internalOptions
 }
 
-func NewHelper(at, p string, rt http.RoundTripper) (*Helper, error) {
-	return newHelper(at, p, rt, internalOptions{
+func NewHelper(at, p string, rt http.RoundTripper, opts ...Option) (*Helper, error) {
+	io := internalOptions{
 		authHandler: authorize,
-	})
+	}
+	for _, o := range opts {
+		o(&io)
+	}
+	return newHelper(at, p, rt, io)
 }
 
 func newHelper(at, p string, rt http.RoundTripper, io internalOptions) (*Helper, error) {
@@ -91,7 +113,7 @@ func (h *Helper) DriveClient() *http.Client {
 }
 
 func (h *Helper) produceDriveClient(rt http.RoundTripper) (*http.Client, error) {
-	ts, err := h.tokenSource()
+	ts, err := h.tokenSource(rt)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +134,8 @@ func (h *Helper) produceDriveClient(rt http.RoundTripper) (*http.Client, error)
 // using previously stored user credentials if available.
 // If authToken is not given at Helper init, we use the Google provider.
 // Otherwise, we use the auth config for the given provider.
-func (h *Helper) tokenSource() (oauth2.TokenSource, error) {
+// A service account key, if given, takes precedence over user credentials.
+func (h *Helper) tokenSource(rt http.RoundTripper) (oauth2.TokenSource, error) {
 	// Create a static token source if we have an auth token.
 	if h.authToken != "" {
 		return oauth2.StaticTokenSource(&oauth2.Token{
@@ -120,22 +143,46 @@ func (h *Helper) tokenSource() (oauth2.TokenSource, error) {
 		}), nil
 	}
 
+	// token requests go through rt too
+	ctx := context.Background()
+	if rt != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: rt})
+	}
+	if h.opts.serviceAccount != "" {
+		return serviceAccountTokenSource(ctx, h.opts.serviceAccount)
+	}
+
 	// Otherwise, use the Google provider.
 	t, err := readToken(h.provider)
 	if err != nil {
-		t, err = h.opts.authHandler(&googleAuthConfig)
+		t, err = h.opts.authHandler(ctx, &googleAuthConfig)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("unable to obtain access token for %q", h.provider)
+		return nil, fmt.Errorf("unable to obtain access token for %q: %v", h.provider, err)
 	}
 	cache := &cachedTokenSource{
-		src:      googleAuthConfig.TokenSource(context.Background(), t),
-		provider: h.provider,
-		config:   &googleAuthConfig,
+		ctx:       ctx,
+		src:       googleAuthConfig.TokenSource(ctx, t),
+		provider:  h.provider,
+		config:    &googleAuthConfig,
+		authorize: h.opts.authHandler,
 	}
 	return oauth2.ReuseTokenSource(nil, cache), nil
 }
 
+// CachedToken returns the credentials of provider stored on local disk
+// by a previous authorization, and the file they are stored in.
+// The token may be expired; it is refreshed when used if it has
+// a refresh token.
+func CachedToken(provider string) (*oauth2.Token, string, error) {
+	l, err := tokenLocation(provider)
+	if err != nil {
+		return nil, "", err
+	}
+	t, err := readToken(provider)
+	return t, l, err
+}
+
 func readToken(provider string) (*oauth2.Token, error) {
 	l, err := tokenLocation(provider)
 	if err != nil {
@@ -172,7 +219,7 @@ func writeToken(provider string, tok *oauth2.Token) error {
 func tokenLocation(provider string) (string, error) {
 	d := homedir()
 	if d == "" {
-		log.Printf("WARNING: unable to identify user home dir")
+		logging.Warnf("unable to identify user home dir")
 	}
 	d = path.Join(d, ".config", "claat")
 	if err := os.MkdirAll(d, 0700); err != nil {
@@ -195,15 +242,17 @@ func homedir() string {
 // cachedTokenSource stores tokens returned from src on local disk.
 // It is usually combined with oauth2.ReuseTokenSource.
 type cachedTokenSource struct {
-	src      oauth2.TokenSource
-	provider string
-	config   *oauth2.Config
+	ctx       context.Context // of authorize
+	src       oauth2.TokenSource
+	provider  string
+	config    *oauth2.Config
+	authorize authorizationHandler // obtains new credentials if src fails
 }
 
 func (c *cachedTokenSource) Token() (*oauth2.Token, error) {
 	t, err := c.src.Token()
 	if err != nil {
-		t, err = authorize(c.config)
+		t, err = c.authorize(c.ctx, c.config)
 	}
 	if err != nil {
 		return nil, err
@@ -213,12 +262,12 @@ func (c *cachedTokenSource) Token() (*oauth2.Token, error) {
 }
 
 // authorize performs user authorization flow, asking for permissions grant.
-func authorize(conf *oauth2.Config) (*oauth2.Token, error) {
+func authorize(ctx context.Context, conf *oauth2.Config) (*oauth2.Token, error) {
 	aurl := conf.AuthCodeURL("unused", oauth2.AccessTypeOffline)
 	fmt.Printf("Authorize me at following URL, please:\n\n%s\n\nCode: ", aurl)
 	var code string
 	if _, err := fmt.Scan(&code); err != nil {
 		return nil, err
 	}
-	return conf.Exchange(context.Background(), code)
+	return conf.Exchange(ctx, code)
 }
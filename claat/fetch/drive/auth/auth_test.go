@@ -14,13 +14,26 @@
 package auth
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
 	"golang.org/x/oauth2"
 )
 
 // Fake authorization handler to skip interactivity breaking the tests.
-func fakeAuthorizationHandler(_ *oauth2.Config) (*oauth2.Token, error) {
+func fakeAuthorizationHandler(_ context.Context, _ *oauth2.Config) (*oauth2.Token, error) {
 	return &oauth2.Token{}, nil
 }
 
@@ -70,3 +83,79 @@ func TestDriveClient(t *testing.T) {
 		t.Fatalf("h.DriveClient() == nil, want non-nil")
 	}
 }
+
+func TestNewHelperAuthorizationError(t *testing.T) {
+	denied := func(context.Context, *oauth2.Config) (*oauth2.Token, error) {
+		return nil, errors.New("access denied")
+	}
+	_, err := newHelper("", "claat-test-no-token", nil, internalOptions{authHandler: denied})
+	if err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("newHelper err = %v; want the authorization error", err)
+	}
+}
+
+func TestServiceAccountTokenSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if v := r.PostForm.Get("grant_type"); v != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("grant_type = %q", v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"sa-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer ts.Close()
+
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(pk)})
+	key, _ := json.Marshal(&serviceAccountKey{
+		Type:        "service_account",
+		ClientEmail: "ci@example.iam.gserviceaccount.com",
+		PrivateKey:  string(pemKey),
+		TokenURI:    ts.URL,
+	})
+	f, err := ioutil.TempFile("", "claat-sa-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Write(key)
+	f.Close()
+
+	h, err := NewHelper("", "pvdr", nil, WithServiceAccount(f.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := h.DriveClient().Transport.(*oauth2.Transport).Source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "sa-token" {
+		t.Errorf("AccessToken = %q; want sa-token", tok.AccessToken)
+	}
+
+	if _, err := NewHelper("", "pvdr", nil, WithServiceAccount("testdata/missing.json")); err == nil {
+		t.Error("NewHelper with missing key file: err = nil")
+	}
+}
+
+func TestServiceAccountEmail(t *testing.T) {
+	f, err := ioutil.TempFile("", "claat-sa-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(`{"type":"service_account","client_email":"ci@example.iam.gserviceaccount.com","private_key":"key"}`)
+	f.Close()
+	email, err := ServiceAccountEmail(f.Name())
+	if err != nil || email != "ci@example.iam.gserviceaccount.com" {
+		t.Errorf("ServiceAccountEmail = %q, %v; want ci@example.iam.gserviceaccount.com", email, err)
+	}
+
+	ioutil.WriteFile(f.Name(), []byte(`{"type":"authorized_user"}`), 0600)
+	if _, err := ServiceAccountEmail(f.Name()); err == nil {
+		t.Error("ServiceAccountEmail of a user key: err = nil")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+// serviceAccountKey is the relevant part of a service account JSON key file.
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ServiceAccountEmail returns the email of the service account of JSON
+// key file, which the Docs need to be shared with.
+// It checks the key file without obtaining a token.
+func ServiceAccountEmail(file string) (string, error) {
+	key, err := readServiceAccountKey(file)
+	if err != nil {
+		return "", err
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return "", fmt.Errorf("%s: service account key has no client_email or private_key", file)
+	}
+	return key.ClientEmail, nil
+}
+
+// readServiceAccountKey reads service account JSON key file.
+func readServiceAccountKey(file string) (*serviceAccountKey, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	key := &serviceAccountKey{}
+	if err := json.Unmarshal(b, key); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	if key.Type != "service_account" {
+		return nil, fmt.Errorf("%s: not a service account key, type %q", file, key.Type)
+	}
+	return key, nil
+}
+
+// serviceAccountTokenSource creates a token source of the service account
+// specified in JSON key file. Tokens are obtained with a signed JWT,
+// without user interaction, and are not stored on disk.
+func serviceAccountTokenSource(ctx context.Context, file string) (oauth2.TokenSource, error) {
+	key, err := readServiceAccountKey(file)
+	if err != nil {
+		return nil, err
+	}
+	conf := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{scopeDriveReadOnly},
+		TokenURL:     key.TokenURI,
+	}
+	if conf.TokenURL == "" {
+		conf.TokenURL = googleAuthConfig.Endpoint.TokenURL
+	}
+	return conf.TokenSource(ctx), nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const (
+	// youtubeAPI is a base URL for YouTube Data API
+	youtubeAPI = "https://www.googleapis.com/youtube/v3"
+	// vimeoOEmbed is Vimeo oEmbed endpoint, which requires no API key
+	vimeoOEmbed = "https://vimeo.com/api/oembed.json"
+)
+
+// isoDurationRegexp matches ISO 8601 durations as reported by YouTube Data API,
+// e.g. "PT1H2M3S" or "P1DT2M".
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// DurationSource reports running time of embedded media, such as videos,
+// to be included in a codelab step duration.
+type DurationSource interface {
+	// Duration returns running time of n.
+	// The ok result is false if n is not handled by the source.
+	Duration(n types.Node) (d time.Duration, ok bool, err error)
+}
+
+// YouTubeDurations is a DurationSource of YouTube videos.
+// It uses YouTube Data API, which requires an API key.
+type YouTubeDurations struct {
+	APIKey string
+	Client *http.Client // default client is used if nil
+	HTTP   HTTPOptions  // retries and backoff of requests
+}
+
+// Duration implements DurationSource.
+func (y *YouTubeDurations) Duration(n types.Node) (time.Duration, bool, error) {
+	yt, ok := n.(*types.YouTubeNode)
+	if !ok {
+		return 0, false, nil
+	}
+	q := url.Values{
+		"part": {"contentDetails"},
+		"id":   {yt.VideoID},
+		"key":  {y.APIKey},
+	}
+	res, err := y.HTTP.get(y.Client, fmt.Sprintf("%s/videos?%s", youtubeAPI, q.Encode()), nil, 3)
+	if err != nil {
+		return 0, true, err
+	}
+	defer res.Body.Close()
+	var v struct {
+		Items []struct {
+			ContentDetails struct {
+				Duration string `json:"duration"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return 0, true, err
+	}
+	if len(v.Items) == 0 {
+		return 0, true, fmt.Errorf("youtube video %s not found", yt.VideoID)
+	}
+	d, err := parseISODuration(v.Items[0].ContentDetails.Duration)
+	return d, true, err
+}
+
+// VimeoDurations is a DurationSource of Vimeo videos embedded with an iframe.
+type VimeoDurations struct {
+	Client *http.Client // default client is used if nil
+	HTTP   HTTPOptions  // retries and backoff of requests
+}
+
+// Duration implements DurationSource.
+func (v *VimeoDurations) Duration(n types.Node) (time.Duration, bool, error) {
+	ifr, ok := n.(*types.IframeNode)
+	if !ok {
+		return 0, false, nil
+	}
+	u, err := url.Parse(ifr.URL)
+	if err != nil || u.Hostname() != "vimeo.com" && u.Hostname() != "player.vimeo.com" {
+		return 0, false, nil
+	}
+	q := url.Values{"url": {ifr.URL}}
+	res, err := v.HTTP.get(v.Client, fmt.Sprintf("%s?%s", vimeoOEmbed, q.Encode()), nil, 3)
+	if err != nil {
+		return 0, true, err
+	}
+	defer res.Body.Close()
+	var meta struct {
+		Duration int `json:"duration"` // seconds
+	}
+	if err := json.NewDecoder(res.Body).Decode(&meta); err != nil {
+		return 0, true, err
+	}
+	return time.Duration(meta.Duration) * time.Second, true, nil
+}
+
+// AddMediaDurations adds running time of media embedded in each step of clab
+// to the step duration, as well as the total codelab duration.
+// The first source which handles a node determines its running time.
+//
+// Added time of each step is rounded up to the nearest minute.
+func AddMediaDurations(clab *types.Codelab, srcs ...DurationSource) error {
+	for _, st := range clab.Steps {
+		var total time.Duration
+		var err error
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			for _, s := range srcs {
+				d, ok, e := s.Duration(n)
+				if !ok {
+					continue
+				}
+				if e != nil && err == nil {
+					err = e
+				}
+				total += d
+				break
+			}
+			return err == nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %v", st.Title, err)
+		}
+		if total == 0 {
+			continue
+		}
+		rd := total.Truncate(time.Minute)
+		if rd < total {
+			rd += time.Minute
+		}
+		st.Duration += rd
+		clab.Duration += int(rd.Minutes())
+	}
+	return nil
+}
+
+// parseISODuration parses ISO 8601 duration string s,
+// supporting days, hours, minutes and seconds.
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDurationRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
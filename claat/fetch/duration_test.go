@@ -0,0 +1,90 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"PT4M13S", 4*time.Minute + 13*time.Second},
+		{"PT1H", time.Hour},
+		{"P1DT2M", 24*time.Hour + 2*time.Minute},
+		{"PT0S", 0},
+	}
+	for i, test := range tests {
+		d, err := parseISODuration(test.in)
+		if err != nil {
+			t.Errorf("%d: parseISODuration(%q): %v", i, test.in, err)
+			continue
+		}
+		if d != test.out {
+			t.Errorf("%d: parseISODuration(%q) = %v; want %v", i, test.in, d, test.out)
+		}
+	}
+	if _, err := parseISODuration("4:13"); err == nil {
+		t.Errorf("parseISODuration(4:13): nil error")
+	}
+}
+
+func TestAddMediaDurations(t *testing.T) {
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		body := `{"items": [{"contentDetails": {"duration": "PT3M10S"}}]}`
+		if r.URL.Host == "vimeo.com" {
+			body = `{"duration": 65}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	}}
+	client := &http.Client{Transport: rt}
+
+	clab := types.NewCodelab()
+	clab.Duration = 5
+	st := clab.NewStep("videos")
+	st.Duration = 5 * time.Minute
+	st.Content.Append(
+		types.NewYouTubeNode("yt"),
+		types.NewListNode(types.NewIframeNode("https://player.vimeo.com/video/1")),
+		types.NewIframeNode("https://codepen.io/pen/1"),
+	)
+	clab.NewStep("empty")
+
+	srcs := []DurationSource{
+		&YouTubeDurations{APIKey: "key", Client: client},
+		&VimeoDurations{Client: client},
+	}
+	if err := AddMediaDurations(clab, srcs...); err != nil {
+		t.Fatal(err)
+	}
+	// 3m10s + 1m5s is rounded up to 5m
+	if st.Duration != 10*time.Minute {
+		t.Errorf("st.Duration = %v; want 10m", st.Duration)
+	}
+	if clab.Duration != 10 {
+		t.Errorf("clab.Duration = %d; want 10", clab.Duration)
+	}
+}
@@ -14,17 +14,19 @@
 package fetch
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/crc64"
 	"io"
 	"io/ioutil"
-	"math"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -40,8 +42,10 @@ const (
 	// TODO: define these in claat/parser/..., e.g. in parser/gdoc
 	// alternate TODO: make this an iota-based enum?
 	SrcInvalid   srcType = ""
-	SrcGoogleDoc srcType = "gdoc" // Google Docs doc
-	SrcMarkdown  srcType = "md"   // Markdown text
+	SrcGoogleDoc srcType = "gdoc"  // Google Docs doc
+	SrcMarkdown  srcType = "md"    // Markdown text
+	SrcNotebook  srcType = "ipynb" // Jupyter notebook
+	SrcRST       srcType = "rst"   // reStructuredText
 
 	// driveAPI is a base URL for Drive API
 	driveAPI = "https://www.googleapis.com/drive/v3"
@@ -52,12 +56,17 @@ const (
 // srcType is codelab source type
 type srcType string
 
+// Stdin is the name of a codelab source read from standard input,
+// in Markdown.
+const Stdin = "-"
+
 // resource is a codelab resource, loaded from local file
 // or fetched from remote location.
 type resource struct {
 	typ  srcType       // source type
 	body io.ReadCloser // resource body
 	mod  time.Time     // last update of content
+	rev  string        // content revision, if known
 }
 
 // codelab wraps types.Codelab, while adding source type
@@ -66,17 +75,18 @@ type codelab struct {
 	*types.Codelab
 	Typ srcType   //  source type
 	Mod time.Time // last modified timestamp
+	Rev string    // source revision, e.g. a Google Doc version; empty if unknown
+	// ParseTime is the time spent parsing the source, once fetched.
+	ParseTime time.Duration
 }
 
 type MemoryFetcher struct {
-	passMetadata map[string]bool
-	mdParser     parser.MarkdownParser
+	parserOpts parser.Options
 }
 
-func NewMemoryFetcher(pm map[string]bool, mdp parser.MarkdownParser) *MemoryFetcher {
+func NewMemoryFetcher(opts parser.Options) *MemoryFetcher {
 	return &MemoryFetcher{
-		passMetadata: pm,
-		mdParser:     mdp,
+		parserOpts: opts,
 	}
 }
 
@@ -88,10 +98,7 @@ func (m *MemoryFetcher) SlurpCodelab(rc io.ReadCloser) (*codelab, error) {
 	}
 	defer r.body.Close()
 
-	opts := *parser.NewOptions(m.mdParser)
-	opts.PassMetadata = m.passMetadata
-
-	clab, err := parser.Parse(string(r.typ), r.body, opts)
+	clab, err := parser.Parse(string(r.typ), r.body, m.parserOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -105,39 +112,82 @@ func (m *MemoryFetcher) SlurpCodelab(rc io.ReadCloser) (*codelab, error) {
 
 type Fetcher struct {
 	authHelper   *auth.Helper
+	authOpts     []auth.Option
 	authToken    string
 	crcTable     *crc64.Table
-	mdParser     parser.MarkdownParser
-	passMetadata map[string]bool
+	credentials  []*ImportCredential
+	http         HTTPOptions
+	importRoot   string
+	imports      *ImportCache
+	parserOpts   parser.Options
 	roundTripper http.RoundTripper
 }
 
-func NewFetcher(at string, pm map[string]bool, rt http.RoundTripper, mdp parser.MarkdownParser) (*Fetcher, error) {
+// NewFetcher creates a new Fetcher. Fetched codelabs and their fragments
+// are parsed with the provided parser options.
+// Auth options select how Drive credentials are obtained if at is empty.
+func NewFetcher(at string, opts parser.Options, rt http.RoundTripper, authOpts ...auth.Option) (*Fetcher, error) {
 	return &Fetcher{
 		authHelper:   nil,
+		authOpts:     authOpts,
 		authToken:    at,
 		crcTable:     crc64.MakeTable(crc64.ECMA),
-		mdParser:     mdp,
-		passMetadata: pm,
+		parserOpts:   opts,
 		roundTripper: rt,
 	}, nil
 }
 
+// SetImportCache stores remote imports of fetched codelabs in c.
+// Imports are fetched on every call of SlurpCodelab if c is nil.
+func (f *Fetcher) SetImportCache(c *ImportCache) {
+	f.imports = c
+}
+
+// SetImportCredentials authenticates requests of remote files,
+// such as imports, with credentials creds. Credentials of environment
+// variables are used for URLs which none of creds apply to,
+// see ImportCredential.
+func (f *Fetcher) SetImportCredentials(creds []*ImportCredential) {
+	f.credentials = creds
+}
+
+// SetHTTPOptions sets retries and backoff of requests of f.
+// Timeouts and proxies are set by the transport passed to NewFetcher,
+// see HTTPOptions.Transport.
+func (f *Fetcher) SetHTTPOptions(o HTTPOptions) {
+	f.http = o
+}
+
+// SetImportRoot confines local imports to directory dir and its
+// subdirectories. Imports of Google Docs with relative paths are
+// resolved against dir. Defaults to the current directory.
+func (f *Fetcher) SetImportRoot(dir string) {
+	f.importRoot = dir
+}
+
+// initAuth sets up Drive API credentials, if not done yet.
+func (f *Fetcher) initAuth() error {
+	if f.authHelper != nil {
+		return nil
+	}
+	var err error
+	f.authHelper, err = auth.NewHelper(f.authToken, auth.ProviderGoogle, f.roundTripper, f.authOpts...)
+	return err
+}
+
 // SlurpCodelab retrieves and parses codelab source.
 // It takes the source, plus an auth token and a set of extra metadata to pass along.
 // It returns parsed codelab and its source type.
 //
 // The function will also fetch and parse fragments included
-// with types.ImportNode.
+// with types.ImportNode, as well as code snippets and question banks
+// included from files.
 func (f *Fetcher) SlurpCodelab(src string) (*codelab, error) {
 	_, err := os.Stat(src)
 	// Only setup oauth if this source is not a local file.
-	if os.IsNotExist(err) {
-		if f.authHelper == nil {
-			f.authHelper, err = auth.NewHelper(f.authToken, auth.ProviderGoogle, f.roundTripper)
-			if err != nil {
-				return nil, err
-			}
+	if os.IsNotExist(err) && src != Stdin {
+		if err := f.initAuth(); err != nil {
+			return nil, err
 		}
 	}
 	res, err := f.fetch(src)
@@ -145,27 +195,37 @@ func (f *Fetcher) SlurpCodelab(src string) (*codelab, error) {
 		return nil, err
 	}
 	defer res.body.Close()
+	// read the source first, so that parsing is timed on its own
+	b, err := ioutil.ReadAll(res.body)
+	if err != nil {
+		return nil, err
+	}
 
-	opts := *parser.NewOptions(f.mdParser)
-	opts.PassMetadata = f.passMetadata
-
-	clab, err := parser.Parse(string(res.typ), res.body, opts)
+	opts := f.parserOpts
+	if res.typ == SrcGoogleDoc {
+		// links to headings of the doc point to its steps
+		opts.DocID = gdocID(src)
+	}
+	start := time.Now()
+	clab, err := parser.ParseContext(f.http.context(), string(res.typ), bytes.NewReader(b), opts)
 	if err != nil {
 		return nil, err
 	}
+	parseTime := time.Since(start)
 
 	// fetch imports and parse them as fragments
 	var imports []*types.ImportNode
 	for _, st := range clab.Steps {
 		imports = append(imports, types.ImportNodes(st.Content.Nodes)...)
 	}
+	// ch is not closed: it has room for all results,
+	// including those sent after an early return on error
 	ch := make(chan error, len(imports))
-	defer close(ch)
 	for _, imp := range imports {
 		go func(n *types.ImportNode) {
-			frag, err := f.slurpFragment(n.URL)
+			frag, err := f.slurpFragment(src, n.URL)
 			if err != nil {
-				ch <- fmt.Errorf("%s: %v", n.URL, err)
+				ch <- &parser.ImportError{Path: n.URL, Err: err}
 				return
 			}
 			n.Content.Nodes = frag
@@ -177,11 +237,19 @@ func (f *Fetcher) SlurpCodelab(src string) (*codelab, error) {
 			return nil, err
 		}
 	}
+	if err := f.slurpSnippets(src, clab); err != nil {
+		return nil, err
+	}
+	if err := f.slurpBanks(src, clab); err != nil {
+		return nil, err
+	}
 
 	v := &codelab{
-		Codelab: clab,
-		Typ:     res.typ,
-		Mod:     res.mod,
+		Codelab:   clab,
+		Typ:       res.typ,
+		Mod:       res.mod,
+		Rev:       res.rev,
+		ParseTime: parseTime,
 	}
 	return v, nil
 }
@@ -201,10 +269,19 @@ func (f *Fetcher) SlurpImages(src, dir string, steps []*types.Step) (map[string]
 	defer close(ch)
 	var count int
 	for _, st := range steps {
-		nodes := types.ImageNodes(st.Content.Nodes)
+		var nodes []*types.ImageNode
+		for _, n := range types.ImageNodes(st.Content.Nodes) {
+			// placeholders have no image to slurp
+			if n.Placeholder == "" {
+				nodes = append(nodes, n)
+			}
+		}
 		count += len(nodes)
 		for _, n := range nodes {
 			go func(n *types.ImageNode) {
+				if n.Alt == "" {
+					n.Alt = f.drawingTitle(n.Src)
+				}
 				url := n.Src
 				file, err := f.slurpBytes(src, dir, url)
 				if err == nil {
@@ -231,9 +308,30 @@ func (f *Fetcher) SlurpImages(src, dir string, steps []*types.Step) (map[string]
 	return imap, nil
 }
 
+// SlurpImage copies or downloads image imgURL of codelab src to dir,
+// like SlurpImages, and returns its path relative to the codelab directory.
+func (f *Fetcher) SlurpImage(src, dir, imgURL string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	file, err := f.slurpBytes(src, dir, imgURL)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(util.ImgDirname, file), nil
+}
+
 func (f *Fetcher) slurpBytes(codelabSrc, dir, imgURL string) (string, error) {
 	// images can be local in Markdown cases or remote.
 	// Only proceed a simple copy on local reference.
+	if strings.HasPrefix(imgURL, "data:") {
+		// inline images, e.g. Jupyter notebook cell outputs
+		b, ext, err := decodeDataURI(imgURL)
+		if err != nil {
+			return "", err
+		}
+		return f.writeBytes(dir, b, ext)
+	}
 	var b []byte
 	var ext string
 	u, err := url.Parse(imgURL)
@@ -256,41 +354,111 @@ func (f *Fetcher) slurpBytes(codelabSrc, dir, imgURL string) (string, error) {
 		ext = filepath.Ext(imgURL)
 	} else {
 		b, err = f.slurpRemoteBytes(u.String(), 5)
-		if string(b[6:10]) == "JFIF" {
-			ext = ".jpeg"
-		} else if string(b[0:3]) == "GIF" {
-			ext = ".gif"
-		} else {
-			ext = ".png"
-		}
+		ext = remoteImageExt(b)
 	}
 	if err != nil {
 		return "", err
 	}
+	return f.writeBytes(dir, b, ext)
+}
+
+// remoteImageExt returns the file extension of downloaded image b,
+// sniffed from its content.
+func remoteImageExt(b []byte) string {
+	switch {
+	case len(b) >= 10 && string(b[6:10]) == "JFIF":
+		return ".jpeg"
+	case bytes.HasPrefix(b, []byte("GIF")):
+		return ".gif"
+	case bytes.HasPrefix(b, []byte("<svg")) || bytes.HasPrefix(b, []byte("<?xml")):
+		return ".svg"
+	}
+	return ".png"
+}
 
+// writeBytes writes image b to dir, named after its checksum and extension ext.
+// It returns the written file name.
+func (f *Fetcher) writeBytes(dir string, b []byte, ext string) (string, error) {
 	crc := crc64.Checksum(b, f.crcTable)
 	file := fmt.Sprintf("%x%s", crc, ext)
 	dst := filepath.Join(dir, file)
 	return file, ioutil.WriteFile(dst, b, 0644)
 }
 
-func (f *Fetcher) slurpFragment(url string) ([]types.Node, error) {
-	res, err := f.fetch(url)
+// decodeDataURI returns contents and file extension of a base64-encoded
+// data URI, e.g. "data:image/png;base64,...".
+func decodeDataURI(uri string) ([]byte, string, error) {
+	i := strings.Index(uri, ",")
+	if i < 0 || !strings.HasSuffix(uri[:i], ";base64") {
+		return nil, "", fmt.Errorf("unsupported data URI %.32q", uri)
+	}
+	typ := strings.TrimSuffix(strings.TrimPrefix(uri[:i], "data:"), ";base64")
+	b, err := base64.StdEncoding.DecodeString(uri[i+1:])
+	if err != nil {
+		return nil, "", err
+	}
+	ext, ok := dataURIExts[strings.ToLower(typ)]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported data URI type %q", typ)
+	}
+	return b, ext, nil
+}
+
+// dataURIExts are file extensions of image types of data URIs.
+// Data URIs of other types are rejected.
+var dataURIExts = map[string]string{
+	"image/png":     ".png",
+	"image/jpeg":    ".jpeg",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/svg+xml": ".svg",
+}
+
+// slurpFragment retrieves and parses a fragment located at url,
+// imported by codelab src. Local files are resolved with importPath.
+// If url has a "#section" suffix, only the content under the heading
+// with that anchor is parsed.
+func (f *Fetcher) slurpFragment(src, url string) ([]types.Node, error) {
+	name, section := url, ""
+	if i := strings.Index(name, "#"); i >= 0 {
+		name, section = name[:i], name[i+1:]
+	}
+	if isLocalImport(name) {
+		var err error
+		if name, err = f.importPath(src, name); err != nil {
+			return nil, err
+		}
+	}
+	res, err := f.fetchImport(name)
 	if err != nil {
 		return nil, err
 	}
 	defer res.body.Close()
 
-	opts := *parser.NewOptions(f.mdParser)
-	opts.PassMetadata = f.passMetadata
-
-	return parser.ParseFragment(string(res.typ), res.body, opts)
+	if section == "" {
+		return parser.ParseFragment(string(res.typ), res.body, f.parserOpts)
+	}
+	b, err := ioutil.ReadAll(res.body)
+	if err != nil {
+		return nil, err
+	}
+	if b, err = extractSection(b, section); err != nil {
+		return nil, err
+	}
+	return parser.ParseFragment(string(res.typ), bytes.NewReader(b), f.parserOpts)
 }
 
 // fetch retrieves codelab doc either from local disk
 // or a remote location.
 // The caller is responsible for closing returned stream.
 func (f *Fetcher) fetch(name string) (*resource, error) {
+	if name == Stdin {
+		return &resource{
+			body: ioutil.NopCloser(os.Stdin),
+			typ:  SrcMarkdown,
+			mod:  time.Now(),
+		}, nil
+	}
 	fi, err := os.Stat(name)
 	if os.IsNotExist(err) {
 		return f.fetchRemote(name, false)
@@ -301,11 +469,37 @@ func (f *Fetcher) fetch(name string) (*resource, error) {
 	}
 	return &resource{
 		body: r,
-		typ:  SrcMarkdown,
+		typ:  srcTypeOf(name),
 		mod:  fi.ModTime(),
 	}, nil
 }
 
+// fetchImport is like fetch, but remote imports go through
+// the import cache of f, if any.
+func (f *Fetcher) fetchImport(name string) (*resource, error) {
+	if _, err := os.Stat(name); f.imports == nil || !os.IsNotExist(err) {
+		return f.fetch(name)
+	}
+	return f.imports.get(name, f.http.logger(), func() (*resource, error) {
+		return f.fetchRemote(name, false)
+	})
+}
+
+// srcTypeOf returns source type of a file or URL name, based on its extension.
+// Files of unknown types are considered to be Markdown.
+func srcTypeOf(name string) srcType {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ipynb":
+		return SrcNotebook
+	case ".rst":
+		return SrcRST
+	}
+	return SrcMarkdown
+}
+
 // fetchRemote retrieves resource r from the network.
 //
 // If urlStr is not a URL, i.e. does not have the host part, it is considered to be
@@ -327,8 +521,33 @@ func (f *Fetcher) fetchRemote(urlStr string, nometa bool) (*resource, error) {
 
 // fetchRemoteFile retrieves codelab resource from url.
 // It is a special case of fetchRemote function.
+//
+// Google Cloud Storage gs:// URLs are fetched over HTTPS. Requests are
+// authenticated with the credential of url, if any, see credential.
 func (f *Fetcher) fetchRemoteFile(url string) (*resource, error) {
-	res, err := retryGet(f.authHelper.DriveClient(), url, 3)
+	typ := srcTypeOf(url)
+	url = gcsURL(url)
+	client, h := &http.Client{Transport: f.roundTripper}, http.Header{}
+	if c := f.credential(url); c != nil {
+		k, v, err := c.header()
+		if err != nil {
+			return nil, err
+		}
+		h.Set(k, v)
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			// credentials are not sent to other hosts
+			if !sameOrigin(req.URL, via[0].URL) {
+				req.Header.Del(k)
+			}
+			return nil
+		}
+	} else if f.authHelper != nil {
+		client = f.authHelper.DriveClient()
+	}
+	res, err := f.http.get(client, url, h, 3)
 	if err != nil {
 		return nil, err
 	}
@@ -339,7 +558,7 @@ func (f *Fetcher) fetchRemoteFile(url string) (*resource, error) {
 	return &resource{
 		body: res.Body,
 		mod:  t,
-		typ:  SrcMarkdown,
+		typ:  typ,
 	}, nil
 }
 
@@ -353,7 +572,7 @@ func (f *Fetcher) fetchDriveFile(id string, nometa bool) (*resource, error) {
 	exportURL := gdocExportURL(id)
 
 	if nometa {
-		res, err := retryGet(f.authHelper.DriveClient(), exportURL, 7)
+		res, err := f.http.get(f.authHelper.DriveClient(), exportURL, nil, 7)
 		if err != nil {
 			return nil, err
 		}
@@ -361,11 +580,11 @@ func (f *Fetcher) fetchDriveFile(id string, nometa bool) (*resource, error) {
 	}
 
 	q := url.Values{
-		"fields":             {"id,mimeType,modifiedTime"},
+		"fields":             {"id,mimeType,modifiedTime,version"},
 		"supportsTeamDrives": {"true"},
 	}
 	u := fmt.Sprintf("%s/files/%s?%s", driveAPI, id, q.Encode())
-	res, err := retryGet(f.authHelper.DriveClient(), u, 7)
+	res, err := f.http.get(f.authHelper.DriveClient(), u, nil, 7)
 	if err != nil {
 		return nil, err
 	}
@@ -374,26 +593,32 @@ func (f *Fetcher) fetchDriveFile(id string, nometa bool) (*resource, error) {
 		ID       string    `json:"id"`
 		MimeType string    `json:"mimeType"`
 		Modified time.Time `json:"modifiedTime"`
+		Version  string    `json:"version"`
 	}{}
 	if err := json.NewDecoder(res.Body).Decode(meta); err != nil {
 		return nil, err
 	}
-	if meta.MimeType != "application/vnd.google-apps.document" {
+	if meta.MimeType != gdocMimeType {
 		return nil, fmt.Errorf("%s: invalid mime type: %s", id, meta.MimeType)
 	}
 
-	if res, err = retryGet(f.authHelper.DriveClient(), exportURL, 7); err != nil {
+	if res, err = f.http.get(f.authHelper.DriveClient(), exportURL, nil, 7); err != nil {
 		return nil, err
 	}
 	return &resource{
 		body: res.Body,
 		mod:  meta.Modified,
+		rev:  meta.Version,
 		typ:  SrcGoogleDoc,
 	}, nil
 }
 
 func (f *Fetcher) slurpRemoteBytes(url string, n int) ([]byte, error) {
-	res, err := retryGet(f.authHelper.DriveClient(), url, n)
+	client := &http.Client{Transport: f.roundTripper}
+	if f.authHelper != nil {
+		client = f.authHelper.DriveClient()
+	}
+	res, err := f.http.get(client, url, nil, n)
 	if err != nil {
 		return nil, err
 	}
@@ -401,19 +626,45 @@ func (f *Fetcher) slurpRemoteBytes(url string, n int) ([]byte, error) {
 	return ioutil.ReadAll(res.Body)
 }
 
-// retryGet tries to GET specified url up to n times.
-// Attempts are spaced out with exponential backoff.
-// Default client will be used if not provided.
-func retryGet(client *http.Client, url string, n int) (*http.Response, error) {
+// get tries to GET specified url with request headers h up to n times,
+// or o.Retries times if set. Attempts are spaced out with exponential
+// backoff, starting at o.Backoff, until o.Context is done.
+// A client with the transport of o is used if client is nil.
+func (o HTTPOptions) get(client *http.Client, url string, h http.Header, n int) (*http.Response, error) {
 	if client == nil {
-		client = http.DefaultClient
+		rt, err := o.Transport(nil)
+		if err != nil {
+			return nil, err
+		}
+		client = &http.Client{Transport: rt}
+	}
+	if o.Retries > 0 {
+		n = o.Retries
 	}
+	backoff := o.Backoff
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+	log := o.logger()
+	var lastErr error
 	for i := 0; i <= n; i++ {
 		if i > 0 {
-			t := time.Duration((math.Pow(2, float64(i)) + rand.Float64()) * float64(time.Second))
-			time.Sleep(t)
+			d := backoff<<uint(i-1) + time.Duration(rand.Int63n(int64(backoff/2)+1))
+			log.Warnf("retrying %s in %v: %v", logURL(url), d.Round(time.Millisecond), logErr(lastErr))
+			if err := o.sleep(d); err != nil {
+				return nil, err
+			}
 		}
-		res, err := client.Get(url)
+		req, err := http.NewRequestWithContext(o.context(), "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range h {
+			req.Header[k] = v
+		}
+		start := time.Now()
+		res, err := client.Do(req)
+		o.logRequest(url, res, err, time.Since(start))
 		// return early with a good response
 		// the rest is error handling
 		if err == nil && res.StatusCode == http.StatusOK {
@@ -424,6 +675,11 @@ func retryGet(client *http.Client, url string, n int) (*http.Response, error) {
 		// we get net/http: TLS handshake timeout instead:
 		// consider this a temporary failure and retry again
 		if err != nil {
+			if o.context().Err() != nil {
+				// canceled requests are not retried
+				return nil, err
+			}
+			lastErr = err
 			continue
 		}
 		// otherwise, decode error response and check for "rate limit"
@@ -444,11 +700,12 @@ func retryGet(client *http.Client, url string, n int) (*http.Response, error) {
 		}
 		// this is neither a rate limit error, nor a server error:
 		// retrying is useless
-		if !rateLimit && res.StatusCode < http.StatusInternalServerError {
+		if !rateLimit && res.StatusCode != http.StatusTooManyRequests && res.StatusCode < http.StatusInternalServerError {
 			return nil, fmt.Errorf("fetch %s: %s; %s", url, res.Status, b)
 		}
+		lastErr = fmt.Errorf("%s", res.Status)
 	}
-	return nil, fmt.Errorf("%s: failed after %d retries", url, n)
+	return nil, fmt.Errorf("%s: failed after %d retries: %v", url, n, lastErr)
 }
 
 func gdocID(url string) string {
@@ -116,3 +116,27 @@ func safeAbs(t *testing.T, p string) string {
 	}
 	return p
 }
+
+func TestDecodeDataURI(t *testing.T) {
+	tests := []struct {
+		uri, ext string
+		ok       bool
+	}{
+		{"data:image/png;base64,aGk=", ".png", true},
+		{"data:image/svg+xml;base64,aGk=", ".svg", true},
+		{"data:image/JPEG;base64,aGk=", ".jpeg", true},
+		{"data:image/../../../../pwned;base64,aGk=", "", false},
+		{"data:text/html;base64,aGk=", "", false},
+		{"data:image/png,hi", "", false},
+	}
+	for _, tc := range tests {
+		b, ext, err := decodeDataURI(tc.uri)
+		if (err == nil) != tc.ok {
+			t.Errorf("decodeDataURI(%q) err = %v; want ok %v", tc.uri, err, tc.ok)
+			continue
+		}
+		if tc.ok && (ext != tc.ext || string(b) != "hi") {
+			t.Errorf("decodeDataURI(%q) = %q, %q; want hi, %q", tc.uri, b, ext, tc.ext)
+		}
+	}
+}
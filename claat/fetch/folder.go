@@ -0,0 +1,90 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// gdocMimeType is the Drive API mime type of Google Docs.
+const gdocMimeType = "application/vnd.google-apps.document"
+
+// folderIDRegexp matches valid Drive folder IDs, which are safe
+// to quote in Drive API search queries.
+var folderIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// FolderDocs returns IDs of all Google Docs in Drive folder id,
+// which can also be a folder of a Shared Drive.
+// The id may be given as a folder URL.
+// Subfolders are not searched and trashed docs are skipped.
+func (f *Fetcher) FolderDocs(id string) ([]string, error) {
+	folder := folderID(id)
+	if !folderIDRegexp.MatchString(folder) {
+		return nil, fmt.Errorf("invalid Drive folder ID %q", folder)
+	}
+	if err := f.initAuth(); err != nil {
+		return nil, err
+	}
+	q := url.Values{
+		"q":                         {fmt.Sprintf("'%s' in parents and mimeType = '%s' and trashed = false", folder, gdocMimeType)},
+		"fields":                    {"nextPageToken,files(id)"},
+		"orderBy":                   {"name"},
+		"pageSize":                  {"1000"},
+		"supportsAllDrives":         {"true"},
+		"includeItemsFromAllDrives": {"true"},
+	}
+	var ids []string
+	for {
+		res, err := f.http.get(f.authHelper.DriveClient(), fmt.Sprintf("%s/files?%s", driveAPI, q.Encode()), nil, 7)
+		if err != nil {
+			return nil, err
+		}
+		list := &struct {
+			NextPageToken string `json:"nextPageToken"`
+			Files         []struct {
+				ID string `json:"id"`
+			} `json:"files"`
+		}{}
+		err = json.NewDecoder(res.Body).Decode(list)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range list.Files {
+			ids = append(ids, file.ID)
+		}
+		if list.NextPageToken == "" {
+			return ids, nil
+		}
+		q.Set("pageToken", list.NextPageToken)
+	}
+}
+
+// folderID extracts Drive folder ID from a folder URL,
+// such as https://drive.google.com/drive/folders/id?usp=sharing.
+func folderID(url string) string {
+	const s = "/folders/"
+	if i := strings.Index(url, s); i >= 0 {
+		url = url[i+len(s):]
+	}
+	if i := strings.IndexAny(url, "/?#"); i > 0 {
+		url = url[:i]
+	}
+	return url
+}
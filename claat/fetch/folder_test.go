@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+func TestFolderDocs(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"nextPageToken": "p2", "files": [{"id": "doc1"}, {"id": "doc2"}]}`,
+		"p2": `{"files": [{"id": "doc3"}]}`,
+	}
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		if v := q.Get("q"); !strings.HasPrefix(v, "'folder' in parents") {
+			t.Errorf("q = %q; want folder parent", v)
+		}
+		if q.Get("supportsAllDrives") != "true" || q.Get("includeItemsFromAllDrives") != "true" {
+			t.Errorf("query %v does not include Shared Drives", q)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(pages[q.Get("pageToken")])),
+		}, nil
+	}}
+	f, err := NewFetcher("token", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := f.FolderDocs("https://drive.google.com/drive/folders/folder?usp=sharing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"doc1", "doc2", "doc3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("FolderDocs = %v; want %v", ids, want)
+	}
+}
+
+func TestFolderDocsInvalidID(t *testing.T) {
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request %s", r.URL)
+		return nil, http.ErrNotSupported
+	}}
+	f, err := NewFetcher("token", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"", "a' or 'b", `a\b`, "https://drive.google.com/drive/folders/a%27b"} {
+		if _, err := f.FolderDocs(id); err == nil {
+			t.Errorf("FolderDocs(%q): no error", id)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+)
+
+// DefaultBackoff is the delay before the first retry of a request,
+// doubled for each subsequent one, if HTTPOptions.Backoff is zero.
+const DefaultBackoff = 2 * time.Second
+
+// DefaultTimeout is the time limit of each request attempt of the claat
+// command, unless set with -request-timeout.
+const DefaultTimeout = time.Minute
+
+// SlowRequest is the duration of a request above which
+// it is logged as a warning.
+const SlowRequest = 10 * time.Second
+
+// HTTPOptions configure requests of network operations, such as
+// exporting Google Docs, fetching imports and images, and checking links,
+// so that flaky networks don't abort long batch exports.
+// The zero value uses defaults of each operation.
+type HTTPOptions struct {
+	// Retries is the number of times a request is retried on network
+	// errors, rate limiting and server errors. If zero, each operation
+	// retries a default number of times.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for each
+	// subsequent one. If zero, DefaultBackoff is used.
+	Backoff time.Duration
+	// Timeout limits each attempt of a request, including reading
+	// the response body. There is no limit if zero.
+	Timeout time.Duration
+	// Proxy is the URL of a proxy server all requests go through.
+	// If empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables select the proxy, if any.
+	Proxy string
+	// Context cancels all requests, and stops retrying them, once done.
+	// Requests are never canceled if nil.
+	Context context.Context
+	// Logger logs retried and slow requests, and all requests
+	// at level Debug. The default logger is used if nil.
+	Logger *logging.Logger
+}
+
+// logger returns o.Logger, or the default logger if nil.
+func (o HTTPOptions) logger() *logging.Logger {
+	if o.Logger == nil {
+		return logging.Default()
+	}
+	return o.Logger
+}
+
+// logRequest logs the response res, or error err, of a GET request of u
+// which took d.
+func (o HTTPOptions) logRequest(u string, res *http.Response, err error, d time.Duration) {
+	log := o.logger()
+	d = d.Round(time.Millisecond)
+	if d > SlowRequest {
+		log.Warnf("slow request %s took %v", logURL(u), d)
+	}
+	if !log.Enabled(logging.Debug) {
+		return
+	}
+	if err != nil {
+		log.Debugf("GET %s: %v in %v", logURL(u), logErr(err), d)
+		return
+	}
+	log.Debugf("GET %s: %s in %v", logURL(u), res.Status, d)
+}
+
+// logErr returns err without the URL of a *url.Error,
+// whose query may hold API keys.
+func logErr(err error) error {
+	if ue, ok := err.(*url.Error); ok {
+		return ue.Err
+	}
+	return err
+}
+
+// logURL returns u with no query, which may hold API keys.
+func logURL(u string) string {
+	if i := strings.IndexByte(u, '?'); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+// context returns o.Context, or the background context if nil.
+func (o HTTPOptions) context() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+	return o.Context
+}
+
+// sleep waits for d, or until o.Context is done and returns its error.
+func (o HTTPOptions) sleep(d time.Duration) error {
+	ctx := o.context()
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Transport returns a transport sending requests with rt, or the default
+// transport if nil, through o.Proxy, limited by o.Timeout and canceled
+// with o.Context.
+// A proxy cannot be set on rt unless it is an *http.Transport.
+func (o HTTPOptions) Transport(rt http.RoundTripper) (http.RoundTripper, error) {
+	if o.Proxy != "" {
+		u, err := url.Parse(o.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("proxy %q is not an absolute URL", o.Proxy)
+		}
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("cannot set a proxy on transport %T", rt)
+		}
+		t = t.Clone()
+		t.Proxy = http.ProxyURL(u)
+		rt = t
+	}
+	if o.Timeout > 0 {
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		rt = &timeoutTransport{rt: rt, timeout: o.Timeout}
+	}
+	if o.Context != nil {
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		rt = &contextTransport{rt: rt, ctx: o.Context}
+	}
+	return rt, nil
+}
+
+// contextTransport cancels requests once ctx is done,
+// including reading their response body.
+type contextTransport struct {
+	rt  http.RoundTripper
+	ctx context.Context
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *contextTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := t.ctx.Err(); err != nil {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	res, err := t.rt.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// timeoutTransport cancels requests which take longer than timeout,
+// including reading their response body.
+type timeoutTransport struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), t.timeout)
+	res, err := t.rt.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelBody releases the context of a request once its response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
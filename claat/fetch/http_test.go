@@ -0,0 +1,152 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPOptionsGet(t *testing.T) {
+	var n int
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		n++
+		if n <= 2 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("ok"))}, nil
+	}}
+	client := &http.Client{Transport: rt}
+
+	o := HTTPOptions{Retries: 2, Backoff: time.Millisecond}
+	res, err := o.get(client, "https://example.com/", nil, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if n != 3 {
+		t.Errorf("%d attempts; want 3", n)
+	}
+
+	n = 0
+	o.Retries = 1
+	if _, err := o.get(client, "https://example.com/", nil, 7); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("get err = %v; want 503 after 1 retry", err)
+	}
+	if n != 2 {
+		t.Errorf("%d attempts; want 2", n)
+	}
+}
+
+func TestHTTPOptionsTransportTimeout(t *testing.T) {
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}}
+	tr, err := HTTPOptions{Timeout: 10 * time.Millisecond}.Transport(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := (&http.Client{Transport: tr}).Get("https://example.com/")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Get = nil error; want a timeout")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request did not time out")
+	}
+}
+
+func TestHTTPOptionsTransportProxy(t *testing.T) {
+	tr, err := HTTPOptions{Proxy: "http://proxy.example.com:3128"}.Transport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ht, ok := tr.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T; want *http.Transport", tr)
+	}
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	u, err := ht.Proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.example.com:3128" {
+		t.Errorf("Proxy(%s) = %v, %v; want proxy.example.com:3128", req.URL, u, err)
+	}
+	if ht == http.DefaultTransport {
+		t.Error("Transport modified http.DefaultTransport")
+	}
+
+	for _, p := range []string{"proxy.example.com", "://"} {
+		if _, err := (HTTPOptions{Proxy: p}).Transport(nil); err == nil {
+			t.Errorf("Transport with proxy %q = nil error; want an error", p)
+		}
+	}
+	if _, err := (HTTPOptions{Proxy: "http://proxy.example.com"}).Transport(&testTransport{}); err == nil {
+		t.Error("Transport with proxy of a custom transport = nil error; want an error")
+	}
+}
+
+func TestHTTPOptionsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var n int
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		n++
+		cancel()
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}}
+	o := HTTPOptions{Retries: 3, Backoff: time.Hour, Context: ctx}
+	if _, err := o.get(&http.Client{Transport: rt}, "https://example.com/", nil, 7); err == nil {
+		t.Error("get = nil error; want a canceled request")
+	}
+	if n != 1 {
+		t.Errorf("%d attempts; want canceled requests not retried", n)
+	}
+
+	// the transport cancels requests of other clients too
+	ctx, cancel = context.WithCancel(context.Background())
+	tr, err := HTTPOptions{Context: ctx}.Transport(&testTransport{func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := (&http.Client{Transport: tr}).Get("https://example.com/")
+		done <- err
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Get = nil error; want a canceled request")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not canceled")
+	}
+	if _, err := (&http.Client{Transport: tr}).Get("https://example.com/"); err == nil {
+		t.Error("Get after cancel = nil error; want a canceled request")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// isLocalImport reports whether import name is a local file path,
+// rather than a URL or a Google Doc ID, which have no file extension.
+func isLocalImport(name string) bool {
+	if u, err := url.Parse(name); err == nil && u.IsAbs() {
+		return false
+	}
+	return filepath.IsAbs(name) || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") || path.Ext(name) != ""
+}
+
+// importPath returns the local file of import name of codelab src.
+// Relative names are resolved against the directory of src, if a local
+// file, or the import root otherwise. The file must be in the import
+// root, after following symbolic links, see SetImportRoot.
+func (f *Fetcher) importPath(src, name string) (string, error) {
+	root, err := filepath.Abs(f.importRoot)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.FromSlash(name)
+	if !filepath.IsAbs(p) {
+		base := root
+		if fi, err := os.Stat(src); err == nil && !fi.IsDir() {
+			base = filepath.Dir(src)
+		}
+		p = filepath.Join(base, p)
+	}
+	if p, err = filepath.Abs(p); err != nil {
+		return "", err
+	}
+	if p, err = filepath.EvalSymlinks(p); err != nil {
+		return "", err
+	}
+	if r, err := filepath.EvalSymlinks(root); err == nil {
+		root = r
+	}
+	if rel, err := filepath.Rel(root, p); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the import root %s", p, root)
+	}
+	return p, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+func TestImportPath(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "claat-importpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	if tmp, err = filepath.EvalSymlinks(tmp); err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(tmp, "repo")
+	for _, name := range []string{"repo/labs/lab/lab.md", "repo/shared/setup.md", "secret.md"} {
+		p := filepath.Join(tmp, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte("Text.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(tmp, "secret.md"), filepath.Join(root, "shared", "link.md")); err != nil {
+		t.Skip(err)
+	}
+	src := filepath.Join(root, "labs", "lab", "lab.md")
+
+	f, err := NewFetcher("", parser.Options{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetImportRoot(root)
+	tests := []struct {
+		src, name string
+		want      string // empty if an error is expected
+	}{
+		{src, "../../shared/setup.md", filepath.Join(root, "shared", "setup.md")},
+		{src, "./lab.md", src},
+		{"1aBcDocID", "./shared/setup.md", filepath.Join(root, "shared", "setup.md")},
+		{src, filepath.Join(root, "shared", "setup.md"), filepath.Join(root, "shared", "setup.md")},
+		{src, "../../../secret.md", ""},
+		{src, filepath.Join(tmp, "secret.md"), ""},
+		{src, "../../shared/link.md", ""},
+		{src, "../../shared/missing.md", ""},
+	}
+	for _, tc := range tests {
+		got, err := f.importPath(tc.src, tc.name)
+		if tc.want == "" {
+			if err == nil {
+				t.Errorf("importPath(%q, %q) = %q; want an error", tc.src, tc.name, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("importPath(%q, %q) = %q, %v; want %q", tc.src, tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestIsLocalImport(t *testing.T) {
+	tests := map[string]bool{
+		"../shared/setup.md":                   true,
+		"./fragments/foo.md":                   true,
+		"fragments/foo.md":                     true,
+		"/abs/foo.md":                          true,
+		"https://example.com/foo.md":           false,
+		"gs://bucket/foo.md":                   false,
+		"1aBcDocID":                            false,
+		"https://docs.google.com/document/d/x": false,
+	}
+	for name, want := range tests {
+		if got := isLocalImport(name); got != want {
+			t.Errorf("isLocalImport(%q) = %v; want %v", name, got, want)
+		}
+	}
+}
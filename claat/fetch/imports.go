@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+)
+
+// ImportCache stores remote imports of codelabs on disk, such as
+// "import:" step instructions and <<url>> imports, so that they are not
+// fetched on every export, and can be read when offline.
+//
+// Fetched imports are always stored, whatever the TTL.
+type ImportCache struct {
+	// Dir is the cache directory. It is created as needed.
+	Dir string
+	// TTL is how long a cached import is used without being fetched again.
+	// Imports are fetched on every export if zero.
+	TTL time.Duration
+	// Offline never fetches imports: cached copies are used, whatever
+	// their age, and imports with none fail.
+	Offline bool
+	// Refresh fetches all imports again, ignoring the TTL.
+	Refresh bool
+}
+
+// DefaultImportCacheDir returns the import cache directory
+// in the user cache directory.
+func DefaultImportCacheDir() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "claat", "imports"), nil
+}
+
+// cachedImport is a cache entry of an import, stored as JSON.
+type cachedImport struct {
+	Name    string    `json:"name"`
+	Fetched time.Time `json:"fetched"`
+	Type    srcType   `json:"type"`
+	Mod     time.Time `json:"modified"`
+	Rev     string    `json:"revision,omitempty"`
+	Body    []byte    `json:"body"`
+}
+
+func (ci *cachedImport) resource() *resource {
+	return &resource{
+		body: ioutil.NopCloser(bytes.NewReader(ci.Body)),
+		typ:  ci.Type,
+		mod:  ci.Mod,
+		rev:  ci.Rev,
+	}
+}
+
+// path returns the cache file of import name.
+func (c *ImportCache) path(name string) string {
+	h := sha256.Sum256([]byte(name))
+	return filepath.Join(c.Dir, hex.EncodeToString(h[:])+".json")
+}
+
+// lookup returns the cached copy of import name, or nil if there is none.
+// Unreadable entries are treated as missing.
+func (c *ImportCache) lookup(name string) *cachedImport {
+	b, err := ioutil.ReadFile(c.path(name))
+	if err != nil {
+		return nil
+	}
+	ci := &cachedImport{}
+	if err := json.Unmarshal(b, ci); err != nil || ci.Name != name {
+		return nil
+	}
+	return ci
+}
+
+// store writes ci to the cache, replacing any previous copy.
+func (c *ImportCache) store(ci *cachedImport) error {
+	b, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	// Concurrent exports may store the same import:
+	// write to a temporary file and rename it into place.
+	tmp, err := ioutil.TempFile(c.Dir, "import-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(ci.Name))
+}
+
+// get returns remote import name from the cache, if fresh enough,
+// or retrieves it with fetch and stores it. A cached copy is returned,
+// whatever its age, if fetch fails. Failures to store an import are
+// logged to log, and do not fail the import.
+func (c *ImportCache) get(name string, log *logging.Logger, fetch func() (*resource, error)) (*resource, error) {
+	ci := c.lookup(name)
+	switch {
+	case ci != nil && (c.Offline || !c.Refresh && time.Since(ci.Fetched) < c.TTL):
+		return ci.resource(), nil
+	case c.Offline:
+		return nil, fmt.Errorf("%s is not in the import cache %s, and imports are not fetched offline", name, c.Dir)
+	}
+	b, res, err := readImport(fetch)
+	if err != nil {
+		if ci == nil {
+			return nil, err
+		}
+		log.Warnf("%s: %v; using the copy cached on %s", name, err, ci.Fetched.Format(time.RFC3339))
+		return ci.resource(), nil
+	}
+	ci = &cachedImport{Name: name, Fetched: time.Now(), Type: res.typ, Mod: res.mod, Rev: res.rev, Body: b}
+	if err := c.store(ci); err != nil {
+		log.Warnf("import cache: %v", err)
+	}
+	return ci.resource(), nil
+}
+
+// readImport retrieves an import with fetch and reads its body.
+func readImport(fetch func() (*resource, error)) ([]byte, *resource, error) {
+	res, err := fetch()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.body.Close()
+	b, err := ioutil.ReadAll(res.body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, res, nil
+}
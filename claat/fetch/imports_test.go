@@ -0,0 +1,133 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+func TestImportCache(t *testing.T) {
+	const frag = "https://example.com/frag.md"
+	var hits int
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		hits++
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("Fragment text."))}, nil
+	}}
+	f, err := NewFetcher("token", parser.Options{}, rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.initAuth(); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "claat-imports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		cache *ImportCache
+		hits  int // network requests made
+		err   bool
+	}{
+		{"offline empty", &ImportCache{Dir: dir, Offline: true}, 0, true},
+		{"no ttl", &ImportCache{Dir: dir}, 1, false},
+		{"fresh", &ImportCache{Dir: dir, TTL: time.Hour}, 0, false},
+		{"refresh", &ImportCache{Dir: dir, TTL: time.Hour, Refresh: true}, 1, false},
+		{"offline", &ImportCache{Dir: dir, Offline: true}, 0, false},
+	}
+	for _, tc := range tests {
+		hits = 0
+		f.SetImportCache(tc.cache)
+		nodes, err := f.slurpFragment("", frag)
+		if (err != nil) != tc.err {
+			t.Errorf("%s: slurpFragment err = %v; want error: %v", tc.name, err, tc.err)
+			continue
+		}
+		if hits != tc.hits {
+			t.Errorf("%s: %d requests; want %d", tc.name, hits, tc.hits)
+		}
+		if !tc.err && len(nodes) == 0 {
+			t.Errorf("%s: no fragment nodes", tc.name)
+		}
+	}
+}
+
+func TestImportCacheFallback(t *testing.T) {
+	const name = "https://example.com/frag.md"
+	var buf bytes.Buffer
+	log, err := logging.New(&buf, logging.Warn, logging.Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "claat-imports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ok := func(body string) func() (*resource, error) {
+		return func() (*resource, error) {
+			return &resource{body: ioutil.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+	fail := func() (*resource, error) { return nil, errors.New("unreachable") }
+	read := func(c *ImportCache, fetch func() (*resource, error)) string {
+		res, err := c.get(name, log, fetch)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		b, _ := ioutil.ReadAll(res.body)
+		return string(b)
+	}
+
+	// a cache directory that cannot be created does not fail the import
+	file := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if b := read(&ImportCache{Dir: filepath.Join(file, "imports")}, ok("fetched")); b != "fetched" {
+		t.Errorf("unwritable cache: body = %q; want %q", b, "fetched")
+	}
+	if !strings.Contains(buf.String(), "import cache") {
+		t.Errorf("unwritable cache: no warning logged: %q", buf.String())
+	}
+
+	// a stale copy is used when fetching fails
+	c := &ImportCache{Dir: dir}
+	read(c, ok("cached"))
+	buf.Reset()
+	if b := read(c, fail); b != "cached" {
+		t.Errorf("failed fetch: body = %q; want %q", b, "cached")
+	}
+	if !strings.Contains(buf.String(), "unreachable") {
+		t.Errorf("failed fetch: no warning logged: %q", buf.String())
+	}
+	if _, err := (&ImportCache{Dir: dir}).get("https://example.com/other.md", log, fail); err == nil {
+		t.Error("failed fetch of an uncached import: no error")
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const (
+	// DefaultLinkConcurrency is the number of URLs a LinkChecker
+	// requests at the same time, if not specified.
+	DefaultLinkConcurrency = 8
+	// DefaultLinkRetries is the number of times a LinkChecker retries
+	// a URL on network errors and server errors, if not specified.
+	DefaultLinkRetries = 2
+	// DefaultLinkTimeout is the time limit of each request
+	// of a LinkChecker, if not specified.
+	DefaultLinkTimeout = 30 * time.Second
+)
+
+// BrokenLink is an external link or image of a codelab
+// which could not be fetched.
+type BrokenLink struct {
+	Step   string // title of the step the URL is in
+	URL    string
+	Status int   // HTTP status code of the last response, or 0
+	Err    error // network error, if no response was received
+}
+
+func (b *BrokenLink) String() string {
+	if b.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", b.Step, b.URL, b.Err)
+	}
+	return fmt.Sprintf("%s: %s: %d %s", b.Step, b.URL, b.Status, http.StatusText(b.Status))
+}
+
+// LinkChecker requests external URLs of codelab links and images,
+// reporting those which respond with a 4xx or 5xx status code, or not at all.
+//
+// Results are cached by URL, so a LinkChecker shared by concurrent
+// exports of several codelabs requests each URL at most once.
+// The zero value is ready to use.
+type LinkChecker struct {
+	Client *http.Client // a client of the default transport is used if nil
+	// Allowlist are URLs which are never requested.
+	// An entry with a scheme, e.g. "https://example.com/private/",
+	// is a URL prefix. Any other entry is a host name,
+	// matching the host and all its subdomains.
+	Allowlist []string
+	// Concurrency is the number of URLs requested at the same time.
+	// If zero, DefaultLinkConcurrency is used.
+	Concurrency int
+	// Retries is the number of times a URL is retried on network errors,
+	// 429 and 5xx status codes. If zero, DefaultLinkRetries is used.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for each
+	// subsequent one. If zero, one second is used.
+	Backoff time.Duration
+	// Timeout limits each request, including reading the start of
+	// the response body. If zero, DefaultLinkTimeout is used.
+	Timeout time.Duration
+	// Context cancels requests, and stops retrying them, once done.
+	// Requests are never canceled if nil.
+	Context context.Context
+
+	once  sync.Once
+	sem   chan struct{}
+	mu    sync.Mutex
+	cache map[string]*linkResult
+}
+
+// linkResult is a cached check of a URL.
+// The done channel is closed once status and err are set.
+type linkResult struct {
+	done   chan struct{}
+	status int
+	err    error
+}
+
+// Check requests all external link and image URLs of clab,
+// and returns the broken ones in order of appearance.
+// URLs other than http and https, and allowlisted URLs, are skipped.
+func (lc *LinkChecker) Check(clab *types.Codelab) []*BrokenLink {
+	lc.once.Do(func() {
+		n := lc.Concurrency
+		if n <= 0 {
+			n = DefaultLinkConcurrency
+		}
+		lc.sem = make(chan struct{}, n)
+		lc.cache = make(map[string]*linkResult)
+	})
+
+	type link struct {
+		step string
+		url  string
+		res  *linkResult
+	}
+	var links []*link
+	seen := make(map[string]bool)
+	for _, st := range clab.Steps {
+		var urls []string
+		for _, n := range types.URLNodes(st.Content.Nodes) {
+			urls = append(urls, n.URL)
+		}
+		for _, n := range types.ImageNodes(st.Content.Nodes) {
+			urls = append(urls, n.Src)
+		}
+		for _, u := range urls {
+			if seen[u] || !lc.checked(u) {
+				continue
+			}
+			seen[u] = true
+			links = append(links, &link{step: st.Title, url: u, res: lc.result(u)})
+		}
+	}
+
+	var broken []*BrokenLink
+	for _, l := range links {
+		<-l.res.done
+		if l.res.err != nil || l.res.status >= http.StatusBadRequest {
+			broken = append(broken, &BrokenLink{
+				Step:   l.step,
+				URL:    l.url,
+				Status: l.res.status,
+				Err:    l.res.err,
+			})
+		}
+	}
+	return broken
+}
+
+// checked reports whether u is an external URL which is not allowlisted.
+func (lc *LinkChecker) checked(u string) bool {
+	pu, err := url.Parse(u)
+	if err != nil || pu.Scheme != "http" && pu.Scheme != "https" || pu.Host == "" {
+		return false
+	}
+	host := strings.ToLower(pu.Hostname())
+	for _, a := range lc.Allowlist {
+		if strings.Contains(a, "://") {
+			if strings.HasPrefix(u, a) {
+				return false
+			}
+			continue
+		}
+		a = strings.ToLower(a)
+		if host == a || strings.HasSuffix(host, "."+a) {
+			return false
+		}
+	}
+	return true
+}
+
+// result returns the cached check of u, starting one if u is new.
+func (lc *LinkChecker) result(u string) *linkResult {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if r, ok := lc.cache[u]; ok {
+		return r
+	}
+	r := &linkResult{done: make(chan struct{})}
+	lc.cache[u] = r
+	go func() {
+		lc.sem <- struct{}{}
+		r.status, r.err = lc.fetch(u)
+		<-lc.sem
+		close(r.done)
+	}()
+	return r
+}
+
+// fetch requests u with HEAD, falling back to GET for servers which
+// don't support HEAD requests, and returns the final status code.
+// Temporary failures are retried with exponential backoff.
+func (lc *LinkChecker) fetch(u string) (int, error) {
+	client := lc.Client
+	if client == nil {
+		rt, err := HTTPOptions{Context: lc.Context}.Transport(nil)
+		if err != nil {
+			return 0, err
+		}
+		client = &http.Client{Transport: rt}
+	}
+	retries := lc.Retries
+	if retries <= 0 {
+		retries = DefaultLinkRetries
+	}
+	backoff := lc.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	timeout := lc.Timeout
+	if timeout <= 0 {
+		timeout = DefaultLinkTimeout
+	}
+	o := HTTPOptions{Context: lc.Context}
+	ctx := o.context()
+	var (
+		status int
+		err    error
+	)
+	for i := 0; i <= retries; i++ {
+		if i > 0 {
+			if err := o.sleep(backoff << uint(i-1)); err != nil {
+				return 0, err
+			}
+		}
+		status, err = linkStatus(ctx, client, http.MethodHead, u, timeout)
+		if err == nil && status >= http.StatusBadRequest {
+			status, err = linkStatus(ctx, client, http.MethodGet, u, timeout)
+		}
+		if err == nil && status != http.StatusTooManyRequests && status < http.StatusInternalServerError {
+			break
+		}
+	}
+	return status, err
+}
+
+// linkStatus sends a request of method to u, canceled with ctx
+// or after timeout, and returns the response status code.
+func linkStatus(ctx context.Context, client *http.Client, method, u string, timeout time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	// drain a little of the body so the connection can be reused
+	io.CopyN(ioutil.Discard, res.Body, 4<<10)
+	res.Body.Close()
+	return res.StatusCode, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestLinkChecker(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.String()]++
+		n := requests[r.Method+" "+r.URL.String()]
+		mu.Unlock()
+		code := http.StatusOK
+		switch r.URL.Path {
+		case "/missing":
+			code = http.StatusNotFound
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				code = http.StatusMethodNotAllowed
+			}
+		case "/flaky":
+			if n == 1 {
+				code = http.StatusServiceUnavailable
+			}
+		case "/down":
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{
+			StatusCode: code,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}}
+	lc := &LinkChecker{
+		Client:    &http.Client{Transport: rt},
+		Allowlist: []string{"private.example.com", "https://example.com/skip/"},
+		Backoff:   time.Millisecond,
+	}
+
+	clab := types.NewCodelab()
+	st := clab.NewStep("Links")
+	st.Content.Append(
+		types.NewURLNode("https://example.com/ok"),
+		types.NewURLNode("https://example.com/missing"),
+		types.NewURLNode("https://example.com/nohead"),
+		types.NewURLNode("https://example.com/flaky"),
+		types.NewURLNode("https://example.com/skip/missing"),
+		types.NewURLNode("https://a.private.example.com/missing"),
+		types.NewURLNode("#1"),
+		types.NewURLNode("mailto:a@example.com"),
+	)
+	st = clab.NewStep("Images")
+	st.Content.Append(
+		types.NewImageNode("http://example.com/down"),
+		types.NewImageNode("img/local.png"),
+		types.NewURLNode("https://example.com/missing"),
+	)
+
+	broken := lc.Check(clab)
+	var got []string
+	for _, b := range broken {
+		got = append(got, b.URL)
+	}
+	want := []string{"https://example.com/missing", "http://example.com/down"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("Check: %v; want %v", got, want)
+	}
+	if s := broken[0].String(); s != "Links: https://example.com/missing: 404 Not Found" {
+		t.Errorf("broken[0] = %q", s)
+	}
+	if broken[1].Err == nil || broken[1].Step != "Images" {
+		t.Errorf("broken[1] = %+v; want Images step with error", broken[1])
+	}
+	if n := requests["HEAD https://example.com/flaky"]; n != 2 {
+		t.Errorf("flaky HEAD requests: %d; want 2", n)
+	}
+	if n := requests["HEAD http://example.com/down"]; n != DefaultLinkRetries+1 {
+		t.Errorf("down HEAD requests: %d; want %d", n, DefaultLinkRetries+1)
+	}
+
+	// results are cached across codelabs
+	lc.Check(clab)
+	if n := requests["HEAD https://example.com/ok"]; n != 1 {
+		t.Errorf("ok HEAD requests: %d; want 1", n)
+	}
+	for k := range requests {
+		if strings.Contains(k, "skip") || strings.Contains(k, "private") {
+			t.Errorf("allowlisted URL requested: %s", k)
+		}
+	}
+}
+
+func TestLinkCheckerTimeout(t *testing.T) {
+	rt := &testTransport{func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}}
+	lc := &LinkChecker{
+		Client:  &http.Client{Transport: rt},
+		Backoff: time.Millisecond,
+		Timeout: 10 * time.Millisecond,
+	}
+	clab := types.NewCodelab()
+	clab.NewStep("Links").Content.Append(types.NewURLNode("https://example.com/stalled"))
+	broken := lc.Check(clab)
+	if len(broken) != 1 || broken[0].Err == nil {
+		t.Fatalf("Check = %+v; want the stalled link broken", broken)
+	}
+}
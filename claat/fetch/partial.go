@@ -0,0 +1,84 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// headingRegexp matches Markdown ATX headings, capturing level and text.
+var headingRegexp = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// extractSection returns Markdown lines of b following the heading
+// whose anchor is section, up to the next heading of the same or higher level.
+// The heading itself is omitted. If section is empty, all of b is returned.
+func extractSection(b []byte, section string) ([]byte, error) {
+	if section == "" {
+		return b, nil
+	}
+	var (
+		lines []string
+		level int // level of the section heading, 0 if not found yet
+		fence string
+	)
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		l := s.Text()
+		if t := strings.TrimSpace(l); fence != "" {
+			if strings.HasPrefix(t, fence) {
+				fence = ""
+			}
+		} else if strings.HasPrefix(t, "```") || strings.HasPrefix(t, "~~~") {
+			fence = t[:3]
+		} else if m := headingRegexp.FindStringSubmatch(l); m != nil {
+			switch {
+			case level > 0 && len(m[1]) <= level:
+				return []byte(strings.Join(lines, "\n") + "\n"), nil
+			case level == 0 && headingAnchor(m[2]) == section:
+				level = len(m[1])
+				continue
+			}
+		}
+		if level > 0 {
+			lines = append(lines, l)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if level == 0 {
+		return nil, fmt.Errorf("section %q not found", section)
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// headingAnchor returns the anchor of a heading with text s,
+// e.g. "Set up gcloud" becomes "set-up-gcloud".
+func headingAnchor(s string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case r == ' ' || r == '-':
+			buf.WriteRune('-')
+		case r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z':
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
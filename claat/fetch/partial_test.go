@@ -0,0 +1,55 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"testing"
+)
+
+func TestExtractSection(t *testing.T) {
+	src := `# Snippets
+
+## Set up gcloud
+
+Install the SDK.
+
+### Authenticate
+
+` + "```sh\n# not a heading\ngcloud auth login\n```" + `
+
+## Enable APIs
+
+Enable them.
+`
+	tests := []struct {
+		section, out string
+		ok           bool
+	}{
+		{"set-up-gcloud", "\nInstall the SDK.\n\n### Authenticate\n\n```sh\n# not a heading\ngcloud auth login\n```\n\n", true},
+		{"authenticate", "\n```sh\n# not a heading\ngcloud auth login\n```\n\n", true},
+		{"enable-apis", "\nEnable them.\n", true},
+		{"not-a-heading", "", false},
+	}
+	for i, test := range tests {
+		out, err := extractSection([]byte(src), test.section)
+		if (err == nil) != test.ok {
+			t.Errorf("%d: extractSection(%q) err = %v; want ok = %v", i, test.section, err, test.ok)
+			continue
+		}
+		if string(out) != test.out {
+			t.Errorf("%d: extractSection(%q) = %q; want %q", i, test.section, out, test.out)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// regionMarkerRegexp matches lines of region start and end markers,
+// which are usually comments like "// [START name]" and "# [END name]".
+var regionMarkerRegexp = regexp.MustCompile(`\[(START|END) ([\w.-]+)\]`)
+
+// slurpSnippets replaces content of code nodes included from files,
+// i.e. with a non-empty Src, found in clab.
+// Relative file paths are resolved against the directory of codelab src.
+func (f *Fetcher) slurpSnippets(src string, clab *types.Codelab) error {
+	for _, st := range clab.Steps {
+		var err error
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			cn, ok := n.(*types.CodeNode)
+			if !ok || cn.Src == "" || err != nil {
+				return err == nil
+			}
+			if err = f.slurpSnippet(src, cn); err != nil {
+				err = &parser.ImportError{Path: cn.Src, Err: err}
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slurpSnippet retrieves file and region referenced by n.Src,
+// replacing n.Value. The file is either local or an http(s) URL.
+func (f *Fetcher) slurpSnippet(src string, n *types.CodeNode) error {
+	name, region := n.Src, ""
+	if i := strings.Index(name, "#"); i >= 0 {
+		name, region = name[:i], name[i+1:]
+	}
+	b, err := f.readRef(src, name)
+	if err != nil {
+		return err
+	}
+	v, err := extractRegion(b, region)
+	if err != nil {
+		return err
+	}
+	n.Value = "\n" + v
+	return nil
+}
+
+// readRef returns content of a file referenced by name in codelab src.
+// The file is either an http(s) URL, or a local file relative to the
+// directory of src, which must be in the import root, see importPath.
+// Relative names of remote sources are resolved against the URL of src.
+func (f *Fetcher) readRef(src, name string) ([]byte, error) {
+	u, err := url.Parse(name)
+	if err == nil && !u.IsAbs() && !filepath.IsAbs(name) {
+		if su, err := url.Parse(src); err == nil && (su.Scheme == "http" || su.Scheme == "https") {
+			name = su.ResolveReference(u).String()
+			u, err = url.Parse(name)
+		}
+	}
+	if err == nil && u.IsAbs() {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported URL scheme of %s", name)
+		}
+		res, err := f.http.get(&http.Client{Transport: f.roundTripper}, name, nil, 3)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		return ioutil.ReadAll(res.Body)
+	}
+	if filepath.IsAbs(name) || path.IsAbs(name) {
+		return nil, fmt.Errorf("%s is an absolute path; use a path relative to the codelab", name)
+	}
+	if src != Stdin {
+		// relative to the directory of src, even if it has no local file
+		if name, err = filepath.Abs(filepath.Join(filepath.Dir(src), filepath.FromSlash(name))); err != nil {
+			return nil, err
+		}
+	}
+	p, err := f.importPath(src, name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(p)
+}
+
+// extractRegion returns lines of b between [START region] and [END region]
+// markers, removing their common indentation. If region is empty, all of b
+// is returned. Lines containing markers of any region are always omitted.
+func extractRegion(b []byte, region string) (string, error) {
+	var (
+		lines  []string
+		inside = region == ""
+		found  bool
+	)
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		l := s.Text()
+		m := regionMarkerRegexp.FindStringSubmatch(l)
+		if m == nil {
+			if inside {
+				lines = append(lines, l)
+			}
+			continue
+		}
+		if m[2] == region {
+			inside = m[1] == "START"
+			found = true
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	if region != "" && !found {
+		return "", fmt.Errorf("region %q not found", region)
+	}
+	return strings.Join(dedent(lines), "\n") + "\n", nil
+}
+
+// dedent removes the longest whitespace prefix common to all non-blank lines.
+func dedent(lines []string) []string {
+	var prefix string
+	first := true
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		indent := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	res := make([]string, len(lines))
+	for i, l := range lines {
+		res[i] = strings.TrimPrefix(l, prefix)
+	}
+	return res
+}
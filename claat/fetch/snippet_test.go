@@ -0,0 +1,77 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+)
+
+func TestExtractRegion(t *testing.T) {
+	src := `package main
+
+// [START imports]
+import "fmt"
+// [END imports]
+
+func main() {
+	// [START hello]
+	fmt.Println("hello")
+	// [START inner]
+	if true {
+		return
+	}
+	// [END inner]
+	// [END hello]
+}
+`
+	tests := []struct {
+		region, out string
+		ok          bool
+	}{
+		{"imports", "import \"fmt\"\n", true},
+		{"hello", "fmt.Println(\"hello\")\nif true {\n\treturn\n}\n", true},
+		{"inner", "if true {\n\treturn\n}\n", true},
+		{"missing", "", false},
+	}
+	for i, test := range tests {
+		out, err := extractRegion([]byte(src), test.region)
+		if (err == nil) != test.ok {
+			t.Errorf("%d: extractRegion(%q) err = %v; want ok = %v", i, test.region, err, test.ok)
+			continue
+		}
+		if out != test.out {
+			t.Errorf("%d: extractRegion(%q) = %q; want %q", i, test.region, out, test.out)
+		}
+	}
+}
+
+func TestReadRefConfined(t *testing.T) {
+	f, err := NewFetcher("", parser.Options{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join("testdata", "codelab.md")
+	if _, err := f.readRef(src, "bank.md"); err != nil {
+		t.Errorf("readRef(bank.md): %v", err)
+	}
+	for _, name := range []string{"/etc/passwd", "../../../../etc/passwd", "file:///etc/passwd"} {
+		if _, err := f.readRef(src, name); err == nil {
+			t.Errorf("readRef(%q) = nil error; want an error", name)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package i18n extracts translatable text of a parsed codelab into
+// XLIFF or PO catalogs for translators, and applies translated catalogs
+// back to a codelab parsed from the same source.
+//
+// Each translatable string is a unit: the codelab title and summary,
+// step titles, the text of each paragraph, list item, header, table cell
+// or button, alternative text of images, and survey questions and options.
+// The text of a block is a single unit in which markup is replaced with
+// numbered placeholders, so that translators see whole sentences:
+// bold and italic runs and links are wrapped in <n>...</n>, and inline
+// code and images, which are not translated, replaced with <n/>, e.g.
+//
+//	Run <1/> and open <2>the console</2>.
+//
+// Placeholders may be moved around in translations, but each must appear
+// once. Literal < and \ of the text are escaped with a backslash.
+//
+// Unit IDs are derived from the text, so that they are stable across
+// edits of other parts of the source, and units of the same text share
+// a single translation. The codelab title and summary are "title" and
+// "summary".
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Unit is a translatable string of a codelab.
+type Unit struct {
+	ID     string // stable ID in the codelab, see package doc
+	Source string // text in the source language
+	Target string // translated text; empty if not translated yet
+}
+
+// Codelab is the translatable text of a single codelab.
+type Codelab struct {
+	ID    string // codelab ID
+	Units []*Unit
+}
+
+// Catalog is the translatable text of one or more codelabs.
+type Catalog struct {
+	SourceLang string // locale of the sources, e.g. "en"
+	TargetLang string // locale of the translations, if known
+	Codelabs   []*Codelab
+}
+
+// langRegexp matches well-formed BCP 47 language tags (RFC 5646),
+// except for the irregular grandfathered ones.
+var langRegexp = regexp.MustCompile(`(?i)^(?:` +
+	`(?:[a-z]{2,3}(?:-[a-z]{3}){0,3}|[a-z]{4,8})` + // language and extlang
+	`(?:-[a-z]{4})?` + // script
+	`(?:-(?:[a-z]{2}|[0-9]{3}))?` + // region
+	`(?:-(?:[a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*` + // variants
+	`(?:-[0-9a-wy-z](?:-[a-z0-9]{2,8})+)*` + // extensions
+	`(?:-x(?:-[a-z0-9]{1,8})+)?` + // private use
+	`|x(?:-[a-z0-9]{1,8})+)$`)
+
+// ValidLang reports whether tag is a well-formed BCP 47 language tag,
+// such as "fr", "pt-BR" or "zh-Hant-TW".
+func ValidLang(tag string) bool {
+	return langRegexp.MatchString(tag)
+}
+
+// Lookup returns the translatable text of the codelab id, or nil.
+func (c *Catalog) Lookup(id string) *Codelab {
+	for _, cl := range c.Codelabs {
+		if cl.ID == id {
+			return cl
+		}
+	}
+	return nil
+}
+
+// Extract returns the translatable text of clab.
+func Extract(clab *types.Codelab) *Codelab {
+	res := &Codelab{ID: clab.ID}
+	seen := make(map[string]bool)
+	for _, s := range texts(clab) {
+		t := strings.TrimSpace(s.source)
+		if t == "" || seen[s.id] {
+			continue
+		}
+		seen[s.id] = true
+		res.Units = append(res.Units, &Unit{ID: s.id, Source: t})
+	}
+	return res
+}
+
+// Apply replaces text of clab with translations of units.
+// Units with no translation, or whose source differs from that of clab,
+// are left untranslated, as are those whose placeholders differ from
+// the source. Apply returns a message about each such unit, in order,
+// and the number of translated strings.
+func Apply(clab *types.Codelab, units []*Unit) (int, []string) {
+	byID := make(map[string]*Unit, len(units))
+	for _, u := range units {
+		byID[u.ID] = u
+	}
+	var (
+		n    int
+		msgs []string
+		seen = make(map[string]bool)
+	)
+	report := func(id, format string, args ...interface{}) {
+		if !seen[id] {
+			seen[id] = true
+			msgs = append(msgs, id+": "+fmt.Sprintf(format, args...))
+		}
+	}
+	for _, s := range texts(clab) {
+		text := strings.TrimSpace(s.source)
+		if text == "" {
+			continue
+		}
+		u := byID[s.id]
+		switch {
+		case u == nil:
+			report(s.id, "not in the catalog")
+		case u.Source != text:
+			report(s.id, "source changed since extraction")
+		case strings.TrimSpace(u.Target) == "":
+			report(s.id, "not translated")
+		default:
+			// keep spacing around the text, which separates it from adjacent runs
+			i := strings.Index(s.source, text)
+			if err := s.apply(s.source[:i] + strings.TrimSpace(u.Target) + s.source[i+len(text):]); err != nil {
+				report(s.id, "%v", err)
+				continue
+			}
+			n++
+		}
+	}
+	return n, msgs
+}
+
+// text is a translatable string of a codelab.
+type text struct {
+	id     string                    // unit ID, see package doc
+	source string                    // text, with placeholders if of a segment
+	apply  func(target string) error // replaces the text with target
+}
+
+// textID returns the unit ID of source text s.
+func textID(s string) string {
+	h := sha256.Sum256([]byte(strings.TrimSpace(s)))
+	return hex.EncodeToString(h[:5])
+}
+
+// stringText returns the translatable string *s.
+func stringText(s *string) text {
+	return text{textID(*s), *s, func(target string) error {
+		*s = target
+		return nil
+	}}
+}
+
+// texts returns translatable strings of clab in document order,
+// including blank ones, which are not translated.
+func texts(clab *types.Codelab) []text {
+	title, summary := stringText(&clab.Title), stringText(&clab.Summary)
+	title.id, summary.id = "title", "summary"
+	res := []text{title, summary}
+	for _, st := range clab.Steps {
+		res = append(res, stringText(&st.Title))
+		if st.Content != nil {
+			res = blockTexts(res, &st.Content.Nodes)
+		}
+	}
+	return res
+}
+
+// blockTexts appends translatable strings of block nodes to res:
+// each run of inline nodes is a segment, and other nodes are blocks.
+func blockTexts(res []text, nodes *[]types.Node) []text {
+	var run []types.Node
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		seg := &segment{nodes: nodes, run: run}
+		src := seg.source()
+		if strings.TrimSpace(plainText(src)) != "" {
+			res = append(res, text{textID(src), src, seg.apply})
+		}
+		for _, img := range seg.images() {
+			res = append(res, stringText(&img.Alt))
+		}
+		run = nil
+	}
+	for _, n := range *nodes {
+		if isInline(n) {
+			run = append(run, n)
+			continue
+		}
+		flush()
+		switch n := n.(type) {
+		case *types.ListNode:
+			res = blockTexts(res, &n.Nodes)
+		case *types.ImportNode:
+			res = blockTexts(res, &n.Content.Nodes)
+		case *types.ItemsListNode:
+			for _, it := range n.Items {
+				res = blockTexts(res, &it.Nodes)
+			}
+		case *types.HeaderNode:
+			res = blockTexts(res, &n.Content.Nodes)
+		case *types.ButtonNode:
+			res = blockTexts(res, &n.Content.Nodes)
+		case *types.InfoboxNode:
+			res = blockTexts(res, &n.Content.Nodes)
+		case *types.GridNode:
+			for _, r := range n.Rows {
+				for _, c := range r {
+					res = blockTexts(res, &c.Content.Nodes)
+				}
+			}
+		case *types.SurveyNode:
+			for _, g := range n.Groups {
+				res = append(res, stringText(&g.Name))
+				for j := range g.Options {
+					res = append(res, stringText(&g.Options[j]))
+				}
+			}
+		}
+	}
+	flush()
+	return res
+}
+
+// Catalog formats.
+const (
+	XLIFF = "xliff" // XLIFF 1.2
+	PO    = "po"    // GNU gettext PO
+)
+
+// FormatOf returns the catalog format of file name from its extension:
+// XLIFF for .xlf and .xliff, PO for .po and .pot.
+func FormatOf(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xlf", ".xliff":
+		return XLIFF, nil
+	case ".po", ".pot":
+		return PO, nil
+	}
+	return "", fmt.Errorf("%s: unknown catalog format, want .xlf, .xliff, .po or .pot", name)
+}
+
+// Ext returns the file extension of catalogs in format, with a dot.
+func Ext(format string) string {
+	if format == PO {
+		return ".po"
+	}
+	return ".xlf"
+}
+
+// Write encodes c to w in format.
+func Write(w io.Writer, format string, c *Catalog) error {
+	switch format {
+	case XLIFF:
+		return writeXLIFF(w, c)
+	case PO:
+		return writePO(w, c)
+	}
+	return fmt.Errorf("unknown catalog format %q", format)
+}
+
+// Read decodes a catalog in format from r.
+func Read(r io.Reader, format string) (*Catalog, error) {
+	switch format {
+	case XLIFF:
+		return readXLIFF(r)
+	case PO:
+		return readPO(r)
+	}
+	return nil, fmt.Errorf("unknown catalog format %q", format)
+}
+
+// ReadFile decodes a catalog stored in a local file,
+// in the format of its extension.
+func ReadFile(name string) (*Catalog, error) {
+	format, err := FormatOf(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := Read(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return c, nil
+}
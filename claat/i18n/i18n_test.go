@@ -0,0 +1,218 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func testCodelab() *types.Codelab {
+	clab := types.NewCodelab()
+	clab.ID = "lab"
+	clab.Title = "My lab"
+	st := clab.NewStep("Setup")
+	bold := types.NewTextNode("world")
+	bold.Bold = true
+	code := types.NewTextNode("go run")
+	code.Code = true
+	img := types.NewImageNode("img.png")
+	img.Alt = "A \"diagram\""
+	st.Content.Append(
+		types.NewTextNode("Hello "),
+		bold,
+		types.NewTextNode(" "),
+		code,
+		types.NewURLNode("https://example.com", types.NewTextNode("a link")),
+		img,
+		types.NewSurveyNode("s", &types.SurveyGroup{Name: "Level?", Options: []string{"Novice", "Expert"}}),
+	)
+	return clab
+}
+
+func TestExtract(t *testing.T) {
+	got := Extract(testCodelab())
+	para := "Hello <1>world</1> <2/><3>a link</3><4/>"
+	want := &Codelab{ID: "lab", Units: []*Unit{
+		{ID: "title", Source: "My lab"},
+		{ID: textID("Setup"), Source: "Setup"},
+		{ID: textID(para), Source: para},
+		{ID: textID(`A "diagram"`), Source: `A "diagram"`},
+		{ID: textID("Level?"), Source: "Level?"},
+		{ID: textID("Novice"), Source: "Novice"},
+		{ID: textID("Expert"), Source: "Expert"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		for _, u := range got.Units {
+			t.Logf("%+v", u)
+		}
+		t.Errorf("Extract = %+v; want %+v", got, want)
+	}
+}
+
+func TestExtractBlocks(t *testing.T) {
+	clab := types.NewCodelab()
+	clab.ID = "lab"
+	st := clab.NewStep("Setup")
+	p1 := types.NewListNode(types.NewTextNode("Use a < b or \\."))
+	p1.MutateBlock(true)
+	p2 := types.NewListNode(types.NewTextNode("Next"))
+	p2.MutateBlock(true)
+	list := types.NewItemsListNode("", 0)
+	list.NewItem(types.NewTextNode("One"))
+	list.NewItem(types.NewTextNode("Next"))
+	st.Content.Append(types.NewHeaderNode(2, types.NewTextNode("Install")), p1, list, p2)
+	var got []string
+	for _, u := range Extract(clab).Units {
+		got = append(got, u.Source)
+	}
+	// units of the same text are extracted once
+	want := []string{"Setup", "Install", `Use a \< b or \\.`, "One", "Next"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract sources = %q; want %q", got, want)
+	}
+}
+
+func TestCatalogFormats(t *testing.T) {
+	for _, format := range []string{XLIFF, PO} {
+		c := &Catalog{SourceLang: "en", TargetLang: "fr", Codelabs: []*Codelab{Extract(testCodelab())}}
+		c.Codelabs[0].Units[2].Target = "Bonjour\n\t\\ « ok »"
+		var buf bytes.Buffer
+		if err := Write(&buf, format, c); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		got, err := Read(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, c) {
+			t.Errorf("%s: Read(Write(c)) = %+v; want %+v", format, got, c)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	clab := testCodelab()
+	units := Extract(clab).Units
+	para := "Hello <1>world</1> <2/><3>a link</3><4/>"
+	targets := map[string]string{
+		"My lab":      "Mon atelier",
+		"Setup":       "Installation",
+		para:          "<3>Un lien</3> et <2/> : bonjour <1>le monde</1><4/>",
+		`A "diagram"`: "Un « diagramme »",
+		"Novice":      "Débutant",
+	}
+	for _, u := range units {
+		u.Target = targets[u.Source]
+	}
+	units[len(units)-1].Source = "Pro" // Expert changed since extraction
+	n, msgs := Apply(clab, units)
+	if n != len(targets) {
+		t.Errorf("Apply = %d; want %d", n, len(targets))
+	}
+	wantMsgs := []string{
+		textID("Level?") + ": not translated",
+		textID("Expert") + ": source changed since extraction",
+	}
+	if !reflect.DeepEqual(msgs, wantMsgs) {
+		t.Errorf("Apply messages = %q; want %q", msgs, wantMsgs)
+	}
+	if clab.Title != "Mon atelier" || clab.Steps[0].Title != "Installation" {
+		t.Errorf("titles = %q, %q", clab.Title, clab.Steps[0].Title)
+	}
+	nodes := clab.Steps[0].Content.Nodes
+	if len(nodes) != 7 {
+		t.Fatalf("%d nodes; want 7", len(nodes))
+	}
+	if u, ok := nodes[0].(*types.URLNode); !ok || u.URL != "https://example.com" || u.Content.Nodes[0].(*types.TextNode).Value != "Un lien" {
+		t.Errorf("nodes[0] = %+v; want the translated link", nodes[0])
+	}
+	if v := nodes[1].(*types.TextNode).Value; v != " et " {
+		t.Errorf("text = %q; want spacing kept", v)
+	}
+	if c := nodes[2].(*types.TextNode); !c.Code || c.Value != "go run" {
+		t.Errorf("code = %+v; want untouched", c)
+	}
+	if b := nodes[4].(*types.TextNode); !b.Bold || b.Value != "le monde" {
+		t.Errorf("bold = %+v; want translated bold text", b)
+	}
+	if img := nodes[5].(*types.ImageNode); img.Alt != "Un « diagramme »" {
+		t.Errorf("alt = %q", img.Alt)
+	}
+	if g := nodes[6].(*types.SurveyNode).Groups[0]; g.Options[0] != "Débutant" || g.Options[1] != "Expert" {
+		t.Errorf("survey options = %q", g.Options)
+	}
+}
+
+func TestApplyPlaceholders(t *testing.T) {
+	para := "Hello <1>world</1> <2/><3>a link</3><4/>"
+	for _, target := range []string{
+		"Bonjour <1>le monde</1> <3>un lien</3><4/>",         // missing
+		"Bonjour <1>le monde</1> <2/><3>un lien</3><4/><1/>", // repeated
+		"Bonjour <1>le monde <2/></1><3>un lien</3><4/>",     // nested in text
+		"Bonjour <1>le monde</1> <2>x</2><3>un lien</3><4/>", // code with content
+		"Bonjour <1>le monde <2/><3>un lien</3><4/>",         // unclosed
+	} {
+		clab := testCodelab()
+		units := Extract(clab).Units
+		for _, u := range units {
+			if u.Source == para {
+				u.Target = target
+			}
+		}
+		n, msgs := Apply(clab, units)
+		var found bool
+		for _, m := range msgs {
+			found = found || strings.HasPrefix(m, textID(para)+": ") && !strings.HasSuffix(m, "not translated")
+		}
+		if n != 0 || !found {
+			t.Errorf("Apply(%q) = %d, %q; want a message about the placeholders", target, n, msgs)
+		}
+		if v := clab.Steps[0].Content.Nodes[0].(*types.TextNode).Value; v != "Hello " {
+			t.Errorf("Apply(%q): text = %q; want untranslated", target, v)
+		}
+	}
+}
+
+func TestValidLang(t *testing.T) {
+	tests := []struct {
+		tag string
+		ok  bool
+	}{
+		{"fr", true},
+		{"pt-BR", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+		{"de-CH-1901", true},
+		{"en-US-x-twain", true},
+		{"x-klingon", true},
+		{"", false},
+		{"f", false},
+		{"pt_BR", false},
+		{"fr/../../x", false},
+		{"fr-", false},
+		{"en US", false},
+		{"toolonglanguage", false},
+	}
+	for _, tc := range tests {
+		if ok := ValidLang(tc.tag); ok != tc.ok {
+			t.Errorf("ValidLang(%q) = %v; want %v", tc.tag, ok, tc.ok)
+		}
+	}
+}
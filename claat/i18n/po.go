@@ -0,0 +1,189 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PO entries are keyed by msgctxt "<codelab ID>/<unit ID>".
+// Catalog languages are stored in the header entry.
+
+func writePO(w io.Writer, c *Catalog) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `msgid ""`)
+	fmt.Fprintln(bw, `msgstr ""`)
+	fmt.Fprintln(bw, `"Content-Type: text/plain; charset=UTF-8\n"`)
+	fmt.Fprintf(bw, "%s\n", poQuote("Language: "+c.TargetLang+"\n"))
+	fmt.Fprintf(bw, "%s\n", poQuote("X-Source-Language: "+c.SourceLang+"\n"))
+	for _, cl := range c.Codelabs {
+		for _, u := range cl.Units {
+			fmt.Fprintln(bw)
+			fmt.Fprintf(bw, "msgctxt %s\n", poQuote(cl.ID+"/"+u.ID))
+			fmt.Fprintf(bw, "msgid %s\n", poQuote(u.Source))
+			fmt.Fprintf(bw, "msgstr %s\n", poQuote(u.Target))
+		}
+	}
+	return bw.Flush()
+}
+
+// poEntry is a PO file entry being read.
+type poEntry struct {
+	ctxt, id, str string
+	field         *string // last keyword value, continued by string lines
+}
+
+func readPO(r io.Reader) (*Catalog, error) {
+	c := &Catalog{}
+	var e *poEntry
+	flush := func() error {
+		if e == nil {
+			return nil
+		}
+		defer func() { e = nil }()
+		if e.ctxt == "" && e.id == "" {
+			for _, l := range strings.Split(e.str, "\n") {
+				if i := strings.Index(l, ":"); i > 0 {
+					v := strings.TrimSpace(l[i+1:])
+					switch strings.TrimSpace(l[:i]) {
+					case "Language":
+						c.TargetLang = v
+					case "X-Source-Language":
+						c.SourceLang = v
+					}
+				}
+			}
+			return nil
+		}
+		i := strings.LastIndex(e.ctxt, "/")
+		if i < 0 {
+			return fmt.Errorf("msgctxt %q: want codelab ID/unit ID", e.ctxt)
+		}
+		id := e.ctxt[:i]
+		cl := c.Lookup(id)
+		if cl == nil {
+			cl = &Codelab{ID: id}
+			c.Codelabs = append(c.Codelabs, cl)
+		}
+		cl.Units = append(cl.Units, &Unit{ID: e.ctxt[i+1:], Source: e.id, Target: e.str})
+		return nil
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, `"`) {
+			if e == nil || e.field == nil {
+				return nil, fmt.Errorf("line %d: string outside of an entry", n)
+			}
+			v, err := poUnquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			*e.field += v
+			continue
+		}
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: invalid entry %q", n, line)
+		}
+		v, err := poUnquote(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		switch line[:i] {
+		case "msgctxt":
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			e = &poEntry{ctxt: v}
+			e.field = &e.ctxt
+		case "msgid":
+			if e == nil || e.id != "" || e.field == &e.str {
+				if err := flush(); err != nil {
+					return nil, fmt.Errorf("line %d: %v", n, err)
+				}
+				e = &poEntry{}
+			}
+			e.id = v
+			e.field = &e.id
+		case "msgstr":
+			if e == nil {
+				return nil, fmt.Errorf("line %d: msgstr without msgid", n)
+			}
+			e.str = v
+			e.field = &e.str
+		default:
+			return nil, fmt.Errorf("line %d: unsupported keyword %q", n, line[:i])
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// poEscapes are the escape sequences of PO strings, as in C.
+var poEscapes = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// poQuote returns s as a quoted PO string.
+func poQuote(s string) string {
+	return `"` + poEscapes.Replace(s) + `"`
+}
+
+// poUnquote returns the value of quoted PO string s.
+func poUnquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			return "", fmt.Errorf("unescaped quote in %q", s)
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		if i++; i == len(s) {
+			return "", fmt.Errorf("trailing backslash in %q", s)
+		}
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			return "", fmt.Errorf("unsupported escape \\%c in %q", s[i], s)
+		}
+	}
+	return b.String(), nil
+}
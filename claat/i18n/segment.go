@@ -0,0 +1,278 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// segment is a run of inline nodes of a block, such as a paragraph,
+// list item or header, translated as a single string in which markup
+// is replaced with numbered placeholders, see encode.
+type segment struct {
+	nodes *[]types.Node // block of the run
+	run   []types.Node  // nodes of the run, in *nodes
+	tags  []types.Node  // nodes of placeholders <n> and <n/>, by n-1
+}
+
+// isInline reports whether n is part of the text of a block,
+// rather than a block of its own.
+func isInline(n types.Node) bool {
+	switch n.(type) {
+	case *types.TextNode, *types.URLNode, *types.ImageNode:
+		return true
+	}
+	return false
+}
+
+// source returns the text of s, with placeholders: bold and italic runs
+// and links are wrapped in <n>...</n>, and code and images, which are not
+// translated, replaced with <n/>. Literal < and \ of the text are escaped
+// with a backslash.
+func (s *segment) source() string {
+	var b strings.Builder
+	s.tags = nil
+	s.encode(&b, s.run)
+	return b.String()
+}
+
+func (s *segment) encode(b *strings.Builder, nodes []types.Node) {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *types.TextNode:
+			if !n.Code && !n.Bold && !n.Italic {
+				b.WriteString(escapeText(n.Value))
+				continue
+			}
+			s.tags = append(s.tags, n)
+			if n.Code {
+				fmt.Fprintf(b, "<%d/>", len(s.tags))
+				continue
+			}
+			fmt.Fprintf(b, "<%d>%s</%d>", len(s.tags), escapeText(n.Value), len(s.tags))
+		case *types.URLNode:
+			s.tags = append(s.tags, n)
+			i := len(s.tags)
+			fmt.Fprintf(b, "<%d>", i)
+			s.encode(b, n.Content.Nodes)
+			fmt.Fprintf(b, "</%d>", i)
+		default:
+			s.tags = append(s.tags, n)
+			fmt.Fprintf(b, "<%d/>", len(s.tags))
+		}
+	}
+}
+
+// textEscaper escapes text of segments, see segment.source.
+var textEscaper = strings.NewReplacer(`\`, `\\`, `<`, `\<`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// plainText returns the text of a segment source, without placeholders.
+func plainText(src string) string {
+	pieces, err := parseSegment(src)
+	if err != nil {
+		return src
+	}
+	var b strings.Builder
+	var write func([]*piece)
+	write = func(pieces []*piece) {
+		for _, p := range pieces {
+			b.WriteString(p.text)
+			write(p.children)
+		}
+	}
+	write(pieces)
+	return b.String()
+}
+
+// images returns the images of the run of s, whose alternative text
+// is translated on its own.
+func (s *segment) images() []*types.ImageNode {
+	var res []*types.ImageNode
+	types.Walk(s.run, func(n types.Node) bool {
+		if img, ok := n.(*types.ImageNode); ok {
+			res = append(res, img)
+		}
+		return true
+	})
+	return res
+}
+
+// apply replaces the run of s with target, a translation of its source
+// with the same placeholders, which may be moved around.
+func (s *segment) apply(target string) error {
+	pieces, err := parseSegment(target)
+	if err != nil {
+		return err
+	}
+	used := make([]bool, len(s.tags))
+	nodes, err := s.build(pieces, used)
+	if err != nil {
+		return err
+	}
+	for i, u := range used {
+		if !u {
+			return fmt.Errorf("placeholder %d is missing", i+1)
+		}
+	}
+	start := -1
+	for i, n := range *s.nodes {
+		if n == s.run[0] {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return errors.New("text moved since extraction")
+	}
+	var res []types.Node
+	res = append(res, (*s.nodes)[:start]...)
+	res = append(res, nodes...)
+	res = append(res, (*s.nodes)[start+len(s.run):]...)
+	*s.nodes = res
+	return nil
+}
+
+// build returns the nodes of translated pieces, marking the placeholders
+// used, each of which must appear once, as in the source.
+func (s *segment) build(pieces []*piece, used []bool) ([]types.Node, error) {
+	var res []types.Node
+	for _, p := range pieces {
+		if p.tag == 0 {
+			if p.text != "" {
+				t := types.NewTextNode(p.text)
+				t.MutateEnv(s.run[0].Env())
+				res = append(res, t)
+			}
+			continue
+		}
+		if p.tag > len(s.tags) || used[p.tag-1] {
+			return nil, fmt.Errorf("unknown or repeated placeholder %d", p.tag)
+		}
+		used[p.tag-1] = true
+		switch n := s.tags[p.tag-1].(type) {
+		case *types.TextNode:
+			if n.Code != p.atom {
+				return nil, fmt.Errorf("placeholder %d changed", p.tag)
+			}
+			if n.Code {
+				res = append(res, n)
+				continue
+			}
+			if len(p.children) > 0 {
+				return nil, fmt.Errorf("placeholder %d has placeholders", p.tag)
+			}
+			t := types.NewTextNode(p.text)
+			t.Bold, t.Italic = n.Bold, n.Italic
+			t.MutateEnv(n.Env())
+			res = append(res, t)
+		case *types.URLNode:
+			if p.atom {
+				return nil, fmt.Errorf("placeholder %d changed", p.tag)
+			}
+			content := []*piece{{text: p.text}}
+			content = append(content, p.children...)
+			nodes, err := s.build(content, used)
+			if err != nil {
+				return nil, err
+			}
+			n.Content.Nodes = nodes
+			res = append(res, n)
+		default:
+			if !p.atom {
+				return nil, fmt.Errorf("placeholder %d changed", p.tag)
+			}
+			res = append(res, n)
+		}
+	}
+	return res, nil
+}
+
+// piece is text of a translated segment, or one of its placeholders.
+type piece struct {
+	text     string   // text, or that of a placeholder before its children
+	tag      int      // placeholder number, or 0 for text
+	atom     bool     // placeholder <n/>, which has no content
+	children []*piece // content of a placeholder <n>, after text
+}
+
+// parseSegment parses translated text s of a segment into pieces.
+func parseSegment(s string) ([]*piece, error) {
+	root := &piece{}
+	stack := []*piece{root}
+	var text strings.Builder
+	// flush appends the pending text to the innermost placeholder
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		if len(top.children) == 0 && top != root {
+			top.text += text.String()
+		} else {
+			top.children = append(top.children, &piece{text: text.String()})
+		}
+		text.Reset()
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s):
+			i++
+			text.WriteByte(s[i])
+		case c == '<':
+			j := strings.IndexByte(s[i:], '>')
+			if j < 0 {
+				return nil, errors.New("unterminated placeholder")
+			}
+			tag := s[i+1 : i+j]
+			i += j
+			closing, atom := strings.HasPrefix(tag, "/"), strings.HasSuffix(tag, "/")
+			n, err := strconv.Atoi(strings.Trim(tag, "/"))
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid placeholder <%s>", tag)
+			}
+			flush()
+			top := stack[len(stack)-1]
+			switch {
+			case closing:
+				if top.tag != n || top.atom {
+					return nil, fmt.Errorf("unexpected </%d>", n)
+				}
+				stack = stack[:len(stack)-1]
+			case atom:
+				top.children = append(top.children, &piece{tag: n, atom: true})
+			default:
+				p := &piece{tag: n}
+				top.children = append(top.children, p)
+				stack = append(stack, p)
+			}
+		default:
+			text.WriteByte(c)
+		}
+	}
+	flush()
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("unclosed <%d>", stack[len(stack)-1].tag)
+	}
+	return root.children, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// xliffNamespace is the XML namespace of XLIFF 1.2 documents.
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+// xliffDoc is an XLIFF 1.2 document with a file element per codelab.
+type xliffDoc struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Xmlns   string      `xml:"xmlns,attr,omitempty"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original   string      `xml:"original,attr"`
+	SourceLang string      `xml:"source-language,attr"`
+	TargetLang string      `xml:"target-language,attr,omitempty"`
+	Datatype   string      `xml:"datatype,attr"`
+	Units      []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string  `xml:"id,attr"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+func writeXLIFF(w io.Writer, c *Catalog) error {
+	doc := &xliffDoc{Xmlns: xliffNamespace, Version: "1.2"}
+	for _, cl := range c.Codelabs {
+		f := xliffFile{
+			Original:   cl.ID,
+			SourceLang: c.SourceLang,
+			TargetLang: c.TargetLang,
+			Datatype:   "plaintext",
+		}
+		for _, u := range cl.Units {
+			target := u.Target
+			f.Units = append(f.Units, xliffUnit{ID: u.ID, Source: u.Source, Target: &target})
+		}
+		doc.Files = append(doc.Files, f)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func readXLIFF(r io.Reader) (*Catalog, error) {
+	var doc xliffDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	c := &Catalog{}
+	for _, f := range doc.Files {
+		if c.SourceLang == "" {
+			c.SourceLang = f.SourceLang
+		}
+		if c.TargetLang == "" {
+			c.TargetLang = f.TargetLang
+		}
+		cl := &Codelab{ID: f.Original}
+		for _, u := range f.Units {
+			unit := &Unit{ID: u.ID, Source: u.Source}
+			if u.Target != nil {
+				unit.Target = *u.Target
+			}
+			cl.Units = append(cl.Units, unit)
+		}
+		c.Codelabs = append(c.Codelabs, cl)
+	}
+	return c, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks codelab Markdown sources for structural issues
+// and, optionally, fixes them.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// headingRegexp matches an ATX-style heading, capturing its level and text.
+var headingRegexp = regexp.MustCompile(`^(#{1,6})(\s+.*|)$`)
+
+// Issue is a single problem found in a codelab source.
+type Issue struct {
+	Line    int    // 1-based line number in the original source, or 0 if unknown
+	Column  int    // 1-based byte column in the line, or 0 if unknown
+	Message string // human readable description
+	Fixed   bool   // the issue has been corrected in the returned source
+	Rule    string // ID of the rule reporting the issue
+}
+
+// String formats the issue as "line:column: message [rule]",
+// omitting the column or the line if unknown.
+func (is *Issue) String() string {
+	s := is.Message
+	switch {
+	case is.Line > 0 && is.Column > 0:
+		s = fmt.Sprintf("%d:%d: %s", is.Line, is.Column, s)
+	case is.Line > 0:
+		s = fmt.Sprintf("%d: %s", is.Line, s)
+	}
+	if is.Rule != "" {
+		s += " [" + is.Rule + "]"
+	}
+	if is.Fixed {
+		s += " (fixed)"
+	}
+	return s
+}
+
+// Markdown checks codelab source b for the following:
+//
+//   - a subheading (H3 and deeper) appearing before the first step (H2),
+//     which the parser would otherwise silently drop
+//   - skipped heading levels, e.g. H4 directly after H2
+//   - a fenced code block not preceded by a blank line
+//
+// The heading issues are reported under RuleHeadingLevel and the code block
+// ones under RuleFenceSpacing, unless the rule is disabled in c, which may be nil.
+// If fix is true, the issues are corrected and the fixed source is returned
+// as the second value. Otherwise, the returned source is b unchanged.
+// Lines inside fenced code blocks are never inspected.
+func Markdown(b []byte, fix bool, c *Config) ([]*Issue, []byte) {
+	var (
+		issues []*Issue
+		out    [][]byte
+		fence  string // opening marker of the current fenced block
+		level  int    // effective level of the last heading
+		inStep bool   // an H2 has been seen
+	)
+	lines := bytes.Split(b, newLine)
+	for i, line := range lines {
+		lineno := i + 1
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(string(line)), fence) {
+				fence = ""
+			}
+			out = append(out, line)
+			continue
+		}
+
+		if f := fenceMarker(line); f != "" {
+			fence = f
+			// indented fences belong to list items and are fine as is
+			if c.Enabled(RuleFenceSpacing) && len(out) > 0 && !isBlank(out[len(out)-1]) && line[0] == f[0] {
+				is := &Issue{Line: lineno, Message: "missing blank line before fenced code block", Fixed: fix, Rule: RuleFenceSpacing}
+				issues = append(issues, is)
+				if fix {
+					out = append(out, nil)
+				}
+			}
+			out = append(out, line)
+			continue
+		}
+
+		m := headingRegexp.FindSubmatch(line)
+		if m == nil {
+			out = append(out, line)
+			continue
+		}
+		orig := len(m[1])
+		want := orig
+		switch {
+		case orig == 1:
+			// codelab title
+		case !c.Enabled(RuleHeadingLevel):
+			// levels are kept as is
+		case orig > 2 && !inStep:
+			want = 2
+			issues = append(issues, &Issue{
+				Line:    lineno,
+				Message: fmt.Sprintf("H%d before the first step heading (H2)", orig),
+				Fixed:   fix,
+				Rule:    RuleHeadingLevel,
+			})
+		case level > 0 && orig > level+1:
+			want = level + 1
+			issues = append(issues, &Issue{
+				Line:    lineno,
+				Message: fmt.Sprintf("heading level skipped: H%d after H%d", orig, level),
+				Fixed:   fix,
+				Rule:    RuleHeadingLevel,
+			})
+		}
+		if want == 2 {
+			inStep = true
+		}
+		level = want
+		if fix && want != orig {
+			line = append([]byte(strings.Repeat("#", want)), m[2]...)
+		}
+		out = append(out, line)
+	}
+	if !fix {
+		return issues, b
+	}
+	return issues, bytes.Join(out, newLine)
+}
+
+// placeholderRegexp matches a placeholder image, capturing its description,
+// e.g. ![](placeholder: "login screen").
+var placeholderRegexp = regexp.MustCompile(`!\[[^\]]*\]\(placeholder:\s*(?:"([^"]*)")?[^)]*\)`)
+
+// Placeholders returns an issue for each placeholder image in codelab
+// source b, which is yet to be replaced with an actual image.
+// Placeholders cannot be fixed automatically.
+// Lines inside fenced code blocks are never inspected.
+func Placeholders(b []byte) []*Issue {
+	var (
+		issues []*Issue
+		fence  string
+	)
+	for i, line := range bytes.Split(b, newLine) {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(string(line)), fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence = fenceMarker(line); fence != "" {
+			continue
+		}
+		for _, m := range placeholderRegexp.FindAllSubmatch(line, -1) {
+			issues = append(issues, &Issue{
+				Line:    i + 1,
+				Message: fmt.Sprintf("placeholder image %q", m[1]),
+				Rule:    RulePlaceholder,
+			})
+		}
+	}
+	return issues
+}
+
+var newLine = []byte{'\n'}
+
+// fenceMarker returns the opening marker of a fenced code block,
+// or an empty string if line doesn't start one.
+func fenceMarker(line []byte) string {
+	s := strings.TrimLeft(string(line), " \t")
+	for _, f := range []string{"```", "~~~"} {
+		if strings.HasPrefix(s, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// isBlank reports whether line contains only space runes.
+func isBlank(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		in    string
+		lines []int
+		out   string
+	}{
+		{
+			in:  "# Title\n## Step\n### Sub\n",
+			out: "# Title\n## Step\n### Sub\n",
+		},
+		{
+			in:    "# Title\n### Intro\n## Step\n",
+			lines: []int{2},
+			out:   "# Title\n## Intro\n## Step\n",
+		},
+		{
+			in:    "# Title\n## Step\n#### Deep\n##### Deeper\n",
+			lines: []int{3, 4},
+			out:   "# Title\n## Step\n### Deep\n#### Deeper\n",
+		},
+		{
+			in:    "## Step\ntext\n```go\n# not a heading\n```\n",
+			lines: []int{3},
+			out:   "## Step\ntext\n\n```go\n# not a heading\n```\n",
+		},
+		{
+			in:  "## Step\n* item\n  ```\n  code\n  ```\n",
+			out: "## Step\n* item\n  ```\n  code\n  ```\n",
+		},
+	}
+	for i, test := range tests {
+		issues, out := Markdown([]byte(test.in), true, nil)
+		if len(issues) != len(test.lines) {
+			t.Errorf("%d: len(issues) = %d (%v); want %d", i, len(issues), issues, len(test.lines))
+			continue
+		}
+		for j, is := range issues {
+			if is.Line != test.lines[j] || !is.Fixed {
+				t.Errorf("%d: issues[%d] = %v; want line %d fixed", i, j, is, test.lines[j])
+			}
+		}
+		if string(out) != test.out {
+			t.Errorf("%d: out = %q; want %q", i, out, test.out)
+		}
+
+		// without fix the source stays intact
+		issues, out = Markdown([]byte(test.in), false, nil)
+		if len(issues) != len(test.lines) || string(out) != test.in {
+			t.Errorf("%d: no fix: %v, %q", i, issues, out)
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	in := "## Step\n![](placeholder: \"login screen\") and ![x](placeholder:)\n```\n![](placeholder: \"code\")\n```\n![](img.png)\n"
+	issues := Placeholders([]byte(in))
+	if len(issues) != 2 {
+		t.Fatalf("len(issues) = %d (%v); want 2", len(issues), issues)
+	}
+	if is := issues[0]; is.Line != 2 || is.Message != `placeholder image "login screen"` || is.Fixed {
+		t.Errorf("issues[0] = %v", is)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Rule IDs. Issues of Markdown and Placeholders are reported under
+// RuleHeadingLevel, RuleFenceSpacing and RulePlaceholder, the rest
+// under rules checked by Check.
+const (
+	RuleHeadingLevel    = "heading-level"
+	RuleFenceSpacing    = "fence-blank-line"
+	RulePlaceholder     = "placeholder"
+	RuleMissingDuration = "missing-duration"
+	RuleLongStep        = "long-step"
+	RuleBrokenAnchor    = "broken-anchor"
+	RuleMissingAlt      = "missing-alt"
+	RuleInvalidID       = "invalid-id"
+	RuleDuplicateID     = "duplicate-id"
+	RuleDroppedContent  = "dropped-content"
+)
+
+// DefaultMaxStepMinutes is the RuleLongStep limit if not configured.
+const DefaultMaxStepMinutes = 20
+
+// Rule is a check of codelab sources.
+type Rule struct {
+	ID          string
+	Description string // short description, e.g. for SARIF logs
+
+	// check returns issues of src, one of all sources checked together.
+	// Rules of source text, checked by Markdown and Placeholders, have none.
+	check func(src *Source, all []*Source, c *Config) []*Issue
+}
+
+// Rules are all rules, in the order they are checked.
+var Rules = []*Rule{
+	{ID: RuleHeadingLevel, Description: "Heading levels are not skipped and steps start with an H2"},
+	{ID: RuleFenceSpacing, Description: "Fenced code blocks are preceded by a blank line"},
+	{ID: RulePlaceholder, Description: "Placeholder images are replaced before publishing"},
+	{ID: RuleMissingDuration, Description: "Steps have a duration", check: checkMissingDuration},
+	{ID: RuleLongStep, Description: "Steps are not longer than the configured number of minutes", check: checkLongStep},
+	{ID: RuleBrokenAnchor, Description: "In-codelab links point to existing steps", check: checkBrokenAnchor},
+	{ID: RuleMissingAlt, Description: "Images have alt text", check: checkMissingAlt},
+	{ID: RuleInvalidID, Description: "Codelab IDs are slugs of lowercase letters and digits", check: checkInvalidID},
+	{ID: RuleDuplicateID, Description: "Codelab IDs are unique among checked sources", check: checkDuplicateID},
+	{ID: RuleDroppedContent, Description: "Source content is not dropped or ignored by the parser", check: checkDroppedContent},
+}
+
+// Config configures rules, as the "lint" section of claat.yaml.
+// The zero value enables all rules with default settings.
+type Config struct {
+	// Disable lists IDs of rules which are not checked.
+	Disable []string `yaml:"disable,omitempty" json:"disable,omitempty"`
+	// MaxStepMinutes is the RuleLongStep limit.
+	// If zero, DefaultMaxStepMinutes is used.
+	MaxStepMinutes int `yaml:"max_step_minutes,omitempty" json:"max_step_minutes,omitempty"`
+}
+
+// Validate checks whether c refers to known rules only.
+// A nil config is valid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, id := range c.Disable {
+		if findRule(id) == nil {
+			return fmt.Errorf("unknown rule %q", id)
+		}
+	}
+	if c.MaxStepMinutes < 0 {
+		return fmt.Errorf("negative max_step_minutes %d", c.MaxStepMinutes)
+	}
+	return nil
+}
+
+// Enabled reports whether the rule of id is checked.
+// A nil config enables all rules.
+func (c *Config) Enabled(id string) bool {
+	if c == nil {
+		return true
+	}
+	for _, d := range c.Disable {
+		if d == id {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) maxStepMinutes() int {
+	if c == nil || c.MaxStepMinutes == 0 {
+		return DefaultMaxStepMinutes
+	}
+	return c.MaxStepMinutes
+}
+
+func findRule(id string) *Rule {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Source is a parsed codelab source checked by Check.
+type Source struct {
+	Name    string         // file name
+	Base    string         // Name without a locale suffix; locale variants share codelab IDs
+	Content []byte         // Markdown source, for line numbers of issues
+	Codelab *types.Codelab // parsed source
+	// Warnings are reported by the parser of Codelab.
+	Warnings []*parser.Warning
+}
+
+// Check runs enabled rules of c on parsed codelab src, one of all sources
+// checked together, and returns issues found in src, in order of Rules.
+// Issues have line numbers of the first matching source line, if any.
+func Check(src *Source, all []*Source, c *Config) []*Issue {
+	var issues []*Issue
+	for _, r := range Rules {
+		if r.check == nil || !c.Enabled(r.ID) {
+			continue
+		}
+		for _, is := range r.check(src, all, c) {
+			is.Rule = r.ID
+			issues = append(issues, is)
+		}
+	}
+	return issues
+}
+
+func checkMissingDuration(src *Source, _ []*Source, _ *Config) []*Issue {
+	var issues []*Issue
+	for _, st := range src.Codelab.Steps {
+		switch {
+		case st.Duration == 0:
+			issues = append(issues, &Issue{
+				Line:    src.stepLine(st),
+				Message: fmt.Sprintf("step %q has no duration", st.Title),
+			})
+		case st.Estimated:
+			issues = append(issues, &Issue{
+				Line:    src.stepLine(st),
+				Message: fmt.Sprintf("step %q has no duration; estimated at %d min from its length", st.Title, int(st.Duration.Minutes())),
+			})
+		}
+	}
+	return issues
+}
+
+func checkLongStep(src *Source, _ []*Source, c *Config) []*Issue {
+	var issues []*Issue
+	max := c.maxStepMinutes()
+	for _, st := range src.Codelab.Steps {
+		if m := int(st.Duration.Minutes()); m > max {
+			issues = append(issues, &Issue{
+				Line:    src.stepLine(st),
+				Message: fmt.Sprintf("step %q takes %d minutes, over %d", st.Title, m, max),
+			})
+		}
+	}
+	return issues
+}
+
+// checkBrokenAnchor reports links to "#N" anchors of steps, 0-based,
+// which the codelab doesn't have, and any other in-page anchors,
+// which codelab formats don't define.
+func checkBrokenAnchor(src *Source, _ []*Source, _ *Config) []*Issue {
+	var issues []*Issue
+	n := len(src.Codelab.Steps)
+	for _, st := range src.Codelab.Steps {
+		for _, u := range types.URLNodes(st.Content.Nodes) {
+			if !strings.HasPrefix(u.URL, "#") {
+				continue
+			}
+			if i, err := strconv.Atoi(u.URL[1:]); err == nil && i >= 0 && i < n {
+				continue
+			}
+			is := &Issue{Message: fmt.Sprintf("link to missing anchor %q in step %q", u.URL, st.Title)}
+			is.Line, is.Column = src.at(u.Pos(), "("+u.URL+")", `"`+u.URL+`"`)
+			issues = append(issues, is)
+		}
+	}
+	return issues
+}
+
+func checkMissingAlt(src *Source, _ []*Source, _ *Config) []*Issue {
+	var issues []*Issue
+	for _, st := range src.Codelab.Steps {
+		for _, img := range types.ImageNodes(st.Content.Nodes) {
+			if img.Alt == "" && img.Placeholder == "" {
+				is := &Issue{Message: fmt.Sprintf("image %q has no alt text", img.Src)}
+				is.Line, is.Column = src.at(img.Pos(), img.Src)
+				issues = append(issues, is)
+			}
+		}
+	}
+	return issues
+}
+
+func checkInvalidID(src *Source, _ []*Source, _ *Config) []*Issue {
+	if types.ValidID(src.Codelab.ID) {
+		return nil
+	}
+	return []*Issue{{
+		Line:    src.line(src.Codelab.ID),
+		Message: fmt.Sprintf("codelab ID %q is not a slug of lowercase letters and digits separated by - or _", src.Codelab.ID),
+	}}
+}
+
+func checkDuplicateID(src *Source, all []*Source, _ *Config) []*Issue {
+	var dups []string
+	for _, s := range all {
+		if s != src && s.Base != src.Base && s.Codelab.ID == src.Codelab.ID {
+			dups = append(dups, s.Name)
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	sort.Strings(dups)
+	return []*Issue{{
+		Line:    src.line(src.Codelab.ID),
+		Message: fmt.Sprintf("codelab ID %q is also used by %s", src.Codelab.ID, strings.Join(dups, ", ")),
+	}}
+}
+
+// checkDroppedContent reports parser warnings of src.
+func checkDroppedContent(src *Source, _ []*Source, _ *Config) []*Issue {
+	issues := make([]*Issue, len(src.Warnings))
+	for i, w := range src.Warnings {
+		issues[i] = &Issue{Line: w.Pos.Line, Column: w.Pos.Column, Message: w.Message}
+	}
+	return issues
+}
+
+// stepLine returns the line number of the heading of step st, or 0.
+func (src *Source) stepLine(st *types.Step) int {
+	if st.Pos.IsValid() {
+		return st.Pos.Line
+	}
+	for i, l := range strings.Split(string(src.Content), "\n") {
+		if strings.HasPrefix(l, "## ") && strings.TrimSpace(l[3:]) == st.Title {
+			return i + 1
+		}
+	}
+	return src.line(st.Title)
+}
+
+// at returns the line and column of position p of a parsed node,
+// or the first source line containing any of v if p is unknown.
+func (src *Source) at(p types.Position, v ...string) (line, column int) {
+	if p.IsValid() {
+		return p.Line, p.Column
+	}
+	return src.line(v...), 0
+}
+
+// line returns the number of the first source line containing any of v,
+// or 0 if none does.
+func (src *Source) line(v ...string) int {
+	for i, l := range strings.Split(string(src.Content), "\n") {
+		for _, s := range v {
+			if s != "" && strings.Contains(l, s) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
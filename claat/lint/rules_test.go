@@ -0,0 +1,157 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func testSource(name, base, id string) *Source {
+	clab := types.NewCodelab()
+	clab.ID = id
+	st := clab.NewStep("Setup")
+	st.Duration = 5 * time.Minute
+	st = clab.NewStep("Build")
+	st.Duration = 30 * time.Minute
+	img := types.NewImageNode("img/a.png")
+	st.Content.Append(
+		types.NewURLNode("#1", types.NewTextNode("next")),
+		types.NewURLNode("#5", types.NewTextNode("nowhere")),
+		img,
+	)
+	clab.NewStep("Done")
+	content := "id: " + id + "\n\n# Title\n\n## Setup\nDuration: 5:00\n\n## Build\nDuration: 30:00\n\n" +
+		"[next](#1) [nowhere](#5)\n\n![](img/a.png)\n\n## Done\n"
+	return &Source{Name: name, Base: base, Content: []byte(content), Codelab: clab}
+}
+
+func TestCheck(t *testing.T) {
+	a := testSource("a.md", "a.md", "same")
+	fr := testSource("a.fr.md", "a.md", "same")
+	b := testSource("b.md", "b.md", "same")
+	all := []*Source{a, fr, b}
+
+	tests := []struct {
+		conf  *Config
+		rules []string
+		lines []int
+	}{
+		{
+			conf:  nil,
+			rules: []string{RuleMissingDuration, RuleLongStep, RuleBrokenAnchor, RuleMissingAlt, RuleDuplicateID},
+			lines: []int{15, 8, 11, 13, 1},
+		},
+		{
+			conf:  &Config{Disable: []string{RuleMissingAlt, RuleDuplicateID}, MaxStepMinutes: 30},
+			rules: []string{RuleMissingDuration, RuleBrokenAnchor},
+			lines: []int{15, 11},
+		},
+	}
+	for i, test := range tests {
+		var rules []string
+		var lines []int
+		for _, is := range Check(a, all, test.conf) {
+			rules = append(rules, is.Rule)
+			lines = append(lines, is.Line)
+		}
+		if !reflect.DeepEqual(rules, test.rules) {
+			t.Errorf("%d: rules = %v; want %v", i, rules, test.rules)
+		}
+		if !reflect.DeepEqual(lines, test.lines) {
+			t.Errorf("%d: lines = %v; want %v", i, lines, test.lines)
+		}
+	}
+}
+
+func TestCheckPositions(t *testing.T) {
+	src := testSource("a.md", "a.md", "a")
+	src.Codelab.Steps[1].Pos = types.Position{Line: 30, Column: 1}
+	img := types.ImageNodes(src.Codelab.Steps[1].Content.Nodes)[0]
+	img.MutatePos(types.Position{Line: 20, Column: 3})
+	src.Warnings = []*parser.Warning{{Pos: types.Position{Line: 3, Column: 1}, Message: "content dropped"}}
+
+	var got []string
+	for _, is := range Check(src, []*Source{src}, nil) {
+		got = append(got, is.String())
+	}
+	want := []string{
+		`15: step "Done" has no duration [missing-duration]`,
+		`30: step "Build" takes 30 minutes, over 20 [long-step]`,
+		`11: link to missing anchor "#5" in step "Build" [broken-anchor]`,
+		`20:3: image "img/a.png" has no alt text [missing-alt]`,
+		`3:1: content dropped [dropped-content]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %q; want %q", got, want)
+	}
+}
+
+func TestCheckEstimatedDuration(t *testing.T) {
+	src := testSource("a.md", "a.md", "a")
+	st := src.Codelab.Steps[2]
+	st.Duration = 2 * time.Minute
+	st.Estimated = true
+	issues := Check(src, []*Source{src}, &Config{Disable: []string{RuleLongStep, RuleBrokenAnchor, RuleMissingAlt}})
+	want := `15: step "Done" has no duration; estimated at 2 min from its length [missing-duration]`
+	if len(issues) != 1 || issues[0].String() != want {
+		t.Errorf("issues = %v; want %q", issues, want)
+	}
+}
+
+func TestCheckInvalidID(t *testing.T) {
+	src := testSource("a.md", "a.md", "My Codelab")
+	issues := Check(src, []*Source{src}, &Config{Disable: []string{RuleMissingDuration, RuleLongStep, RuleBrokenAnchor, RuleMissingAlt}})
+	if len(issues) != 1 || issues[0].Rule != RuleInvalidID || issues[0].Line != 1 {
+		t.Errorf("issues = %v; want an %s issue at line 1", issues, RuleInvalidID)
+	}
+}
+
+func TestCheckDuplicateIDLocales(t *testing.T) {
+	a := testSource("a.md", "a.md", "same")
+	fr := testSource("a.fr.md", "a.md", "same")
+	if issues := checkDuplicateID(a, []*Source{a, fr}, nil); len(issues) != 0 {
+		t.Errorf("checkDuplicateID(locale variant) = %v; want none", issues)
+	}
+	b := testSource("b.md", "b.md", "same")
+	issues := checkDuplicateID(a, []*Source{a, fr, b}, nil)
+	if len(issues) != 1 {
+		t.Fatalf("checkDuplicateID = %v; want 1 issue", issues)
+	}
+	if want := `codelab ID "same" is also used by b.md`; issues[0].Message != want {
+		t.Errorf("Message = %q; want %q", issues[0].Message, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		conf *Config
+		ok   bool
+	}{
+		{nil, true},
+		{&Config{Disable: []string{RuleLongStep}, MaxStepMinutes: 10}, true},
+		{&Config{Disable: []string{"no-such-rule"}}, false},
+		{&Config{MaxStepMinutes: -1}, false},
+	}
+	for i, test := range tests {
+		if err := test.conf.Validate(); (err == nil) != test.ok {
+			t.Errorf("%d: Validate() = %v; want ok = %v", i, err, test.ok)
+		}
+	}
+}
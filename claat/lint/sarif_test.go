@@ -0,0 +1,61 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	reports := []*Report{{
+		File: "a.md",
+		Issues: []*Issue{
+			{Line: 3, Message: "fixed", Fixed: true, Rule: RuleHeadingLevel},
+			{Line: 7, Message: "no alt", Rule: RuleMissingAlt},
+			{Message: "dup", Rule: RuleDuplicateID},
+		},
+	}}
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, "1.0", reports); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("json.Unmarshal: %v\n%s", err, buf.Bytes())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version = %q, %d runs; want 2.1.0, 1 run", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(Rules) {
+		t.Errorf("%d driver rules; want %d", len(run.Tool.Driver.Rules), len(Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("%d results; want 2 unfixed issues", len(run.Results))
+	}
+	res := run.Results[0]
+	if res.RuleID != RuleMissingAlt || res.Message.Text != "no alt" {
+		t.Errorf("result 0 = %+v", res)
+	}
+	pl := res.Locations[0].PhysicalLocation
+	if pl.ArtifactLocation.URI != "a.md" || pl.Region == nil || pl.Region.StartLine != 7 {
+		t.Errorf("location 0 = %+v", pl)
+	}
+	if r := run.Results[1].Locations[0].PhysicalLocation.Region; r != nil {
+		t.Errorf("region of issue without line = %+v; want none", r)
+	}
+}
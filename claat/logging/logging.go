@@ -0,0 +1,237 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging provides the leveled logger of the claat command.
+//
+// Entries are written as plain text lines, through the standard log
+// package, or as JSON objects, one per line, so that CI systems can parse
+// warnings of each codelab. Fields added with With, such as the source
+// of a codelab, are only written in JSON.
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+// Levels of log entries, least severe first.
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < Debug || l > Error {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level named s, e.g. "warn".
+func ParseLevel(s string) (Level, error) {
+	for i, n := range levelNames {
+		if strings.EqualFold(s, n) {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q; want %s", s, strings.Join(levelNames, ", "))
+}
+
+// Formats of log entries.
+const (
+	Text = "text"
+	JSON = "json"
+)
+
+// now returns the time of log entries; tests replace it.
+var now = time.Now
+
+// Logger writes entries of its level or above.
+// It is safe for concurrent use.
+type Logger struct {
+	out    *output
+	fields []field
+}
+
+// output is where entries of a logger, and loggers derived from it
+// with With, are written.
+type output struct {
+	mu     sync.Mutex
+	w      io.Writer // output of the standard log package if nil
+	level  Level
+	format string
+}
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// New returns a logger writing entries of level or above to w,
+// formatted as Text or JSON. If w is nil, entries are written to the
+// output of the standard log package, text ones with log.Output.
+func New(w io.Writer, level Level, format string) (*Logger, error) {
+	switch format {
+	case Text, JSON:
+	default:
+		return nil, fmt.Errorf("unknown log format %q; want %s or %s", format, Text, JSON)
+	}
+	return &Logger{out: &output{w: w, level: level, format: format}}, nil
+}
+
+var std = &Logger{out: &output{level: Info, format: Text}}
+
+// Default returns the logger of the package-level functions,
+// which writes text entries of level Info or above to the standard log
+// package, unless replaced with SetDefault.
+func Default() *Logger {
+	return std
+}
+
+// SetDefault replaces the logger of the package-level functions.
+// It is meant to be called once at startup.
+func SetDefault(l *Logger) {
+	std = l
+}
+
+// With returns a logger writing entries of l with field key set to value,
+// e.g. With("src", src).
+func (l *Logger) With(key string, value interface{}) *Logger {
+	fields := make([]field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	return &Logger{out: l.out, fields: append(fields, field{key, value})}
+}
+
+// JSON reports whether l writes JSON entries, whose fields, such as
+// the source of a codelab, text messages would otherwise repeat.
+func (l *Logger) JSON() bool {
+	return l.out.format == JSON
+}
+
+// Enabled reports whether l writes entries of level.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.out.level
+}
+
+// Debugf logs a message of level Debug, formatted as with fmt.Sprintf.
+func (l *Logger) Debugf(format string, args ...interface{}) { l.logf(Debug, format, args...) }
+
+// Infof logs a message of level Info, formatted as with fmt.Sprintf.
+func (l *Logger) Infof(format string, args ...interface{}) { l.logf(Info, format, args...) }
+
+// Warnf logs a message of level Warn, formatted as with fmt.Sprintf.
+func (l *Logger) Warnf(format string, args ...interface{}) { l.logf(Warn, format, args...) }
+
+// Errorf logs a message of level Error, formatted as with fmt.Sprintf.
+func (l *Logger) Errorf(format string, args ...interface{}) { l.logf(Error, format, args...) }
+
+// Fatalf is like Errorf followed by os.Exit(1).
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.logf(Error, format, args...)
+	os.Exit(1)
+}
+
+func (l *Logger) logf(level Level, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	o := l.out
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.format == Text {
+		if o.w == nil {
+			log.Output(3, msg)
+			return
+		}
+		if !strings.HasSuffix(msg, "\n") {
+			msg += "\n"
+		}
+		io.WriteString(o.w, msg)
+		return
+	}
+	w := o.w
+	if w == nil {
+		w = log.Writer()
+	}
+	w.Write(l.entry(level, msg))
+}
+
+// entry returns a JSON entry of msg, terminated by a newline.
+func (l *Logger) entry(level Level, msg string) []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	add := func(k string, v interface{}) {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		kb, _ := json.Marshal(k)
+		vb, err := json.Marshal(v)
+		if err != nil {
+			vb, _ = json.Marshal(fmt.Sprint(v))
+		}
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	add("time", now().UTC().Format(time.RFC3339Nano))
+	add("level", level.String())
+	add("msg", msg)
+	for _, f := range l.fields {
+		if err, ok := f.value.(error); ok {
+			add(f.key, err.Error())
+			continue
+		}
+		add(f.key, f.value)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// With returns a logger writing entries of the default logger
+// with field key set to value.
+func With(key string, value interface{}) *Logger { return std.With(key, value) }
+
+// Debugf logs a message of level Debug with the default logger.
+func Debugf(format string, args ...interface{}) { std.logf(Debug, format, args...) }
+
+// Infof logs a message of level Info with the default logger.
+func Infof(format string, args ...interface{}) { std.logf(Info, format, args...) }
+
+// Warnf logs a message of level Warn with the default logger.
+func Warnf(format string, args ...interface{}) { std.logf(Warn, format, args...) }
+
+// Errorf logs a message of level Error with the default logger.
+func Errorf(format string, args ...interface{}) { std.logf(Error, format, args...) }
+
+// Fatalf logs a message of level Error with the default logger,
+// followed by os.Exit(1).
+func Fatalf(format string, args ...interface{}) {
+	std.logf(Error, format, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoggerText(t *testing.T) {
+	var b bytes.Buffer
+	l, err := New(&b, Warn, Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Infof("ok\t%s", "lab")
+	l.With("src", "lab.md").Warnf("lab.md: %s", "dropped node")
+	l.Errorf("err\tlab.md %v", errors.New("not found"))
+	want := "lab.md: dropped node\nerr\tlab.md not found\n"
+	if b.String() != want {
+		t.Errorf("output = %q; want %q", b.String(), want)
+	}
+}
+
+func TestLoggerJSON(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC) }
+	var b bytes.Buffer
+	l, err := New(&b, Debug, JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := l.With("src", "lab.md")
+	src.With("err", errors.New("timeout")).Warnf("slow request %q", "https://example.com/")
+	src.Debugf("exported")
+	l.Infof("done")
+	want := `{"time":"2020-01-02T03:04:05Z","level":"warn","msg":"slow request \"https://example.com/\"","src":"lab.md","err":"timeout"}
+{"time":"2020-01-02T03:04:05Z","level":"debug","msg":"exported","src":"lab.md"}
+{"time":"2020-01-02T03:04:05Z","level":"info","msg":"done"}
+`
+	if b.String() != want {
+		t.Errorf("output:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range []Level{Debug, Info, Warn, Error} {
+		got, err := ParseLevel(l.String())
+		if err != nil || got != l {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", l.String(), got, err, l)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) = nil error; want an error")
+	}
+	if _, err := New(nil, Info, "xml"); err == nil {
+		t.Error("New with format xml = nil error; want an error")
+	}
+}
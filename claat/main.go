@@ -20,44 +20,135 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/googlecodelabs/tools/claat/cmd"
+	"github.com/googlecodelabs/tools/claat/config"
+	"github.com/googlecodelabs/tools/claat/fetch"
+	"github.com/googlecodelabs/tools/claat/logging"
 	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/render"
+	"github.com/googlecodelabs/tools/claat/util"
 
 	// allow parsers to register themselves
 	_ "github.com/googlecodelabs/tools/claat/parser/gdoc"
+	_ "github.com/googlecodelabs/tools/claat/parser/ipynb"
 	_ "github.com/googlecodelabs/tools/claat/parser/md"
+	_ "github.com/googlecodelabs/tools/claat/parser/rst"
 )
 
 var (
 	version string // set by linker -X
 
 	// Flags.
+	a11yCheck    = flag.String("a11y-check", "", "Audit exported codelabs for accessibility issues, and \"warn\" or \"fail\" on violations")
+	a11yNav      = flag.Bool("a11y-nav", false, "Add a skip link, aria-current step navigation, focus management and n/p keyboard shortcuts to the html format")
 	addr         = flag.String("addr", "localhost:9090", "hostname and port to bind web server to")
+	analytics    = flag.String("analytics", "", "Analytics providers of the html format, e.g. ga4:G-XXXX, gtm:GTM-XXXX, plausible:example.com or beacon:https://example.com/events. Comma-delimited list.")
 	authToken    = flag.String("auth", "", "OAuth2 Bearer token; alternative credentials override.")
+	badges       = flag.Bool("badges", false, "Write SVG badges of duration, last update and step count to each codelab dir")
+	baseURL      = flag.String("base-url", "", "Base URL to resolve relative links and images against")
+	baseExclude  = flag.String("base-url-exclude", "", "Relative paths to leave intact with -base-url. Comma-delimited list of path.Match patterns.")
+	buildTime    = flag.String("build-time", "", "Update time of codelabs without updated metadata, instead of their source modification time, as RFC 3339 or Unix seconds; defaults to $SOURCE_DATE_EPOCH")
+	checkIDs     = flag.Bool("check-ids", false, "Fail exporting codelabs with an invalid ID or an ID used by another source")
+	checkLinks   = flag.String("check-links", "", "Request external links and images of exported codelabs, and \"warn\" or \"fail\" on broken ones")
+	chipDate     = flag.String("chip-date-layout", "", "Go time layout of date smart chips of Google Docs, e.g. \"January 2, 2006\"; dates are written as in the doc if empty")
+	chipMailto   = flag.Bool("chip-mailto", false, "Link people smart chips of Google Docs to their email address")
+	configFile   = flag.String("config", "", "Project configuration file; defaults to "+config.DefaultFile+" or claat.json files in the directory of each local source, or the current directory, and their parents")
+	drawingFmt   = flag.String("drawing-format", "png", "Image format of Google Drawings embedded in Google Docs, \"png\" or \"svg\"")
+	driveFolder  = flag.String("drive-folder", "", "Export all Google Docs in a Drive folder, including Shared Drives, given by ID or URL")
+	dryRun       = flag.Bool("dry-run", false, "Parse and render codelabs in memory without writing files, reporting statistics of each codelab; with update, list codelabs which would be updated")
 	expenv       = flag.String("e", "web", "codelab environment")
+	extLinks     = flag.Bool("external-links", false, "Open external links in a new tab, with rel=\"noopener\"")
 	extra        = flag.String("extra", "", "Additional arguments to pass to format templates. JSON object of string,string key values.")
+	feedbackWdgt = flag.String("feedback-widget", "", "Add a thumbs up/down and comment widget to each step of the html format, posting to this URL or opening issues of github:owner/repo")
+	fingerprint  = flag.Bool("fingerprint", false, "Give content-hashed names to images and to inline styles and scripts of the html format, moved to files, for long cache lifetimes")
+	fix          = flag.Bool("fix", false, "Write lint corrections back to the source files")
 	globalGA     = flag.String("ga", "UA-49880327-14", "global Google Analytics account")
+	glossary     = flag.Bool("glossary", false, "Append a step listing all glossary terms, [[term|definition]]")
+	i18nFormat   = flag.String("i18n-format", "xliff", "Catalog format of i18n extract: \"xliff\" or \"po\"; implied by an -o file extension")
+	iframeAllow  = flag.String("iframe-allowlist", "", "File with domains allowed to be embedded as iframes, one per line. Replaces the default list.")
+	imageFormats = flag.String("image-formats", "avif,webp", "Comma-separated alternate formats of images optimized with -optimize-images, converted with avifenc and cwebp")
+	importCache  = flag.String("import-cache", "", "Directory caching remote imports of codelabs; defaults to claat/imports in the user cache directory")
+	importRoot   = flag.String("import-root", "", "Directory local imports must be in, resolving relative imports of Google Docs; defaults to the current directory")
+	importTTL    = flag.Duration("import-ttl", 0, "How long cached remote imports are used without fetching them again, e.g. 24h; fetched on every export if 0")
+	jobs         = flag.Int("jobs", 0, "Maximum number of codelabs exported or updated concurrently; defaults to the number of CPUs")
+	lang         = flag.String("lang", "en", "Locale of sources with no locale suffix, exported along with locale variants like foo.fr.md")
+	linkAllow    = flag.String("link-allowlist", "", "File with hosts and URL prefixes never requested by -check-links, one per line")
+	lintFormat   = flag.String("lint-format", "text", "Report format of the lint command: \"text\" or \"sarif\"")
+	locale       = flag.String("locale", "", "Locale of the html viewer chrome, such as button labels, for codelabs which are not locale variants; English by default")
+	logFormat    = flag.String("log-format", logging.Text, "Format of log entries: \"text\" lines, or \"json\" objects, one per line, with the source of each codelab")
+	logLevel     = flag.String("log-level", "info", "Least severe log entries written: debug, info, warn or error")
 	mdParser     = flag.String("md_parser", "blackfriday", "Markdown parser to use. Accepted values: \"blackfriday\", \"goldmark\"")
+	metricsFile  = flag.String("metrics-file", "", "File to write timing and size metrics of each exported codelab to, in the Prometheus text format, e.g. for the textfile collector")
+	metricsPush  = flag.String("metrics-push", "", "URL of a Prometheus Pushgateway group to push metrics of the export to, e.g. http://localhost:9091/metrics/job/claat")
+	nbOutputs    = flag.Bool("nb-outputs", false, "Include outputs of Jupyter notebook code cells")
+	offline      = flag.Bool("offline", false, "Read remote imports from the import cache only, failing codelabs which import uncached ones")
+	onError      = flag.String("on-error", "continue", "What export does after a source fails: \"continue\" with the other sources, or \"fail-fast\" to skip them")
+	optimizeImgs = flag.Bool("optimize-images", false, "Recompress PNG and JPEG images, resize wide ones to 1x and 2x variants, and convert them to -image-formats")
 	output       = flag.String("o", ".", "output directory or '-' for stdout")
 	passMetadata = flag.String("pass_metadata", "", "Metadata fields to pass through to the output. Comma-delimited list of field names.")
+	patchFile    = flag.String("patch", "", "JSON Patch file to apply to each parsed codelab before rendering")
 	prefix       = flag.String("prefix", "https://storage.googleapis.com", "URL prefix for html format")
+	progressURL  = flag.String("progress-endpoint", "", "URL the html format posts reading progress to, in addition to storing it in the browser")
+	proxy        = flag.String("proxy", "", "URL of a proxy server for all network requests; defaults to $HTTPS_PROXY and $HTTP_PROXY, except hosts of $NO_PROXY")
+	pwa          = flag.Bool("pwa", false, "Write a web app manifest and a service worker alongside each codelab of the html format, making it installable and readable offline")
+	pwaScope     = flag.String("pwa-scope", "codelab", "What -pwa makes installable: each \"codelab\", or all codelabs of the output \"dir\" as a single app")
+	qrCode       = flag.Bool("qr", false, "Write qr.svg and qr.png, QR codes of the -site-url URL of each codelab, and show it on the title page of the pdf format")
+	readingWPM   = flag.Int("reading-wpm", parser.DefaultReadingWPM, "Words per minute estimating durations of steps with no Duration; 0 leaves them at zero")
+	refreshImpts = flag.Bool("refresh-imports", false, "Fetch all remote imports again, ignoring -import-ttl")
+	reportFile   = flag.String("report", "", "File to write a JSON report of the export to, with the status of each source")
+	reqTimeout   = flag.Duration("request-timeout", fetch.DefaultTimeout, "Time limit of each network request attempt, including reading the response; no limit if 0")
+	retries      = flag.Int("retries", 0, "Number of times network requests are retried on network errors, rate limiting and server errors; 0 uses the default of each operation")
+	retryBackoff = flag.Duration("retry-backoff", 0, "Delay before the first retry of a network request, doubled for each subsequent one; 0 uses the default of each operation")
+	review       = flag.String("review", "strip", "Handling of unresolved comments and suggestions in Google Docs: \"strip\", \"warn\" or \"fail\"")
+	revision     = flag.String("revision", "", "Source revision to stamp exported codelabs with, e.g. a git commit; \"auto\" uses the last git commit of local sources and the version of Google Docs")
+	schemaVer    = flag.String("schema", "", "JSON export schema version of the json format, \"v1\" or \"v2\"; defaults to v1 for export and v2 for the schema command")
+	serveSrc     = flag.String("src", "", "Directory of Markdown sources which serve renders on each request, without exporting")
+	serviceAcct  = flag.String("service-account", "", "Service account JSON key file for Drive access, for headless exports of Google Docs")
+	siteURL      = flag.String("site-url", "", "URL the output directory is hosted at; writes a sitemap.xml and an Atom feed.xml of the codelabs in the output directory")
+	starter      = flag.String("starter", cmd.DefaultStarter, "Starter template of codelabs created by new: "+strings.Join(cmd.Starters(), ", "))
+	strict       = flag.Bool("strict", false, "Fail exporting a source if the parser drops or ignores any of its content")
+	targetLang   = flag.String("target-lang", "", "Target locale of catalogs written by i18n extract, e.g. \"fr\"")
+	template     = flag.String("template", "", "Template file rendering the html format instead of the built-in one, unless a codelab sets its own template metadata")
+	theme        = flag.String("theme", "", "Theme bundle of claat.yaml rendering the html format, unless a codelab sets its own theme metadata")
 	tmplout      = flag.String("f", "html", "output format")
+	translation  = flag.String("translation", "", "Translated XLIFF or PO catalog of i18n extract, exported by i18n apply")
+	videoDur     = flag.Bool("video-durations", false, "Include running time of embedded Vimeo and YouTube videos in step durations")
+	youtubeKey   = flag.String("youtube-api-key", "", "YouTube Data API key used with -video-durations; YouTube videos are skipped without it")
+	utmSource    = flag.String("utm-source", "", "utm_source value to add to external links, along with utm_medium and codelab ID as utm_campaign")
 )
 
+var (
+	// filters select codelabs to update by their metadata.
+	filters = keyValues{}
+	// vars are values of {{var "key"}} references in codelab sources.
+	vars = keyValues{}
+)
+
+func init() {
+	flag.Var(filters, "filter", "Metadata of codelabs to update, e.g. category=firebase. Comma-delimited list of key=value pairs; can be repeated.")
+	flag.Var(vars, "vars", "Values of {{var \"key\"}} references in codelab sources. Comma-delimited list of key=value pairs; can be repeated.")
+}
+
 func main() {
 	log.SetFlags(0)
 	rand.Seed(time.Now().UnixNano())
 	if len(os.Args) == 1 {
-		log.Fatalf("Need subcommand. Try '-h' for options.")
+		logging.Fatalf("Need subcommand. Try '-h' for options.")
 	}
 	if os.Args[1] == "-h" || os.Args[1] == "--help" {
 		usage()
@@ -65,7 +156,64 @@ func main() {
 	}
 
 	flag.Usage = usage
-	flag.CommandLine.Parse(os.Args[2:])
+	args := os.Args[2:]
+	// "claat i18n" and "claat course" take an action before flags,
+	// and "claat new" the codelab ID
+	var action string
+	if (os.Args[1] == "i18n" || os.Args[1] == "course" || os.Args[1] == "new") && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	flag.CommandLine.Parse(args)
+	setupLogging()
+
+	// "claat doctor" reports configuration errors instead of failing on them
+	if os.Args[1] == "doctor" {
+		os.Exit(doctor())
+	}
+
+	// batch commands stop on the first interrupt, cleaning up partial output
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "course", "export", "i18n", "update":
+		ctx = interruptContext()
+	}
+
+	// local sources are configured by files of their directories
+	var srcs []string
+	switch os.Args[1] {
+	case "check-render", "export", "lint", "update":
+		srcs = flag.Args()
+	}
+	confs, err := loadConfigs(*configFile, srcs)
+	if err != nil {
+		logging.Fatalf("Error reading config: %v", err)
+	}
+	cmdline := cmdlineFlags()
+	exitCode := 0
+	for _, c := range confs {
+		if err := applyConfigFlags(c.conf.Flags, cmdline); err != nil {
+			logging.Fatalf("Error reading config: %v", err)
+		}
+		// the config may set -log-format and -log-level
+		setupLogging()
+		if code := run(ctx, action, c.conf, c.srcs); code != 0 {
+			exitCode = code
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// run runs the subcommand of os.Args with flags and configuration conf
+// of sources srcs, returning a process exit code.
+func run(ctx context.Context, action string, conf *config.Config, srcs []string) int {
+	// "claat export -" is a filter from stdin to stdout, unless -o is given
+	if os.Args[1] == "export" && flag.NArg() == 1 && flag.Arg(0) == "-" {
+		var outputSet bool
+		flag.Visit(func(f *flag.Flag) { outputSet = outputSet || f.Name == "o" })
+		if !outputSet {
+			*output = "-"
+		}
+	}
 
 	extraVars, err := ParseExtraVars(*extra)
 	if err != nil {
@@ -73,6 +221,40 @@ func main() {
 	}
 
 	pm := parsePassMetadata(*passMetadata)
+	excl := parseList(*baseExclude)
+
+	var providers []*render.Analytics
+	for _, spec := range parseList(*analytics) {
+		a, err := render.ParseAnalytics(spec)
+		if err != nil {
+			logging.Fatalf("Invalid -analytics: %v", err)
+		}
+		providers = append(providers, a)
+	}
+
+	if *feedbackWdgt != "" {
+		if err := render.ValidateFeedbackWidget(*feedbackWdgt); err != nil {
+			logging.Fatalf("Invalid -feedback-widget: %v", err)
+		}
+	}
+
+	built, err := parseBuildTime(*buildTime)
+	if err != nil {
+		logging.Fatalf("Invalid -build-time: %v", err)
+	}
+
+	var iframes []string
+	if *iframeAllow != "" {
+		if iframes, err = util.ReadLines(*iframeAllow); err != nil {
+			logging.Fatalf("Error reading iframe allowlist: %v", err)
+		}
+	}
+	var linkAllowlist []string
+	if *linkAllow != "" {
+		if linkAllowlist, err = util.ReadLines(*linkAllow); err != nil {
+			logging.Fatalf("Error reading link allowlist: %v", err)
+		}
+	}
 
 	var mdp parser.MarkdownParser
 	switch *mdParser {
@@ -81,44 +263,207 @@ func main() {
 	case "goldmark":
 		mdp = parser.Goldmark
 	default:
-		log.Fatalf("Unrecognized md_parser value %q", *mdParser)
+		logging.Fatalf("Unrecognized md_parser value %q", *mdParser)
+	}
+
+	rm, err := parser.ParseReviewMode(*review)
+	if err != nil {
+		logging.Fatalf("Unrecognized review value %q", *review)
+	}
+	if *theme != "" && conf.Themes[*theme] == nil {
+		logging.Fatalf("Unknown theme %q", *theme)
+	}
+	for l, m := range conf.Locales {
+		if err := render.RegisterLocale(l, m); err != nil {
+			logging.Fatalf("Error reading config: locale %q: %v", l, err)
+		}
+	}
+	if *locale != "" && !render.HasLocale(*locale) {
+		logging.Fatalf("Unknown locale %q; available: %s", *locale, strings.Join(render.Locales(), ", "))
+	}
+
+	httpOpts := fetch.HTTPOptions{
+		Retries: *retries,
+		Backoff: *retryBackoff,
+		Timeout: *reqTimeout,
+		Proxy:   *proxy,
+		Context: ctx,
+	}
+
+	exportOpts := cmd.CmdExportOptions{
+		A11yCheck:         *a11yCheck,
+		A11yNav:           *a11yNav,
+		Analytics:         providers,
+		AuthToken:         *authToken,
+		Badges:            *badges,
+		BaseURL:           *baseURL,
+		BaseURLExclude:    excl,
+		BuildTime:         built,
+		CheckIDs:          *checkIDs,
+		CheckLinks:        *checkLinks,
+		ChipDateLayout:    *chipDate,
+		ChipMailto:        *chipMailto,
+		Context:           ctx,
+		DefaultLang:       *lang,
+		DrawingFormat:     *drawingFmt,
+		DriveFolder:       *driveFolder,
+		DryRun:            *dryRun,
+		Expenv:            *expenv,
+		ExternalLinks:     *extLinks,
+		ExtraVars:         extraVars,
+		FeedbackWidget:    *feedbackWdgt,
+		Fingerprint:       *fingerprint,
+		GlobalGA:          *globalGA,
+		Glossary:          *glossary,
+		HTTP:              httpOpts,
+		IframeAllowlist:   iframes,
+		ImageFormats:      parseList(*imageFormats),
+		ImportCache:       *importCache,
+		ImportCredentials: conf.Credentials,
+		ImportRoot:        *importRoot,
+		ImportTTL:         *importTTL,
+		Jobs:              *jobs,
+		LinkAllowlist:     linkAllowlist,
+		Locale:            *locale,
+		MDParser:          mdp,
+		MetaRules:         conf.Metadata,
+		MetricsFile:       *metricsFile,
+		MetricsPush:       *metricsPush,
+		NotebookOutputs:   *nbOutputs,
+		Offline:           *offline,
+		OnError:           *onError,
+		OptimizeImages:    *optimizeImgs,
+		Output:            *output,
+		PassMetadata:      pm,
+		Patch:             *patchFile,
+		Prefix:            *prefix,
+		ProgressURL:       *progressURL,
+		PWA:               *pwa,
+		PWAScope:          *pwaScope,
+		QR:                *qrCode,
+		ReadingWPM:        *readingWPM,
+		RefreshImports:    *refreshImpts,
+		Report:            *reportFile,
+		Review:            rm,
+		Revision:          *revision,
+		Schema:            *schemaVer,
+		ServiceAccount:    *serviceAcct,
+		SiteURL:           *siteURL,
+		Srcs:              srcs,
+		Strict:            *strict,
+		Template:          *template,
+		Theme:             *theme,
+		Themes:            conf.Themes,
+		Tmplout:           *tmplout,
+		Transforms:        conf.Transforms,
+		UTMSource:         *utmSource,
+		Vars:              vars,
+		Version:           version,
+		VideoDurations:    *videoDur,
+		YouTubeAPIKey:     *youtubeKey,
 	}
 
 	exitCode := 0
 	switch os.Args[1] {
 	case "export":
-		exitCode = cmd.CmdExport(cmd.CmdExportOptions{
-			AuthToken:    *authToken,
-			Expenv:       *expenv,
-			ExtraVars:    extraVars,
-			GlobalGA:     *globalGA,
-			MDParser:     mdp,
-			Output:       *output,
-			PassMetadata: pm,
-			Prefix:       *prefix,
-			Srcs:         flag.Args(),
-			Tmplout:      *tmplout,
+		exitCode = cmd.CmdExport(exportOpts)
+	case "lint":
+		exitCode = cmd.CmdLint(cmd.CmdLintOptions{
+			Config:     conf.Lint,
+			Fix:        *fix,
+			Format:     *lintFormat,
+			MDParser:   mdp,
+			MetaRules:  conf.Metadata,
+			ReadingWPM: *readingWPM,
+			Srcs:       srcs,
+			Vars:       vars,
+			Version:    version,
+		})
+	case "check-render":
+		exitCode = cmd.CmdCheckRender(cmd.CmdCheckRenderOptions{
+			AuthToken:      *authToken,
+			Expenv:         *expenv,
+			ExtraVars:      extraVars,
+			MDParser:       mdp,
+			PassMetadata:   pm,
+			ServiceAccount: *serviceAcct,
+			Srcs:           srcs,
+			Vars:           vars,
+		})
+	case "course":
+		if flag.NArg() != 1 {
+			logging.Fatalf("Need a course manifest. Try '-h' for options.")
+		}
+		exitCode = cmd.CmdCourse(action, cmd.CmdCourseOptions{
+			Export:   exportOpts,
+			Manifest: flag.Arg(0),
+		})
+	case "diff":
+		if flag.NArg() != 2 {
+			logging.Fatalf("Need an old and a new codelab. Try '-h' for options.")
+		}
+		exitCode = cmd.CmdDiff(cmd.CmdDiffOptions{
+			Expenv:   *expenv,
+			MDParser: mdp,
+			New:      flag.Arg(1),
+			Old:      flag.Arg(0),
+			Vars:     vars,
 		})
+	case "i18n":
+		exitCode = cmd.CmdI18n(action, cmd.CmdI18nOptions{
+			Export:      exportOpts,
+			Format:      *i18nFormat,
+			SourceLang:  *lang,
+			TargetLang:  *targetLang,
+			Translation: *translation,
+		})
+	case "new":
+		// the codelab ID may also follow the flags
+		if action == "" && flag.NArg() == 1 {
+			action = flag.Arg(0)
+		} else if action == "" || flag.NArg() > 0 {
+			logging.Fatalf("Need a codelab ID. Try '-h' for options.")
+		}
+		exitCode = cmd.CmdNew(cmd.CmdNewOptions{
+			ID:      action,
+			Output:  *output,
+			Starter: *starter,
+		})
+	case "schema":
+		exitCode = cmd.CmdSchema(*schemaVer)
 	case "serve":
-		exitCode = cmd.CmdServe(*addr)
+		exitCode = cmd.CmdServe(cmd.CmdServeOptions{
+			Addr:   *addr,
+			Export: exportOpts,
+			Src:    *serveSrc,
+		})
 	case "update":
 		exitCode = cmd.CmdUpdate(cmd.CmdUpdateOptions{
-			AuthToken:    *authToken,
-			ExtraVars:    extraVars,
-			GlobalGA:     *globalGA,
-			MDParser:     mdp,
-			PassMetadata: pm,
-			Prefix:       *prefix,
+			AuthToken:         *authToken,
+			DryRun:            *dryRun,
+			ExtraVars:         extraVars,
+			Filters:           filters,
+			GlobalGA:          *globalGA,
+			HTTP:              httpOpts,
+			ImportCredentials: conf.Credentials,
+			ImportRoot:        *importRoot,
+			Jobs:              *jobs,
+			MDParser:          mdp,
+			PassMetadata:      pm,
+			Prefix:            *prefix,
+			ReadingWPM:        *readingWPM,
+			ServiceAccount:    *serviceAcct,
+			Srcs:              srcs,
 		})
 	case "help":
 		usage()
 	case "version":
 		fmt.Println(version)
 	default:
-		log.Fatalf("Unknown subcommand. Try '-h' for options.")
+		logging.Fatalf("Unknown subcommand. Try '-h' for options.")
 	}
 
-	os.Exit(exitCode)
+	return exitCode
 }
 
 // parsePassMetadata parses metadata fields to parse that are not explicitly handled elsewhere.
@@ -131,6 +476,220 @@ func parsePassMetadata(passMeta string) map[string]bool {
 	return fields
 }
 
+// parseBuildTime parses build time t, an RFC 3339 time or Unix seconds,
+// or the SOURCE_DATE_EPOCH environment variable if t is empty, as set by
+// reproducible build tools. The time is zero if neither is set.
+func parseBuildTime(t string) (time.Time, error) {
+	if t == "" {
+		t = os.Getenv("SOURCE_DATE_EPOCH")
+	}
+	if t == "" {
+		return time.Time{}, nil
+	}
+	if sec, err := strconv.ParseInt(t, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, t)
+}
+
+// parseList splits a comma separated list of values, dropping empty elements
+// and extraneous spaces.
+func parseList(list string) []string {
+	var res []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// keyValues is a flag.Value of key=value pairs, specified either as
+// a comma separated list or with repeated flags.
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	var pairs []string
+	for k, v := range kv {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(s string) error {
+	for _, p := range parseList(s) {
+		i := strings.Index(p, "=")
+		if i < 1 {
+			return fmt.Errorf("invalid key=value pair %q", p)
+		}
+		kv[strings.TrimSpace(p[:i])] = strings.TrimSpace(p[i+1:])
+	}
+	return nil
+}
+
+// setupLogging sets the default logger of -log-format and -log-level.
+func setupLogging() {
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		logging.Fatalf("Invalid -log-level: %v", err)
+	}
+	l, err := logging.New(nil, level, *logFormat)
+	if err != nil {
+		logging.Fatalf("Invalid -log-format: %v", err)
+	}
+	logging.SetDefault(l)
+}
+
+// interruptContext returns a context canceled on the first SIGINT
+// or SIGTERM. Signals after the first one terminate the process.
+func interruptContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ch
+		signal.Stop(ch)
+		logging.Warnf("Interrupted; cleaning up, interrupt again to quit now")
+		cancel()
+	}()
+	return ctx
+}
+
+// sourceConfig is the project configuration of sources srcs.
+type sourceConfig struct {
+	conf *config.Config
+	srcs []string
+}
+
+// loadConfigs reads project configuration of srcs from file name.
+// If name is empty, local sources are configured by files of their
+// directory and its parents, and other sources, such as Google Docs,
+// by those of the current directory, see config.Discover.
+// Sources configured by the same files are grouped, in order.
+func loadConfigs(name string, srcs []string) ([]*sourceConfig, error) {
+	if name != "" {
+		c, err := config.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		return []*sourceConfig{{conf: c, srcs: srcs}}, nil
+	}
+	if len(srcs) == 0 {
+		c, _, err := config.Discover(".")
+		if err != nil {
+			return nil, err
+		}
+		return []*sourceConfig{{conf: c}}, nil
+	}
+	var res []*sourceConfig
+	groups := make(map[string]*sourceConfig) // by names of files
+	for _, src := range srcs {
+		dir := "."
+		if fi, err := os.Stat(src); err == nil {
+			dir = src
+			if !fi.IsDir() {
+				dir = filepath.Dir(src)
+			}
+		}
+		c, files, err := config.Discover(dir)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.Join(files, "\n")
+		g := groups[key]
+		if g == nil {
+			g = &sourceConfig{conf: c}
+			groups[key] = g
+			res = append(res, g)
+		}
+		g.srcs = append(g.srcs, src)
+	}
+	return res, nil
+}
+
+// doctor runs the doctor command with the flags and configuration
+// other commands would use, without validating them first.
+func doctor() int {
+	var (
+		conf  *config.Config
+		files []string
+		err   error
+	)
+	if *configFile == "" {
+		conf, files, err = config.Discover(".")
+	} else if conf, err = config.Load(*configFile); err == nil {
+		files = []string{*configFile}
+	}
+	if err == nil {
+		err = applyConfigFlags(conf.Flags, cmdlineFlags())
+	}
+	return cmd.CmdDoctor(cmd.CmdDoctorOptions{
+		Analytics:      parseList(*analytics),
+		AuthToken:      *authToken,
+		Config:         conf,
+		ConfigErr:      err,
+		ConfigFiles:    files,
+		FeedbackWidget: *feedbackWdgt,
+		Locale:         *locale,
+		Output:         *output,
+		ServiceAccount: *serviceAcct,
+		SiteURL:        *siteURL,
+		Srcs:           flag.Args(),
+		Template:       *template,
+		Theme:          *theme,
+		Tmplout:        *tmplout,
+		Version:        version,
+	})
+}
+
+// cmdlineFlags returns the names of flags specified on the command line.
+func cmdlineFlags() map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// applyConfigFlags sets flags to their values in configuration,
+// unless they are in cmdline, i.e. specified on the command line.
+// Other flags are reset to their defaults, so that configuration
+// of a previous group of sources does not carry over.
+func applyConfigFlags(flags map[string]config.FlagValue, cmdline map[string]bool) error {
+	var err error
+	flag.VisitAll(func(f *flag.Flag) {
+		if cmdline[f.Name] || err != nil {
+			return
+		}
+		if kv, ok := f.Value.(keyValues); ok {
+			for k := range kv {
+				delete(kv, k)
+			}
+			return
+		}
+		err = f.Value.Set(f.DefValue)
+	})
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "config" || flag.Lookup(name) == nil {
+			return fmt.Errorf("flags: unknown flag %q", name)
+		}
+		if cmdline[name] {
+			continue
+		}
+		if err := flag.Set(name, string(flags[name])); err != nil {
+			return fmt.Errorf("flags: %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
 // ParseExtraVars parses extra template variables from command line.
 // extra is any additional arguments to pass to format templates. Should be formatted as JSON objects of string:string KV pairs.
 func ParseExtraVars(extra string) (map[string]string, error) {
@@ -141,7 +700,7 @@ func ParseExtraVars(extra string) (map[string]string, error) {
 	b := []byte(extra)
 	err := json.Unmarshal(b, &vars)
 	if err != nil {
-		log.Printf("Error parsing additional template data: %v", err)
+		logging.Errorf("Error parsing additional template data: %v", err)
 		return nil, err
 	}
 	return vars, nil
@@ -155,7 +714,7 @@ func usage() {
 
 const usageText = `Usage: claat <cmd> [options] src [src ...]
 
-Available commands are: export, serve, update, version.
+Available commands are: export, lint, check-render, course, diff, doctor, i18n, new, schema, serve, update, version.
 
 ## Export command
 
@@ -166,12 +725,42 @@ The following formats are built-in:
 
 - html (Polymer-based app)
 - md (Markdown)
+- md-full (high-fidelity Markdown using claat extensions for every node,
+  such as surveys, iframes and per-node environments, which the md parser
+  reads back into the same codelab; use it to migrate Google Docs to md)
 - offline (plain HTML markup for offline consumption)
+- obsidian (Markdown notes with wikilinks, one per step, for Obsidian vaults)
+- text (plain text, keeping steps, lists and code blocks)
+- gemtext (Gemini protocol text/gemini, as index.gmi)
+- docx (Word document for review; blocks are bookmarked as s<step>_b<block>
+  so comments can be mapped back to the source)
+- term (ANSI-colored text for a quick preview in a terminal, always
+  written to stdout; pipe to "less -R" for paging)
+- pdf (paginated document for printed handouts, as index.pdf; images are
+  embedded from local files, remote ones are replaced with alt text)
+- epub (e-book with a chapter per step, as index.epub; local images
+  are embedded)
+- scorm, scorm2004 (SCORM 1.2 and 2004 packages of the html format, as
+  index.zip, reporting visited steps and completion to an LMS)
+- xapi (the same package with a tincan.xml descriptor, sending xAPI
+  statements to the LRS given in launch parameters)
+- slides (reveal.js deck with a slide per step and a sub-slide per header;
+  infoboxes become speaker notes)
+- hugo, jekyll (Markdown page with front matter for static sites; with its
+  img directory, the output is a Hugo page bundle or an entry of a Jekyll
+  "codelabs" collection)
+- json (codelab as JSON, as index.json; see -schema and the schema command)
+- proto (codelab as a binary Protocol Buffers message, as index.pb; see
+  claat/schema/codelab.proto)
 
 Note that the built-in templates of the formats are not guaranteed to be stable.
 They can be found in https://github.com/googlecodelabs/tools/tree/master/claat/render.
 Please avoid using default templates in production. Use your own copies.
 
+Programs embedding claat can also add formats with render.Register,
+and template functions and partials available to all templates,
+built-in ones included, with render.RegisterFuncs and render.RegisterPartial.
+
 To use a custom format, specify a local file path to a Go template file.
 More info on Go templates: https://golang.org/pkg/text/template/.
 
@@ -180,16 +769,515 @@ Source formats currently supported are:
 
 - Google Doc (Codelab Format, go/codelab-guide)
 - Markdown
+- Jupyter notebook (.ipynb), with codelab metadata in a "claat"
+  object of notebook metadata; -nb-outputs includes cell outputs
+- reStructuredText (.rst), with codelab metadata in a field list
+  before the first step section, e.g. ":id: my-codelab"; tables and
+  directives other than admonitions, code blocks and images are dropped
 
 When 'src' is a Google Doc, it must be specified as a doc ID,
 omitting https://docs.google.com/... part.
 
+When 'src' is -, a Markdown codelab is read from stdin and, unless -o
+is given, rendered to stdout without metadata or images, so that claat
+can be used as a filter in pipelines, e.g.:
+
+  cat codelab.md | claat export -f json - | jq .title
+
+It cannot be combined with other sources. Relative imports and code
+snippets are resolved against -import-root.
+Formats writing several files, offline and obsidian, cannot be written
+to stdout.
+
+Google Docs are fetched with the Drive API. Unless -auth provides
+an access token, claat asks for permissions in a browser on first use
+and stores the credentials in ~/.config/claat. CI systems, and machines
+without a browser, can use a service account instead: pass its JSON
+key file with -service-account and share the docs with the service
+account email.
+
+With -drive-folder, all Google Docs of a Drive folder, including folders
+of Shared Drives, are exported at once, in addition to any 'src' given.
+Subfolders are not searched. Metadata of the exported codelabs is also
+combined in a single codelabs.json file in the output directory.
+
+Google Drawings and charts of Google Sheets embedded in Google Docs
+are downloaded along with other images, rendered by their export URL:
+drawings in the -drawing-format, and charts as PNG. Drawings with no
+alt text get their title as alt text.
+
+Smart chips of Google Docs are exported as plain links and text: people
+are written as their names, or links to their email address with
+-chip-mailto, files as links to them, and dates as in the doc, or in the
+Go time layout given with -chip-date-layout, e.g. "2 Jan 2006". Since
+date chips are exported as text, the layout applies to all dates of the
+doc written like date chips, such as "Mar 5, 2024" or "2024-03-05".
+
+Local imports, such as <<../shared/setup.md>> in Markdown, are resolved
+relative to the importing file, and [[import ./fragments/setup.md]]
+instructions of Google Docs relative to -import-root. Imported files
+must be in -import-root, the current directory by default, even through
+symbolic links, so that sources cannot read other files. The same goes
+for local code snippets and question banks, which cannot be absolute
+paths.
+
+Remote imports, such as [[import url]] instructions of Google Docs
+and <<url>> imports of Markdown, are cached in the -import-cache
+directory. Cached imports younger than -import-ttl are not fetched
+again, unless -refresh-imports is given; by default they are fetched
+on every export. With -offline, imports are never fetched: cached
+copies are used whatever their age, and codelabs importing uncached
+ones fail with an error naming the import.
+
+Network requests, such as exports of Google Docs, imports, images and
+-check-links requests, are retried on network errors, rate limiting and
+server errors, with exponential backoff. Use -retries and -retry-backoff
+to ride out flaky networks during long batch exports, and
+-request-timeout to give up on stalled requests, which are then retried;
+attempts time out after a minute by default.
+Requests go through the proxy of the HTTPS_PROXY and HTTP_PROXY
+environment variables, if set, or the -proxy URL.
+
+An interrupt (Ctrl-C) stops an export: codelabs not started yet are
+skipped, and those being exported stop, removing their partially
+written directories. A second interrupt quits at once.
+
+Log entries have a level: debug, info, warn or error. Use -log-level
+to leave out the less severe ones, e.g. "warn" to only see problems, or
+"debug" to also see each network request and how long exports take.
+With -log-format json, each entry is a JSON object on a line of its own,
+with "time", "level" and "msg" keys, and the "src" of the codelab it
+is about, if any, so that CI systems can collect warnings per codelab.
+Network requests slower than 10s are logged as warnings.
+
+Relative links and image sources are kept as is, unless -base-url
+is given, in which case they are resolved against the base URL.
+Use -base-url-exclude to keep some relative paths intact, e.g. "img/*"
+for assets copied alongside the codelab.
+
+With -patch option, JSON Patch (RFC 6902) operations are applied
+to each parsed codelab before rendering. Paths point into the codelab
+data model, e.g. "/steps/0/content/nodes/1/src", which allows
+customizing the output without maintaining a copy of the source.
+
+Iframes can only embed pages hosted on a set of allowed domains.
+Use -iframe-allowlist to provide your own set, stored in a file
+with one domain per line. Lines starting with # are ignored.
+A file with no domains allows no iframes at all.
+
+Steps with no Duration instruction get a duration estimated from their
+length: words read at -reading-wpm words per minute, 200 by default,
+with each line of code counting as 10 words, rounded up to whole minutes.
+Use -reading-wpm 0 to leave such steps at zero minutes.
+
+With -check-links, every external link and image URL is requested,
+concurrently and at most once per export, retrying temporary failures.
+URLs responding with a 4xx or 5xx status code, or not at all, are logged
+with -check-links warn, and fail the export of the codelab with
+-check-links fail, so published codelabs don't ship dead links.
+Use -link-allowlist to skip URLs, e.g. of private or rate-limited sites,
+listed in a file with one host or URL prefix per line.
+
+With -a11y-check, each exported codelab is audited for accessibility
+violations: images with no alt text, headings which are empty or skip
+a level, iframes with no title, and theme colors with a contrast ratio
+below the WCAG AA 4.5:1 against the text or background they are shown
+with. Violations are logged at their source line and column, and fail
+the export of the codelab with -a11y-check fail. Iframes of Markdown
+sources are titled by the image title, e.g. ![URL](img.png "Demo").
+
+With -a11y-nav, the html format adds a "Skip to content" link, marks
+the current step of the navigation drawer with aria-current, moves
+focus to each step as it is selected, and selects the next and previous
+steps with the n and p keys. It is off by default so that existing
+exports and custom styles are unaffected.
+
+With -analytics, or the "analytics providers" metadata of a codelab,
+the html format sends events to analytics providers besides the Google
+Analytics account: step_view when a step is shown, step_duration with
+the seconds spent on a step, and codelab_complete when the last step is
+shown. Providers are given as provider:id, one of ga4:G-XXXX for Google
+Analytics 4, gtm:GTM-XXXX for Google Tag Manager, plausible:example.com
+for Plausible, and beacon:https://example.com/events to post the events
+as JSON to a URL of your own.
+
+With -feedback-widget, each step of the html format ends with a "Was
+this step helpful?" widget of thumbs up and down buttons and an optional
+comment. If the value is a URL, each vote is posted to it on click as
+a JSON object with codelab, step, title, vote ("up" or "down") and url
+fields, and again with a comment field if one is sent. If it is
+github:owner/repo, sending the vote and comment opens a pre-filled issue
+of that GitHub repository, labeled feedback, instead.
+
+With -site-url, the URL the output directory is hosted at, export also
+writes sitemap.xml of the codelabs in the output directory, exported
+now or before, and feed.xml, an Atom feed of the 20 most recently
+updated ones, to the output directory. Hidden codelabs are left out. Codelabs are updated at the modification time of
+their source, or at the date of their "updated" metadata, and the feed
+has the date of their "published" metadata, if any. Dates are RFC 3339
+times or YYYY-MM-DD.
+Link previews of the html format then give the published URL of each
+codelab, og:url, and its hero image as an absolute URL; a local hero
+image is left out of them without -site-url.
+
+The html format records the reading progress of each codelab in the
+browser's localStorage: steps the reader has moved past or scrolled to
+the end of, the current step, and the scroll position of each step. The
+step and its position are restored on the next visit, unless the URL
+selects a step. With -progress-endpoint, progress is also posted as JSON
+to that URL, e.g. to sync it across devices. The index pages of claat
+serve and claat course show the completion percentage of started codelabs.
+
+Codelabs are exported to directories named after their IDs, so sources
+with the same ID overwrite each other's output. With -check-ids, an ID
+must be a slug of lowercase letters and digits separated by - or _,
+and a codelab fails to export if another source of the same invocation
+has already exported a codelab with its ID. Locale variants like
+foo.fr.md get IDs of their own and don't collide with foo.md.
+
+Codelab nodes can be dropped or rewritten on export with transform
+rules listed in a claat.yaml project configuration file, e.g.:
+
+  transforms:
+  - {type: youtube, action: drop}
+  - {type: iframe, action: link}
+  - {type: image, action: rewrite-host, from: a.example.com, to: b.example.com}
+
+Node types are button, code, grid, header, iframe, image, infobox, list,
+survey, term, text, url and youtube. Actions are drop, link (youtube,
+iframe and image) and rewrite-host (url, image and iframe).
+Use -config to read a different file.
+
+The metadata section of the configuration file constrains metadata
+of every parsed codelab, keyed by metadata key as named in sources.
+A key can be required, limited to allowed values, compared ignoring
+case, or to values matching a regular expression pattern. Values of
+list keys, such as categories, are checked one by one. For example:
+
+  metadata:
+    categories: {required: true}
+    status: {required: true, allowed: [draft, published, hidden]}
+    feedback link: {required: true, pattern: '^https://github\.com/'}
+
+Codelabs violating a rule fail to parse, and so to export or lint.
+
+Markdown sources named with a locale suffix, e.g. foo.fr.md and foo.ja.md,
+are exported as locale variants of foo.md under locale-suffixed IDs,
+such as "my-codelab-fr", and cross-linked with a language switcher
+in the html format, listing languages by their own names. Each page also
+links to all variants with hreflang alternates for search engines, foo.md
+being the x-default. Variants must share the same codelab ID.
+The locale of foo.md itself is specified with -lang.
+
+With -optimize-images, PNG and JPEG images of each codelab are
+recompressed, and images wider than 800 pixels are resized to a 1x
+variant, which replaces the original img/abc.png, and a 2x variant,
+img/abc@2x.png. Variants are
+converted to the -image-formats, AVIF and WebP by default, with the
+avifenc and cwebp tools; formats whose tool is not installed are skipped
+with a warning. The html format references variants with <picture>
+and srcset, so that browsers download the smallest image they support. Images
+of more than 50 megapixels, and JPEG images rotated by an EXIF
+orientation tag, are left intact.
+
+With -fingerprint, files of each codelab are named after a hash of their
+content, so that published codelabs can be served with long cache
+lifetimes, such as Cache-Control: max-age=31536000, immutable, without
+serving stale content: images are renamed if their content changed since
+they were copied, e.g. by -optimize-images, and inline styles and scripts
+of the html format are moved to files such as 1a2b3c4d5e6f7a8b.css.
+Pages and codelab.json are rendered with the new names; only the pages
+themselves keep their names, and should be served with short lifetimes.
+Images and such files left by previous exports of the codelab are removed.
+
+With -pwa, codelabs of the html format are installable web apps which
+are fully readable offline, e.g. at workshops with poor connectivity:
+manifest.webmanifest, icon.svg and a service worker, sw.js, are written
+to each codelab directory. Once a codelab is opened online, the service
+worker caches its pages, images, styles and scripts, including those
+loaded from -prefix and other origins, and serves them from the cache
+first. Each export changes the cache name, so that installed codelabs
+are updated once online again. Service workers require the export to be
+served over HTTPS or from localhost, e.g. with the serve command.
+Web fonts of the pages, such as Roboto and Material Icons, are cached
+along with their stylesheets. With -pwa-scope dir, all codelabs exported
+to -o are a single app instead, e.g. for a workshop: its manifest, service
+worker and an index.html page listing the codelabs are written to -o,
+and once any page is opened online, every codelab is readable offline.
+
+With -qr, a QR code of the published URL of each codelab under -site-url,
+which is required, is written to its directory as qr.svg and qr.png,
+e.g. for instructors to project on the start slide of a workshop.
+The pdf format also prints it on its title page, so that printed
+handouts link to the published codelab.
+
+With -badges, small SVG badges are written to each codelab directory:
+badge-duration.svg, badge-updated.svg and badge-steps.svg, which README
+files and catalogs can embed from the published export.
+
+A single source can produce multiple codelabs with variables:
+references like {{var "region"}} in the source are replaced with values
+specified with -vars, e.g. -vars region=us-central1,version=2.0.
+Exporting fails if a referenced variable is not specified.
+
+Multiple sources are exported concurrently, up to -jobs at a time,
+the number of CPUs by default. Results are reported in order of the
+sources, followed by the number of failed exports, if any.
+The exit code is non-zero if any source fails. By default, the other
+sources are still exported; with -on-error fail-fast, sources not started
+yet are skipped after the first failure.
+
+With -report file, a JSON report is also written to the file, listing
+each source with its status, "ok", "error" or "skipped", the exported
+codelab ID, warnings such as broken links, and the error message,
+with line and column in the source where available.
+
+To monitor batch exports, -metrics-file writes timing and size metrics
+of each codelab in the Prometheus text format, e.g. to a .prom file of
+the node exporter textfile collector, and -metrics-push pushes them to
+a Pushgateway group URL. Metrics are labeled with the source and ID of
+each codelab: claat_export_success, claat_export_duration_seconds by
+stage, "fetch", "parse", "render" and "total", and
+claat_export_output_bytes, the size of files written for the codelab.
+
+With -dry-run, codelabs are parsed and rendered in memory, validating
+sources and templates without writing any file or fetching images.
+Each codelab is reported with its number of steps, words of text,
+images, code blocks and external links, and its total duration,
+followed by totals of all codelabs; -report includes the same
+statistics, so that CI can check and comment on changes without
+producing artifacts.
+
+Exports are reproducible: identical sources and options are exported
+to byte-identical files, with metadata and manifests in a stable order.
+The update time of a codelab is its updated metadata, if any, or else
+the modification time of its source, which differs between checkouts
+of the same files. To have CI builds produce identical output, pass
+-build-time, an RFC 3339 time or Unix seconds, such as the time of
+the last commit:
+
+  claat export -build-time 2019-05-01T10:00:00Z codelab.md
+
+The SOURCE_DATE_EPOCH environment variable is used if -build-time
+is not set.
+
+With -revision, exported codelabs are stamped with the revision of their
+source, shown with their last update date at the end of the last step
+of the html format, and stored in codelab.json along with the update
+time, so that caches can tell exports apart. Pass a revision such as
+a git commit hash, or "auto" for the last git commit of each local
+source and the version of each Google Doc. The revision metadata of
+a codelab takes precedence.
+
+Parsers warn about source content they drop or ignore, such as text
+before the first step, unsupported HTML elements or invalid image
+attributes. With -strict, any such warning fails exporting the source,
+with the location of the first dropped content, so that publishing
+pipelines can be sure nothing vanishes between source and output.
+Review artifacts stripped with -review warn also count as warnings.
+
+The html format can be rendered with a custom template to brand codelab
+pages with their own header, footer, fonts or navigation, without
+rebuilding claat. Pass the template file with -template, or set it per
+codelab with the "template" metadata, an .html file relative to the
+directory of a local source, and within it:
+
+  template: templates/codelab.html
+
+The metadata takes precedence over -template. It is ignored, with a
+warning, for remote sources and paths outside the source directory.
+The template is executed
+like the built-in html one, see claat/render/template.html, with its
+functions and context, e.g. {{.Meta.Title}} and {{range .Steps}}.
+Files ending with .html are parsed as html/template, others as
+text/template. Other formats always use their built-in templates.
+
+Codelabs can refer to codelabs to take before and after them with the
+"prerequisites" and "related" metadata, comma-separated lists of codelab
+IDs or URLs:
+
+  prerequisites: go-basics, https://example.com/setup
+  related: go-testing
+
+The html format lists them at the start of the first step and the end
+of the last one, linking IDs to sibling codelab directories. They are
+also exported in codelab.json and the v2 JSON schema, so that a site
+index can build learning paths.
+
 Instead of writing to an output directory, use "-o -" to specify
 stdout. In this case images and metadata are not exported.
 When writing to a directory, existing files will be overwritten.
 
 The program exits with non-zero code if at least one src could not be exported.
 
+## Lint command
+
+Lint checks one or more local Markdown 'src' files, or directories
+of them, for structural issues which make the parser drop or misplace
+content, and parses each codelab to check its steps. The rules are:
+
+- heading-level: a subheading (H3 and deeper) before the first
+  step heading (H2), or skipped heading levels, e.g. H4 after H2
+- fence-blank-line: a fenced code block not preceded by a blank line
+- placeholder: an image still pointing to a placeholder
+- missing-duration: a step with no duration, or one estimated from its
+  length with -reading-wpm
+- long-step: a step longer than max_step_minutes, 20 by default
+- broken-anchor: a link to a "#N" step anchor that does not exist
+- missing-alt: an image without alt text
+- invalid-id: a codelab ID which is not a slug of lowercase letters
+  and digits separated by - or _
+- duplicate-id: a codelab ID used by another codelab among the srcs;
+  locale variants like foo.fr.md may share the ID of foo.md
+- dropped-content: content the parser drops or ignores, such as
+  text before the first step or unsupported HTML elements
+
+Rules are configured in the lint section of the project configuration
+file, e.g.:
+
+    lint:
+      disable: [missing-alt]
+      max_step_minutes: 30
+
+Each issue is reported as file:line: message [rule].
+With -lint-format sarif, a SARIF 2.1.0 log is written to stdout instead,
+for code scanning annotations in CI.
+With -fix option, the source text issues are corrected and the files
+are rewritten in place.
+
+The program exits with non-zero code if at least one issue
+remains unfixed or a src could not be read or parsed.
+
+## Check-render command
+
+Check-render parses each 'src' like export does and renders the codelab
+to every known format, html, md, text, docx and the rest, in memory.
+Each output is checked for content of the codelab:
+
+- step titles
+- absolute link URLs
+- the first line of code blocks
+- headers and images
+
+Content missing from a format is reported as src: format: message,
+catching renderer-specific losses before publishing.
+Use -e to check the output of a specific environment.
+
+The program exits with non-zero code if at least one format
+misses content or a src could not be processed.
+
+## Course command
+
+Course exports the codelabs of a course, a learning path taking them
+in order, and builds its landing page:
+
+  claat course build [export options] course.yaml
+
+The course manifest names the course and lists its codelab sources,
+local files relative to the manifest, Google Doc IDs or URLs:
+
+  id: go-course
+  title: Go from zero
+  summary: Write, test and ship your first Go program.
+  codelabs:
+    - go-basics.md
+    - go-testing.md
+
+Each codelab is exported like export does, with links to the course and
+to the previous and next codelabs appended to its last step. The landing
+page, index.html of the course ID directory next to the codelabs, lists
+them in order with their durations and when each starts into the course.
+
+## Diff command
+
+Diff compares two versions of a codelab step by step, for instance
+to review a regenerated export of a Google Doc before publishing it:
+
+  claat diff [-e env] old.md new.md
+  claat diff old/index.json new/index.json
+
+Versions are local Markdown sources or exports of the json format in
+the v2 schema, with a .json extension (-f json -schema v2). Steps are
+matched by title. Diff prints changed metadata, steps added, removed
+and renamed, that is with a new title but mostly the same content,
+duration changes, and content nodes added, removed and changed within
+steps. The updated time is not compared. With -e, only the content of
+the env environment is compared.
+
+Like diff(1), the program exits with code 0 if the versions are
+the same, 1 if they differ and 2 if one could not be read.
+
+## Doctor command
+
+Doctor diagnoses the environment and sources of claat, for instance
+before a workshop, and prints an actionable fix for each problem found:
+
+  claat doctor [export options] [src ...]
+
+It checks the claat and Go versions, the configuration, Google Drive
+credentials cached by a previous authorization or given with -auth or
+-service-account, the -f format and -template file, the theme, locale
+and other options, and whether the -o directory can be written to.
+Each 'src', or the current directory if none, is checked for Markdown
+sources which fail to parse, have an invalid ID, or refer to images
+or templates not found next to them. Checks are made offline; use lint
+to check the content of the sources.
+
+The program exits with non-zero code if a problem was found.
+Warnings, such as a development build, don't fail.
+
+## I18n command
+
+I18n hands codelab text to translators as XLIFF 1.2 or PO catalogs,
+which they can work on without touching the source structure:
+
+  claat i18n extract [-o dir|file] [-target-lang fr] src [src ...]
+  claat i18n apply -translation file [export options] src [src ...]
+
+Extract writes all translatable text of each 'src', such as titles,
+paragraphs, list items, image alternative text and survey questions,
+with IDs derived from the text, which are stable across edits of other
+parts of the source. Each paragraph or list item is a single unit,
+in which links and bold or italic text are wrapped in placeholders such
+as <1>...</1>, and inline code and images replaced with ones such as <2/>;
+translations may move placeholders, but must keep each of them.
+Inline code and code blocks are not extracted.
+With -o dir, the default, a catalog named after the codelab ID is written
+for each src; with a file ending in .xlf, .xliff, .po or .pot, or
+"-o -" for stdout, a single catalog holds all sources. The format of
+catalogs in a directory or stdout is set with -i18n-format.
+Sources are assumed to be in -lang, and -target-lang is stored as
+the target language of the catalogs, if set.
+
+Apply exports each 'src' like export does, with its text replaced by
+the translations of the catalog, as a locale variant of the target
+language, e.g. "my-codelab-fr". Text which is not translated, or whose
+source changed since the extraction, is kept and reported as a warning.
+
+## New command
+
+New creates a codelab to start writing from, a known-good structure
+of the Markdown format:
+
+  claat new [-starter basic|gcp|web] [-o dir] my-codelab
+
+The codelab directory, named after the codelab ID, is created in the -o
+directory, with a Markdown source of the same name and an img directory
+of assets. The source has valid metadata, timed steps, info boxes,
+a survey, a download button and a hero image, and passes lint.
+The starter template is given with -starter: basic, the default,
+gcp for Google Cloud codelabs with project setup and clean-up steps,
+or web for web apps. An existing directory is never overwritten.
+
+## Schema command
+
+Schema prints the JSON Schema definition of a json export schema version,
+given with -schema, or of the latest version by default.
+Version v2 export is stable: fields and node types are only ever added,
+so consumers should ignore unknown fields and skip unknown node types.
+Version v1 is the codelab metadata of codelab.json files
+and has no definition.
+
 ## Serve command
 
 Serve provides a simple web server for viewing exported codelabs.
@@ -198,9 +1286,26 @@ Clicking on a directory representing an exported codelab will load
 all the required dependencies and render the generated codelab as
 it would appear in production.
 
+Unless the directory has an index.html file, the root page lists all
+codelabs with their categories, duration, status and tags, along with
+a search box and category and status filters, approximating the
+production codelabs index.
+
 The serve command takes a -addr host:port option, to specify the
 desired hostname or IP address and port number to bind to.
 
+Given local 'src' files, serve runs in watch mode instead: the sources
+are exported to the -o directory like export does, using the same options,
+and served from there. The sources and the files of their directories,
+such as images, are watched for changes. A changed codelab is re-exported
+on the fly and its pages open in a browser reload automatically.
+
+With -src dir, serve skips exporting altogether: Markdown sources found
+in dir, recursively, are parsed and rendered in the -f format on each
+request, and cached until modified. The root page lists the codelabs,
+each served at /<codelab id>/ with files such as images read from
+the directory of its source.
+
 ## Update command
 
 Update scans one or more 'src' local directories for codelab.json metadata
@@ -217,12 +1322,104 @@ In the latter case, where codelab ID has changed, the new directory
 will be placed alongside the old one. In other words, it will have the same ancestor
 as the old one.
 
+Codelabs are updated concurrently, up to -jobs at a time, the number
+of CPUs by default, and reported in order of their directories.
+
+With -filter, only codelabs whose stored metadata match all of the
+given key=value pairs are updated, e.g. -filter category=firebase
+or -filter status=draft. Values are compared case-insensitively; known
+keys are category, id, lang, status, tag and theme.
+
+With -dry-run, codelabs which would be updated are listed, along with
+their source, without fetching sources or writing any file.
+
 While -prefix and -ga can override existing codelab metadata, the other
 arguments have no effect during update.
 
 The program does not follow symbolic links and exits with non-zero code
 if no metadata found or at least one src could not be updated.
 
+## Configuration
+
+Project configuration is read from claat.yaml, or claat.json, files
+in the directory of each local source and its parent directories, up to
+one with "root: true". Other sources, such as Google Docs, use those of
+the current directory. Configuration of nested directories overrides
+that of outer ones, and transforms of all files are applied, outermost
+first. Sources of directories configured differently are processed in
+separate runs of the command, in order. Use -config to read a single
+file for all sources instead.
+
+The flags section specifies default values of any of the flags below,
+which are used unless the flag is specified on the command line.
+Lists are joined with commas. For example:
+
+  root: true
+  flags:
+    f: md
+    o: build
+    ga: UA-12345-6
+    prefix: https://example.com/codelabs
+    extra: '{"env": "prod"}'
+    pass_metadata: [team, product]
+
+Relative paths in values of flags naming files or directories, such
+as -o, -template or -report, are relative to the configuration file.
+
+The themes section defines named theme bundles of the html format, so that
+codelabs of different products get distinct looks. A codelab uses the
+bundle named by its "theme" metadata, or -theme otherwise. Colors are
+emitted as --claat-color-<role> CSS variables, "primary" coloring the
+title bar and "link" the links; the logo and fonts as --claat-logo,
+--claat-font and --claat-code-font, for custom templates to use too:
+
+  themes:
+    cloud:
+      colors:
+        primary: "#1a73e8"
+        link: "#174ea6"
+      logo: https://example.com/cloud.svg
+      font: "'Google Sans', Roboto, sans-serif"
+      code_font: "'Roboto Mono', monospace"
+      font_url: https://fonts.googleapis.com/css?family=Google+Sans|Roboto+Mono
+
+The viewer chrome of the html format, such as button labels, the time
+remaining and feedback cards, is in the locale of locale variants of a
+codelab, or -locale otherwise, falling back to the base language, e.g.
+"pt" for "pt-BR", and then English. Built-in locales are ar, de, en,
+es, fa, fr, he, it, ja, ko, pt and zh. Pages of right-to-left locales,
+such as ar, fa and he, are rendered with dir="rtl" and a mirrored layout.
+The locales section adds locales or replaces their messages:
+
+  locales:
+    nl:
+      back: Terug
+      next: Volgende
+      done: Klaar
+      minutes-remaining: "Nog {n} min"
+      copy: Kopiëren
+
+The credentials section authenticates remote imports of private hosts,
+including Google Cloud Storage gs:// URLs, which are fetched from
+storage.googleapis.com. The header, Authorization by default, is set on
+requests of URLs under the prefix; the longest matching prefix wins.
+$VAR and ${VAR} in values are replaced with environment variables,
+so that tokens are not stored in the file:
+
+  credentials:
+    - prefix: https://git.example.com/docs/
+      value: Bearer ${GIT_TOKEN}
+    - prefix: gs://my-bucket/
+      value: Bearer ${GCS_TOKEN}
+    - prefix: https://wiki.example.com/
+      header: X-Api-Key
+      value: $WIKI_KEY
+
+Without a matching credential, imports from raw.githubusercontent.com
+use the GITHUB_TOKEN environment variable, and those from Cloud Storage
+use GOOGLE_OAUTH_ACCESS_TOKEN, e.g. from "gcloud auth print-access-token",
+if set.
+
 ## Flags
 
 `
@@ -0,0 +1,207 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics records timing and size metrics of codelab exports,
+// so that teams running batch exports of many codelabs can monitor
+// regressions, e.g. with Prometheus.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Export are the metrics of exporting one codelab.
+type Export struct {
+	Src string // source of the codelab
+	ID  string // codelab ID, empty if the export failed before parsing
+	Err error  // why the export failed, nil if it succeeded
+
+	Fetch  time.Duration // fetching the source, its imports and images
+	Parse  time.Duration // parsing the source
+	Render time.Duration // rendering the output format
+	Total  time.Duration // the whole export
+
+	Bytes int64 // size of files written for the codelab, zero if written to stdout
+}
+
+// Recorder records metrics of exports. Codelabs are exported
+// concurrently, so Record must be safe for concurrent use.
+type Recorder interface {
+	Record(e *Export)
+}
+
+// RecorderFunc is a function recording metrics of exports.
+type RecorderFunc func(e *Export)
+
+// Record calls f(e).
+func (f RecorderFunc) Record(e *Export) {
+	f(e)
+}
+
+// Multi returns a recorder recording exports with each of rs.
+// Nil recorders are ignored.
+func Multi(rs ...Recorder) Recorder {
+	var m multi
+	for _, r := range rs {
+		if r != nil {
+			m = append(m, r)
+		}
+	}
+	if len(m) == 1 {
+		return m[0]
+	}
+	return m
+}
+
+type multi []Recorder
+
+func (m multi) Record(e *Export) {
+	for _, r := range m {
+		r.Record(e)
+	}
+}
+
+// Prometheus records the last export of each source and writes their
+// metrics in the Prometheus text exposition format, for the textfile
+// collector of the node exporter or a Pushgateway.
+// The zero value is ready to use.
+type Prometheus struct {
+	mu      sync.Mutex
+	exports map[string]*Export // keyed by source
+}
+
+// Record implements Recorder.
+func (p *Prometheus) Record(e *Export) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exports == nil {
+		p.exports = make(map[string]*Export)
+	}
+	c := *e
+	p.exports[e.Src] = &c
+}
+
+// prometheusContentType is the media type of the text exposition format.
+const prometheusContentType = "text/plain; version=0.0.4"
+
+// WriteTo writes metrics of the recorded exports to w, ordered by source.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	exports := make([]*Export, 0, len(p.exports))
+	for _, e := range p.exports {
+		exports = append(exports, e)
+	}
+	p.mu.Unlock()
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Src < exports[j].Src })
+
+	var b bytes.Buffer
+	metric := func(name, typ, help string, value func(e *Export) []sample) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, e := range exports {
+			for _, s := range value(e) {
+				fmt.Fprintf(&b, "%s{src=%s,codelab=%s%s} %s\n", name, quote(e.Src), quote(e.ID), s.labels, strconv.FormatFloat(s.value, 'g', -1, 64))
+			}
+		}
+	}
+	metric("claat_export_success", "gauge", "Whether the last export of a codelab succeeded.", func(e *Export) []sample {
+		if e.Err != nil {
+			return []sample{{value: 0}}
+		}
+		return []sample{{value: 1}}
+	})
+	metric("claat_export_duration_seconds", "gauge", "Time spent exporting a codelab, by stage.", func(e *Export) []sample {
+		return []sample{
+			{",stage=\"fetch\"", e.Fetch.Seconds()},
+			{",stage=\"parse\"", e.Parse.Seconds()},
+			{",stage=\"render\"", e.Render.Seconds()},
+			{",stage=\"total\"", e.Total.Seconds()},
+		}
+	})
+	metric("claat_export_output_bytes", "gauge", "Size of files written for a codelab.", func(e *Export) []sample {
+		return []sample{{value: float64(e.Bytes)}}
+	})
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+type sample struct {
+	labels string // extra labels, each starting with a comma
+	value  float64
+}
+
+// quote returns s as a quoted label value.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// WriteFile writes metrics of the recorded exports to file name.
+// The file is replaced at once, so that a textfile collector never
+// reads it partially written. Its name must end in ".prom" to be
+// read by the node exporter.
+func (p *Prometheus) WriteFile(name string) error {
+	f, err := ioutil.TempFile(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := p.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// Push replaces the metrics of the Pushgateway group at url,
+// e.g. "http://pushgateway:9091/metrics/job/claat", with metrics of the
+// recorded exports. The default client is used if client is nil.
+func (p *Prometheus) Push(client *http.Client, url string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var b bytes.Buffer
+	p.WriteTo(&b)
+	req, err := http.NewRequest(http.MethodPut, url, &b)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", prometheusContentType)
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1<<10))
+		return fmt.Errorf("push %s: %s; %s", url, res.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
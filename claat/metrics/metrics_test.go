@@ -0,0 +1,141 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPrometheus() *Prometheus {
+	p := &Prometheus{}
+	p.Record(&Export{Src: "b.md", Err: errors.New("not found"), Fetch: 250 * time.Millisecond, Total: 250 * time.Millisecond})
+	p.Record(&Export{
+		Src:    `a "1".md`,
+		ID:     "a",
+		Fetch:  time.Second,
+		Parse:  20 * time.Millisecond,
+		Render: 30 * time.Millisecond,
+		Total:  1050 * time.Millisecond,
+		Bytes:  1234,
+	})
+	return p
+}
+
+const wantMetrics = `# HELP claat_export_success Whether the last export of a codelab succeeded.
+# TYPE claat_export_success gauge
+claat_export_success{src="a \"1\".md",codelab="a"} 1
+claat_export_success{src="b.md",codelab=""} 0
+# HELP claat_export_duration_seconds Time spent exporting a codelab, by stage.
+# TYPE claat_export_duration_seconds gauge
+claat_export_duration_seconds{src="a \"1\".md",codelab="a",stage="fetch"} 1
+claat_export_duration_seconds{src="a \"1\".md",codelab="a",stage="parse"} 0.02
+claat_export_duration_seconds{src="a \"1\".md",codelab="a",stage="render"} 0.03
+claat_export_duration_seconds{src="a \"1\".md",codelab="a",stage="total"} 1.05
+claat_export_duration_seconds{src="b.md",codelab="",stage="fetch"} 0.25
+claat_export_duration_seconds{src="b.md",codelab="",stage="parse"} 0
+claat_export_duration_seconds{src="b.md",codelab="",stage="render"} 0
+claat_export_duration_seconds{src="b.md",codelab="",stage="total"} 0.25
+# HELP claat_export_output_bytes Size of files written for a codelab.
+# TYPE claat_export_output_bytes gauge
+claat_export_output_bytes{src="a \"1\".md",codelab="a"} 1234
+claat_export_output_bytes{src="b.md",codelab=""} 0
+`
+
+func TestPrometheusWriteTo(t *testing.T) {
+	var b bytes.Buffer
+	if _, err := testPrometheus().WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != wantMetrics {
+		t.Errorf("WriteTo:\n%s\nwant:\n%s", b.String(), wantMetrics)
+	}
+}
+
+func TestPrometheusWriteFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "TestPrometheusWriteFile-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "claat.prom")
+	if err := testPrometheus().WriteFile(name); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != wantMetrics {
+		t.Errorf("file:\n%s\nwant:\n%s", b, wantMetrics)
+	}
+	if files, _ := ioutil.ReadDir(tmp); len(files) != 1 {
+		t.Errorf("%d files in %s; want only claat.prom", len(files), tmp)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestPrometheusPush(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	status := http.StatusOK
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r
+		body, _ = ioutil.ReadAll(r.Body)
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: ioutil.NopCloser(strings.NewReader("bad"))}, nil
+	})}
+	const url = "http://pushgateway:9091/metrics/job/claat"
+	if err := testPrometheus().Push(client, url); err != nil {
+		t.Fatal(err)
+	}
+	if got.Method != http.MethodPut || got.URL.String() != url {
+		t.Errorf("request %s %s; want PUT %s", got.Method, got.URL, url)
+	}
+	if ct := got.Header.Get("Content-Type"); ct != prometheusContentType {
+		t.Errorf("Content-Type = %q; want %q", ct, prometheusContentType)
+	}
+	if string(body) != wantMetrics {
+		t.Errorf("body:\n%s\nwant:\n%s", body, wantMetrics)
+	}
+
+	status = http.StatusBadRequest
+	if err := testPrometheus().Push(client, url); err == nil {
+		t.Error("Push = nil error; want an error of status 400")
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var n int
+	r := RecorderFunc(func(e *Export) { n++ })
+	Multi(nil, r, r).Record(&Export{})
+	if n != 2 {
+		t.Errorf("%d records; want 2", n)
+	}
+	if Multi(nil, r) == nil {
+		t.Error("Multi(nil, r) = nil")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"golang.org/x/net/html"
+)
+
+// DefaultMaxDepth is the maximum nesting depth of a parsed document
+// when Options.MaxDepth is zero. Codelabs are rarely nested deeper than 20.
+const DefaultMaxDepth = 512
+
+// CheckDepth verifies that the tree rooted at root is nested no deeper than
+// opts.MaxDepth levels. Parsers traverse documents recursively, so they call
+// CheckDepth prior to parsing to fail with an error instead of exhausting
+// the stack on pathological or adversarial input.
+//
+// The tree is traversed iteratively.
+func CheckDepth(root *html.Node, opts Options) error {
+	max := opts.MaxDepth
+	if max == 0 {
+		max = DefaultMaxDepth
+	}
+	n, depth := root, 0
+	for {
+		if n.FirstChild != nil {
+			if depth++; depth > max {
+				return &ErrTooDeep{Max: max}
+			}
+			n = n.FirstChild
+			continue
+		}
+		for n.NextSibling == nil || n == root {
+			if depth == 0 {
+				return nil
+			}
+			n = n.Parent
+			depth--
+		}
+		n = n.NextSibling
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// DefaultReadingWPM is a typical reading speed, in words per minute,
+// for Options.ReadingWPM.
+const DefaultReadingWPM = 200
+
+// codeLineWords is the number of words each line of a code block counts as
+// in duration estimates, code being read more slowly than prose.
+const codeLineWords = 10
+
+// estimateDurations sets the duration of each step of c which has none
+// to the time it takes to read its content at wpm words per minute,
+// rounded up to whole minutes, and adds it to the codelab duration.
+// Lines of code blocks count as codeLineWords words each.
+// It does nothing if wpm is not positive.
+func estimateDurations(c *types.Codelab, wpm int) {
+	if wpm <= 0 {
+		return
+	}
+	for _, st := range c.Steps {
+		if st.Duration > 0 {
+			continue
+		}
+		min := (stepWords(st) + wpm - 1) / wpm
+		if min < 1 {
+			min = 1
+		}
+		st.Duration = time.Duration(min) * time.Minute
+		st.Estimated = true
+		c.Duration += min
+	}
+}
+
+// stepWords returns the number of words of st, including its title,
+// with code lines counting as codeLineWords words.
+func stepWords(st *types.Step) int {
+	n := len(strings.Fields(st.Title))
+	types.Walk(st.Content.Nodes, func(node types.Node) bool {
+		switch node := node.(type) {
+		case *types.TextNode:
+			n += len(strings.Fields(node.Value))
+		case *types.CodeNode:
+			n += codeLineWords * len(strings.Split(strings.TrimRight(node.Value, "\n"), "\n"))
+		}
+		return true
+	})
+	return n
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestEstimateDurations(t *testing.T) {
+	clab := types.NewCodelab()
+	clab.Duration = 5
+	given := clab.NewStep("Given")
+	given.Duration = 5 * time.Minute
+	prose := clab.NewStep("Prose")
+	prose.Content.Append(types.NewTextNode(strings.Repeat("word ", 249)))
+	code := clab.NewStep("Code")
+	code.Content.Append(types.NewCodeNode(strings.Repeat("x := 1\n", 30), false, "go"))
+	empty := clab.NewStep("")
+
+	estimateDurations(clab, 0)
+	if prose.Duration != 0 || prose.Estimated {
+		t.Fatalf("wpm 0: prose step = %v, estimated %v; want no estimate", prose.Duration, prose.Estimated)
+	}
+	estimateDurations(clab, 100)
+	tests := []struct {
+		st        *types.Step
+		want      time.Duration
+		estimated bool
+	}{
+		{given, 5 * time.Minute, false},
+		{prose, 3 * time.Minute, true}, // 250 words
+		{code, 4 * time.Minute, true},  // 1 title word and 30 code lines
+		{empty, time.Minute, true},
+	}
+	for _, test := range tests {
+		if test.st.Duration != test.want || test.st.Estimated != test.estimated {
+			t.Errorf("%q: duration = %v, estimated %v; want %v, %v", test.st.Title, test.st.Duration, test.st.Estimated, test.want, test.estimated)
+		}
+	}
+	if clab.Duration != 13 {
+		t.Errorf("clab.Duration = %d; want 13", clab.Duration)
+	}
+}
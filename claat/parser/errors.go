@@ -0,0 +1,155 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoBody means a parsed document has no body element.
+var ErrNoBody = errors.New("document without a body")
+
+// ErrUnknownParser means no parser is registered under Name.
+type ErrUnknownParser struct {
+	Name string
+}
+
+func (e *ErrUnknownParser) Error() string {
+	return fmt.Sprintf("no parser named %q", e.Name)
+}
+
+// ErrMissingMetadata means a codelab lacks required metadata Key.
+type ErrMissingMetadata struct {
+	Key string
+	// Line and Column locate the metadata in the source, if known.
+	Line, Column int
+}
+
+func (e *ErrMissingMetadata) Error() string {
+	return fmt.Sprintf("invalid metadata format, missing %q", e.Key)
+}
+
+// Position returns the line and column of the metadata.
+func (e *ErrMissingMetadata) Position() (line, column int) {
+	return e.Line, e.Column
+}
+
+// ErrInvalidMetadata means a codelab metadata Key has Value,
+// which is not allowed by a MetaRule for the Reason.
+type ErrInvalidMetadata struct {
+	Key, Value, Reason string
+	// Line and Column locate the metadata key in the source, if known.
+	Line, Column int
+}
+
+func (e *ErrInvalidMetadata) Error() string {
+	return fmt.Sprintf("invalid metadata %q value %q: %s", e.Key, e.Value, e.Reason)
+}
+
+// Position returns the line and column of the metadata key.
+func (e *ErrInvalidMetadata) Position() (line, column int) {
+	return e.Line, e.Column
+}
+
+// ErrUndefinedVar means a codelab references variable Key,
+// which is not defined in Options.Vars.
+type ErrUndefinedVar struct {
+	Key string
+	// Line and Column locate the first reference in the source,
+	// both 1-based. Column counts bytes.
+	Line, Column int
+}
+
+func (e *ErrUndefinedVar) Error() string {
+	return fmt.Sprintf("undefined variable %q", e.Key)
+}
+
+// Position returns the line and column of the reference.
+func (e *ErrUndefinedVar) Position() (line, column int) {
+	return e.Line, e.Column
+}
+
+// ErrStrict means a parser reported Warning, the first of a source,
+// which is an error with Options.Strict.
+type ErrStrict struct {
+	Warning *Warning
+}
+
+func (e *ErrStrict) Error() string {
+	return "strict: " + e.Warning.Message
+}
+
+// Position returns the line and column of the warning, if known.
+func (e *ErrStrict) Position() (line, column int) {
+	return e.Warning.Pos.Line, e.Warning.Pos.Column
+}
+
+// ErrTooDeep means a parsed document is nested deeper than Max levels.
+type ErrTooDeep struct {
+	Max int
+}
+
+func (e *ErrTooDeep) Error() string {
+	return fmt.Sprintf("document is nested deeper than %d levels", e.Max)
+}
+
+// ErrUnsupportedVersion means a codelab requires a claat version
+// other than Version, as specified in Requires.
+type ErrUnsupportedVersion struct {
+	Requires string
+	Version  string
+}
+
+func (e *ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("codelab requires %q, this is claat %s", e.Requires, e.Version)
+}
+
+// ErrUnsupportedFeature means a codelab requires feature Name,
+// which is not supported by this claat build.
+type ErrUnsupportedFeature struct {
+	Name string
+}
+
+func (e *ErrUnsupportedFeature) Error() string {
+	return fmt.Sprintf("codelab requires unsupported feature %q", e.Name)
+}
+
+// ErrUnresolvedReview means a source has unresolved review artifacts,
+// which is an error with ReviewFail mode.
+type ErrUnresolvedReview struct {
+	Comments    int
+	Suggestions int
+}
+
+func (e *ErrUnresolvedReview) Error() string {
+	return fmt.Sprintf("%d unresolved comments and %d suggestions", e.Comments, e.Suggestions)
+}
+
+// ImportError means content imported from Path could not be fetched
+// or parsed. The cause is available with errors.Unwrap.
+type ImportError struct {
+	Path string
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gdoc
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// indexAnchors maps IDs of headings and bookmarks in the body of a doc
+// to the index of the codelab step they belong to.
+// Steps are counted the same way parseDoc does, starting at each non-empty <h1>.
+// Anchors before the first step are not indexed.
+func indexAnchors(css cssStyle, body *html.Node) map[string]int {
+	anchors := make(map[string]int)
+	step := -1
+	var walk func(*html.Node)
+	walk = func(hn *html.Node) {
+		if step >= 0 {
+			if id := nodeAttr(hn, "id"); id != "" {
+				anchors[id] = step
+			}
+		}
+		for c := hn.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if isComment(css, c) {
+			// docs export comments at the end of the body
+			break
+		}
+		if c.DataAtom == atom.H1 && stringifyNode(c, true, false) != "" {
+			step++
+		}
+		walk(c)
+	}
+	return anchors
+}
+
+// anchorID extracts a heading or bookmark ID from an internal link
+// of doc docID. Exported docs link to them as "#h.xyz", while links copied
+// from the Docs UI look like "#heading=h.xyz" or "#bookmark=id.xyz",
+// optionally prefixed with the doc URL.
+// It returns an empty string if href is not an internal link,
+// e.g. links to other docs or to a doc of unknown docID.
+func anchorID(href, docID string) string {
+	i := strings.IndexByte(href, '#')
+	if i < 0 {
+		return ""
+	}
+	if i > 0 {
+		u, err := url.Parse(href)
+		if err != nil || u.Host != "docs.google.com" || docID == "" || urlDocID(u.Path) != docID {
+			return ""
+		}
+	}
+	frag := href[i+1:]
+	for _, p := range []string{"heading=", "bookmark="} {
+		if strings.HasPrefix(frag, p) {
+			return frag[len(p):]
+		}
+	}
+	if i > 0 {
+		// other fragments of a doc URL are not anchors
+		return ""
+	}
+	return frag
+}
+
+// urlDocID returns the doc ID of a Google Docs URL path,
+// such as /document/d/id/edit, or an empty string.
+func urlDocID(p string) string {
+	const s = "/document/d/"
+	if !strings.HasPrefix(p, s) {
+		return ""
+	}
+	p = p[len(s):]
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		p = p[:i]
+	}
+	return p
+}
+
+// stepAnchor returns an in-codelab URL of the step containing
+// the heading or bookmark linked to by href.
+// The codelab viewer selects a step from a "#N" fragment.
+// It returns an empty string if href is not a link to a known anchor.
+func (ds *docState) stepAnchor(href string) string {
+	id := anchorID(href, ds.docID)
+	if id == "" {
+		return ""
+	}
+	i, ok := ds.anchors[id]
+	if !ok {
+		return ""
+	}
+	return "#" + strconv.Itoa(i)
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gdoc
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Smart chips are exported by Google Docs without any chip markup:
+// people as mailto links named after them, files as links to them,
+// wrapped like other links, and dates as text in the format of the chip.
+
+var (
+	// chipDateLayouts are the formats of date chips, parsed in order.
+	chipDateLayouts = []string{
+		"Monday, January 2, 2006",
+		"January 2, 2006",
+		"Jan 2, 2006",
+		"2 January 2006",
+		"2 Jan 2006",
+		"2006-01-02",
+	}
+	// chipDateRegexp matches dates in formats of chipDateLayouts.
+	chipDateRegexp = regexp.MustCompile(`\b(?:[A-Z][a-z]+, )?[A-Z][a-z]+ \d{1,2}, \d{4}\b|\b\d{1,2} [A-Z][a-z]+ \d{4}\b|\b\d{4}-\d{2}-\d{2}\b`)
+	// driveHosts are hosts of files which file chips link to.
+	driveHosts = map[string]bool{
+		"docs.google.com":  true,
+		"drive.google.com": true,
+	}
+)
+
+// isPersonChip reports whether a link to href with text is a people chip:
+// a mailto link named after a person rather than their email address.
+func isPersonChip(href, text string) bool {
+	return strings.HasPrefix(href, "mailto:") && !strings.Contains(text, "@")
+}
+
+// fileChipURL returns href without the usp sharing parameter which links
+// of file chips to Drive files have, e.g. "usp=drive_link".
+func fileChipURL(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || !driveHosts[u.Host] {
+		return href
+	}
+	q := u.Query()
+	if _, ok := q["usp"]; !ok {
+		return href
+	}
+	q.Del("usp")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// formatChipDates returns s with dates in formats of date chips
+// formatted with layout. It returns s as is if layout is empty.
+func formatChipDates(s, layout string) string {
+	if layout == "" {
+		return s
+	}
+	var format func(d string) string
+	format = func(d string) string {
+		for _, l := range chipDateLayouts {
+			if t, err := time.Parse(l, d); err == nil {
+				return t.Format(layout)
+			}
+		}
+		// a word other than a weekday before the date
+		if i := strings.Index(d, ", "); i > 0 && strings.Count(d, ", ") == 2 {
+			return d[:i+2] + format(d[i+2:])
+		}
+		return d
+	}
+	return chipDateRegexp.ReplaceAllStringFunc(s, format)
+}
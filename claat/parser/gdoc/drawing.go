@@ -0,0 +1,53 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gdoc
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// drawingExportURL returns the URL rendering the Google Drawing or the chart
+// of Google Sheets at URL v to an image, if v is either: drawings in format
+// "png" or "svg", PNG if empty, and charts, given by their oid parameter,
+// always in PNG. Export URLs are fetched with Drive credentials.
+func drawingExportURL(v, format string) (string, bool) {
+	u, err := url.Parse(cleanURL(v))
+	if err != nil || u.Host != "docs.google.com" {
+		return "", false
+	}
+	// drawings/d/<id>/... or spreadsheets/d/<id>/...
+	p := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(p) < 3 || p[1] != "d" || p[2] == "" {
+		return "", false
+	}
+	id := p[2]
+	switch p[0] {
+	case "drawings":
+		if format == "" {
+			format = "png"
+		}
+		return fmt.Sprintf("https://docs.google.com/drawings/d/%s/export/%s", id, format), true
+	case "spreadsheets":
+		oid := u.Query().Get("oid")
+		if oid == "" {
+			return "", false
+		}
+		q := url.Values{"id": {id}, "oid": {oid}, "format": {"image"}}
+		return fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/embed/oimg?%s", id, q.Encode()), true
+	}
+	return "", false
+}
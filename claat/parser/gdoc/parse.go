@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"net/url"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -41,6 +40,11 @@ func init() {
 type Parser struct {
 }
 
+// HTML reports that Google Docs are parsed from their HTML export.
+func (p *Parser) HTML() bool {
+	return true
+}
+
 // Parse parses a codelab exported in HTML from Google Docs.
 func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error) {
 	// TODO: use html.Tokenizer instead
@@ -48,6 +52,9 @@ func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := parser.CheckDepth(doc, opts); err != nil {
+		return nil, err
+	}
 	return parseDoc(doc, opts)
 }
 
@@ -58,7 +65,10 @@ func (p *Parser) ParseFragment(r io.Reader, opts parser.Options) ([]types.Node,
 	if err != nil {
 		return nil, err
 	}
-	return parseFragment(doc)
+	if err := parser.CheckDepth(doc, opts); err != nil {
+		return nil, err
+	}
+	return parseFragment(doc, opts)
 }
 
 const (
@@ -121,6 +131,14 @@ type docState struct {
 	flags        stateFlag       // current flags
 	stack        []*stackItem    // cur and flags stack
 	passMetadata map[string]bool // set of metadata fields to pass along.
+	anchors      map[string]int  // step index of heading and bookmark IDs
+	docID        string          // ID of the parsed doc, if known
+	// iframeAllowed reports whether an iframe host can be embedded.
+	iframeAllowed func(host string) bool
+	drawingFormat string             // image format of drawings, see parser.Options
+	chips         parser.ChipOptions // conversion of smart chips
+	// warn receives warnings about the doc, may be nil.
+	warn parser.WarningSink
 }
 
 type stackItem struct {
@@ -130,7 +148,8 @@ type stackItem struct {
 
 func newDocState() *docState {
 	ds := &docState{
-		clab: types.NewCodelab(),
+		clab:          types.NewCodelab(),
+		iframeAllowed: parser.Options{}.IframeAllowed,
 	}
 
 	return ds
@@ -156,6 +175,12 @@ func (ds *docState) pop() {
 	ds.flags = item.flags
 }
 
+// warnf reports a warning about the doc to ds.warn, if any.
+// Docs have no source positions.
+func (ds *docState) warnf(format string, args ...interface{}) {
+	parser.Options{WarningSink: ds.warn}.Warnf(types.Position{}, format, args...)
+}
+
 func (ds *docState) appendNodes(nn ...types.Node) {
 	if ds.step == nil || len(nn) == 0 {
 		return
@@ -169,18 +194,27 @@ func (ds *docState) appendNodes(nn ...types.Node) {
 	ds.lastNode = nn[len(nn)-1]
 }
 
-func parseFragment(doc *html.Node) ([]types.Node, error) {
+func parseFragment(doc *html.Node, opts parser.Options) ([]types.Node, error) {
 	body := findAtom(doc, atom.Body)
 	if body == nil {
-		return nil, fmt.Errorf("document without a body")
+		return nil, parser.ErrNoBody
 	}
 	style, err := parseStyle(doc)
 	if err != nil {
 		return nil, err
 	}
 
+	comments, suggestions := stripReview(style, body)
+	if err := checkReview(opts, "fragment", comments, suggestions); err != nil {
+		return nil, err
+	}
+
 	ds := newDocState()
 	ds.css = style
+	ds.iframeAllowed = opts.IframeAllowed
+	ds.drawingFormat = opts.DrawingFormat
+	ds.chips = opts.Chips
+	ds.warn = opts.WarningSink
 	ds.step = ds.clab.NewStep("fragment")
 	for ds.cur = body.FirstChild; ds.cur != nil; ds.cur = ds.cur.NextSibling {
 		if isComment(ds.css, ds.cur) {
@@ -198,16 +232,24 @@ func parseFragment(doc *html.Node) ([]types.Node, error) {
 func parseDoc(doc *html.Node, opts parser.Options) (*types.Codelab, error) {
 	body := findAtom(doc, atom.Body)
 	if body == nil {
-		return nil, fmt.Errorf("document without a body")
+		return nil, parser.ErrNoBody
 	}
 	style, err := parseStyle(doc)
 	if err != nil {
 		return nil, err
 	}
 
+	comments, suggestions := stripReview(style, body)
+
 	ds := newDocState()
 	ds.css = style
 	ds.passMetadata = opts.PassMetadata
+	ds.iframeAllowed = opts.IframeAllowed
+	ds.drawingFormat = opts.DrawingFormat
+	ds.chips = opts.Chips
+	ds.warn = opts.WarningSink
+	ds.anchors = indexAnchors(style, body)
+	ds.docID = opts.DocID
 
 	for ds.cur = body.FirstChild; ds.cur != nil; ds.cur = ds.cur.NextSibling {
 		if isComment(ds.css, ds.cur) {
@@ -233,6 +275,8 @@ func parseDoc(doc *html.Node, opts parser.Options) (*types.Codelab, error) {
 		// ignore everything else before the first step
 		if ds.step != nil {
 			parseTop(ds)
+		} else if v := stringifyNode(ds.cur, true, false); v != "" {
+			ds.warnf("content before the first step dropped: %.40q", v)
 		}
 	}
 
@@ -240,6 +284,10 @@ func parseDoc(doc *html.Node, opts parser.Options) (*types.Codelab, error) {
 	ds.clab.Tags = util.Unique(ds.clab.Tags)
 	sort.Strings(ds.clab.Tags)
 	ds.clab.Duration = int(ds.totdur.Minutes())
+	// warnings refer to the codelab ID, known only after parsing
+	if err := checkReview(opts, ds.clab.ID, comments, suggestions); err != nil {
+		return nil, err
+	}
 	return ds.clab, nil
 }
 
@@ -286,12 +334,19 @@ func finalizeStep(s *types.Step) {
 }
 
 func transformNodes(name string, nodes []types.Node) types.Node {
-	if name == metaTagImport && len(nodes) == 1 {
-		u, ok := nodes[0].(*types.URLNode)
-		if !ok {
-			return nil
+	if name != metaTagImport || len(nodes) != 1 {
+		return nil
+	}
+	switch n := nodes[0].(type) {
+	case *types.URLNode:
+		return types.NewImportNode(n.URL)
+	case *types.TextNode:
+		// local paths, e.g. "./fragments/setup.md",
+		// resolved against the import root of the fetcher
+		v := strings.TrimSpace(n.Value)
+		if (strings.HasPrefix(v, "./") || strings.HasPrefix(v, "../")) && !strings.ContainsAny(v, " \t") {
+			return types.NewImportNode(v)
 		}
-		return types.NewImportNode(u.URL)
 	}
 	return nil
 }
@@ -410,14 +465,46 @@ func metaTable(ds *docState) {
 			ds.clab.Feedback = s
 		case "analytics", "analytics account", "google analytics":
 			ds.clab.GA = s
+		case "analytics providers":
+			ds.clab.Analytics = stringSlice(s)
+		case "gate steps":
+			ds.clab.GateSteps, _ = strconv.ParseBool(s)
+		case "requires":
+			ds.clab.Requires = s
+		case "features":
+			ds.clab.Features = stringSlice(s)
+			toLowerSlice(ds.clab.Features)
+		case "template":
+			ds.clab.Template = s
+		case "theme":
+			ds.clab.Theme = s
+		case "prerequisites":
+			ds.clab.Prerequisites = stringSlice(s)
+		case "related":
+			ds.clab.Related = stringSlice(s)
+		case "description":
+			ds.clab.Description = s
+		case "hero image":
+			ds.clab.HeroImage = s
+		case "published":
+			ds.clab.Published = s
+		case "updated":
+			ds.clab.LastUpdated = s
+		case "revision":
+			ds.clab.Revision = s
 		default:
 			// If not explicitly parsed, it might be a pass_metadata value.
 			if _, ok := ds.passMetadata[fieldName]; ok {
 				ds.clab.Extra[fieldName] = s
 			}
+			// All are checked against metadata rules.
+			if ds.clab.Other == nil {
+				ds.clab.Other = make(map[string]string)
+			}
+			ds.clab.Other[fieldName] = s
 		}
 	}
-	if len(ds.clab.Categories) > 0 {
+	if ds.clab.Theme == "" && len(ds.clab.Categories) > 0 {
 		ds.clab.Theme = slug(ds.clab.Categories[0])
 	}
 }
@@ -521,9 +608,16 @@ func table(ds *docState) types.Node {
 	if len(rows) == 0 {
 		return nil
 	}
+	fitSpans(rows)
 	return types.NewGridNode(rows...)
 }
 
+// Limits of cell spans, those of HTML tables.
+const (
+	maxColspan = 1000
+	maxRowspan = 65534
+)
+
 func tableRow(ds *docState) []*types.GridCell {
 	var row []*types.GridCell
 	for td := findAtom(ds.cur, atom.Td); td != nil; td = td.NextSibling {
@@ -535,16 +629,18 @@ func tableRow(ds *docState) []*types.GridCell {
 		nn = parser.BlockNodes(nn)
 		nn = parser.CompactNodes(nn)
 		ds.pop()
-		if len(nn) == 0 {
-			continue
-		}
+		// empty cells keep their place in the grid
 		cs, err := strconv.Atoi(nodeAttr(td, "colspan"))
-		if err != nil {
+		if err != nil || cs < 1 {
 			cs = 1
+		} else if cs > maxColspan {
+			cs = maxColspan
 		}
 		rs, err := strconv.Atoi(nodeAttr(td, "rowspan"))
-		if err != nil {
+		if err != nil || rs < 1 {
 			rs = 1
+		} else if rs > maxRowspan {
+			rs = maxRowspan
 		}
 		cell := &types.GridCell{
 			Colspan: cs,
@@ -556,6 +652,56 @@ func tableRow(ds *docState) []*types.GridCell {
 	return row
 }
 
+// fitSpans fits the spans of cells of rows, laid out like those of an HTML
+// table, to the table: rowspans to the rows below, and colspans to its width,
+// that of the widest row up to its last non-empty cell. Empty cells past
+// the width, written in place of cells covered by merged ones, are dropped.
+func fitSpans(rows [][]*types.GridCell) {
+	type placed struct {
+		cell *types.GridCell
+		col  int
+	}
+	layout := make([][]placed, len(rows))
+	taken := make([]map[int]bool, len(rows)) // columns taken by cells, by row
+	for r := range taken {
+		taken[r] = make(map[int]bool)
+	}
+	var width int
+	for r, row := range rows {
+		var col int
+		for _, c := range row {
+			for taken[r][col] {
+				col++
+			}
+			if c.Rowspan > len(rows)-r {
+				c.Rowspan = len(rows) - r
+			}
+			for i := r; i < r+c.Rowspan; i++ {
+				for j := col; j < col+c.Colspan; j++ {
+					taken[i][j] = true
+				}
+			}
+			layout[r] = append(layout[r], placed{c, col})
+			if !c.Content.Empty() && col+c.Colspan > width {
+				width = col + c.Colspan
+			}
+			col += c.Colspan
+		}
+	}
+	for r, row := range layout {
+		rows[r] = rows[r][:0]
+		for _, p := range row {
+			if p.col >= width {
+				continue
+			}
+			if p.col+p.cell.Colspan > width {
+				p.cell.Colspan = width - p.col
+			}
+			rows[r] = append(rows[r], p.cell)
+		}
+	}
+}
+
 // survey expects a header followed by 1 or more lists.
 func survey(ds *docState) types.Node {
 	// find direct parent of the survey elements
@@ -640,6 +786,7 @@ func list(ds *docState) types.Node {
 	}
 	start, _ := strconv.Atoi(nodeAttr(ds.cur, "start"))
 	list := types.NewItemsListNode(typ, start)
+	list.Level = listLevel(ds.cur)
 	for hn := findAtom(ds.cur, atom.Li); hn != nil; hn = hn.NextSibling {
 		if hn.DataAtom != atom.Li {
 			continue
@@ -666,6 +813,21 @@ func list(ds *docState) types.Node {
 	return list
 }
 
+// listLevel returns the nesting level of list hn, 0 for top-level lists.
+// Google Docs exports nested lists as flat sibling lists, with a class
+// ending in their level, such as lst-kix_abc123-2.
+func listLevel(hn *html.Node) int {
+	for _, c := range classList(hn) {
+		if !strings.HasPrefix(c, "lst-") {
+			continue
+		}
+		if n, err := strconv.Atoi(c[strings.LastIndexByte(c, '-')+1:]); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
 // image creates a new ImageNode out of hn, parsing its src attribute.
 // It returns nil if src is empty.
 // It may also return a YouTubeNode if alt property contains specific substring.
@@ -677,6 +839,9 @@ func image(ds *docState) types.Node {
 	alt = strings.Replace(alt, "\n", " ", -1)
 	alt = html.EscapeString(alt)
 	errorAlt := ""
+	if n := drawing(ds, alt); n != nil {
+		return n
+	}
 	if strings.Contains(alt, "youtube.com/watch") {
 		return youtube(ds)
 	} else if strings.Contains(alt, "https://") {
@@ -684,22 +849,16 @@ func image(ds *docState) types.Node {
 		if err != nil {
 			return nil
 		}
-		// For iframe, make sure URL ends in whitelisted domain.
-		ok := false
-		for _, domain := range types.IframeWhitelist {
-			if strings.HasSuffix(u.Hostname(), domain) {
-				ok = true
-				break
-			}
-		}
-		if ok {
+		// For iframe, make sure URL ends in an allowed domain.
+		if ds.iframeAllowed(u.Hostname()) {
 			return iframe(ds)
 		}
 		errorAlt = "The domain of the requested iframe (" + u.Hostname() + ") has not been whitelisted."
-		fmt.Fprint(os.Stderr, errorAlt+"\n")
+		ds.warnf("iframe of %s not embedded: domain is not allowed", u.Hostname())
 	}
 	s := nodeAttr(ds.cur, "src")
 	if s == "" {
+		ds.warnf("image with no source dropped")
 		return nil
 	}
 	n := types.NewImageNode(s)
@@ -715,6 +874,30 @@ func image(ds *docState) types.Node {
 	return n
 }
 
+// drawing returns an ImageNode of a Google Drawing or a chart of Google
+// Sheets, if image ds.cur is one, or links to one: rendered by its export
+// URL, with alt text alt, or its title. Docs may export such images with
+// no source. It returns nil otherwise.
+func drawing(ds *docState, alt string) *types.ImageNode {
+	s := nodeAttr(ds.cur, "src")
+	u, ok := drawingExportURL(s, ds.drawingFormat)
+	if a := findParent(ds.cur, atom.A); !ok && a != nil {
+		u, ok = drawingExportURL(nodeAttr(a, "href"), ds.drawingFormat)
+	}
+	if !ok {
+		return nil
+	}
+	n := types.NewImageNode(u)
+	n.Width = styleFloatValue(ds.cur, "width")
+	n.MutateBlock(findBlockParent(ds.cur))
+	n.Title = html.EscapeString(nodeAttr(ds.cur, "title"))
+	n.Alt = alt
+	if n.Alt == "" {
+		n.Alt = n.Title
+	}
+	return n
+}
+
 func youtube(ds *docState) types.Node {
 	u, err := url.Parse(nodeAttr(ds.cur, "alt"))
 	if err != nil {
@@ -736,6 +919,7 @@ func iframe(ds *docState) types.Node {
 	}
 	// Allow only https.
 	if u.Scheme != "https" {
+		ds.warnf("iframe of %s dropped: only https is allowed", u)
 		return nil
 	}
 	n := types.NewIframeNode(u.String())
@@ -776,14 +960,22 @@ func button(ds *docState) types.Node {
 // It returns nil if hn contents is empty.
 // The resuling link's content is always a single text node.
 func link(ds *docState) types.Node {
-	href := cleanURL(nodeAttr(ds.cur, "href"))
+	href := fileChipURL(cleanURL(nodeAttr(ds.cur, "href")))
 	if strings.HasPrefix(href, commentPrefix) {
 		// doc comments; ignore
 		return nil
 	}
+	// headings and bookmarks of the doc, including a table of contents
+	anchor := ds.stepAnchor(href)
 
 	text := stringifyNode(ds.cur, false, true)
 	if strings.TrimSpace(text) == "" {
+		// linked images, such as drawings and charts
+		if img := findAtom(ds.cur, atom.Img); img != nil {
+			ds.push(img, ds.flags)
+			defer ds.pop()
+			return image(ds)
+		}
 		return nil
 	}
 
@@ -797,7 +989,14 @@ func link(ds *docState) types.Node {
 	if ds.flags&fMakeCode != 0 || isCode(ds.css, ds.cur.Parent) {
 		t.Code = true
 	}
-	if href == "" || href[0] == '#' {
+	if anchor != "" {
+		n := types.NewURLNode(anchor, t)
+		// navigate within the codelab viewer
+		n.Target = ""
+		n.MutateBlock(findBlockParent(ds.cur))
+		return n
+	}
+	if href == "" || href[0] == '#' || (isPersonChip(href, text) && !ds.chips.PersonLinks) {
 		t.MutateBlock(findBlockParent(ds.cur))
 		return t
 	}
@@ -840,6 +1039,9 @@ func text(ds *docState) types.Node {
 	}
 
 	v := stringifyNode(ds.cur, false, true)
+	if !code {
+		v = formatChipDates(v, ds.chips.DateLayout)
+	}
 	n := types.NewTextNode(v)
 	// Only apply styling if the node contains non-whitespace.
 	if len(strings.TrimSpace(v)) > 0 {
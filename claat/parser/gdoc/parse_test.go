@@ -16,13 +16,16 @@ package gdoc
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 
 	"github.com/googlecodelabs/tools/claat/parser"
 	"github.com/googlecodelabs/tools/claat/render"
@@ -137,6 +140,194 @@ func TestParseTopCodeBlock(t *testing.T) {
 	}
 }
 
+func TestParseNestedLists(t *testing.T) {
+	// Google Docs exports nested lists as flat sibling lists
+	const markup = `
+	<ul class="lst-kix_a-0 start"><li><span>One</span></li></ul>
+	<ol class="lst-kix_b-1 start"><li><span>Two</span></li><li><span>Three</span></li></ol>
+	<ul class="lst-kix_a-2 start"><li><span>Four</span></li></ul>
+	<ol class="lst-kix_b-1"><li><span>Five</span></li></ol>
+	<ul class="lst-kix_a-0"><li><span>Six</span></li></ul>
+	`
+	doc, err := html.Parse(markupReader(markup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := &docState{
+		step: &types.Step{Content: types.NewListNode()},
+		css:  cssStyle{},
+		cur:  findAtom(doc, atom.Body),
+	}
+	parseTop(ds)
+	var ctx render.Context
+	b, _ := render.HTML(ctx, ds.step.Content)
+	want := "<ul>\n<li>One<ol type=\"1\">\n<li>Two</li>\n<li>Three<ul>\n<li>Four</li>\n</ul>\n</li>\n<li>Five</li>\n</ol>\n</li>\n<li>Six</li>\n</ul>"
+	if s := strings.TrimSpace(string(b)); s != want {
+		t.Errorf("step.Content:\n\n%s\nwant:\n\n%s", s, want)
+	}
+}
+
+func TestParseTableSpans(t *testing.T) {
+	tests := []struct {
+		name   string
+		markup string
+		want   string // cells of each row as text:colspan:rowspan
+	}{
+		{
+			"header row",
+			`<tr><td colspan="2"><p>H</p></td></tr>
+			<tr><td><p>a</p></td><td><p>b</p></td></tr>`,
+			"H:2:1 | a:1:1 b:1:1",
+		},
+		{
+			"row header",
+			`<tr><td rowspan="2"><p>H</p></td><td><p>a</p></td></tr>
+			<tr><td><p>b</p></td></tr>`,
+			"H:1:2 a:1:1 | b:1:1",
+		},
+		{
+			"block",
+			`<tr><td colspan="2" rowspan="2"><p>X</p></td><td><p>a</p></td></tr>
+			<tr><td><p>b</p></td></tr>
+			<tr><td><p>c</p></td><td><p>d</p></td><td><p>e</p></td></tr>`,
+			"X:2:2 a:1:1 | b:1:1 | c:1:1 d:1:1 e:1:1",
+		},
+		{
+			"empty cells",
+			`<tr><td><p>a</p></td><td></td><td><p>c</p></td></tr>
+			<tr><td></td><td><p>e</p></td><td></td></tr>`,
+			"a:1:1 :1:1 c:1:1 | :1:1 e:1:1 :1:1",
+		},
+		{
+			"covered placeholders",
+			`<tr><td colspan="2" rowspan="2"><p>X</p></td><td></td></tr>
+			<tr><td></td><td></td></tr>`,
+			"X:2:2 | ",
+		},
+		{
+			"oversized spans",
+			`<tr><td rowspan="5"><p>H</p></td><td><p>a</p></td></tr>
+			<tr><td colspan="4"></td></tr>`,
+			"H:1:2 a:1:1 | :1:1",
+		},
+		{
+			"huge spans",
+			`<tr><td colspan="2000000000" rowspan="2000000000"><p>H</p></td></tr>
+			<tr><td><p>a</p></td></tr>`,
+			"H:1000:2 | a:1:1",
+		},
+	}
+	for _, test := range tests {
+		markup := "<table><tbody>" + test.markup + "</tbody></table>"
+		doc, err := html.Parse(markupReader(markup))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds := newDocState()
+		ds.cur = findAtom(doc, atom.Table)
+		g, ok := table(ds).(*types.GridNode)
+		if !ok {
+			t.Errorf("%s: table is not a grid", test.name)
+			continue
+		}
+		var rows []string
+		for _, r := range g.Rows {
+			var cells []string
+			for _, c := range r {
+				cells = append(cells, fmt.Sprintf("%s:%d:%d", cellText(c.Content), c.Colspan, c.Rowspan))
+			}
+			rows = append(rows, strings.Join(cells, " "))
+		}
+		if got := strings.Join(rows, " | "); got != test.want {
+			t.Errorf("%s: cells = %q; want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func cellText(l *types.ListNode) string {
+	var s string
+	for _, n := range l.Nodes {
+		switch n := n.(type) {
+		case *types.TextNode:
+			s += n.Value
+		case *types.ListNode:
+			s += cellText(n)
+		}
+	}
+	return s
+}
+
+func TestParseDrawings(t *testing.T) {
+	const markup = `<html><body>
+		<p><img src="https://docs.google.com/drawings/d/d1/image?w=400&amp;h=300" alt="Flow" style="width: 400px"></p>
+		<p><a href="https://www.google.com/url?q=https://docs.google.com/drawings/d/d2/edit"><img src="" title="Architecture"></a></p>
+		<p><a href="https://docs.google.com/spreadsheets/d/s1/edit?oid=42"><img alt="Sales chart"></a></p>
+		<p><a href="https://docs.google.com/spreadsheets/d/s1/edit"><img src="https://host/chart.png"></a></p>
+	</body></html>`
+	opts := *parser.NewOptions(parser.Blackfriday)
+	opts.DrawingFormat = "svg"
+	nodes, err := (&Parser{}).ParseFragment(markupReader(markup), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imgs := types.ImageNodes(nodes)
+	want := []struct{ src, alt string }{
+		{"https://docs.google.com/drawings/d/d1/export/svg", "Flow"},
+		{"https://docs.google.com/drawings/d/d2/export/svg", "Architecture"},
+		{"https://docs.google.com/spreadsheets/d/s1/embed/oimg?format=image&id=s1&oid=42", "Sales chart"},
+		{"https://host/chart.png", ""},
+	}
+	if len(imgs) != len(want) {
+		t.Fatalf("got %d images; want %d", len(imgs), len(want))
+	}
+	for i, w := range want {
+		if imgs[i].Src != w.src || imgs[i].Alt != w.alt {
+			t.Errorf("image %d: src %q, alt %q; want %q, %q", i, imgs[i].Src, imgs[i].Alt, w.src, w.alt)
+		}
+	}
+	if imgs[0].Width != 400 {
+		t.Errorf("image 0: width %v; want 400", imgs[0].Width)
+	}
+}
+
+func TestParseChips(t *testing.T) {
+	// smart chips as exported by Docs: people, a file and dates
+	markup, err := ioutil.ReadFile("testdata/chips.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		chips parser.ChipOptions
+		want  string
+	}{
+		{
+			parser.ChipOptions{},
+			`<p>Owner: Jo Doe, due Mar 5, 2024.</p>
+<p>Spec: <a href="https://docs.google.com/document/d/1AbCdEf/edit" target="_blank">Design doc</a>, reviewed 2024-03-01.</p>
+<p>Write to <a href="mailto:team@example.com" target="_blank">team@example.com</a>.</p>`,
+		},
+		{
+			parser.ChipOptions{PersonLinks: true, DateLayout: "2 January 2006"},
+			`<p>Owner: <a href="mailto:jo@example.com" target="_blank">Jo Doe</a>, due 5 March 2024.</p>
+<p>Spec: <a href="https://docs.google.com/document/d/1AbCdEf/edit" target="_blank">Design doc</a>, reviewed 1 March 2024.</p>
+<p>Write to <a href="mailto:team@example.com" target="_blank">team@example.com</a>.</p>`,
+		},
+	}
+	for _, test := range tests {
+		opts := *parser.NewOptions(parser.Blackfriday)
+		opts.Chips = test.chips
+		nodes, err := (&Parser{}).ParseFragment(bytes.NewReader(markup), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ctx render.Context
+		b, _ := render.HTML(ctx, nodes...)
+		if s := strings.TrimSpace(string(b)); s != test.want {
+			t.Errorf("%+v:\n%s\nwant:\n%s", test.chips, s, test.want)
+		}
+	}
+}
+
 func TestMetaTable(t *testing.T) {
 	const markup = `
 	<html>
@@ -275,6 +466,10 @@ func TestMetaTablePassMetadata(t *testing.T) {
 		Extra: map[string]string{
 			"extrafieldone": "11111",
 		},
+		Other: map[string]string{
+			"extrafieldone": "11111",
+			"extrafieldtwo": "22222",
+		},
 	}
 	if !reflect.DeepEqual(clab.Meta, meta) {
 		t.Errorf("Meta: \n%+v\nwant:\n%+v", clab.Meta, meta)
@@ -288,6 +483,29 @@ func TestMetaTablePassMetadata(t *testing.T) {
 	}
 }
 
+func TestTransformNodesImport(t *testing.T) {
+	tests := []struct {
+		node types.Node
+		want string // import URL, or empty for no import
+	}{
+		{types.NewURLNode("https://example.com/import"), "https://example.com/import"},
+		{types.NewTextNode("\u00a0./fragments/setup.md"), "./fragments/setup.md"},
+		{types.NewTextNode(" ../shared/setup.md "), "../shared/setup.md"},
+		{types.NewTextNode("shared setup"), ""},
+		{types.NewTextNode("./a file.md"), ""},
+	}
+	for _, tc := range tests {
+		n := transformNodes(metaTagImport, []types.Node{tc.node})
+		var got string
+		if in, ok := n.(*types.ImportNode); ok {
+			got = in.URL
+		}
+		if got != tc.want {
+			t.Errorf("transformNodes(%+v) import = %q; want %q", tc.node, got, tc.want)
+		}
+	}
+}
+
 func TestParseDoc(t *testing.T) {
 	const markup = `
 	<html><head><style>
@@ -586,3 +804,90 @@ func TestParseFragment(t *testing.T) {
 		t.Errorf("nodes:\n\n%s\nwant:\n\n%s", html1, html2)
 	}
 }
+
+func TestStripReview(t *testing.T) {
+	const markup = `<html><body>
+		<p><span>Keep</span><ins>added</ins><del> old</del><span id="suggest.1">new</span><span id="suggest.2" style="text-decoration:line-through"> text</span><sup><a href="#cmnt1">[a]</a></sup></p>
+		<div style="border:1px solid black"><p><a href="#cmnt_ref1">[a]</a>Fix this.</p></div>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(markup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := findAtom(doc, atom.Body)
+	comments, suggestions := stripReview(cssStyle{}, body)
+	if comments != 1 || suggestions != 4 {
+		t.Errorf("stripReview = %d, %d; want 1, 4", comments, suggestions)
+	}
+	p := findAtom(body, atom.P)
+	if v := stringifyNode(p, true, false); v != "Keep old text" {
+		t.Errorf("stripped content = %q; want %q", v, "Keep old text")
+	}
+
+	for _, mode := range []parser.ReviewMode{parser.ReviewStrip, parser.ReviewWarn} {
+		if err := checkReview(parser.Options{Review: mode}, "doc", comments, suggestions); err != nil {
+			t.Errorf("checkReview(%d) = %v", mode, err)
+		}
+	}
+	err = checkReview(parser.Options{Review: parser.ReviewFail}, "doc", comments, suggestions)
+	if _, ok := err.(*parser.ErrUnresolvedReview); !ok {
+		t.Errorf("checkReview(ReviewFail) = %v; want ErrUnresolvedReview", err)
+	}
+	if err := checkReview(parser.Options{Review: parser.ReviewFail}, "doc", 0, 0); err != nil {
+		t.Errorf("checkReview(ReviewFail, 0, 0) = %v", err)
+	}
+
+	var warnings []string
+	opts := parser.Options{
+		Review:      parser.ReviewWarn,
+		WarningSink: func(w *parser.Warning) { warnings = append(warnings, w.String()) },
+	}
+	if err := checkReview(opts, "doc", comments, suggestions); err != nil {
+		t.Errorf("checkReview(ReviewWarn) = %v", err)
+	}
+	want := []string{"stripped 1 unresolved comments and 4 suggestions"}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q; want %q", warnings, want)
+	}
+}
+
+func TestStepAnchors(t *testing.T) {
+	const markup = `<html><body>
+		<p><a href="#h.intro">Intro</a></p>
+		<h1 id="h.intro"><span>Intro</span></h1>
+		<p><a id="id.bm"></a><span>Bookmarked</span></p>
+		<h1 id="h.setup"><span>Setup</span></h1>
+		<h2 id="h.sub"><span>Details</span></h2>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(markup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := newDocState()
+	ds.docID = "abc"
+	ds.anchors = indexAnchors(cssStyle{}, findAtom(doc, atom.Body))
+	want := map[string]int{"h.intro": 0, "id.bm": 0, "h.setup": 1, "h.sub": 1}
+	if !reflect.DeepEqual(ds.anchors, want) {
+		t.Errorf("indexAnchors = %v; want %v", ds.anchors, want)
+	}
+
+	tests := []struct{ href, want string }{
+		{"#h.setup", "#1"},
+		{"#id.bm", "#0"},
+		{"#heading=h.sub", "#1"},
+		{"#bookmark=id.bm", "#0"},
+		{"https://docs.google.com/document/d/abc/edit#heading=h.setup", "#1"},
+		{"https://docs.google.com/document/d/abc/edit#h.setup", ""},
+		{"https://docs.google.com/document/d/other/edit#heading=h.setup", ""},
+		{"https://docs.google.com/document/d/abcd/edit#heading=h.setup", ""},
+		{"https://example.com/#h.setup", ""},
+		{"#h.unknown", ""},
+		{"#cmnt1", ""},
+		{"https://example.com/", ""},
+	}
+	for _, test := range tests {
+		if v := ds.stepAnchor(test.href); v != test.want {
+			t.Errorf("stepAnchor(%q) = %q; want %q", test.href, v, test.want)
+		}
+	}
+}
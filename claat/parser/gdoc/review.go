@@ -0,0 +1,109 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gdoc
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/googlecodelabs/tools/claat/logging"
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// suggestionPrefix is the ID prefix of suggested edits in exported docs.
+const suggestionPrefix = "suggest."
+
+// stripReview removes suggested edits from the body of a doc, rejecting them:
+// suggested insertions are dropped and suggested deletions are kept as regular
+// content. Comments are skipped by the parser later on.
+// It returns the number of comments and suggestions found.
+func stripReview(css cssStyle, body *html.Node) (comments, suggestions int) {
+	var walk func(*html.Node)
+	walk = func(hn *html.Node) {
+		for c := hn.FirstChild; c != nil; {
+			next := c.NextSibling
+			if isComment(css, c) {
+				// docs export comments at the end of the body
+				return
+			}
+			if c.DataAtom == atom.A && strings.HasPrefix(nodeAttr(c, "href"), commentPrefix) {
+				comments++
+			}
+			switch insert, ok := isSuggestion(css, c); {
+			case ok && insert:
+				suggestions++
+				hn.RemoveChild(c)
+			case ok:
+				suggestions++
+				// unwrap deleted content, which is visited next
+				if c.FirstChild != nil {
+					next = c.FirstChild
+				}
+				for c.FirstChild != nil {
+					gc := c.FirstChild
+					c.RemoveChild(gc)
+					hn.InsertBefore(gc, c)
+				}
+				hn.RemoveChild(c)
+			default:
+				walk(c)
+			}
+			c = next
+		}
+	}
+	walk(body)
+	return comments, suggestions
+}
+
+// isSuggestion reports whether hn is a suggested edit and whether it is
+// an insertion, as opposed to a deletion.
+// Suggestions are either <ins> and <del> elements or elements with IDs
+// prefixed with suggestionPrefix, where deletions are struck through.
+func isSuggestion(css cssStyle, hn *html.Node) (insert, ok bool) {
+	switch {
+	case hn.Type != html.ElementNode:
+		return false, false
+	case hn.DataAtom == atom.Ins:
+		return true, true
+	case hn.DataAtom == atom.Del:
+		return false, true
+	case strings.HasPrefix(nodeAttr(hn, "id"), suggestionPrefix):
+		return !hasClassStyle(css, hn, "text-decoration", "line-through"), true
+	}
+	return false, false
+}
+
+// checkReview handles review artifacts found by stripReview according
+// to opts.Review. Logged warnings refer to the doc as name.
+func checkReview(opts parser.Options, name string, comments, suggestions int) error {
+	if comments == 0 && suggestions == 0 {
+		return nil
+	}
+	switch opts.Review {
+	case parser.ReviewFail:
+		return &parser.ErrUnresolvedReview{Comments: comments, Suggestions: suggestions}
+	case parser.ReviewWarn:
+		const format = "stripped %d unresolved comments and %d suggestions"
+		if opts.WarningSink == nil {
+			logging.Warnf("%s: "+format, name, comments, suggestions)
+			return nil
+		}
+		opts.Warnf(types.Position{}, format, comments, suggestions)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// glossaryTitle is the title of auto-generated glossary step.
+const glossaryTitle = "Glossary"
+
+// addGlossaryStep appends a step listing all glossary terms of c,
+// sorted alphabetically. The first definition of a term wins.
+// No step is added if c has no terms.
+func addGlossaryStep(c *types.Codelab) {
+	defs := map[string]*types.TermNode{}
+	for _, st := range c.Steps {
+		for _, tn := range types.TermNodes(st.Content.Nodes) {
+			k := strings.ToLower(tn.Term)
+			if _, ok := defs[k]; !ok {
+				defs[k] = tn
+			}
+		}
+	}
+	if len(defs) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(defs))
+	for k := range defs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := types.NewItemsListNode("", 0)
+	for _, k := range keys {
+		term := types.NewTextNode(defs[k].Term)
+		term.Bold = true
+		list.NewItem(term, types.NewTextNode(": "+defs[k].Definition))
+	}
+	c.NewStep(glossaryTitle).Content.Append(list)
+}
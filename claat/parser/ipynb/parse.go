@@ -0,0 +1,254 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipynb implements a parser of Jupyter notebooks.
+//
+// A notebook is converted to a Markdown codelab and parsed with the md parser.
+// Markdown cells become codelab content, with top-level "#" headings
+// starting new steps, and code cells become code blocks.
+// Codelab metadata is taken from the "claat" object of notebook metadata,
+// using the same keys as Markdown codelabs, e.g. "id" and "summary".
+package ipynb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/parser/md"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// init registers this parser so it is available to CLaaT.
+func init() {
+	parser.Register("ipynb", &Parser{})
+}
+
+// Parser is a Jupyter notebook parser.
+type Parser struct {
+}
+
+// Parse parses a codelab written as a Jupyter notebook.
+func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error) {
+	nb, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeHeader(&buf, nb.Metadata); err != nil {
+		return nil, err
+	}
+	writeCells(&buf, nb, opts.NotebookOutputs)
+	return (&md.Parser{}).Parse(&buf, opts)
+}
+
+// ParseFragment parses a codelab fragment written as a Jupyter notebook.
+func (p *Parser) ParseFragment(r io.Reader, opts parser.Options) ([]types.Node, error) {
+	nb, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writeCells(&buf, nb, opts.NotebookOutputs)
+	return (&md.Parser{}).ParseFragment(&buf, opts)
+}
+
+// notebook is the subset of nbformat 4 used by the parser.
+type notebook struct {
+	Metadata *metadata `json:"metadata"`
+	Cells    []*cell   `json:"cells"`
+}
+
+type metadata struct {
+	Claat        map[string]string `json:"claat"`
+	Title        string            `json:"title"`
+	LanguageInfo struct {
+		Name string `json:"name"`
+	} `json:"language_info"`
+	Kernelspec struct {
+		Language string `json:"language"`
+	} `json:"kernelspec"`
+}
+
+// lang returns the programming language of code cells.
+func (m *metadata) lang() string {
+	if m.LanguageInfo.Name != "" {
+		return m.LanguageInfo.Name
+	}
+	return m.Kernelspec.Language
+}
+
+type cell struct {
+	Type    string    `json:"cell_type"`
+	Source  multiline `json:"source"`
+	Outputs []*output `json:"outputs"`
+}
+
+type output struct {
+	Type      string               `json:"output_type"`
+	Text      multiline            `json:"text"`
+	Data      map[string]multiline `json:"data"`
+	Traceback []string             `json:"traceback"`
+}
+
+// multiline is a notebook text value, which is either a string
+// or a list of lines.
+type multiline string
+
+func (m *multiline) UnmarshalJSON(b []byte) error {
+	var lines []string
+	if err := json.Unmarshal(b, &lines); err == nil {
+		*m = multiline(strings.Join(lines, ""))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*m = multiline(s)
+	return nil
+}
+
+// ErrNoCells means a notebook has no cells, or is not a notebook at all.
+var ErrNoCells = errors.New("notebook without cells")
+
+func decode(r io.Reader) (*notebook, error) {
+	nb := &notebook{Metadata: &metadata{}}
+	if err := json.NewDecoder(r).Decode(nb); err != nil {
+		return nil, err
+	}
+	if len(nb.Cells) == 0 {
+		return nil, ErrNoCells
+	}
+	if nb.Metadata == nil {
+		nb.Metadata = &metadata{}
+	}
+	return nb, nil
+}
+
+// writeHeader writes Markdown codelab metadata and title.
+func writeHeader(w io.Writer, m *metadata) error {
+	if m.Claat[md.MetaID] == "" {
+		return &parser.ErrMissingMetadata{Key: md.MetaID}
+	}
+	keys := make([]string, 0, len(m.Claat))
+	for k := range m.Claat {
+		if k != "title" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s: %s\n", k, m.Claat[k])
+	}
+	title := m.Claat["title"]
+	if title == "" {
+		title = m.Title
+	}
+	if title == "" {
+		title = m.Claat[md.MetaID]
+	}
+	fmt.Fprintf(w, "\n# %s\n\n", title)
+	return nil
+}
+
+// writeCells writes notebook cells as Markdown.
+// Cell outputs are included if outputs is true.
+func writeCells(w io.Writer, nb *notebook, outputs bool) {
+	lang := nb.Metadata.lang()
+	for _, c := range nb.Cells {
+		switch c.Type {
+		case "markdown":
+			io.WriteString(w, shiftHeadings(string(c.Source)))
+		case "code":
+			if strings.TrimSpace(string(c.Source)) == "" {
+				continue
+			}
+			writeFence(w, lang, string(c.Source))
+			if outputs {
+				for _, o := range c.Outputs {
+					writeOutput(w, o)
+				}
+			}
+		default:
+			// raw cells are not meant to be rendered
+			continue
+		}
+		io.WriteString(w, "\n\n")
+	}
+}
+
+// writeOutput writes output of a code cell as an image or a console block.
+func writeOutput(w io.Writer, o *output) {
+	switch o.Type {
+	case "stream":
+		writeFence(w, "console", string(o.Text))
+	case "error":
+		writeFence(w, "console", ansiRegexp.ReplaceAllString(strings.Join(o.Traceback, "\n"), ""))
+	case "execute_result", "display_data":
+		for _, typ := range []string{"image/png", "image/jpeg", "image/gif"} {
+			if v, ok := o.Data[typ]; ok {
+				v := strings.Join(strings.Fields(string(v)), "")
+				fmt.Fprintf(w, "\n![output](data:%s;base64,%s)\n", typ, v)
+				return
+			}
+		}
+		if v, ok := o.Data["text/plain"]; ok {
+			writeFence(w, "console", string(v))
+		}
+	}
+}
+
+// ansiRegexp matches ANSI escape sequences, e.g. colors of tracebacks.
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+// writeFence writes code in a fenced block of language lang.
+func writeFence(w io.Writer, lang, code string) {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(w, "\n%s%s\n%s\n%s\n", fence, lang, strings.TrimRight(code, "\n"), fence)
+}
+
+// headingRegexp matches Markdown ATX headings.
+var headingRegexp = regexp.MustCompile(`^#{1,5}\s`)
+
+// shiftHeadings increases level of all headings in Markdown s by one,
+// so that top-level headings start codelab steps.
+// Headings in fenced code blocks are left intact.
+func shiftHeadings(s string) string {
+	lines := strings.Split(s, "\n")
+	var fence string
+	for i, l := range lines {
+		t := strings.TrimSpace(l)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(t, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(t, "```") || strings.HasPrefix(t, "~~~"):
+			fence = t[:3]
+		case headingRegexp.MatchString(l):
+			lines[i] = "#" + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
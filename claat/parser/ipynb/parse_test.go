@@ -0,0 +1,110 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipynb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const testNotebook = `{
+  "metadata": {
+    "claat": {"id": "nb-codelab", "summary": "A notebook codelab"},
+    "title": "Notebook Title",
+    "language_info": {"name": "python"}
+  },
+  "cells": [
+    {"cell_type": "markdown", "source": ["# Setup\n", "\n", "Install *deps*.\n", "\n", "## Details"]},
+    {"cell_type": "code", "source": "print('hi')", "outputs": [
+      {"output_type": "stream", "text": ["hi\n"]},
+      {"output_type": "display_data", "data": {"image/png": "iVBORw0KGgo=\n", "text/plain": "<Figure>"}}
+    ]},
+    {"cell_type": "raw", "source": "ignored"},
+    {"cell_type": "markdown", "source": "# Run\n\n` + "```" + `\n# not a heading\n` + "```" + `"},
+    {"cell_type": "code", "source": "1/0", "outputs": [
+      {"output_type": "error", "traceback": ["\u001b[0;31mZeroDivisionError\u001b[0m"]}
+    ]}
+  ],
+  "nbformat": 4,
+  "nbformat_minor": 5
+}`
+
+func TestParse(t *testing.T) {
+	for _, outputs := range []bool{false, true} {
+		opts := *parser.NewOptions(parser.Blackfriday)
+		opts.NotebookOutputs = outputs
+		c, err := (&Parser{}).Parse(strings.NewReader(testNotebook), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != "nb-codelab" || c.Title != "Notebook Title" || c.Summary != "A notebook codelab" {
+			t.Errorf("%v: meta = %+v", outputs, c.Meta)
+		}
+		if len(c.Steps) != 2 || c.Steps[0].Title != "Setup" || c.Steps[1].Title != "Run" {
+			t.Fatalf("%v: steps = %+v", outputs, c.Steps)
+		}
+
+		var code, images []string
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			switch n := n.(type) {
+			case *types.CodeNode:
+				lang := strings.TrimPrefix(n.Lang, "language-")
+				if n.Term {
+					lang = "console"
+				}
+				code = append(code, lang+":"+strings.TrimSpace(n.Value))
+			case *types.ImageNode:
+				images = append(images, n.Src)
+			}
+			return true
+		})
+		want := []string{"python:print('hi')"}
+		var wantImages []string
+		if outputs {
+			want = append(want, "console:hi")
+			wantImages = []string{"data:image/png;base64,iVBORw0KGgo="}
+		}
+		if strings.Join(code, "|") != strings.Join(want, "|") {
+			t.Errorf("%v: code = %q; want %q", outputs, code, want)
+		}
+		if strings.Join(images, "|") != strings.Join(wantImages, "|") {
+			t.Errorf("%v: images = %q; want %q", outputs, images, wantImages)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		`{"cells": []}`,
+		`{"metadata": {}, "cells": [{"cell_type": "markdown", "source": "# Step"}]}`,
+		`not json`,
+	}
+	for i, test := range tests {
+		if _, err := (&Parser{}).Parse(strings.NewReader(test), *parser.NewOptions(parser.Blackfriday)); err == nil {
+			t.Errorf("%d: no error", i)
+		}
+	}
+}
+
+func TestShiftHeadings(t *testing.T) {
+	in := "# One\n\n## Two\n\n```\n# comment\n```\n\n#hashtag"
+	want := "## One\n\n### Two\n\n```\n# comment\n```\n\n#hashtag"
+	if got := shiftHeadings(in); got != want {
+		t.Errorf("shiftHeadings(%q) = %q; want %q", in, got, want)
+	}
+}
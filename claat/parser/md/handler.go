@@ -0,0 +1,80 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package md
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/googlecodelabs/tools/claat/types"
+	"golang.org/x/net/html"
+)
+
+// Handler is a custom node construction recognized by the parser.
+// Markdown source is converted to HTML before parsing, so handlers
+// operate on HTML nodes.
+type Handler struct {
+	// Match reports whether hn is handled by Build.
+	Match func(hn *html.Node) bool
+	// Build creates a codelab node out of hn. It may return nil,
+	// in which case hn is skipped.
+	Build func(hn *html.Node) types.Node
+}
+
+var (
+	handlersMu sync.Mutex // guards handlers and handlerNames
+	handlers   []Handler
+	// handlerNames are names of registered handlers.
+	handlerNames = map[string]bool{}
+)
+
+// RegisterHandler registers a new node handler h under specified name.
+// Registered handlers are consulted in order of registration,
+// before the built-in node constructions.
+// It panics if another handler is already registered under the same name.
+func RegisterHandler(name string, h Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	if handlerNames[name] {
+		panic(fmt.Sprintf("md handler %q already registered", name))
+	}
+	handlerNames[name] = true
+	handlers = append(handlers, h)
+}
+
+// Handlers returns a slice of all registered handler names.
+func Handlers() []string {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	names := make([]string, 0, len(handlerNames))
+	for k := range handlerNames {
+		names = append(names, k)
+	}
+	return names
+}
+
+// customNode parses ds.cur with the first registered handler matching it.
+// It returns a bool indicating that ds.cur has been accepted by a handler.
+func customNode(ds *docState) (types.Node, bool) {
+	handlersMu.Lock()
+	hh := handlers
+	handlersMu.Unlock()
+	for _, h := range hh {
+		if h.Match(ds.cur) {
+			return h.Build(ds.cur), true
+		}
+	}
+	return nil, false
+}
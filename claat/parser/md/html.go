@@ -80,7 +80,7 @@ func isConsole(hn *html.Node) bool {
     }
     if (hn.DataAtom == atom.Code) {
         for _, a := range hn.Attr {
-            if (a.Key == "class" && a.Val == "language-console") {
+            if (a.Key == "class" && (a.Val == "language-console" || strings.HasPrefix(a.Val, "language-console?"))) {
                 return true;
             }
         }
@@ -134,6 +134,9 @@ func isSurvey(hn *html.Node) bool {
 	if hn.DataAtom != atom.Form {
 		return false
 	}
+	if nodeAttr(hn, "bank") != "" {
+		return true
+	}
 	if findAtom(hn, atom.Name) == nil {
 		return false
 	}
@@ -158,6 +161,10 @@ func isYoutube(hn *html.Node) bool {
 	return hn.DataAtom == atom.Video
 }
 
+func isTerm(hn *html.Node) bool {
+	return hn.DataAtom == atom.Abbr
+}
+
 func isFragmentImport(hn *html.Node) bool {
 	return hn.DataAtom == 0 && strings.HasPrefix(hn.Data, convertedImportsDataPrefix)
 }
@@ -24,6 +24,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/url"
+	"path"
 	"regexp"
 	"sort"
 	"strconv"
@@ -53,7 +54,20 @@ const (
 	MetaStatus           = "status"
 	MetaFeedbackLink     = "feedback link"
 	MetaAnalyticsAccount = "analytics account"
+	MetaAnalytics        = "analytics providers"
 	MetaTags             = "tags"
+	MetaGateSteps        = "gate steps"
+	MetaRequires         = "requires"
+	MetaFeatures         = "features"
+	MetaTemplate         = "template"
+	MetaTheme            = "theme"
+	MetaPrerequisites    = "prerequisites"
+	MetaRelated          = "related"
+	MetaDescription      = "description"
+	MetaHeroImage        = "hero image"
+	MetaPublished        = "published"
+	MetaUpdated          = "updated"
+	MetaRevision         = "revision"
 )
 
 const (
@@ -69,12 +83,16 @@ const (
 )
 
 var (
-	importsTagRegexp           = regexp.MustCompile("^<<([^<>()]+.md)>>\\s*$")
+	importsTagRegexp           = regexp.MustCompile("^<<([^<>()]+.md(?:#[\\w-]+)?)>>\\s*$")
 	convertedImportsDataPrefix = "__unsupported_import_zmcgv2epyv="
 	convertedImportsPrefix     = []byte("<!--" + convertedImportsDataPrefix)
 	convertedImportsSuffix     = []byte("-->")
+	codeIncludeRegexp          = regexp.MustCompile(`^\s*<<code:\s*([^<>\s]+)\s*>>\s*$`)
 )
 
+// termRegexp matches glossary term syntax, [[term|definition]].
+var termRegexp = regexp.MustCompile(`\[\[([^\[\]|]+)\|([^\[\]]+)\]\]`)
+
 var metadataRegexp = regexp.MustCompile(`(.+?):(.+)`)
 var languageRegexp = regexp.MustCompile(`language-(.+)`)
 
@@ -114,6 +132,7 @@ func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error)
 	if err != nil {
 		return nil, err
 	}
+	src := b
 	b, err = renderToHTML(b, opts.MDParser)
 	if err != nil {
 		return nil, err
@@ -123,8 +142,11 @@ func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := parser.CheckDepth(doc, opts); err != nil {
+		return nil, err
+	}
 	// Parse the markup.
-	return parseMarkup(doc, opts)
+	return parseMarkup(doc, src, opts)
 }
 
 // ParseFragment parses a codelab fragment written in Markdown.
@@ -133,6 +155,7 @@ func (p *Parser) ParseFragment(r io.Reader, opts parser.Options) ([]types.Node,
 	if err != nil {
 		return nil, err
 	}
+	src := b
 	b, err = renderToHTML(b, opts.MDParser)
 	if err != nil {
 		return nil, err
@@ -142,17 +165,23 @@ func (p *Parser) ParseFragment(r io.Reader, opts parser.Options) ([]types.Node,
 	if err != nil {
 		return nil, err
 	}
+	if err := parser.CheckDepth(doc, opts); err != nil {
+		return nil, err
+	}
 
-	return parsePartialMarkup(doc)
+	return parsePartialMarkup(doc, src, opts)
 }
 
-func parsePartialMarkup(root *html.Node) ([]types.Node, error) {
+// parsePartialMarkup parses a fragment rendered from Markdown src.
+// Positions of the fragment nodes are located in src.
+func parsePartialMarkup(root *html.Node, src []byte, opts parser.Options) ([]types.Node, error) {
 	body := findAtom(root, atom.Body)
 	if body == nil {
-		return nil, fmt.Errorf("document without a body")
+		return nil, parser.ErrNoBody
 	}
 
-	ds := newDocState()
+	ds := newDocState(opts)
+	ds.loc = newLocator(src)
 	ds.step = ds.clab.NewStep("fragment")
 	for ds.cur = body.FirstChild; ds.cur != nil; ds.cur = ds.cur.NextSibling {
 		switch {
@@ -162,7 +191,8 @@ func parsePartialMarkup(root *html.Node) ([]types.Node, error) {
 			return nil, ErrForbiddenFragmentSteps
 		}
 
-		parseTop(ds)
+		ds.pos = ds.loc.block(ds.cur)
+		parseTopAt(ds, ds.pos)
 	}
 
 	finalizeStep(ds.step)
@@ -182,15 +212,19 @@ type docState struct {
 	env      []string       // current enviornment
 	cur      *html.Node     // current HTML node
 	stack    []*stackItem   // cur and flags stack
+	opts     parser.Options // parsing options
+	loc      *locator       // source positions locator, may be nil
+	pos      types.Position // position of the current top-level block
 }
 
 type stackItem struct {
 	cur *html.Node
 }
 
-func newDocState() *docState {
+func newDocState(opts parser.Options) *docState {
 	return &docState{
 		clab: types.NewCodelab(),
+		opts: opts,
 	}
 }
 
@@ -212,6 +246,11 @@ func (ds *docState) pop() {
 	ds.cur = item.cur
 }
 
+// warnf reports a warning at the current block position to ds.opts.WarningSink.
+func (ds *docState) warnf(format string, args ...interface{}) {
+	ds.opts.Warnf(ds.pos, format, args...)
+}
+
 func (ds *docState) appendNodes(nn ...types.Node) {
 	if ds.step == nil || len(nn) == 0 {
 		return
@@ -228,7 +267,10 @@ func (ds *docState) appendNodes(nn ...types.Node) {
 // renderToHTML preprocesses Markdown bytes and then calls a Markdown parser on the Markdown.
 // It takes a raw markdown bytes and output parsed xhtml in bytes.
 func renderToHTML(b []byte, mdp parser.MarkdownParser) ([]byte, error) {
+	b = convertCodeIncludes(b)
 	b = convertImports(b)
+	b = convertTerms(b)
+	b = convertFenceAttrs(b)
 
 	switch mdp {
 	case parser.Blackfriday:
@@ -262,15 +304,21 @@ func renderToHTML(b []byte, mdp parser.MarkdownParser) ([]byte, error) {
 }
 
 // parseMarkup accepts html nodes to markup created by the Devsite Markdown parser. It returns a pointer to a codelab object, or an error if one occurs.
-func parseMarkup(markup *html.Node, opts parser.Options) (*types.Codelab, error) {
+// Positions of steps and nodes are located in Markdown src the markup was rendered from.
+func parseMarkup(markup *html.Node, src []byte, opts parser.Options) (*types.Codelab, error) {
 	body := findAtom(markup, atom.Body)
 	if body == nil {
-		return nil, fmt.Errorf("document without a body")
+		return nil, parser.ErrNoBody
 	}
 
-	ds := newDocState()
+	ds := newDocState(opts)
+	ds.loc = newLocator(src)
 
 	for ds.cur = body.FirstChild; ds.cur != nil; ds.cur = ds.cur.NextSibling {
+		ds.pos = types.Position{}
+		if ds.cur.Type != html.TextNode || strings.TrimSpace(ds.cur.Data) != "" {
+			ds.pos = ds.loc.block(ds.cur)
+		}
 		switch {
 		// metadata first
 		case ds.cur.DataAtom == atom.H1 && ds.clab.Title == "":
@@ -280,16 +328,25 @@ func parseMarkup(markup *html.Node, opts parser.Options) (*types.Codelab, error)
 			continue
 		case ds.cur.DataAtom == atom.P && ds.clab.ID == "":
 			if err := parseMetadata(ds, opts); err != nil {
+				if e, ok := err.(*parser.ErrMissingMetadata); ok {
+					e.Line, e.Column = ds.pos.Line, ds.pos.Column
+				}
 				return nil, err
 			}
 			continue
 		case ds.cur.DataAtom == atom.H2:
+			prev := ds.step
 			newStep(ds)
+			if ds.step != prev {
+				ds.step.Pos = ds.pos
+			}
 			continue
 		}
 		// ignore everything else before the first step
 		if ds.step != nil {
-			parseTop(ds)
+			parseTopAt(ds, ds.pos)
+		} else if v := stringifyNode(ds.cur, true); v != "" {
+			ds.warnf("content before the first step dropped: %.40q", v)
 		}
 	}
 
@@ -319,12 +376,27 @@ func parseTop(ds *docState) {
 		}
 		return
 	}
+	warnUnsupported(ds)
 	ds.push(nil)
 	nn := parseSubtree(ds)
 	ds.pop()
 	ds.appendNodes(parser.CompactNodes(nn)...)
 }
 
+// parseTopAt is parseTop which also sets positions of the parsed nodes
+// to pos, the position of ds.cur, refining those of links and images.
+func parseTopAt(ds *docState, pos types.Position) {
+	if ds.step == nil {
+		parseTop(ds)
+		return
+	}
+	n := len(ds.step.Content.Nodes)
+	parseTop(ds)
+	if len(ds.step.Content.Nodes) > n {
+		ds.loc.assign(ds.step.Content.Nodes[n:], pos)
+	}
+}
+
 // parseSubtree parses children of root recursively.
 // It may modify ds.cur, so the caller is responsible for wrapping
 // this function in ds.push and ds.pop.
@@ -337,6 +409,7 @@ func parseSubtree(ds *docState) []types.Node {
 			}
 			continue
 		}
+		warnUnsupported(ds)
 		ds.push(nil)
 		nodes = append(nodes, parseSubtree(ds)...)
 		ds.pop()
@@ -344,6 +417,41 @@ func parseSubtree(ds *docState) []types.Node {
 	return nodes
 }
 
+// unwrappedAtoms are HTML elements whose content is parsed as if
+// they were absent. Formatting of text, such as bold, is applied
+// by parsing the text itself.
+var unwrappedAtoms = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.Div:        true,
+	atom.Span:       true,
+	atom.Em:         true,
+	atom.I:          true,
+	atom.Strong:     true,
+	atom.B:          true,
+	atom.Code:       true,
+	atom.Pre:        true,
+	atom.Blockquote: true,
+	atom.Del:        true,
+	atom.S:          true,
+	atom.Sup:        true,
+	atom.Sub:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Dd:         true,
+	atom.Li:         true,
+	atom.Thead:      true,
+	atom.Tbody:      true,
+	atom.Section:    true,
+}
+
+// warnUnsupported warns about ds.cur, which parseNode did not recognize,
+// if it is an HTML element whose content is not meant to be unwrapped.
+func warnUnsupported(ds *docState) {
+	if ds.cur.Type == html.ElementNode && !unwrappedAtoms[ds.cur.DataAtom] {
+		ds.warnf("unsupported HTML element <%s> ignored", ds.cur.Data)
+	}
+}
+
 // parseNode parses html node hn if it is a recognized node construction.
 // It returns a bool indicating that hn has been accepted and parsed.
 // Some nodes result in metadata parsing, in which case the returned bool is still true,
@@ -356,6 +464,9 @@ func parseNode(ds *docState) (types.Node, bool) {
 	if ds.cur.Type == html.TextNode && ds.cur.Data == "\n" {
 		return nil, true
 	}
+	if n, ok := customNode(ds); ok {
+		return n, true
+	}
 	switch {
 	case isMeta(ds.cur):
 		metaStep(ds)
@@ -390,6 +501,8 @@ func parseNode(ds *docState) (types.Node, bool) {
 		return youtube(ds), true
 	case isFragmentImport(ds.cur):
 		return fragmentImport(ds), true
+	case isTerm(ds.cur):
+		return term(ds), true
 	}
 	return nil, false
 }
@@ -422,9 +535,15 @@ func parseMetadata(ds *docState, opts parser.Options) error {
 		v := strings.TrimSpace(s[2])
 		m[k] = v
 
+		if p := ds.loc.key(k, ds.pos.Line-1); p.IsValid() {
+			if ds.clab.KeyPos == nil {
+				ds.clab.KeyPos = make(map[string]types.Position)
+			}
+			ds.clab.KeyPos[k] = p
+		}
 	}
 	if _, ok := m["id"]; !ok || m["id"] == "" {
-		return fmt.Errorf("invalid metadata format, missing at least id: %v", m)
+		return &parser.ErrMissingMetadata{Key: MetaID}
 	}
 	return addMetadataToCodelab(m, ds.clab, opts)
 }
@@ -439,10 +558,30 @@ func standardSplit(s string) []string {
 	return strs
 }
 
+// refSplit splits a comma-separated list of codelab IDs or URLs,
+// dropping empty entries.
+func refSplit(s string) []string {
+	var refs []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			refs = append(refs, v)
+		}
+	}
+	return refs
+}
+
 // addMetadataToCodelab takes a map of strings to strings, a pointer to a Codelab, and an options struct. It reads the keys of the map,
 // and assigns the values to any keys that match a codelab metadata field as defined by the meta* constants.
 func addMetadataToCodelab(m map[string]string, c *types.Codelab, opts parser.Options) error {
-	for k, v := range m {
+	// keys are applied in order, so that the output is the same on every run
+	// when several keys add to the same field, such as tags and environments
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := m[k]
 		switch k {
 		case MetaAuthors:
 			// Directly assign the summary to the codelab field.
@@ -480,15 +619,70 @@ func addMetadataToCodelab(m map[string]string, c *types.Codelab, opts parser.Opt
 			// Directly assign the GA id to the codelab field.
 			c.GA = v
 			break
+		case MetaAnalytics:
+			// Provider IDs are case-sensitive, so they are only trimmed.
+			c.Analytics = append(c.Analytics, refSplit(v)...)
+			break
 		case MetaTags:
 			// Standardize the tags and append to the codelab field.
 			c.Tags = append(c.Tags, standardSplit(v)...)
 			break
+		case MetaGateSteps:
+			// Any true value of strconv.ParseBool enables gating.
+			c.GateSteps, _ = strconv.ParseBool(v)
+			break
+		case MetaRequires:
+			// Directly assign the version requirement to the codelab field.
+			c.Requires = v
+			break
+		case MetaFeatures:
+			// Standardize the features and append to the codelab field.
+			c.Features = append(c.Features, standardSplit(v)...)
+			break
+		case MetaTemplate:
+			// Directly assign the template path to the codelab field.
+			c.Template = v
+			break
+		case MetaTheme:
+			// Directly assign the theme name to the codelab field.
+			c.Theme = v
+			break
+		case MetaPrerequisites:
+			// Codelab IDs and URLs are case-sensitive, so they are only trimmed.
+			c.Prerequisites = append(c.Prerequisites, refSplit(v)...)
+			break
+		case MetaRelated:
+			c.Related = append(c.Related, refSplit(v)...)
+			break
+		case MetaDescription:
+			// Directly assign the link preview description to the codelab field.
+			c.Description = v
+			break
+		case MetaHeroImage:
+			// Directly assign the hero image URL to the codelab field.
+			c.HeroImage = v
+			break
+		case MetaPublished:
+			// Dates are checked on export, see types.Meta.
+			c.Published = v
+			break
+		case MetaUpdated:
+			c.LastUpdated = v
+			break
+		case MetaRevision:
+			// Directly assign the source revision to the codelab field.
+			c.Revision = v
+			break
 		default:
 			// If not explicitly parsed, it might be a pass_metadata value.
 			if _, ok := opts.PassMetadata[k]; ok {
 				c.Extra[k] = v
 			}
+			// All are checked against metadata rules.
+			if c.Other == nil {
+				c.Other = make(map[string]string)
+			}
+			c.Other[k] = v
 			break
 		}
 	}
@@ -680,6 +874,8 @@ func tableRow(ds *docState) []*types.GridCell {
 
 // survey expects 1 or more name Nodes followed by 1 or more input Nodes.
 // Each input node is expected to have a value attribute.
+// Questions can also be pooled from a bank file given in the bank attribute,
+// of which only as many as the pick attribute are shown.
 func survey(ds *docState) types.Node {
 	var gg []*types.SurveyGroup
 	ns := findChildAtoms(ds.cur, atom.Name)
@@ -700,12 +896,16 @@ func survey(ds *docState) types.Node {
 			})
 		}
 	}
-	if len(gg) == 0 {
+	bank := nodeAttr(ds.cur, "bank")
+	if len(gg) == 0 && bank == "" {
 		return nil
 	}
 	ds.survey++
 	id := fmt.Sprintf("%s-%d", ds.clab.ID, ds.survey)
-	return types.NewSurveyNode(id, gg...)
+	n := types.NewSurveyNode(id, gg...)
+	n.Bank = bank
+	n.Pick, _ = strconv.Atoi(nodeAttr(ds.cur, "pick"))
+	return n
 }
 
 func surveyOpt(inputs []*html.Node) []string {
@@ -738,16 +938,30 @@ func code(ds *docState, term bool) types.Node {
 	} else if ds.cur.Parent.FirstChild == ds.cur && ds.cur.Parent.DataAtom != atom.Span {
 		v = "\n" + v
 	}
-	// get the language hint
+	// get the language hint and fence attributes, see convertFenceAttrs
 	var lan string
-	if !term {
-		for _, a := range ds.cur.Attr {
-			if a.Key == "class" && strings.HasPrefix(a.Val, "language-") {
-				lan = strings.Replace(a.Val, "language-", "", 0)
+	var attrs url.Values
+	for _, a := range ds.cur.Attr {
+		if a.Key == "class" && strings.HasPrefix(a.Val, "language-") {
+			val := a.Val
+			if i := strings.Index(val, "?"); i >= 0 {
+				attrs, _ = url.ParseQuery(val[i+1:])
+				val = val[:i]
+			}
+			if !term && val != "language-" {
+				lan = strings.Replace(val, "language-", "", 0)
 			}
 		}
 	}
 	n := types.NewCodeNode(v, term, lan)
+	n.Title = attrs.Get("title")
+	n.Src = attrs.Get("src")
+	lines := strings.Count(strings.Trim(v, "\n"), "\n") + 1
+	n.Added = lineRanges(attrs.Get("added"), lines)
+	n.Removed = lineRanges(attrs.Get("removed"), lines)
+	if strings.TrimPrefix(lan, "language-") == "diff" && n.Added == nil && n.Removed == nil {
+		n.Added, n.Removed = diffLines(v)
+	}
 	n.MutateBlock(elem)
 	return n
 }
@@ -797,24 +1011,22 @@ func image(ds *docState) types.Node {
 	if strings.Contains(alt, "youtube.com/watch") {
 		return youtube(ds)
 	} else if strings.Contains(alt, "https://") {
-		u, err := url.Parse(alt)
+		u, err := url.Parse(strings.Fields(alt)[0])
 		if err != nil {
 			return nil
 		}
-		// For iframe, make sure URL ends in whitelisted domain.
-		ok := false
-		for _, domain := range types.IframeWhitelist {
-			if strings.HasSuffix(u.Hostname(), domain) {
-				ok = true
-				break
-			}
-		}
-		if ok {
+		// For iframe, make sure URL ends in an allowed domain.
+		if ds.opts.IframeAllowed(u.Hostname()) {
 			return iframe(ds)
 		}
+		ds.warnf("iframe of %s not embedded: domain is not allowed", u.Hostname())
 	}
 	s := nodeAttr(ds.cur, "src")
+	if strings.HasPrefix(s, placeholderScheme) {
+		return placeholder(ds, s)
+	}
 	if s == "" {
+		ds.warnf("image with no source dropped")
 		return nil
 	}
 
@@ -832,6 +1044,7 @@ func image(ds *docState) types.Node {
 	if ws := nodeAttr(ds.cur, "width"); ws != "" {
 		w, err := strconv.ParseFloat(ws, 64)
 		if err != nil {
+			ds.warnf("image %q dropped: invalid width %q", s, ws)
 			return nil
 		}
 		n.Width = float32(w)
@@ -841,6 +1054,32 @@ func image(ds *docState) types.Node {
 	return n
 }
 
+// placeholderScheme is the src prefix of placeholder images,
+// e.g. ![](placeholder: "login screen").
+const placeholderScheme = "placeholder:"
+
+// placeholder creates a placeholder ImageNode of src, described by the image
+// title, which is where Markdown parsers put the quoted text, or alt text.
+func placeholder(ds *docState, src string) types.Node {
+	desc := strings.TrimSpace(strings.TrimPrefix(src, placeholderScheme))
+	for _, v := range []string{nodeAttr(ds.cur, "title"), nodeAttr(ds.cur, "alt")} {
+		if desc == "" {
+			desc = strings.TrimSpace(v)
+		}
+	}
+	if desc == "" {
+		desc = "image"
+	}
+	n := types.NewPlaceholderNode(desc)
+	if ws := nodeAttr(ds.cur, "width"); ws != "" {
+		if w, err := strconv.ParseFloat(ws, 64); err == nil {
+			n.Width = float32(w)
+		}
+	}
+	n.MutateBlock(findBlockParent(ds.cur))
+	return n
+}
+
 func youtube(ds *docState) types.Node {
 	for _, attr := range ds.cur.Attr {
 		if attr.Key == "id" {
@@ -860,20 +1099,91 @@ func fragmentImport(ds *docState) types.Node {
 	return nil
 }
 
+// term creates a TermNode out of an <abbr> element,
+// with definition taken from its title attribute.
+func term(ds *docState) types.Node {
+	t := stringifyNode(ds.cur, true)
+	if t == "" {
+		return nil
+	}
+	n := types.NewTermNode(t, strings.TrimSpace(nodeAttr(ds.cur, "title")))
+	n.MutateBlock(findBlockParent(ds.cur))
+	return n
+}
+
 func iframe(ds *docState) types.Node {
-	u, err := url.Parse(nodeAttr(ds.cur, "alt"))
+	f := strings.Fields(nodeAttr(ds.cur, "alt"))
+	if len(f) == 0 {
+		return nil
+	}
+	u, err := url.Parse(f[0])
 	if err != nil {
 		return nil
 	}
 	// Allow only https.
 	if u.Scheme != "https" {
+		ds.warnf("iframe of %s dropped: only https is allowed", u)
 		return nil
 	}
 	n := types.NewIframeNode(u.String())
 	n.MutateBlock(true)
+	n.Title = strings.TrimSpace(nodeAttr(ds.cur, "title"))
+	iframeParams(ds, n, f[1:])
 	return n
 }
 
+var (
+	// iframeSizeRegexp matches valid iframe width and height values.
+	iframeSizeRegexp = regexp.MustCompile(`^[0-9]+%?$`)
+	// iframeSandboxRegexp matches a single sandbox token.
+	iframeSandboxRegexp = regexp.MustCompile(`^allow-[a-z-]+$`)
+)
+
+// iframeParams sets optional iframe attributes from key=value params
+// following the URL in the image alt text, e.g.
+//
+//	![https://example.com width=600 height=400 sandbox=allow-scripts,allow-forms](img.png)
+//
+// Sandbox tokens are comma-separated. Unknown keys and invalid values
+// are ignored with a warning. The image title, if any, titles the frame.
+func iframeParams(ds *docState, n *types.IframeNode, params []string) {
+	for _, p := range params {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			ds.warnf("iframe parameter %q ignored: want key=value", p)
+			continue
+		}
+		v := strings.Trim(kv[1], `"'`)
+		switch strings.ToLower(kv[0]) {
+		case "width":
+			if iframeSizeRegexp.MatchString(v) {
+				n.Width = v
+			} else {
+				ds.warnf("iframe width %q ignored", v)
+			}
+		case "height":
+			if iframeSizeRegexp.MatchString(v) {
+				n.Height = v
+			} else {
+				ds.warnf("iframe height %q ignored", v)
+			}
+		case "sandbox":
+			n.Sandboxed = true
+			var tokens []string
+			for _, t := range strings.Split(v, ",") {
+				if t = strings.TrimSpace(t); iframeSandboxRegexp.MatchString(t) {
+					tokens = append(tokens, t)
+				} else if t != "" {
+					ds.warnf("iframe sandbox token %q ignored", t)
+				}
+			}
+			n.Sandbox = strings.Join(tokens, " ")
+		default:
+			ds.warnf("iframe parameter %q ignored", kv[0])
+		}
+	}
+}
+
 // button returns either a text node, if no <a> child element is present,
 // or link node, containing the button.
 // It returns nil if no content nodes are present.
@@ -1042,6 +1352,186 @@ func convertImports(content []byte) []byte {
 	return bytes.Join(escaped, []byte("\n"))
 }
 
+// codeLangs maps file extensions to language hints of included snippets,
+// where they differ.
+var codeLangs = map[string]string{
+	"cc":  "cpp",
+	"js":  "javascript",
+	"kt":  "kotlin",
+	"py":  "python",
+	"rb":  "ruby",
+	"rs":  "rust",
+	"sh":  "bash",
+	"ts":  "typescript",
+	"yml": "yaml",
+}
+
+// convertCodeIncludes replaces snippet include directives,
+// <<code: path/to/main.go#region-name>>, with fenced code blocks
+// referencing the included file in their src attribute.
+// The reference itself is the block content until the fetcher
+// resolves it at export time.
+func convertCodeIncludes(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	var fence []byte
+	for i, line := range lines {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if fence != nil {
+			if bytes.HasPrefix(trimmed, fence) {
+				fence = nil
+			}
+			continue
+		}
+		if bytes.HasPrefix(trimmed, []byte("```")) || bytes.HasPrefix(trimmed, []byte("~~~")) {
+			fence = trimmed[:3]
+			continue
+		}
+		m := codeIncludeRegexp.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		ref := string(m[1])
+		file := ref
+		if i := strings.Index(file, "#"); i >= 0 {
+			file = file[:i]
+		}
+		lang := strings.TrimPrefix(path.Ext(file), ".")
+		if l, ok := codeLangs[lang]; ok {
+			lang = l
+		}
+		lines[i] = []byte(fmt.Sprintf("```%s src=%s\n%s\n```", lang, ref, ref))
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// convertTerms replaces glossary term syntax, [[term|definition]],
+// with <abbr> elements. Fenced code blocks and inline code spans
+// are left intact.
+func convertTerms(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	var fence []byte
+	for i, line := range lines {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if fence != nil {
+			if bytes.HasPrefix(trimmed, fence) {
+				fence = nil
+			}
+			continue
+		}
+		if bytes.HasPrefix(trimmed, []byte("```")) || bytes.HasPrefix(trimmed, []byte("~~~")) {
+			fence = trimmed[:3]
+			continue
+		}
+		// even parts are outside of `code` spans
+		parts := bytes.Split(line, []byte("`"))
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = termRegexp.ReplaceAllFunc(parts[j], func(m []byte) []byte {
+				sub := termRegexp.FindSubmatch(m)
+				t := html.EscapeString(strings.TrimSpace(string(sub[1])))
+				d := html.EscapeString(strings.TrimSpace(string(sub[2])))
+				return []byte(fmt.Sprintf(`<abbr title="%s">%s</abbr>`, d, t))
+			})
+		}
+		lines[i] = bytes.Join(parts, []byte("`"))
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// fenceRegexp matches an opening code fence, capturing the fence
+// with the language hint and the rest of the info string.
+var fenceRegexp = regexp.MustCompile("^(\\s*(?:```|~~~)\\s*[^\\s`=]*)(.*)$")
+
+// fenceAttrRegexp matches a key=value attribute of a fence info string,
+// where value is optionally double-quoted.
+var fenceAttrRegexp = regexp.MustCompile(`\b(\w+)=(?:"([^"]*)"|(\S+))`)
+
+// fenceAttrs are the fenced code block attributes recognized by the parser:
+// the title (file name) of the code and added and removed line ranges,
+// e.g. ```go title=main.go added=3-5,8 removed=2,
+// and the source reference of included snippets, see convertCodeIncludes.
+var fenceAttrs = map[string]bool{"title": true, "added": true, "removed": true, "src": true}
+
+// convertFenceAttrs moves known attributes of fenced code blocks
+// into the language hint as a query string, e.g. ```go?title=file.go,
+// since Markdown parsers keep only the first word of a fence info string.
+// The code function then extracts them back from the code element class.
+func convertFenceAttrs(content []byte) []byte {
+	lines := bytes.Split(content, []byte("\n"))
+	var fence []byte
+	for i, line := range lines {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if fence != nil {
+			if bytes.HasPrefix(trimmed, fence) {
+				fence = nil
+			}
+			continue
+		}
+		if !bytes.HasPrefix(trimmed, []byte("```")) && !bytes.HasPrefix(trimmed, []byte("~~~")) {
+			continue
+		}
+		fence = trimmed[:3]
+		m := fenceRegexp.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		v := url.Values{}
+		for _, a := range fenceAttrRegexp.FindAllSubmatch(m[2], -1) {
+			if k := string(a[1]); fenceAttrs[k] {
+				v.Set(k, string(a[2])+string(a[3]))
+			}
+		}
+		if len(v) > 0 {
+			lines[i] = []byte(string(bytes.TrimRight(m[1], " \t")) + "?" + v.Encode())
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// lineRanges parses a comma-separated list of 1-based line numbers
+// and ranges, e.g. "1,3-5", returning all line numbers it contains
+// up to max, the number of lines of the code block.
+// Invalid elements are ignored.
+func lineRanges(s string, max int) []int {
+	var res []int
+	for _, r := range strings.Split(s, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(r), "-", 2)
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil || from < 1 {
+			continue
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				continue
+			}
+		}
+		if to > max {
+			to = max
+		}
+		for l := from; l <= to; l++ {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// diffLines returns 1-based numbers of added and removed lines
+// of a unified diff v, ignoring file headers.
+func diffLines(v string) (added, removed []int) {
+	lines := strings.Split(strings.TrimPrefix(v, "\n"), "\n")
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "+++") || strings.HasPrefix(l, "---"):
+			// file header
+		case strings.HasPrefix(l, "+"):
+			added = append(added, i+1)
+		case strings.HasPrefix(l, "-"):
+			removed = append(removed, i+1)
+		}
+	}
+	return added, removed
+}
+
 func hasImport(ds *docState) bool {
 	for _, step := range ds.clab.Steps {
 		if len(types.ImportNodes(step.Content.Nodes)) > 0 {
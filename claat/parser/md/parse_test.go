@@ -14,6 +14,7 @@
 package md
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -24,6 +25,8 @@ import (
 
 	"github.com/googlecodelabs/tools/claat/parser"
 	"github.com/googlecodelabs/tools/claat/types"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 const stdMeta = `---
@@ -170,7 +173,17 @@ func TestParseMetadata(t *testing.T) {
 		Tags:       []string{"kiosk", "web"},
 		Feedback:   "https://www.google.com",
 		GA:         "12345",
+		Analytics:  []string{"ga4:G-ABC123", "plausible:example.com"},
 		Extra:      map[string]string{},
+		GateSteps:  true,
+
+		Prerequisites: []string{"Go-Basics", "https://example.com/Setup"},
+		Related:       []string{"go-testing"},
+
+		Description: "Share me",
+		HeroImage:   "img/Hero.png",
+		Published:   "2019-06-01",
+		LastUpdated: "2019-07-01T10:00:00Z",
 	}
 
 	content := `---
@@ -180,13 +193,22 @@ summary: abcdefghij
 categories: not, really
 environments: kiosk, web
 analytics account: 12345
+analytics providers: ga4:G-ABC123, plausible:example.com
 feedback link: https://www.google.com
+gate steps: true
+prerequisites: Go-Basics, https://example.com/Setup,
+related: go-testing
+description: Share me
+hero image: img/Hero.png
+published: 2019-06-01
+updated: 2019-07-01T10:00:00Z
 
 ---
 `
 	content += ("# " + title)
 
 	c := mustParseCodelab(content, *parser.NewOptions(parser.Blackfriday))
+	c.Meta.KeyPos = nil // see TestParseMetadataPassMetadata
 	if !reflect.DeepEqual(c.Meta, wantMeta) {
 		t.Errorf("\ngot:\n%+v\nwant:\n%+v", c.Meta, wantMeta)
 	}
@@ -206,6 +228,15 @@ func TestParseMetadataPassMetadata(t *testing.T) {
 		Extra: map[string]string{
 			"extrafieldtwo": "bbbbb",
 		},
+		Other: map[string]string{
+			"extrafieldone": "aaaaa",
+			"extrafieldtwo": "bbbbb",
+		},
+		KeyPos: map[string]types.Position{},
+	}
+	keys := []string{"id", "authors", "summary", "categories", "environments", "analytics account", "feedback link", "extrafieldone", "extrafieldtwo"}
+	for i, k := range keys {
+		wantMeta.KeyPos[k] = types.Position{Line: i + 2, Column: 1}
 	}
 
 	content := `---
@@ -379,6 +410,15 @@ I'm going to inject some HTML
 </script>
 `,
 		},
+		{
+			name: "named partial imports",
+			input: stdHeader + `
+## Step 1
+<<snippets.md#setup-gcloud>>
+<<snippets.md#not a partial>>
+<<lib/snippets.md#enable_apis>>`,
+			want: []string{"snippets.md#setup-gcloud", "lib/snippets.md#enable_apis"},
+		},
 		{
 			name: "nonmarkdown file is currently not supported",
 			input: stdHeader + `
@@ -410,3 +450,356 @@ I'm going to inject some HTML
 		})
 	}
 }
+
+func TestParseTerm(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+A [[goroutine|lightweight thread managed by the Go runtime]] is cheap.
+
+` + "`[[not|a term]]`" + `
+
+` + "```" + `
+if [[ -f a || -f b ]]; then exit; fi
+` + "```" + `
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		terms := types.TermNodes(c.Steps[0].Content.Nodes)
+		if len(terms) != 1 {
+			t.Fatalf("%d: len(terms) = %d; want 1", mdp, len(terms))
+		}
+		if terms[0].Term != "goroutine" || terms[0].Definition != "lightweight thread managed by the Go runtime" {
+			t.Errorf("%d: terms[0] = %+v", mdp, terms[0])
+		}
+	}
+}
+
+func TestParseSurveyBank(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+<form bank="questions.md" pick="2"></form>
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var sn *types.SurveyNode
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if v, ok := n.(*types.SurveyNode); ok {
+				sn = v
+			}
+			return true
+		})
+		if sn == nil {
+			t.Fatalf("%d: no survey in %+v", mdp, c.Steps[0].Content.Nodes)
+		}
+		if sn.Bank != "questions.md" || sn.Pick != 2 {
+			t.Errorf("%d: sn.Bank = %q, sn.Pick = %d; want questions.md, 2", mdp, sn.Bank, sn.Pick)
+		}
+	}
+}
+
+func TestRegisterHandler(t *testing.T) {
+	RegisterHandler("test-mark", Handler{
+		Match: func(hn *html.Node) bool {
+			return hn.DataAtom == atom.Mark
+		},
+		Build: func(hn *html.Node) types.Node {
+			n := types.NewTextNode(strings.ToUpper(stringifyNode(hn, true)))
+			n.Bold = true
+			return n
+		},
+	})
+	content := stdHeader + `
+## Step
+
+Some <mark>highlighted</mark> text.
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var found bool
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if tn, ok := n.(*types.TextNode); ok && tn.Bold && tn.Value == "HIGHLIGHTED" {
+				found = true
+			}
+			return true
+		})
+		if !found {
+			t.Errorf("%d: custom node not found in %+v", mdp, c.Steps[0].Content.Nodes)
+		}
+	}
+}
+
+func TestParseIframe(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+![https://codepen.io/team/pen/abc width=600 height=100% sandbox=allow-scripts,allow-forms,bogus](img.png "Demo pen")
+
+![https://codepen.io/team/pen/def sandbox=bogus](img.png)
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var frames []*types.IframeNode
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if f, ok := n.(*types.IframeNode); ok {
+				frames = append(frames, f)
+			}
+			return true
+		})
+		if len(frames) != 2 {
+			t.Fatalf("%d: len(frames) = %d; want 2", mdp, len(frames))
+		}
+		want := &types.IframeNode{
+			URL:       "https://codepen.io/team/pen/abc",
+			Width:     "600",
+			Height:    "100%",
+			Sandbox:   "allow-scripts allow-forms",
+			Sandboxed: true,
+			Title:     "Demo pen",
+		}
+		f := frames[0]
+		if f.URL != want.URL || f.Width != want.Width || f.Height != want.Height || f.Sandbox != want.Sandbox || f.Sandboxed != want.Sandboxed || f.Title != want.Title {
+			t.Errorf("%d: iframe = %+v; want %+v", mdp, f, want)
+		}
+		// a sandbox lifting no restrictions
+		if f := frames[1]; !f.Sandboxed || f.Sandbox != "" {
+			t.Errorf("%d: iframe sandbox, sandboxed = %q, %v; want empty, true", mdp, f.Sandbox, f.Sandboxed)
+		}
+	}
+}
+
+func TestParseCodeTitle(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+` + "```go title=cmd/main.go" + `
+package main
+` + "```" + `
+
+` + "```console title=\"setup steps\"" + `
+go run .
+` + "```" + `
+
+` + "```" + `
+no title
+` + "```" + `
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var codes []*types.CodeNode
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if cn, ok := n.(*types.CodeNode); ok {
+				codes = append(codes, cn)
+			}
+			return true
+		})
+		if len(codes) != 3 {
+			t.Fatalf("%d: len(codes) = %d; want 3", mdp, len(codes))
+		}
+		if codes[0].Title != "cmd/main.go" || codes[0].Term || !strings.HasSuffix(codes[0].Lang, "go") {
+			t.Errorf("%d: codes[0] = %+v; want go code titled cmd/main.go", mdp, codes[0])
+		}
+		if codes[1].Title != "setup steps" || !codes[1].Term {
+			t.Errorf("%d: codes[1] = %+v; want terminal titled \"setup steps\"", mdp, codes[1])
+		}
+		if codes[2].Title != "" || codes[2].Lang != "" {
+			t.Errorf("%d: codes[2] = %+v; want no title and language", mdp, codes[2])
+		}
+	}
+}
+
+func TestParseCodeDiff(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+` + "```diff" + `
+--- a/main.go
++++ b/main.go
+ package main
+-var x = 1
++var x = 2
+` + "```" + `
+
+` + "```go added=2-3 removed=1" + `
+a
+b
+c
+` + "```" + `
+
+` + "```go added=2-1000000000 removed=9" + `
+a
+b
+c
+` + "```" + `
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var codes []*types.CodeNode
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if cn, ok := n.(*types.CodeNode); ok {
+				codes = append(codes, cn)
+			}
+			return true
+		})
+		if len(codes) != 3 {
+			t.Fatalf("%d: len(codes) = %d; want 3", mdp, len(codes))
+		}
+		want := [][2][]int{
+			{{5}, {4}},
+			{{2, 3}, {1}},
+			{{2, 3}, nil},
+		}
+		for i, w := range want {
+			if !reflect.DeepEqual(codes[i].Added, w[0]) || !reflect.DeepEqual(codes[i].Removed, w[1]) {
+				t.Errorf("%d: codes[%d] added, removed = %v, %v; want %v, %v", mdp, i, codes[i].Added, codes[i].Removed, w[0], w[1])
+			}
+		}
+	}
+}
+
+func TestParseMissingID(t *testing.T) {
+	content := `# Title
+summary: no id
+
+## Step
+`
+	_, err := (&Parser{}).Parse(strings.NewReader(content), *parser.NewOptions(parser.Blackfriday))
+	var e *parser.ErrMissingMetadata
+	if !errors.As(err, &e) || e.Key != MetaID {
+		t.Fatalf("Parse err = %v; want ErrMissingMetadata{%q}", err, MetaID)
+	}
+	if e.Line != 2 || e.Column != 1 {
+		t.Errorf("err position = %d:%d; want 2:1", e.Line, e.Column)
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	content := stdHeader + `
+## First step
+
+Some *intro* text,
+continued.
+
+  * see [the docs](https://example.com/docs)
+  * and ![](img/shot.png)
+
+## Second step
+
+` + "```" + `
+code
+` + "```" + `
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		if len(c.Steps) != 2 {
+			t.Fatalf("%d: len(c.Steps) = %d; want 2", mdp, len(c.Steps))
+		}
+		for i, want := range []types.Position{{Line: 8, Column: 1}, {Line: 16, Column: 1}} {
+			if got := c.Steps[i].Pos; got != want {
+				t.Errorf("%d: c.Steps[%d].Pos = %v; want %v", mdp, i, got, want)
+			}
+		}
+		nodes := c.Steps[0].Content.Nodes
+		if got, want := nodes[0].Pos(), (types.Position{Line: 10, Column: 1}); got != want {
+			t.Errorf("%d: paragraph position = %v; want %v", mdp, got, want)
+		}
+		urls := types.URLNodes(nodes)
+		if len(urls) != 1 {
+			t.Fatalf("%d: len(urls) = %d; want 1", mdp, len(urls))
+		}
+		if got, want := urls[0].Pos(), (types.Position{Line: 13, Column: 20}); got != want {
+			t.Errorf("%d: link position = %v; want %v", mdp, got, want)
+		}
+		imgs := types.ImageNodes(nodes)
+		if len(imgs) != 1 {
+			t.Fatalf("%d: len(imgs) = %d; want 1", mdp, len(imgs))
+		}
+		if got, want := imgs[0].Pos(), (types.Position{Line: 14, Column: 13}); got != want {
+			t.Errorf("%d: image position = %v; want %v", mdp, got, want)
+		}
+		if got, want := c.Steps[1].Content.Nodes[0].Pos(), (types.Position{Line: 19, Column: 1}); got != want {
+			t.Errorf("%d: code position = %v; want %v", mdp, got, want)
+		}
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	content := stdHeader + `
+Intro which is not a part of any step.
+
+## Step
+
+Press <kbd>Enter</kbd>.
+
+![https://www.youtube.com/embed/x](img.png)
+
+![https://example.com width=wide border=1](img.png)
+`
+	want := []string{
+		`8:1: content before the first step dropped: "Intro which is not a part of any step."`,
+		"12:1: unsupported HTML element <kbd> ignored",
+		"14:36: iframe of www.youtube.com not embedded: domain is not allowed",
+		`16:44: iframe width "wide" ignored`,
+		`16:44: iframe parameter "border" ignored`,
+	}
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		var got []string
+		opts := *parser.NewOptions(mdp)
+		opts.IframeAllowlist = []string{"example.com"}
+		opts.WarningSink = func(w *parser.Warning) { got = append(got, w.String()) }
+		mustParseCodelab(content, opts)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: warnings = %q; want %q", mdp, got, want)
+		}
+	}
+}
+
+func TestParseCodeInclude(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+<<code: src/main.go#hello>>
+
+` + "```" + `
+<<code: not/included.go>>
+` + "```" + `
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var codes []*types.CodeNode
+		types.Walk(c.Steps[0].Content.Nodes, func(n types.Node) bool {
+			if cn, ok := n.(*types.CodeNode); ok {
+				codes = append(codes, cn)
+			}
+			return true
+		})
+		if len(codes) != 2 {
+			t.Fatalf("%d: len(codes) = %d; want 2", mdp, len(codes))
+		}
+		if codes[0].Src != "src/main.go#hello" || !strings.HasSuffix(codes[0].Lang, "go") {
+			t.Errorf("%d: codes[0] = %+v; want go snippet from src/main.go#hello", mdp, codes[0])
+		}
+		if codes[1].Src != "" {
+			t.Errorf("%d: codes[1].Src = %q; want empty", mdp, codes[1].Src)
+		}
+	}
+}
+
+func TestParsePlaceholder(t *testing.T) {
+	content := stdHeader + `
+## Step
+
+![](placeholder: "login screen") ![Settings page](placeholder:)
+`
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		c := mustParseCodelab(content, *parser.NewOptions(mdp))
+		var desc []string
+		for _, n := range types.PlaceholderNodes(c.Steps[0].Content.Nodes) {
+			desc = append(desc, n.Placeholder)
+		}
+		if want := []string{"login screen", "Settings page"}; !reflect.DeepEqual(desc, want) {
+			t.Errorf("%d: placeholders = %q; want %q", mdp, desc, want)
+		}
+	}
+}
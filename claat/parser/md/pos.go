@@ -0,0 +1,191 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package md
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const (
+	// maxNeedle is the number of letters and digits of a block text
+	// searched for in the source.
+	maxNeedle = 32
+	// maxInlineLines is the number of source lines, starting with that
+	// of a block, searched for links and images of the block.
+	maxInlineLines = 50
+)
+
+// locator finds positions of parsed HTML blocks in the Markdown source.
+//
+// The HTML rendered from Markdown carries no positions, so blocks are
+// located by their text, searching forward from the previous block.
+// Only letters and digits are compared, since Markdown syntax, entities
+// and typographic replacements change the rest.
+type locator struct {
+	lines []string // source lines
+	norm  []string // normalized source lines
+	next  int      // index of the line to search from, after the previous block
+}
+
+func newLocator(src []byte) *locator {
+	lines := strings.Split(string(src), "\n")
+	norm := make([]string, len(lines))
+	for i, l := range lines {
+		norm[i] = normalize(l)
+	}
+	return &locator{lines: lines, norm: norm}
+}
+
+// block returns the position of block n, the first source line
+// after that of the previous block containing the beginning of its text.
+// The column is that of the first non-blank character of the line.
+// Blocks with no text are located by their image source, if any.
+func (l *locator) block(n *html.Node) types.Position {
+	if l == nil {
+		return types.Position{}
+	}
+	needle := normalize(firstText(n))
+	if r := []rune(needle); len(r) > maxNeedle {
+		needle = string(r[:maxNeedle])
+	}
+	if needle == "" {
+		if src := firstImage(n); src != "" {
+			return l.find(src, l.next, len(l.lines), true)
+		}
+		return types.Position{}
+	}
+	for i := l.next; i < len(l.norm); i++ {
+		if strings.Contains(l.norm[i], needle) {
+			l.next = i + 1
+			return types.Position{Line: i + 1, Column: indent(l.lines[i]) + 1}
+		}
+	}
+	return types.Position{}
+}
+
+// key returns the position of metadata key k, compared case-insensitively,
+// at the beginning of a line followed by a colon, searching up to
+// maxInlineLines from line index from.
+func (l *locator) key(k string, from int) types.Position {
+	if l == nil || from < 0 {
+		return types.Position{}
+	}
+	to := from + maxInlineLines
+	if to > len(l.lines) {
+		to = len(l.lines)
+	}
+	for i := from; i < to; i++ {
+		t := strings.TrimLeft(l.lines[i], " \t")
+		if len(t) > len(k) && strings.EqualFold(t[:len(k)], k) && strings.HasPrefix(strings.TrimLeft(t[len(k):], " \t"), ":") {
+			return types.Position{Line: i + 1, Column: len(l.lines[i]) - len(t) + 1}
+		}
+	}
+	return types.Position{}
+}
+
+// find returns the position of the first occurrence of s in lines
+// [from, to). If advance is true, the next block is searched from there.
+func (l *locator) find(s string, from, to int, advance bool) types.Position {
+	if to > len(l.lines) {
+		to = len(l.lines)
+	}
+	for i := from; i < to; i++ {
+		if j := strings.Index(l.lines[i], s); j >= 0 {
+			if advance {
+				l.next = i + 1
+			}
+			return types.Position{Line: i + 1, Column: j + 1}
+		}
+	}
+	return types.Position{}
+}
+
+// assign sets position of nodes parsed from a block at pos.
+// Links and images are located more precisely by their URLs.
+func (l *locator) assign(nodes []types.Node, pos types.Position) {
+	if l == nil || !pos.IsValid() {
+		return
+	}
+	types.Walk(nodes, func(n types.Node) bool {
+		p := pos
+		var u string
+		switch n := n.(type) {
+		case *types.URLNode:
+			u = n.URL
+		case *types.ImageNode:
+			u = n.Src
+		}
+		if u != "" {
+			if up := l.find(u, pos.Line-1, pos.Line-1+maxInlineLines, false); up.IsValid() {
+				p = up
+			}
+		}
+		n.MutatePos(p)
+		return true
+	})
+}
+
+// firstText returns the first non-blank line of text in the subtree of n.
+func firstText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		for _, s := range strings.Split(n.Data, "\n") {
+			if strings.TrimSpace(s) != "" {
+				return s
+			}
+		}
+		return ""
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if s := firstText(c); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// firstImage returns the source of the first image in the subtree of n.
+func firstImage(n *html.Node) string {
+	if n.DataAtom == atom.Img {
+		return nodeAttr(n, "src")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if s := firstImage(c); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// normalize returns the lowercase letters and digits of s.
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// indent returns the number of leading blank bytes of s.
+func indent(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
@@ -0,0 +1,174 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// MetaRule constrains values of a metadata key of parsed codelabs,
+// as the "metadata" section of claat.yaml, e.g.:
+//
+//	metadata:
+//	  status:
+//	    required: true
+//	    allowed: [draft, published]
+//	  feedback link:
+//	    pattern: ^https://github\.com/
+//
+// Values of list keys, such as categories, are checked one by one.
+type MetaRule struct {
+	// Required fails parsing codelabs with no value of the key.
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+	// Allowed, if not empty, lists allowed values, compared case-insensitively.
+	Allowed []string `yaml:"allowed,omitempty" json:"allowed,omitempty"`
+	// Pattern, if not empty, is a regular expression matching valid values.
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+
+	re *regexp.Regexp // compiled Pattern, set by Validate
+}
+
+// Validate compiles r.Pattern, returning an error if it is malformed.
+func (r *MetaRule) Validate() error {
+	if r.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("pattern: %v", err)
+	}
+	r.re = re
+	return nil
+}
+
+// metaValues returns values of metadata key of m, as named in codelab
+// sources. Keys other than those of types.Meta fields are looked up
+// in m.Other, or in m.Extra if the parser sets no other keys.
+func metaValues(m *types.Meta, key string) []string {
+	var v []string
+	switch key {
+	case "id":
+		v = []string{m.ID}
+	case "title":
+		v = []string{m.Title}
+	case "authors":
+		v = []string{m.Authors}
+	case "badge path":
+		v = []string{m.BadgePath}
+	case "summary":
+		v = []string{m.Summary}
+	case "categories":
+		v = m.Categories
+	case "environments", "tags":
+		v = m.Tags
+	case "status":
+		if m.Status != nil {
+			v = *m.Status
+		}
+	case "feedback link":
+		v = []string{m.Feedback}
+	case "analytics account":
+		v = []string{m.GA}
+	case "analytics providers":
+		v = m.Analytics
+	case "requires":
+		v = []string{m.Requires}
+	case "features":
+		v = m.Features
+	case "template":
+		v = []string{m.Template}
+	case "theme":
+		v = []string{m.Theme}
+	case "prerequisites":
+		v = m.Prerequisites
+	case "related":
+		v = m.Related
+	case "description":
+		v = []string{m.Description}
+	case "hero image":
+		v = []string{m.HeroImage}
+	case "published":
+		v = []string{m.Published}
+	case "updated":
+		v = []string{m.LastUpdated}
+	case "revision":
+		v = []string{m.Revision}
+	default:
+		s, ok := m.Other[key]
+		if !ok {
+			s = m.Extra[key]
+		}
+		v = []string{s}
+	}
+	var res []string
+	for _, s := range v {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// checkMetadata verifies metadata m satisfies rules, keyed by metadata key.
+// Keys are checked in sorted order, so the first violation is reported.
+func checkMetadata(m *types.Meta, rules map[string]*MetaRule) error {
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r := rules[k]
+		if r == nil {
+			continue
+		}
+		re := r.re
+		if re == nil && r.Pattern != "" {
+			// not validated; rules may be shared by concurrent parsers
+			var err error
+			if re, err = regexp.Compile(r.Pattern); err != nil {
+				return fmt.Errorf("metadata %q: pattern: %v", k, err)
+			}
+		}
+		v := metaValues(m, k)
+		if len(v) == 0 && r.Required {
+			return &ErrMissingMetadata{Key: k}
+		}
+		pos := m.KeyPos[k]
+		for _, s := range v {
+			if len(r.Allowed) > 0 && !containsFold(r.Allowed, s) {
+				return &ErrInvalidMetadata{Key: k, Value: s, Reason: "want one of " + strings.Join(r.Allowed, ", "), Line: pos.Line, Column: pos.Column}
+			}
+			if re != nil && !re.MatchString(s) {
+				return &ErrInvalidMetadata{Key: k, Value: s, Reason: "does not match " + r.Pattern, Line: pos.Line, Column: pos.Column}
+			}
+		}
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
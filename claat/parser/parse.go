@@ -15,8 +15,10 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
 	"github.com/googlecodelabs/tools/claat/types"
@@ -32,6 +34,19 @@ type Parser interface {
 	ParseFragment(r io.Reader, opts Options) ([]types.Node, error)
 }
 
+// HTMLParser is implemented by parsers of HTML sources, such as Google Docs
+// exports, in which values of {{var "key"}} references are HTML-escaped.
+type HTMLParser interface {
+	Parser
+	HTML() bool
+}
+
+// isHTML reports whether p parses HTML sources.
+func isHTML(p Parser) bool {
+	h, ok := p.(HTMLParser)
+	return ok && h.HTML()
+}
+
 type MarkdownParser int
 
 const (
@@ -43,15 +58,93 @@ const (
 type Options struct {
 	PassMetadata map[string]bool
 	MDParser     MarkdownParser
+	// BaseURL, if not empty, is used to resolve relative link hrefs
+	// and image srcs.
+	BaseURL string
+	// BaseURLExclude is a set of path.Match patterns. Relative references
+	// matching any of them are left intact, e.g. assets copied alongside
+	// the codelab.
+	BaseURLExclude []string
+	// Glossary appends a step listing all glossary terms of a codelab.
+	Glossary bool
+	// IframeAllowlist is a set of domains allowed to be embedded as iframes.
+	// If nil, types.IframeWhitelist is used. If empty, no iframes are allowed.
+	IframeAllowlist []string
+	// Vars are values of {{var "key"}} references in codelab sources.
+	Vars map[string]string
+	// MaxDepth limits nesting depth of parsed HTML documents.
+	// If zero, DefaultMaxDepth is used.
+	MaxDepth int
+	// Version is the claat version checked against "requires" metadata.
+	// If empty, only required features are checked.
+	Version string
+	// NotebookOutputs includes outputs of Jupyter notebook code cells.
+	NotebookOutputs bool
+	// Review is how unresolved comments and suggestions of Google Docs
+	// are handled. The zero value strips them silently.
+	Review ReviewMode
+	// WarningSink, if not nil, receives non-fatal issues of parsed sources,
+	// such as dropped content. Otherwise they are discarded.
+	WarningSink WarningSink
+	// Strict fails parsing with ErrStrict if the parser reports any
+	// warning, i.e. drops or ignores any source content.
+	// Warnings are still reported to WarningSink.
+	Strict bool
+	// MetaRules constrain metadata of parsed codelabs, keyed by metadata
+	// key as named in sources, e.g. "feedback link".
+	MetaRules map[string]*MetaRule
+	// ReadingWPM, if positive, estimates durations of steps with none
+	// from their length, read at this many words per minute.
+	// See DefaultReadingWPM and types.Step.Estimated.
+	ReadingWPM int
+	// DrawingFormat is the image format Google Drawings embedded in
+	// Google Docs are rendered to, "png" or "svg"; PNG if empty.
+	// Charts of Google Sheets are always rendered to PNG.
+	DrawingFormat string
+	// DocID is the ID of the parsed Google Doc, if known.
+	// Links to headings of the doc by its URL point to codelab steps
+	// only if it is set.
+	DocID string
+	// Chips is how smart chips of Google Docs are converted.
+	Chips ChipOptions
+}
+
+// ChipOptions is how smart chips of Google Docs, which mention people,
+// files and dates, are converted. Docs exports people as mailto links
+// named after them, files as links and dates as text: people are written
+// as their names, files as links to them, and dates as written in the doc.
+type ChipOptions struct {
+	// PersonLinks keeps names of people linked to their email address.
+	PersonLinks bool
+	// DateLayout formats dates with a Go time layout, such as
+	// "January 2, 2006", if not empty. Since dates are exported as text,
+	// any date of the doc in a format of date chips is formatted.
+	DateLayout string
 }
 
 func NewOptions(mdp MarkdownParser) *Options {
 	return &Options{
-		PassMetadata: map[string]bool{},
-		MDParser:     mdp,
+		PassMetadata:    map[string]bool{},
+		MDParser:        mdp,
+		IframeAllowlist: types.IframeWhitelist,
 	}
 }
 
+// IframeAllowed reports whether an iframe hosted at host can be embedded
+// in a codelab, i.e. host ends in one of opts.IframeAllowlist domains.
+func (opts Options) IframeAllowed(host string) bool {
+	allow := opts.IframeAllowlist
+	if allow == nil {
+		allow = types.IframeWhitelist
+	}
+	for _, domain := range allow {
+		if strings.HasSuffix(host, domain) {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	parsersMu sync.Mutex // guards parsers
 	parsers   = map[string]Parser{}
@@ -82,20 +175,84 @@ func Parsers() []string {
 // Parse parses source r into a Codelab using a parser registered with
 // the specified name.
 func Parse(name string, r io.Reader, opts Options) (*types.Codelab, error) {
+	return ParseContext(context.Background(), name, r, opts)
+}
+
+// ParseContext is like Parse but stops reading r, and returns the error
+// of ctx, once ctx is done.
+func ParseContext(ctx context.Context, name string, r io.Reader, opts Options) (*types.Codelab, error) {
 	parsersMu.Lock()
 	p, ok := parsers[name]
 	parsersMu.Unlock()
 	if !ok {
-		return nil, fmt.Errorf("no parser named %q", name)
+		return nil, &ErrUnknownParser{Name: name}
 	}
+	return ParseWithContext(ctx, p, r, opts)
+}
+
+// ParseWith parses source r into a Codelab using parser p, which needs
+// not be registered. Like Parse, it substitutes variables, checks
+// requirements and metadata rules, and resolves relative URLs.
+func ParseWith(p Parser, r io.Reader, opts Options) (*types.Codelab, error) {
+	return ParseWithContext(context.Background(), p, r, opts)
+}
+
+// ParseWithContext is like ParseWith but stops reading r, and returns
+// the error of ctx, once ctx is done.
+func ParseWithContext(ctx context.Context, p Parser, r io.Reader, opts Options) (*types.Codelab, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r, err := substituteVars(&contextReader{ctx: ctx, r: r}, opts.Vars, isHTML(p))
+	if err != nil {
+		return nil, err
+	}
+	opts, strictErr := opts.strict()
 	c, err := p.Parse(r, opts)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
+	if err := strictErr(); err != nil {
+		return nil, err
+	}
+	if err := checkRequirements(&c.Meta, opts.Version); err != nil {
+		return nil, err
+	}
+	if err := checkMetadata(&c.Meta, opts.MetaRules); err != nil {
+		return nil, err
+	}
 	c.URL = c.ID
+	for _, s := range c.Steps {
+		if err := rewriteURLs(s.Content.Nodes, opts); err != nil {
+			return nil, err
+		}
+	}
+	if err := rewriteHeroImage(&c.Meta, opts); err != nil {
+		return nil, err
+	}
+	estimateDurations(c, opts.ReadingWPM)
+	if opts.Glossary {
+		addGlossaryStep(c)
+	}
 	return c, err
 }
 
+// contextReader reads from r until ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
 // ParseFragment parses a codelab fragment provided in r, using a parser
 // registered with the specified name.
 func ParseFragment(name string, r io.Reader, opts Options) ([]types.Node, error) {
@@ -103,7 +260,19 @@ func ParseFragment(name string, r io.Reader, opts Options) ([]types.Node, error)
 	p, ok := parsers[name]
 	parsersMu.Unlock()
 	if !ok {
-		return nil, fmt.Errorf("no parser named %q", name)
+		return nil, &ErrUnknownParser{Name: name}
+	}
+	r, err := substituteVars(r, opts.Vars, isHTML(p))
+	if err != nil {
+		return nil, err
+	}
+	opts, strictErr := opts.strict()
+	nodes, err := p.ParseFragment(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := strictErr(); err != nil {
+		return nil, err
 	}
-	return p.ParseFragment(r, opts)
+	return nodes, rewriteURLs(nodes, opts)
 }
@@ -0,0 +1,235 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+	"golang.org/x/net/html"
+)
+
+func TestIframeAllowed(t *testing.T) {
+	tests := []struct {
+		allow []string
+		host  string
+		ok    bool
+	}{
+		{nil, "codepen.io", true},
+		{nil, "example.com", false},
+		{[]string{"example.com"}, "demo.example.com", true},
+		{[]string{"example.com"}, "codepen.io", false},
+		{[]string{}, "codepen.io", false},
+	}
+	for i, test := range tests {
+		opts := Options{IframeAllowlist: test.allow}
+		if ok := opts.IframeAllowed(test.host); ok != test.ok {
+			t.Errorf("%d: IframeAllowed(%q) = %v; want %v", i, test.host, ok, test.ok)
+		}
+	}
+}
+
+func TestCheckDepth(t *testing.T) {
+	tests := []struct {
+		depth, max int
+		ok         bool
+	}{
+		{0, 0, true},
+		{10, 10, true},
+		{11, 10, false},
+		{DefaultMaxDepth, 0, true},
+		{DefaultMaxDepth + 1, 0, false},
+	}
+	for i, test := range tests {
+		root := &html.Node{Type: html.DocumentNode}
+		n := root
+		for j := 0; j < test.depth; j++ {
+			c := &html.Node{Type: html.ElementNode, Data: "div"}
+			n.AppendChild(c)
+			// siblings must not affect the depth
+			n.AppendChild(&html.Node{Type: html.TextNode, Data: "x"})
+			n = c
+		}
+		err := CheckDepth(root, Options{MaxDepth: test.max})
+		if (err == nil) != test.ok {
+			t.Errorf("%d: CheckDepth(%d levels, max %d) = %v; want ok = %v", i, test.depth, test.max, err, test.ok)
+		}
+	}
+}
+
+func TestParseUnknownParser(t *testing.T) {
+	_, err := Parse("no-such-parser", strings.NewReader(""), Options{})
+	var e *ErrUnknownParser
+	if !errors.As(err, &e) || e.Name != "no-such-parser" {
+		t.Errorf("Parse err = %v; want ErrUnknownParser", err)
+	}
+}
+
+// warnParser reports a warning for each line of its source.
+type warnParser struct{}
+
+func (warnParser) Parse(r io.Reader, opts Options) (*types.Codelab, error) {
+	_, err := warnParser{}.ParseFragment(r, opts)
+	return types.NewCodelab(), err
+}
+
+func (warnParser) ParseFragment(r io.Reader, opts Options) ([]types.Node, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, l := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		opts.Warnf(types.Position{Line: i + 1, Column: 1}, "%s dropped", l)
+	}
+	return nil, nil
+}
+
+func TestParseStrict(t *testing.T) {
+	Register("test-warn", warnParser{})
+	var warnings []string
+	opts := Options{
+		Strict:      true,
+		WarningSink: func(w *Warning) { warnings = append(warnings, w.String()) },
+	}
+	_, err := Parse("test-warn", strings.NewReader("foo\nbar"), opts)
+	var e *ErrStrict
+	if !errors.As(err, &e) {
+		t.Fatalf("Parse err = %v; want ErrStrict", err)
+	}
+	if line, col := e.Position(); err.Error() != "strict: foo dropped" || line != 1 || col != 1 {
+		t.Errorf("Parse err = %v at %d:%d; want strict: foo dropped at 1:1", err, line, col)
+	}
+	if want := []string{"1:1: foo dropped", "2:1: bar dropped"}; !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q; want %q", warnings, want)
+	}
+
+	if _, err := ParseFragment("test-warn", strings.NewReader("foo"), opts); !errors.As(err, &e) {
+		t.Errorf("ParseFragment err = %v; want ErrStrict", err)
+	}
+	opts.Strict = false
+	if _, err := Parse("test-warn", strings.NewReader("foo"), opts); err != nil {
+		t.Errorf("Parse err = %v; want nil without Strict", err)
+	}
+}
+
+func TestSubstituteVars(t *testing.T) {
+	vars := map[string]string{"region": "us-central1", "v": "2.0", "cmd": "a < b && c"}
+	tests := []struct {
+		in, out   string
+		html      bool
+		undef     string
+		line, col int
+	}{
+		{`no vars`, `no vars`, false, "", 0, 0},
+		{`deploy to {{var "region"}} with {{ var "v" }}`, `deploy to us-central1 with 2.0`, false, "", 0, 0},
+		{`docs: {{var “region”}}, {{var &quot;v&quot;}}`, `docs: us-central1, 2.0`, true, "", 0, 0},
+		{`run {{var "cmd"}}`, `run a < b && c`, false, "", 0, 0},
+		{`<p>run {{var &quot;cmd&quot;}}</p>`, `<p>run a &lt; b &amp;&amp; c</p>`, true, "", 0, 0},
+		{`{{var "zone"}}`, "", false, "zone", 1, 1},
+		{"{{var \"v\"}}\nin {{var \"zone\"}}", "", false, "zone", 2, 4},
+	}
+	for i, test := range tests {
+		r, err := substituteVars(strings.NewReader(test.in), vars, test.html)
+		if test.undef != "" {
+			var e *ErrUndefinedVar
+			if !errors.As(err, &e) || e.Key != test.undef || e.Line != test.line || e.Column != test.col {
+				t.Errorf("%d: err = %#v; want ErrUndefinedVar{%q, %d, %d}", i, err, test.undef, test.line, test.col)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		b, _ := ioutil.ReadAll(r)
+		if string(b) != test.out {
+			t.Errorf("%d: out = %q; want %q", i, b, test.out)
+		}
+	}
+}
+
+func TestCheckRequirements(t *testing.T) {
+	tests := []struct {
+		requires string
+		features []string
+		version  string
+		ok       bool
+	}{
+		{"", nil, "1.0.4", true},
+		{"claat >= 1.0", []string{"quiz", "glossary"}, "1.0.4-20190101T000000Z-abcdef", true},
+		{"claat >= 2.3", nil, "1.0.4", false},
+		{"2.3", nil, "2.3.0", true},
+		{"claat < 2", nil, "2.0.1", false},
+		{"claat >= 2.3", nil, "", true}, // dev build
+		{"claat ~ 2", nil, "2.0", false},
+		{"", []string{"tabs"}, "1.0.4", false},
+	}
+	for i, test := range tests {
+		m := &types.Meta{Requires: test.requires, Features: test.features}
+		err := checkRequirements(m, test.version)
+		if (err == nil) != test.ok {
+			t.Errorf("%d: checkRequirements(%q, %q, %q) = %v; want ok = %v", i, test.requires, test.features, test.version, err, test.ok)
+		}
+	}
+}
+
+func TestCheckMetadata(t *testing.T) {
+	rules := map[string]*MetaRule{
+		"categories":    {Required: true},
+		"status":        {Allowed: []string{"draft", "published"}},
+		"feedback link": {Pattern: `^https://github\.com/`},
+		"team":          {Required: true},
+	}
+	status := types.LegacyStatus{"Published"}
+	valid := types.Meta{
+		Categories: []string{"web"},
+		Status:     &status,
+		Feedback:   "https://github.com/org/repo/issues",
+		Extra:      map[string]string{"team": "devrel"},
+	}
+	tests := []struct {
+		mutate func(m *types.Meta)
+		err    error
+	}{
+		{func(m *types.Meta) {}, nil},
+		{func(m *types.Meta) { m.Categories = []string{" "} }, &ErrMissingMetadata{Key: "categories"}},
+		{func(m *types.Meta) { m.Status = &types.LegacyStatus{"draft", "final"} }, &ErrInvalidMetadata{Key: "status", Value: "final", Reason: "want one of draft, published"}},
+		{func(m *types.Meta) { m.Status = nil }, nil},
+		{func(m *types.Meta) { m.Feedback = "https://example.com" }, &ErrInvalidMetadata{Key: "feedback link", Value: "https://example.com", Reason: `does not match ^https://github\.com/`}},
+		{func(m *types.Meta) { m.Extra = nil }, &ErrMissingMetadata{Key: "team"}},
+		// keys not passed through are checked too
+		{func(m *types.Meta) { m.Extra, m.Other = nil, map[string]string{"team": "devrel"} }, nil},
+		{func(m *types.Meta) {
+			m.Feedback = "https://example.com"
+			m.KeyPos = map[string]types.Position{"feedback link": {Line: 4, Column: 1}}
+		}, &ErrInvalidMetadata{Key: "feedback link", Value: "https://example.com", Reason: `does not match ^https://github\.com/`, Line: 4, Column: 1}},
+	}
+	for i, test := range tests {
+		m := valid
+		test.mutate(&m)
+		if err := checkMetadata(&m, rules); !reflect.DeepEqual(err, test.err) {
+			t.Errorf("%d: checkMetadata = %v; want %v", i, err, test.err)
+		}
+	}
+
+	if err := (&MetaRule{Pattern: "("}).Validate(); err == nil {
+		t.Error("Validate of a malformed pattern = nil; want an error")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+var (
+	featuresMu sync.Mutex // guards features
+	// features are names of optional constructs supported by this claat
+	// build, which codelabs can require in "features" metadata.
+	features = map[string]bool{
+		"code-include": true,
+		"diff":         true,
+		"gate-steps":   true,
+		"glossary":     true,
+		"iframe":       true,
+		"partials":     true,
+		"quiz":         true,
+		"vars":         true,
+	}
+)
+
+// RegisterFeature adds name to the supported features, usually along with
+// a custom node handler implementing it.
+func RegisterFeature(name string) {
+	featuresMu.Lock()
+	defer featuresMu.Unlock()
+	features[strings.ToLower(name)] = true
+}
+
+// Features returns a sorted slice of all supported feature names.
+func Features() []string {
+	featuresMu.Lock()
+	defer featuresMu.Unlock()
+	f := make([]string, 0, len(features))
+	for k := range features {
+		f = append(f, k)
+	}
+	sort.Strings(f)
+	return f
+}
+
+// requiresRegexp matches version requirements like "claat >= 2.3".
+var requiresRegexp = regexp.MustCompile(`^(?:claat\s*)?(>=|>|<=|<|==|=)?\s*v?(\d+(?:\.\d+)*)$`)
+
+// checkRequirements verifies claat version and features required by m
+// are satisfied by this build of the given version.
+// The version requirement is not checked if version is empty or malformed,
+// which is the case of development builds.
+func checkRequirements(m *types.Meta, version string) error {
+	featuresMu.Lock()
+	for _, f := range m.Features {
+		if f != "" && !features[f] {
+			featuresMu.Unlock()
+			return &ErrUnsupportedFeature{Name: f}
+		}
+	}
+	featuresMu.Unlock()
+
+	if m.Requires == "" {
+		return nil
+	}
+	req := requiresRegexp.FindStringSubmatch(strings.TrimSpace(m.Requires))
+	if req == nil {
+		return &ErrUnsupportedVersion{Requires: m.Requires, Version: version}
+	}
+	have := versionRegexp.FindString(version)
+	if have == "" {
+		return nil
+	}
+	c := compareVersions(have, req[2])
+	var ok bool
+	switch req[1] {
+	case "", ">=":
+		ok = c >= 0
+	case ">":
+		ok = c > 0
+	case "<=":
+		ok = c <= 0
+	case "<":
+		ok = c < 0
+	case "=", "==":
+		ok = c == 0
+	}
+	if !ok {
+		return &ErrUnsupportedVersion{Requires: m.Requires, Version: version}
+	}
+	return nil
+}
+
+// versionRegexp matches the numeric part of a version like "1.0.4-20190101-abcdef".
+var versionRegexp = regexp.MustCompile(`^v?\d+(\.\d+)*`)
+
+// compareVersions compares dotted numeric versions a and b,
+// returning -1, 0 or 1. Missing components are treated as zeros.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import "fmt"
+
+// ReviewMode is how parsers handle review artifacts left in a source,
+// i.e. unresolved comments and suggested edits.
+type ReviewMode int
+
+const (
+	// ReviewStrip silently strips comments and rejects suggestions.
+	ReviewStrip ReviewMode = iota
+	// ReviewWarn does the same as ReviewStrip and reports a warning
+	// to Options.WarningSink, or logs it if there is no sink.
+	ReviewWarn
+	// ReviewFail fails parsing with ErrUnresolvedReview.
+	ReviewFail
+)
+
+var reviewModes = map[string]ReviewMode{
+	"strip": ReviewStrip,
+	"warn":  ReviewWarn,
+	"fail":  ReviewFail,
+}
+
+// ParseReviewMode returns a review mode named s: "strip", "warn" or "fail".
+func ParseReviewMode(s string) (ReviewMode, error) {
+	m, ok := reviewModes[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown review mode %q", s)
+	}
+	return m, nil
+}
@@ -0,0 +1,432 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rst implements a parser of reStructuredText codelabs.
+//
+// A document is converted to a Markdown codelab and parsed with the md parser.
+// The document title is the codelab title and second-level sections start
+// new steps. Codelab metadata is taken from a field list preceding
+// the first step, e.g. ":id: my-codelab", using the same keys
+// as Markdown codelabs.
+//
+// Admonitions become infoboxes, code-block directives and literal blocks
+// become code blocks, and image and figure directives become images.
+// Other directives and comments are dropped. Grid and simple tables
+// are dropped with a warning.
+package rst
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/parser/md"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// init registers this parser so it is available to CLaaT.
+func init() {
+	parser.Register("rst", &Parser{})
+}
+
+// Parser is a reStructuredText parser.
+type Parser struct {
+}
+
+// Parse parses a codelab written in reStructuredText.
+func (p *Parser) Parse(r io.Reader, opts parser.Options) (*types.Codelab, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &converter{levels: map[string]int{}, opts: opts}
+	c.convert(lines, "", 1)
+	var buf bytes.Buffer
+	for _, f := range c.meta {
+		fmt.Fprintf(&buf, "%s: %s\n", f[0], f[1])
+	}
+	buf.WriteString("\n")
+	buf.Write(c.out.Bytes())
+	return (&md.Parser{}).Parse(&buf, opts)
+}
+
+// ParseFragment parses a codelab fragment written in reStructuredText.
+func (p *Parser) ParseFragment(r io.Reader, opts parser.Options) ([]types.Node, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	// fragments have no title, so top-level sections are headers within a step
+	c := &converter{levels: map[string]int{}, fragment: true, opts: opts}
+	c.convert(lines, "", 1)
+	return (&md.Parser{}).ParseFragment(&c.out, opts)
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		lines = append(lines, strings.TrimRight(strings.Replace(s.Text(), "\t", "    ", -1), " "))
+	}
+	return lines, s.Err()
+}
+
+var (
+	// directiveRegexp matches explicit markup, e.g. ".. code-block:: go".
+	directiveRegexp = regexp.MustCompile(`^(\s*)\.\.\s+([\w-]+)::\s*(.*)$`)
+	// optionRegexp matches a directive option or a docinfo field, e.g. ":alt: text".
+	optionRegexp = regexp.MustCompile(`^\s*:([\w -]+):\s*(.*)$`)
+	// enumRegexp matches auto-numbered list items, e.g. "#. item".
+	enumRegexp = regexp.MustCompile(`^(\s*)#\.\s`)
+	// gridTableRegexp matches grid table borders, e.g. "+-----+-----+".
+	gridTableRegexp = regexp.MustCompile(`^\s*\+([-=]+\+)+$`)
+	// simpleTableRegexp matches simple table borders of two or more
+	// columns, e.g. "=====  =====".
+	simpleTableRegexp = regexp.MustCompile(`^\s*=+( +=+)+$`)
+	// fenceRegexp matches backtick runs starting a line, e.g. "```go".
+	fenceRegexp = regexp.MustCompile("^\\s*(`+)")
+)
+
+// Admonition directives are converted to positive or negative infoboxes.
+var admonitions = map[string]string{
+	"admonition": "positive",
+	"hint":       "positive",
+	"important":  "positive",
+	"note":       "positive",
+	"seealso":    "positive",
+	"tip":        "positive",
+	"attention":  "negative",
+	"caution":    "negative",
+	"danger":     "negative",
+	"error":      "negative",
+	"warning":    "negative",
+}
+
+// converter converts reStructuredText to Markdown.
+type converter struct {
+	opts     parser.Options // options the document is parsed with
+	out      bytes.Buffer
+	meta     [][2]string    // docinfo fields, in order of appearance
+	levels   map[string]int // section levels by adornment style
+	fragment bool           // whether the document has no title
+	steps    bool           // whether a step section has been seen
+}
+
+// convert writes Markdown of lines to c.out, prefixing each line with prefix.
+// The first of lines is line number first of the document, or 0 if unknown.
+func (c *converter) convert(lines []string, prefix string, first int) {
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		if n := table(lines[i:]); n > 0 {
+			var pos types.Position
+			if first > 0 {
+				pos = types.Position{Line: first + i, Column: 1}
+			}
+			c.opts.Warnf(pos, "dropped table")
+			i += n - 1
+			continue
+		}
+		if level, title, n := c.section(lines[i:]); n > 0 {
+			if level > 1 || c.fragment {
+				c.steps = true
+			}
+			c.writeHeading(prefix, level, title)
+			i += n - 1
+			continue
+		}
+		if m := optionRegexp.FindStringSubmatch(l); m != nil && prefix == "" && !c.steps && !strings.HasPrefix(l, " ") {
+			c.meta = append(c.meta, [2]string{strings.ToLower(m[1]), m[2]})
+			continue
+		}
+		if m := directiveRegexp.FindStringSubmatch(l); m != nil {
+			body, n := indented(lines[i+1:], len(m[1]))
+			c.directive(prefix, m[2], m[3], body)
+			i += n
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(l), "..") {
+			// comments and hyperlink targets
+			_, n := indented(lines[i+1:], len(l)-len(strings.TrimLeft(l, " ")))
+			i += n
+			continue
+		}
+		if strings.HasSuffix(l, "::") && (i+1 == len(lines) || lines[i+1] == "") {
+			// paragraph followed by a literal block
+			if t := strings.TrimSuffix(l, "::"); strings.TrimSpace(t) != "" {
+				if !strings.HasSuffix(t, " ") {
+					t += ":"
+				}
+				c.writeLine(prefix, inline(strings.TrimRight(t, " ")))
+				c.writeLine(prefix, "")
+			}
+			ind := len(l) - len(strings.TrimLeft(l, " "))
+			body, n := indented(lines[i+1:], ind)
+			c.writeFence(prefix, "", "", body)
+			i += n
+			continue
+		}
+		l = enumRegexp.ReplaceAllString(l, "${1}1. ")
+		c.writeLine(prefix, inline(l))
+	}
+}
+
+// section reports whether lines start with a section title,
+// returning its level, title and the number of lines it takes.
+func (c *converter) section(lines []string) (level int, title string, n int) {
+	var style string
+	switch {
+	case len(lines) >= 3 && isAdornment(lines[0]) && isTitle(lines[1]) && lines[2] == lines[0]:
+		style, title, n = "o"+lines[0][:1], strings.TrimSpace(lines[1]), 3
+	case len(lines) >= 2 && isTitle(lines[0]) && isAdornment(lines[1]) &&
+		len(lines[1]) >= len([]rune(lines[0])):
+		style, title, n = lines[1][:1], lines[0], 2
+	default:
+		return 0, "", 0
+	}
+	level, ok := c.levels[style]
+	if !ok {
+		level = len(c.levels) + 1
+		c.levels[style] = level
+	}
+	return level, title, n
+}
+
+// table reports whether lines start with a grid or simple table,
+// returning the number of lines it takes.
+// Simple tables end with a border followed by a blank line.
+func table(lines []string) int {
+	switch {
+	case gridTableRegexp.MatchString(lines[0]):
+		n := 1
+		for n < len(lines) {
+			if l := strings.TrimSpace(lines[n]); !strings.HasPrefix(l, "+") && !strings.HasPrefix(l, "|") {
+				break
+			}
+			n++
+		}
+		return n
+	case simpleTableRegexp.MatchString(lines[0]):
+		for n := 1; n < len(lines); n++ {
+			if simpleTableRegexp.MatchString(lines[n]) && (n+1 == len(lines) || lines[n+1] == "") {
+				return n + 1
+			}
+		}
+	}
+	return 0
+}
+
+// isAdornment reports whether s is a section title over- or underline.
+func isAdornment(s string) bool {
+	if len(s) < 3 || !strings.ContainsRune("=-~`:'\"^_*+#<>.", rune(s[0])) {
+		return false
+	}
+	return strings.Trim(s, s[:1]) == ""
+}
+
+// isTitle reports whether s can be a section title.
+func isTitle(s string) bool {
+	return s != "" && s[0] != ' ' && !isAdornment(s)
+}
+
+func (c *converter) writeHeading(prefix string, level int, title string) {
+	if c.fragment {
+		level++
+	}
+	if level > 6 {
+		level = 6
+	}
+	c.writeLine(prefix, strings.Repeat("#", level)+" "+inline(title))
+	c.writeLine(prefix, "")
+}
+
+func (c *converter) writeLine(prefix, s string) {
+	c.out.WriteString(strings.TrimRight(prefix+s, " ") + "\n")
+}
+
+// writeFence writes body lines as a fenced code block.
+// The fence is longer than backtick fences of body, if any,
+// so that code such as Markdown does not end the block.
+func (c *converter) writeFence(prefix, lang, title string, body []string) {
+	info := lang
+	if title != "" {
+		info += fmt.Sprintf(" title=%q", title)
+	}
+	body = trimBlank(body)
+	fence := "```"
+	for _, l := range body {
+		if m := fenceRegexp.FindStringSubmatch(l); m != nil && len(m[1]) >= len(fence) {
+			fence = m[1] + "`"
+		}
+	}
+	c.writeLine(prefix, fence+info)
+	for _, l := range body {
+		c.writeLine(prefix, l)
+	}
+	c.writeLine(prefix, fence)
+	c.writeLine(prefix, "")
+}
+
+// directive converts a directive of name with argument arg and body lines,
+// where options come first.
+func (c *converter) directive(prefix, name, arg string, body []string) {
+	opts := map[string]string{}
+	for len(body) > 0 {
+		m := optionRegexp.FindStringSubmatch(body[0])
+		if m == nil {
+			break
+		}
+		opts[m[1]] = m[2]
+		body = body[1:]
+	}
+	switch name {
+	case "code-block", "code", "sourcecode":
+		c.writeFence(prefix, arg, opts["caption"], body)
+	case "literalinclude":
+		// the md parser includes the file at export time
+		c.writeLine(prefix, fmt.Sprintf("```%s src=%s", opts["language"], arg))
+		c.writeLine(prefix, arg)
+		c.writeLine(prefix, "```")
+		c.writeLine(prefix, "")
+	case "image", "figure":
+		alt := opts["alt"]
+		c.writeLine(prefix, fmt.Sprintf("![%s](%s)", alt, arg))
+		c.writeLine(prefix, "")
+		if body = trimBlank(body); len(body) > 0 {
+			// figure caption and legend
+			c.convert(body, prefix, 0)
+			c.writeLine(prefix, "")
+		}
+	default:
+		kind, ok := admonitions[name]
+		if !ok {
+			return
+		}
+		p := prefix + "> "
+		c.writeLine(p, "aside "+kind)
+		if name == "admonition" && arg != "" {
+			c.writeLine(p, "**"+inline(arg)+"**")
+			c.writeLine(p, "")
+		} else if arg != "" {
+			// content may start on the directive line
+			body = append([]string{arg}, body...)
+		}
+		c.convert(trimBlank(body), p, 0)
+		c.writeLine(prefix, "")
+	}
+}
+
+// indented returns lines indented deeper than ind, up to the first line
+// which is not, along with the number of consumed lines.
+// Returned lines are dedented by the indentation of the first non-blank one.
+func indented(lines []string, ind int) ([]string, int) {
+	n := 0
+	for n < len(lines) {
+		l := lines[n]
+		if l != "" && len(l)-len(strings.TrimLeft(l, " ")) <= ind {
+			break
+		}
+		n++
+	}
+	// trailing blank lines separate the block from what follows
+	end := n
+	for end > 0 && lines[end-1] == "" {
+		end--
+	}
+	body := lines[:end]
+	dedent := -1
+	for _, l := range body {
+		if l == "" {
+			continue
+		}
+		if k := len(l) - len(strings.TrimLeft(l, " ")); dedent < 0 || k < dedent {
+			dedent = k
+		}
+	}
+	out := make([]string, len(body))
+	for i, l := range body {
+		if len(l) >= dedent && dedent > 0 {
+			l = l[dedent:]
+		}
+		out[i] = l
+	}
+	return out, n
+}
+
+// trimBlank returns lines without leading and trailing blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+var (
+	// literalRegexp matches inline literals, ``code``.
+	literalRegexp = regexp.MustCompile("``(.+?)``")
+	// roleRegexp matches interpreted text with a role, :role:`text`.
+	roleRegexp = regexp.MustCompile(":([\\w:-]+):`([^`]+)`")
+	// linkRegexp matches embedded URI references, `text <url>`_.
+	linkRegexp = regexp.MustCompile("`([^`<]*?)\\s*<([^`>]+)>`__?")
+	// refRegexp matches other references, `text`_.
+	refRegexp = regexp.MustCompile("`([^`]+)`__?")
+)
+
+// inline converts inline markup of s, skipping inline literals.
+func inline(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range literalRegexp.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(inlineText(s[last:m[0]]))
+		b.WriteString("`" + s[m[2]:m[3]] + "`")
+		last = m[1]
+	}
+	b.WriteString(inlineText(s[last:]))
+	return b.String()
+}
+
+// inlineText converts roles and references of s, which has no literals.
+func inlineText(s string) string {
+	s = roleRegexp.ReplaceAllStringFunc(s, func(v string) string {
+		m := roleRegexp.FindStringSubmatch(v)
+		switch m[1] {
+		case "code", "literal", "file", "command", "kbd", "samp":
+			return "`" + m[2] + "`"
+		case "strong":
+			return "**" + m[2] + "**"
+		case "emphasis", "term", "dfn":
+			return "*" + m[2] + "*"
+		}
+		// cross-references, e.g. :ref:`Title <label>`, keep the title only
+		if i := strings.Index(m[2], " <"); i > 0 {
+			return m[2][:i]
+		}
+		return strings.TrimPrefix(m[2], "~")
+	})
+	s = linkRegexp.ReplaceAllStringFunc(s, func(v string) string {
+		m := linkRegexp.FindStringSubmatch(v)
+		if m[1] == "" {
+			m[1] = m[2]
+		}
+		return "[" + m[1] + "](" + m[2] + ")"
+	})
+	return refRegexp.ReplaceAllString(s, "$1")
+}
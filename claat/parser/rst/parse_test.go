@@ -0,0 +1,231 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rst
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/parser"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const testDoc = `==============
+Codelab Title
+==============
+
+:id: rst-codelab
+:summary: An RST codelab
+
+.. _setup:
+
+Setup
+=====
+
+Install the ` + "``claat``" + ` tool, see ` + "`the docs <https://example.com/docs>`_" + `.
+
+.. note:: Requires Go.
+
+.. code-block:: python
+   :caption: main.py
+
+   print("hi")
+
+Run it::
+
+   $ python main.py
+
+Images
+======
+
+.. image:: img/diagram.png
+   :alt: Diagram
+
+.. warning::
+
+   Do not *panic*.
+
+Details
+-------
+
+#. First
+#. Second
+`
+
+func TestParse(t *testing.T) {
+	c, err := (&Parser{}).Parse(strings.NewReader(testDoc), *parser.NewOptions(parser.Blackfriday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ID != "rst-codelab" || c.Title != "Codelab Title" || c.Summary != "An RST codelab" {
+		t.Errorf("meta = %+v", c.Meta)
+	}
+	if len(c.Steps) != 2 || c.Steps[0].Title != "Setup" || c.Steps[1].Title != "Images" {
+		t.Fatalf("steps = %+v", c.Steps)
+	}
+
+	var (
+		code      []string
+		infoboxes []types.InfoboxKind
+		images    []string
+		links     []string
+		headers   []string
+	)
+	for _, st := range c.Steps {
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			switch n := n.(type) {
+			case *types.CodeNode:
+				code = append(code, n.Title+":"+strings.TrimSpace(n.Value))
+			case *types.InfoboxNode:
+				infoboxes = append(infoboxes, n.Kind)
+			case *types.ImageNode:
+				images = append(images, n.Src+":"+n.Alt)
+			case *types.URLNode:
+				links = append(links, n.URL)
+			case *types.HeaderNode:
+				headers = append(headers, headerText(n))
+			}
+			return true
+		})
+	}
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"code", strings.Join(code, "|"), `main.py:print("hi")|:$ python main.py`},
+		{"infoboxes", infoboxes, []types.InfoboxKind{types.InfoboxPositive, types.InfoboxNegative}},
+		{"images", strings.Join(images, "|"), "img/diagram.png:Diagram"},
+		{"links", strings.Join(links, "|"), "https://example.com/docs"},
+		{"headers", strings.Join(headers, "|"), "Details"},
+	}
+	for _, test := range tests {
+		if !equal(test.got, test.want) {
+			t.Errorf("%s = %v; want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func headerText(n *types.HeaderNode) string {
+	var s string
+	types.Walk(n.Content.Nodes, func(n types.Node) bool {
+		if t, ok := n.(*types.TextNode); ok {
+			s += t.Value
+		}
+		return true
+	})
+	return s
+}
+
+func equal(a, b interface{}) bool {
+	if ak, ok := a.([]types.InfoboxKind); ok {
+		bk := b.([]types.InfoboxKind)
+		if len(ak) != len(bk) {
+			return false
+		}
+		for i := range ak {
+			if ak[i] != bk[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func TestInline(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"``a `b`_``", "`a `b`_`"},
+		{":code:`x` and :ref:`Title <label>`", "`x` and Title"},
+		{"`Go <https://go.dev>`__ and `ref`_", "[Go](https://go.dev) and ref"},
+		{"`<https://go.dev>`_", "[https://go.dev](https://go.dev)"},
+	}
+	for _, test := range tests {
+		if v := inline(test.in); v != test.out {
+			t.Errorf("inline(%q) = %q; want %q", test.in, v, test.out)
+		}
+	}
+}
+
+func TestParseFencedCode(t *testing.T) {
+	const doc = `.. note::
+
+   .. code-block:: markdown
+
+      ` + "```go" + `
+      x := 1
+      ` + "```" + `
+
+After.
+`
+	want := "```go\nx := 1\n```"
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		nodes, err := (&Parser{}).ParseFragment(strings.NewReader(doc), *parser.NewOptions(mdp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var code []string
+		types.Walk(nodes, func(n types.Node) bool {
+			if n, ok := n.(*types.CodeNode); ok {
+				code = append(code, strings.TrimSpace(n.Value))
+			}
+			return true
+		})
+		if len(code) != 1 || code[0] != want {
+			t.Errorf("%v: code = %q; want [%q]", mdp, code, want)
+		}
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	const doc = `Before.
+
++-----+-----+
+| a   | b   |
++=====+=====+
+| 1   | 2   |
++-----+-----+
+
+=====  =====
+a      b
+=====  =====
+1      2
+=====  =====
+
+After.
+`
+	var warnings []string
+	opts := parser.NewOptions(parser.Blackfriday)
+	opts.WarningSink = func(w *parser.Warning) {
+		warnings = append(warnings, w.String())
+	}
+	nodes, err := (&Parser{}).ParseFragment(strings.NewReader(doc), *opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text []string
+	types.Walk(nodes, func(n types.Node) bool {
+		if n, ok := n.(*types.TextNode); ok {
+			text = append(text, n.Value)
+		}
+		return true
+	})
+	if v := strings.Join(text, "|"); v != "Before.|After." {
+		t.Errorf("text = %q; want %q", v, "Before.|After.")
+	}
+	want := "3:1: dropped table|9:1: dropped table"
+	if v := strings.Join(warnings, "|"); v != want {
+		t.Errorf("warnings = %q; want %q", v, want)
+	}
+}
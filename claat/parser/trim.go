@@ -49,6 +49,7 @@ func squashHeadBlock(nodes []types.Node) (squash, remainder []types.Node) {
 	head := types.NewListNode(hnodes...)
 	head.MutateBlock(true)
 	head.MutateEnv(first.Env())
+	head.MutatePos(first.Pos())
 	return []types.Node{head}, next
 }
 
@@ -86,10 +87,20 @@ func concatNodes(a, b types.Node) bool {
 	return false
 }
 
+// concatItemsList appends the items of list b to list a, or nests b
+// in the last item of a if b is of a deeper level.
 func concatItemsList(a, b types.Node) bool {
 	l1 := a.(*types.ItemsListNode)
 	l2 := b.(*types.ItemsListNode)
-	if l1.ListType != l2.ListType {
+	if l2.Level > l1.Level && len(l1.Items) > 0 {
+		item := l1.Items[len(l1.Items)-1]
+		if n := len(item.Nodes); n > 0 && types.IsItemsList(item.Nodes[n-1].Type()) && concatItemsList(item.Nodes[n-1], l2) {
+			return true
+		}
+		item.Append(l2)
+		return true
+	}
+	if l1.Level != l2.Level || l1.ListType != l2.ListType {
 		return false
 	}
 	if l1.ListType != "" && l1.Start > 0 && l2.Start > 0 && l2.Start-len(l1.Items) != 1 {
@@ -137,7 +148,11 @@ func concatText(a, b types.Node) bool {
 func concatCode(a, b types.Node) bool {
 	c1 := a.(*types.CodeNode)
 	c2 := b.(*types.CodeNode)
-	if c1.Block() != c2.Block() || c1.Term != c2.Term || c1.Lang != c2.Lang {
+	if c1.Block() != c2.Block() || c1.Term != c2.Term || c1.Lang != c2.Lang || c1.Title != c2.Title {
+		return false
+	}
+	// line highlights of c2 would be off after concatenation
+	if len(c2.Added) > 0 || len(c2.Removed) > 0 {
 		return false
 	}
 	c1.Value += c2.Value
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// rewriteURLs resolves relative URLNode and ImageNode references found in nodes
+// against opts.BaseURL, skipping those matching opts.BaseURLExclude.
+// It is a noop if opts.BaseURL is empty.
+func rewriteURLs(nodes []types.Node, opts Options) error {
+	base, err := baseURL(opts)
+	if base == nil {
+		return err
+	}
+	for _, n := range types.URLNodes(nodes) {
+		n.URL = resolveURL(base, n.URL, opts.BaseURLExclude)
+	}
+	for _, n := range types.ImageNodes(nodes) {
+		n.Src = resolveURL(base, n.Src, opts.BaseURLExclude)
+	}
+	return nil
+}
+
+// rewriteHeroImage resolves a relative hero image of m like rewriteURLs,
+// so that link previews of the codelab can load it.
+func rewriteHeroImage(m *types.Meta, opts Options) error {
+	base, err := baseURL(opts)
+	if base == nil || m.HeroImage == "" {
+		return err
+	}
+	m.HeroImage = resolveURL(base, m.HeroImage, opts.BaseURLExclude)
+	return nil
+}
+
+// baseURL returns the parsed opts.BaseURL, or nil if it is empty.
+func baseURL(opts Options) (*url.URL, error) {
+	if opts.BaseURL == "" {
+		return nil, nil
+	}
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if !base.IsAbs() {
+		return nil, fmt.Errorf("base URL %q is not absolute", opts.BaseURL)
+	}
+	return base, nil
+}
+
+// resolveURL resolves ref against base if ref is a relative reference
+// which doesn't match any of exclude patterns.
+// Otherwise, ref is returned unmodified.
+func resolveURL(base *url.URL, ref string, exclude []string) string {
+	u, err := url.Parse(ref)
+	// fragment-only refs point within the codelab itself
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return ref
+	}
+	p := strings.TrimPrefix(path.Clean(u.Path), "./")
+	for _, pat := range exclude {
+		if ok, _ := path.Match(pat, p); ok {
+			return ref
+		}
+	}
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestRewriteURLs(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"docs/setup.html", "https://example.com/codelabs/docs/setup.html"},
+		{"./docs/setup.html", "https://example.com/codelabs/docs/setup.html"},
+		{"../other/", "https://example.com/other/"},
+		{"/root.html", "https://example.com/root.html"},
+		{"#step-2", "#step-2"},
+		{"https://golang.org/", "https://golang.org/"},
+		{"//cdn.example.org/x.js", "//cdn.example.org/x.js"},
+		{"mailto:someone@example.com", "mailto:someone@example.com"},
+		{"img/shot.png", "img/shot.png"},
+		{"./img/shot.png", "./img/shot.png"},
+		{"logo.svg", "logo.svg"},
+	}
+	opts := Options{
+		BaseURL:        "https://example.com/codelabs/",
+		BaseURLExclude: []string{"img/*", "*.svg"},
+	}
+	for i, test := range tests {
+		link := types.NewURLNode(test.in, types.NewTextNode("link"))
+		img := types.NewImageNode(test.in)
+		nodes := []types.Node{types.NewListNode(link), img}
+		if err := rewriteURLs(nodes, opts); err != nil {
+			t.Fatalf("%d: rewriteURLs: %v", i, err)
+		}
+		if link.URL != test.out {
+			t.Errorf("%d: link.URL = %q; want %q", i, link.URL, test.out)
+		}
+		if img.Src != test.out {
+			t.Errorf("%d: img.Src = %q; want %q", i, img.Src, test.out)
+		}
+	}
+}
+
+func TestRewriteURLsRelativeBase(t *testing.T) {
+	opts := Options{BaseURL: "codelabs/"}
+	if err := rewriteURLs(nil, opts); err == nil {
+		t.Errorf("rewriteURLs(%q): nil error", opts.BaseURL)
+	}
+}
+
+func TestRewriteHeroImage(t *testing.T) {
+	opts := Options{BaseURL: "https://example.com/codelabs/"}
+	for in, want := range map[string]string{
+		"":                              "",
+		"img/hero.png":                  "https://example.com/codelabs/img/hero.png",
+		"https://cdn.example.org/h.png": "https://cdn.example.org/h.png",
+	} {
+		m := &types.Meta{HeroImage: in}
+		if err := rewriteHeroImage(m, opts); err != nil {
+			t.Fatal(err)
+		}
+		if m.HeroImage != want {
+			t.Errorf("rewriteHeroImage(%q) = %q; want %q", in, m.HeroImage, want)
+		}
+	}
+	m := &types.Meta{HeroImage: "img/hero.png"}
+	if err := rewriteHeroImage(m, Options{}); err != nil || m.HeroImage != "img/hero.png" {
+		t.Errorf("rewriteHeroImage with no base URL = %q, %v; want unchanged", m.HeroImage, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"io/ioutil"
+	"regexp"
+)
+
+// varRegexp matches {{var "key"}} references. Quotes may also be smart quotes
+// or HTML-escaped, as found in Google Docs exports.
+var varRegexp = regexp.MustCompile(`\{\{\s*var\s+(?:"|“|”|&quot;|&#34;)([\w.-]+)(?:"|“|”|&quot;|&#34;)\s*\}\}`)
+
+// substituteVars reads all of r and returns its content with {{var "key"}}
+// references replaced with values of vars, HTML-escaped if escape is true,
+// as for sources in HTML.
+// It returns ErrUndefinedVar if a referenced key is missing from vars.
+func substituteVars(r io.Reader, vars map[string]string, escape bool) (io.Reader, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range varRegexp.FindAllSubmatchIndex(b, -1) {
+		k := string(b[m[2]:m[3]])
+		if _, ok := vars[k]; !ok {
+			line := bytes.Count(b[:m[0]], []byte("\n")) + 1
+			col := m[0] - bytes.LastIndexByte(b[:m[0]], '\n')
+			return nil, &ErrUndefinedVar{Key: k, Line: line, Column: col}
+		}
+	}
+	b = varRegexp.ReplaceAllFunc(b, func(m []byte) []byte {
+		v := vars[string(varRegexp.FindSubmatch(m)[1])]
+		if escape {
+			v = html.EscapeString(v)
+		}
+		return []byte(v)
+	})
+	return bytes.NewReader(b), nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Warning is a non-fatal issue found while parsing a codelab,
+// such as source content the parser drops.
+type Warning struct {
+	Pos     types.Position // position in the source, if known
+	Message string
+}
+
+// String formats the warning as "line:column: message",
+// or just the message if its position is unknown.
+func (w *Warning) String() string {
+	if !w.Pos.IsValid() {
+		return w.Message
+	}
+	return w.Pos.String() + ": " + w.Message
+}
+
+// WarningSink receives warnings of a parser as they are found.
+type WarningSink func(*Warning)
+
+// strict returns opts with a warning sink which also records the first
+// warning, if opts.Strict, and a func returning ErrStrict of that warning
+// once parsing is done.
+func (opts Options) strict() (Options, func() error) {
+	if !opts.Strict {
+		return opts, func() error { return nil }
+	}
+	var first *Warning
+	sink := opts.WarningSink
+	opts.WarningSink = func(w *Warning) {
+		if first == nil {
+			first = w
+		}
+		if sink != nil {
+			sink(w)
+		}
+	}
+	return opts, func() error {
+		if first == nil {
+			return nil
+		}
+		return &ErrStrict{Warning: first}
+	}
+}
+
+// Warnf reports a warning at pos, formatted as fmt.Sprintf does,
+// to opts.WarningSink. Without a sink, the warning is discarded.
+func (opts Options) Warnf(pos types.Position, format string, args ...interface{}) {
+	if opts.WarningSink == nil {
+		return
+	}
+	opts.WarningSink(&Warning{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
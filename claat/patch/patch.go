@@ -0,0 +1,342 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package patch applies JSON Patch (RFC 6902) operations to a parsed codelab.
+//
+// Paths are JSON Pointers (RFC 6901) into the codelab data model,
+// where struct fields are addressed by their JSON name or, case-insensitively,
+// by their Go name. For instance, "/steps/1/content/nodes/0/src"
+// points to the src of an image which is the first node of the second step.
+//
+// Values are decoded into the type of the target. Since nodes are interfaces,
+// they cannot be decoded from JSON, but can still be removed, moved or copied.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Operation is a single JSON Patch operation.
+type Operation struct {
+	Op    string          `json:"op"`             // add, remove, replace, move, copy or test
+	Path  string          `json:"path"`           // target location
+	From  string          `json:"from,omitempty"` // source location of move and copy
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Parse decodes a JSON Patch document from r.
+func Parse(r io.Reader) ([]*Operation, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var ops []*Operation
+	if err := json.Unmarshal(b, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// ReadFile decodes a JSON Patch document stored in a local file.
+func ReadFile(name string) ([]*Operation, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Apply applies ops to clab in order.
+// It stops at the first failed operation, leaving clab partially patched.
+func Apply(clab *types.Codelab, ops []*Operation) error {
+	root := reflect.ValueOf(clab).Elem()
+	for i, op := range ops {
+		if err := apply(root, op); err != nil {
+			return fmt.Errorf("patch op %d (%s %s): %v", i, op.Op, op.Path, err)
+		}
+	}
+	return nil
+}
+
+func apply(root reflect.Value, op *Operation) error {
+	dst, err := locate(root, op.Path)
+	if err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add", "replace":
+		v, err := decode(dst.typ(), op.Value)
+		if err != nil {
+			return err
+		}
+		if op.Op == "add" {
+			return dst.insert(v)
+		}
+		if _, err := dst.get(); err != nil {
+			return err
+		}
+		return dst.set(v)
+	case "remove":
+		_, err := dst.remove()
+		return err
+	case "move", "copy":
+		src, err := locate(root, op.From)
+		if err != nil {
+			return err
+		}
+		var v reflect.Value
+		if op.Op == "move" {
+			v, err = src.remove()
+		} else {
+			v, err = src.get()
+		}
+		if err != nil {
+			return err
+		}
+		// dst may have been shifted by removal of src from the same slice
+		if dst, err = locate(root, op.Path); err != nil {
+			return err
+		}
+		return dst.insert(v)
+	case "test":
+		v, err := dst.get()
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		var got, want interface{}
+		if err := json.Unmarshal(b, &got); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(op.Value, &want); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("test failed: value is %s", b)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown op %q", op.Op)
+}
+
+// location is a reference to a value within its container.
+type location struct {
+	parent reflect.Value // a struct, slice or map
+	key    string        // field name, slice index or map key
+}
+
+// locate resolves JSON Pointer path starting at root.
+// All but the last path tokens must refer to existing values.
+func locate(root reflect.Value, path string) (*location, error) {
+	if path == "" || path[0] != '/' {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+	toks := strings.Split(path[1:], "/")
+	for i, t := range toks {
+		toks[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	v := root
+	for _, t := range toks[:len(toks)-1] {
+		var err error
+		if v, err = (&location{deref(v), t}).get(); err != nil {
+			return nil, err
+		}
+	}
+	return &location{deref(v), toks[len(toks)-1]}, nil
+}
+
+// deref follows pointers and interfaces until a non-nil concrete value.
+func deref(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// field returns a field of struct v matching name, looking into
+// embedded structs. Unexported fields are never matched.
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if fv, ok := field(v.Field(i), name); ok {
+				return fv, true
+			}
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == name || strings.EqualFold(f.Name, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// index parses l.key as an index of a slice of length n.
+// The special "-" index, as well as n, are accepted only if end is true.
+func (l *location) index(end bool) (int, error) {
+	n := l.parent.Len()
+	if l.key == "-" && end {
+		return n, nil
+	}
+	i, err := strconv.Atoi(l.key)
+	if err != nil || i < 0 || i > n || i == n && !end {
+		return 0, fmt.Errorf("index %q out of range", l.key)
+	}
+	return i, nil
+}
+
+func (l *location) typ() reflect.Type {
+	switch l.parent.Kind() {
+	case reflect.Slice, reflect.Map:
+		return l.parent.Type().Elem()
+	case reflect.Struct:
+		if f, ok := field(l.parent, l.key); ok {
+			return f.Type()
+		}
+	}
+	return nil
+}
+
+func (l *location) get() (reflect.Value, error) {
+	switch l.parent.Kind() {
+	case reflect.Struct:
+		if f, ok := field(l.parent, l.key); ok {
+			return f, nil
+		}
+	case reflect.Slice:
+		i, err := l.index(false)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return l.parent.Index(i), nil
+	case reflect.Map:
+		if v := l.parent.MapIndex(reflect.ValueOf(l.key)); v.IsValid() {
+			return v, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%q not found", l.key)
+}
+
+func (l *location) set(v reflect.Value) error {
+	switch l.parent.Kind() {
+	case reflect.Map:
+		if l.parent.IsNil() {
+			if !l.parent.CanSet() {
+				return fmt.Errorf("cannot set %q", l.key)
+			}
+			l.parent.Set(reflect.MakeMap(l.parent.Type()))
+		}
+		l.parent.SetMapIndex(reflect.ValueOf(l.key), v)
+		return nil
+	}
+	dst, err := l.get()
+	if err != nil {
+		return err
+	}
+	if !dst.CanSet() || !v.Type().AssignableTo(dst.Type()) {
+		return fmt.Errorf("cannot set %q to a value of type %s", l.key, v.Type())
+	}
+	dst.Set(v)
+	return nil
+}
+
+// insert adds v to a slice at l.key, shifting subsequent elements,
+// or sets it for any other container kind.
+func (l *location) insert(v reflect.Value) error {
+	if l.parent.Kind() != reflect.Slice {
+		return l.set(v)
+	}
+	i, err := l.index(true)
+	if err != nil {
+		return err
+	}
+	if !l.parent.CanSet() || !v.Type().AssignableTo(l.parent.Type().Elem()) {
+		return fmt.Errorf("cannot insert a value of type %s at %q", v.Type(), l.key)
+	}
+	n := l.parent.Len()
+	s := reflect.MakeSlice(l.parent.Type(), n+1, n+1)
+	reflect.Copy(s, l.parent.Slice(0, i))
+	s.Index(i).Set(v)
+	reflect.Copy(s.Slice(i+1, n+1), l.parent.Slice(i, n))
+	l.parent.Set(s)
+	return nil
+}
+
+// remove deletes the value at l and returns it.
+// Struct fields are reset to their zero value.
+func (l *location) remove() (reflect.Value, error) {
+	old, err := l.get()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	// copy the value before it is overwritten
+	v := reflect.New(old.Type()).Elem()
+	v.Set(old)
+	switch l.parent.Kind() {
+	case reflect.Map:
+		l.parent.SetMapIndex(reflect.ValueOf(l.key), reflect.Value{})
+	case reflect.Slice:
+		if !l.parent.CanSet() {
+			return reflect.Value{}, fmt.Errorf("cannot remove %q", l.key)
+		}
+		i, _ := l.index(false)
+		n := l.parent.Len()
+		s := reflect.MakeSlice(l.parent.Type(), n-1, n-1)
+		reflect.Copy(s, l.parent.Slice(0, i))
+		reflect.Copy(s.Slice(i, n-1), l.parent.Slice(i+1, n))
+		l.parent.Set(s)
+	default:
+		if !old.CanSet() {
+			return reflect.Value{}, fmt.Errorf("cannot remove %q", l.key)
+		}
+		old.Set(reflect.Zero(old.Type()))
+	}
+	return v, nil
+}
+
+// decode unmarshals JSON value b into a new value of type t.
+func decode(t reflect.Type, b json.RawMessage) (reflect.Value, error) {
+	if t == nil {
+		return reflect.Value{}, fmt.Errorf("unknown target")
+	}
+	if t.Kind() == reflect.Interface {
+		return reflect.Value{}, fmt.Errorf("cannot decode a value of type %s", t)
+	}
+	if len(b) == 0 {
+		return reflect.Value{}, fmt.Errorf("missing value")
+	}
+	p := reflect.New(t)
+	if err := json.Unmarshal(b, p.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return p.Elem(), nil
+}
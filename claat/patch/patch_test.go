@@ -0,0 +1,91 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func testCodelab() *types.Codelab {
+	clab := types.NewCodelab()
+	clab.ID = "clab"
+	clab.Categories = []string{"web"}
+	st := clab.NewStep("one")
+	st.Content.Append(
+		types.NewImageNode("img/a.png"),
+		types.NewURLNode("https://example.com/", types.NewTextNode("link")),
+		types.NewTextNode("text"),
+	)
+	return clab
+}
+
+func TestApply(t *testing.T) {
+	const doc = `[
+		{"op": "test", "path": "/id", "value": "clab"},
+		{"op": "replace", "path": "/steps/0/content/nodes/0/src", "value": "https://mirror.example.cn/a.png"},
+		{"op": "replace", "path": "/Steps/0/Content/Nodes/1/URL", "value": "https://example.cn/"},
+		{"op": "add", "path": "/category/-", "value": "cloud"},
+		{"op": "add", "path": "/extra/region", "value": "cn"},
+		{"op": "move", "from": "/steps/0/content/nodes/2", "path": "/steps/0/content/nodes/0"},
+		{"op": "remove", "path": "/steps/0/content/nodes/2"}
+	]`
+	ops, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clab := testCodelab()
+	img := clab.Steps[0].Content.Nodes[0].(*types.ImageNode)
+	if err := Apply(clab, ops); err != nil {
+		t.Fatal(err)
+	}
+
+	if img.Src != "https://mirror.example.cn/a.png" {
+		t.Errorf("img.Src = %q", img.Src)
+	}
+	if !reflect.DeepEqual(clab.Categories, []string{"web", "cloud"}) {
+		t.Errorf("clab.Categories = %v", clab.Categories)
+	}
+	if clab.Extra["region"] != "cn" {
+		t.Errorf("clab.Extra = %v", clab.Extra)
+	}
+	nodes := clab.Steps[0].Content.Nodes
+	if len(nodes) != 2 || nodes[0].Type() != types.NodeText || nodes[1] != img {
+		t.Errorf("nodes = %v", nodes)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []string{
+		`[{"op": "test", "path": "/id", "value": "other"}]`,
+		`[{"op": "replace", "path": "/steps/1/title", "value": "x"}]`,
+		`[{"op": "replace", "path": "/nosuchfield", "value": "x"}]`,
+		`[{"op": "add", "path": "/steps/0/content/nodes/0", "value": {}}]`,
+		`[{"op": "replace", "path": "/id", "value": 1}]`,
+		`[{"op": "frobnicate", "path": "/id"}]`,
+	}
+	for i, test := range tests {
+		ops, err := Parse(strings.NewReader(test))
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if err := Apply(testCodelab(), ops); err == nil {
+			t.Errorf("%d: Apply(%s): nil error", i, test)
+		}
+	}
+}
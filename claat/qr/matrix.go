@@ -0,0 +1,278 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qr
+
+// newCode returns a code of version n with its function patterns drawn,
+// and format modules reserved.
+func newCode(n int) *Code {
+	size := 17 + 4*n
+	c := &Code{
+		Size:     size,
+		Version:  n,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+	// timing patterns, partly covered by the others
+	for i := 0; i < size; i++ {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+	// finder patterns, with their separators
+	for _, p := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := p[0]+dx, p[1]+dy
+				if x >= 0 && y >= 0 && x < size && y < size {
+					d := max(abs(dx), abs(dy))
+					c.set(x, y, d != 2 && d != 4)
+				}
+			}
+		}
+	}
+	// alignment patterns, except where finder patterns are
+	align := versions[n].align
+	last := len(align) - 1
+	for i, ay := range align {
+		for j, ax := range align {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.set(ax+dx, ay+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	c.drawFormat(0) // reserved, drawn once masked
+	c.drawVersion()
+	return c
+}
+
+// set sets the function module at column x and row y.
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.Size+x] = dark
+	c.function[y*c.Size+x] = true
+}
+
+// formatBits returns the 15-bit format information of mask,
+// at error correction level M.
+func formatBits(mask int) int {
+	data := 0<<3 | mask // level M is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18-bit version information of version n.
+func versionBits(n int) int {
+	rem := n
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	return n<<12 | rem
+}
+
+// drawFormat draws both copies of the format information of mask.
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(i))
+	}
+	c.set(8, 7, bit(6))
+	c.set(8, 8, bit(7))
+	c.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		c.set(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.Size-15+i, bit(i))
+	}
+	c.set(8, c.Size-8, true) // dark module
+}
+
+// drawVersion draws both copies of the version information,
+// of versions 7 and up.
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.set(a, b, dark)
+		c.set(b, a, dark)
+	}
+}
+
+// drawCodewords draws data in the zigzag order of non-function modules,
+// from the bottom right corner, two columns at a time. Remainder modules
+// are left light.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert // upward
+				}
+				if !c.function[y*c.Size+x] && i < len(data)*8 {
+					c.modules[y*c.Size+x] = data[i>>3]>>uint(7-i&7)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts non-function modules selected by mask pattern mask.
+// Applying it twice restores the modules.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !c.function[y*c.Size+x] {
+				c.modules[y*c.Size+x] = !c.modules[y*c.Size+x]
+			}
+		}
+	}
+}
+
+// penalty returns the penalty score of the modules of c, which
+// the mask pattern of a code minimizes.
+func (c *Code) penalty() int {
+	var p int
+	// runs of 5 or more modules of the same color, and finder-like
+	// patterns, in rows and columns
+	for _, row := range []bool{true, false} {
+		for i := 0; i < c.Size; i++ {
+			line := make([]bool, c.Size)
+			for j := range line {
+				if row {
+					line[j] = c.Black(j, i)
+				} else {
+					line[j] = c.Black(i, j)
+				}
+			}
+			p += runPenalty(line) + finderPenalty(line)
+		}
+	}
+	// 2x2 blocks of the same color
+	for y := 0; y < c.Size-1; y++ {
+		for x := 0; x < c.Size-1; x++ {
+			v := c.Black(x, y)
+			if v == c.Black(x+1, y) && v == c.Black(x, y+1) && v == c.Black(x+1, y+1) {
+				p += 3
+			}
+		}
+	}
+	// balance of dark and light modules, by steps of 5%
+	var dark int
+	for _, m := range c.modules {
+		if m {
+			dark++
+		}
+	}
+	total := c.Size * c.Size
+	p += abs(dark*20-total*10) / total * 10
+	return p
+}
+
+// runPenalty returns the penalty of runs of 5 or more modules
+// of the same color in line.
+func runPenalty(line []bool) int {
+	var p int
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			p += 3 + run - 5
+		}
+		run = 1
+	}
+	return p
+}
+
+// finderPattern is the 1:1:3:1:1 dark and light module pattern
+// of finder patterns, followed by 4 light modules.
+var finderPattern = []bool{true, false, true, true, true, false, true, false, false, false, false}
+
+// finderPenalty returns the penalty of finder-like patterns in line,
+// either way.
+func finderPenalty(line []bool) int {
+	var p int
+	n := len(finderPattern)
+	for i := 0; i+n <= len(line); i++ {
+		fwd, bwd := true, true
+		for j, v := range finderPattern {
+			fwd = fwd && line[i+j] == v
+			bwd = bwd && line[i+n-1-j] == v
+		}
+		if fwd {
+			p += 40
+		}
+		if bwd {
+			p += 40
+		}
+	}
+	return p
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
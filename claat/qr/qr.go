@@ -0,0 +1,218 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qr encodes QR codes of short text, such as codelab URLs.
+//
+// Text is encoded in byte mode with error correction level M,
+// which restores up to 15% of damaged codewords, in the smallest
+// of versions 1 to 10 which fits it, up to 213 bytes.
+// See ISO/IEC 18004.
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// QuietZone is the width of the light border around rendered codes,
+// in modules.
+const QuietZone = 4
+
+// ErrTooLong is returned when text does not fit in a supported version.
+var ErrTooLong = errors.New("qr: text too long")
+
+// Code is a QR code: a square of dark and light modules.
+type Code struct {
+	Size     int // Number of modules on a side, excluding the quiet zone
+	Version  int // Version, from 1 to 10
+	Mask     int // Mask pattern, from 0 to 7
+	modules  []bool
+	function []bool // modules of function patterns, not masked
+}
+
+// version is the error correction block structure of a version,
+// at level M.
+type version struct {
+	ecc    int    // error correction codewords per block
+	blocks [2]int // number of blocks of each group
+	data   [2]int // data codewords per block of each group
+	align  []int  // alignment pattern center coordinates
+}
+
+// versions are supported versions, indexed by version number.
+var versions = [...]version{
+	1:  {10, [2]int{1, 0}, [2]int{16, 0}, nil},
+	2:  {16, [2]int{1, 0}, [2]int{28, 0}, []int{6, 18}},
+	3:  {26, [2]int{1, 0}, [2]int{44, 0}, []int{6, 22}},
+	4:  {18, [2]int{2, 0}, [2]int{32, 0}, []int{6, 26}},
+	5:  {24, [2]int{2, 0}, [2]int{43, 0}, []int{6, 30}},
+	6:  {16, [2]int{4, 0}, [2]int{27, 0}, []int{6, 34}},
+	7:  {18, [2]int{4, 0}, [2]int{31, 0}, []int{6, 22, 38}},
+	8:  {22, [2]int{2, 2}, [2]int{38, 39}, []int{6, 24, 42}},
+	9:  {22, [2]int{3, 2}, [2]int{36, 37}, []int{6, 26, 46}},
+	10: {26, [2]int{4, 1}, [2]int{43, 44}, []int{6, 28, 50}},
+}
+
+// dataCodewords returns the number of data codewords of v.
+func (v version) dataCodewords() int {
+	return v.blocks[0]*v.data[0] + v.blocks[1]*v.data[1]
+}
+
+// Encode returns the QR code of text, with the mask pattern of the
+// lowest penalty.
+func Encode(text string) (*Code, error) {
+	for n := 1; n < len(versions); n++ {
+		countBits := 8
+		if n >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(text) > 8*versions[n].dataCodewords() {
+			continue
+		}
+		data := encodeData(text, countBits, versions[n].dataCodewords())
+		c := newCode(n)
+		c.drawCodewords(interleave(data, versions[n]))
+		best, penalty := 0, -1
+		for mask := 0; mask < 8; mask++ {
+			c.applyMask(mask)
+			c.drawFormat(mask)
+			if p := c.penalty(); penalty < 0 || p < penalty {
+				best, penalty = mask, p
+			}
+			c.applyMask(mask) // undo
+		}
+		c.Mask = best
+		c.applyMask(best)
+		c.drawFormat(best)
+		return c, nil
+	}
+	return nil, ErrTooLong
+}
+
+// Black reports whether the module at column x and row y is dark.
+// Modules outside of the code are light.
+func (c *Code) Black(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.Size && y < c.Size && c.modules[y*c.Size+x]
+}
+
+// SVG returns an SVG image of c, including its quiet zone, in which
+// each module is a unit square.
+func (c *Code) SVG() string {
+	n := c.Size + 2*QuietZone
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Black(x, y) {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String()
+}
+
+// Image returns a grayscale image of c, including its quiet zone,
+// in which each module is scale pixels wide.
+func (c *Code) Image(scale int) image.Image {
+	n := (c.Size + 2*QuietZone) * scale
+	m := image.NewGray(image.Rect(0, 0, n, n))
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			v := color.Gray{0xff}
+			if c.Black(x/scale-QuietZone, y/scale-QuietZone) {
+				v = color.Gray{0}
+			}
+			m.SetGray(x, y, v)
+		}
+	}
+	return m
+}
+
+// encodeData returns the data codewords of text in byte mode, padded
+// to n codewords. Its length is encoded in countBits bits.
+func encodeData(text string, countBits, n int) []byte {
+	var bb bitBuffer
+	bb.append(0x4, 4) // byte mode
+	bb.append(len(text), countBits)
+	for i := 0; i < len(text); i++ {
+		bb.append(int(text[i]), 8)
+	}
+	// terminator, up to 4 bits, and padding to a byte boundary
+	for i := 0; i < 4 && len(bb) < 8*n; i++ {
+		bb = append(bb, false)
+	}
+	for len(bb)%8 != 0 {
+		bb = append(bb, false)
+	}
+	data := bb.bytes()
+	for pad := byte(0xec); len(data) < n; pad ^= 0xec ^ 0x11 {
+		data = append(data, pad)
+	}
+	return data
+}
+
+// interleave splits data codewords into the blocks of v, and returns
+// them interleaved, followed by their error correction codewords.
+func interleave(data []byte, v version) []byte {
+	div := rsDivisor(v.ecc)
+	var blocks, eccs [][]byte
+	for g := 0; g < 2; g++ {
+		for i := 0; i < v.blocks[g]; i++ {
+			b := data[:v.data[g]]
+			data = data[v.data[g]:]
+			blocks = append(blocks, b)
+			eccs = append(eccs, rsRemainder(b, div))
+		}
+	}
+	var res []byte
+	for i := 0; i < v.data[0] || i < v.data[1]; i++ {
+		for _, b := range blocks {
+			if i < len(b) {
+				res = append(res, b[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecc; i++ {
+		for _, e := range eccs {
+			res = append(res, e[i])
+		}
+	}
+	return res
+}
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+// append appends the n low bits of v.
+func (bb *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, v>>uint(i)&1 != 0)
+	}
+}
+
+// bytes returns the bits of bb, whose length is a multiple of 8, as bytes.
+func (bb bitBuffer) bytes() []byte {
+	res := make([]byte, len(bb)/8)
+	for i, bit := range bb {
+		if bit {
+			res[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+	return res
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// "HELLO WORLD" at version 1-M, from the worked example of ISO/IEC 18004
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !reflect.DeepEqual(got, want) {
+		t.Errorf("rsRemainder = %v; want %v", got, want)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	want := []int{
+		0x5412, // 101010000010010
+		0x5125, // 101000100100101
+		0x5e7c, // 101111001111100
+		0x5b4b, // 101101101001011
+		0x45f9, // 100010111111001
+		0x40ce, // 100000011001110
+		0x4f97, // 100111110010111
+		0x4aa0, // 100101010100000
+	}
+	for mask, w := range want {
+		if got := formatBits(mask); got != w {
+			t.Errorf("formatBits(%d) = %015b; want %015b", mask, got, w)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	tests := map[int]int{
+		7:  0x07c94, // 000111110010010100
+		10: 0x0a4d3, // 001010010011010011
+	}
+	for n, want := range tests {
+		if got := versionBits(n); got != want {
+			t.Errorf("versionBits(%d) = %018b; want %018b", n, got, want)
+		}
+	}
+}
+
+// readCodewords returns the codewords of c, unmasked, read in the zigzag
+// order of non-function modules.
+func readCodewords(c *Code) []byte {
+	c.applyMask(c.Mask)
+	defer c.applyMask(c.Mask)
+	var bb bitBuffer
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.function[y*c.Size+x] {
+					bb = append(bb, c.Black(x, y))
+				}
+			}
+		}
+	}
+	return bb[:len(bb)/8*8].bytes()
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		text    string
+		version int
+	}{
+		{"https://codelabs.example.com/", 3},
+		{"https://codelabs.example.com/codelabs/getting-started-with-claat/", 5},
+		{strings.Repeat("x", 150), 8},
+		{strings.Repeat("x", 213), 10},
+	}
+	for _, test := range tests {
+		c, err := Encode(test.text)
+		if err != nil {
+			t.Errorf("Encode(%q): %v", test.text, err)
+			continue
+		}
+		if c.Version != test.version || c.Size != 17+4*test.version {
+			t.Errorf("Encode(%q): version %d, size %d; want version %d", test.text, c.Version, c.Size, test.version)
+		}
+		// finder pattern centers and separators
+		for _, p := range [][2]int{{3, 3}, {c.Size - 4, 3}, {3, c.Size - 4}} {
+			if !c.Black(p[0], p[1]) || c.Black(p[0]+2, p[1]) || !c.Black(p[0]+3, p[1]) {
+				t.Errorf("Encode(%q): no finder pattern at %v", test.text, p)
+			}
+		}
+		// first copy of the format information
+		var format int
+		for i := 0; i <= 5; i++ {
+			format |= bit(c.Black(8, i)) << uint(i)
+		}
+		format |= bit(c.Black(8, 7))<<6 | bit(c.Black(8, 8))<<7 | bit(c.Black(7, 8))<<8
+		for i := 9; i < 15; i++ {
+			format |= bit(c.Black(14-i, 8)) << uint(i)
+		}
+		if want := formatBits(c.Mask); format != want {
+			t.Errorf("Encode(%q): format %015b; want %015b", test.text, format, want)
+		}
+		v := versions[c.Version]
+		countBits := 8
+		if c.Version >= 10 {
+			countBits = 16
+		}
+		want := interleave(encodeData(test.text, countBits, v.dataCodewords()), v)
+		if got := readCodewords(c); !bytes.Equal(got, want) {
+			t.Errorf("Encode(%q): codewords\n%v\nwant\n%v", test.text, got, want)
+		}
+	}
+}
+
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestEncodeData(t *testing.T) {
+	// byte mode, 3 bytes, terminator and padding codewords
+	got := encodeData("abc", 8, 8)
+	want := []byte{0x40, 0x36, 0x16, 0x26, 0x30, 0xec, 0x11, 0xec}
+	if !bytes.Equal(got, want) {
+		t.Errorf("encodeData = %x; want %x", got, want)
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("x", 214)); err != ErrTooLong {
+		t.Errorf("Encode: err = %v; want ErrTooLong", err)
+	}
+}
+
+func TestCodeImage(t *testing.T) {
+	c, err := Encode("https://codelabs.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svg := c.SVG(); !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 37 37"`) {
+		t.Errorf("SVG: %.80s", svg)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(4)); err != nil {
+		t.Fatal(err)
+	}
+	m, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := m.Bounds(); b.Dx() != 37*4 || b.Dy() != 37*4 {
+		t.Errorf("Image(4) bounds = %v; want 148x148", b)
+	}
+	if r, _, _, _ := m.At(0, 0).RGBA(); r == 0 {
+		t.Errorf("Image(4): quiet zone is dark")
+	}
+	if r, _, _, _ := m.At(4*4, 4*4).RGBA(); r != 0 {
+		t.Errorf("Image(4): finder pattern corner is light")
+	}
+}
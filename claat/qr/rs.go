@@ -0,0 +1,60 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qr
+
+// rsDivisor returns the Reed-Solomon generator polynomial of degree n,
+// over GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1, with coefficients from
+// the highest to the lowest power, excluding the leading 1.
+func rsDivisor(n int) []byte {
+	res := make([]byte, n)
+	res[n-1] = 1
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		// multiply by (x - root^i)
+		for j := range res {
+			res[j] = gfMul(res[j], root)
+			if j+1 < n {
+				res[j] ^= res[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return res
+}
+
+// rsRemainder returns the error correction codewords of data,
+// the remainder of its division by divisor.
+func rsRemainder(data, divisor []byte) []byte {
+	res := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i, d := range divisor {
+			res[i] ^= gfMul(d, factor)
+		}
+	}
+	return res
+}
+
+// gfMul returns the product of x and y in GF(2^8).
+func gfMul(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>uint(i)&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Analytics providers of the html format.
+const (
+	AnalyticsGA4       = "ga4"       // Google Analytics 4, by measurement ID
+	AnalyticsGTM       = "gtm"       // Google Tag Manager, by container ID
+	AnalyticsPlausible = "plausible" // Plausible, by site domain
+	AnalyticsBeacon    = "beacon"    // JSON events posted to a URL
+)
+
+var (
+	ga4IDRegexp    = regexp.MustCompile(`^G-[A-Z0-9]+$`)
+	gtmIDRegexp    = regexp.MustCompile(`^GTM-[A-Z0-9]+$`)
+	domainIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)
+)
+
+// Analytics is a provider which the html format sends events to:
+// step_view when a step is shown, step_duration with the seconds spent
+// on a step when the reader leaves it, and codelab_complete when the last
+// step is shown.
+type Analytics struct {
+	Provider string `json:"provider"` // one of the Analytics* constants
+	ID       string `json:"id"`       // measurement or container ID, domain or URL
+}
+
+// String returns the spec of a, as parsed by ParseAnalytics.
+func (a *Analytics) String() string {
+	return a.Provider + ":" + a.ID
+}
+
+// ParseAnalytics parses spec of an analytics provider, "provider:id",
+// e.g. "ga4:G-XXXXXXX", "gtm:GTM-XXXXXX", "plausible:example.com"
+// or "beacon:https://example.com/events".
+func ParseAnalytics(spec string) (*Analytics, error) {
+	i := strings.Index(spec, ":")
+	if i < 0 {
+		return nil, fmt.Errorf("analytics %q: want provider:id", spec)
+	}
+	a := &Analytics{
+		Provider: strings.ToLower(strings.TrimSpace(spec[:i])),
+		ID:       strings.TrimSpace(spec[i+1:]),
+	}
+	var ok bool
+	switch a.Provider {
+	case AnalyticsGA4:
+		ok = ga4IDRegexp.MatchString(a.ID)
+	case AnalyticsGTM:
+		ok = gtmIDRegexp.MatchString(a.ID)
+	case AnalyticsPlausible:
+		ok = domainIDRegexp.MatchString(a.ID)
+	case AnalyticsBeacon:
+		u, err := url.Parse(a.ID)
+		ok = err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	default:
+		return nil, fmt.Errorf("analytics %q: unknown provider %q; want %s, %s, %s or %s",
+			spec, a.Provider, AnalyticsGA4, AnalyticsGTM, AnalyticsPlausible, AnalyticsBeacon)
+	}
+	if !ok {
+		return nil, fmt.Errorf("analytics %q: invalid %s ID %q", spec, a.Provider, a.ID)
+	}
+	return a, nil
+}
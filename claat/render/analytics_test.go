@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestParseAnalytics(t *testing.T) {
+	tests := []struct {
+		spec string
+		want *Analytics
+	}{
+		{"ga4:G-ABC123", &Analytics{AnalyticsGA4, "G-ABC123"}},
+		{" GTM : GTM-XYZ9 ", &Analytics{AnalyticsGTM, "GTM-XYZ9"}},
+		{"plausible:codelabs.example.com", &Analytics{AnalyticsPlausible, "codelabs.example.com"}},
+		{"beacon:https://example.com/events?src=claat", &Analytics{AnalyticsBeacon, "https://example.com/events?src=claat"}},
+		{"G-ABC123", nil},
+		{"ga4:UA-12345-1", nil},
+		{"gtm:G-ABC123", nil},
+		{"plausible:example.com/x", nil},
+		{"beacon:javascript:alert(1)", nil},
+		{"beacon:/events", nil},
+		{"matomo:1", nil},
+	}
+	for _, test := range tests {
+		a, err := ParseAnalytics(test.spec)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("ParseAnalytics(%q) = %v; want error", test.spec, a)
+			}
+			continue
+		}
+		if err != nil || *a != *test.want {
+			t.Errorf("ParseAnalytics(%q) = %v, %v; want %v", test.spec, a, err, test.want)
+		}
+	}
+}
+
+func TestExecuteAnalytics(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:  &types.Meta{ID: "go-basics"},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "gtag") || strings.Contains(out, "step_view") {
+		t.Errorf("Execute(html) with no analytics has analytics scripts:\n%s", out)
+	}
+
+	data.Analytics = []*Analytics{
+		{AnalyticsGA4, "G-ABC123"},
+		{AnalyticsGTM, "GTM-XYZ9"},
+		{AnalyticsPlausible, "example.com"},
+		{AnalyticsBeacon, "https://example.com/events"},
+	}
+	buf.Reset()
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123"></script>`,
+		`<script>gtag('config', "G-ABC123");</script>`,
+		`<script async src="https://www.googletagmanager.com/gtm.js?id=GTM-XYZ9"></script>`,
+		`<script defer data-domain="example.com" src="https://plausible.io/js/script.js"></script>`,
+		`send('step_view', {step: i + 1, title: steps[i].getAttribute('label')});`,
+		`send('codelab_complete', {steps: steps.length});`,
+		`{"provider":"beacon","id":"https://example.com/events"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	htmlTemplate "html/template"
+	"io"
+	"unicode/utf8"
+)
+
+// badgeTemplate is a flat, shields.io-style badge.
+var badgeTemplate = htmlTemplate.Must(htmlTemplate.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Value}}">
+<title>{{.Label}}: {{.Value}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.ValueWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.ValueX}}" y="14">{{.Value}}</text>
+</g>
+</svg>
+`))
+
+// BadgeColor is the default background color of a badge value.
+const BadgeColor = "#4285f4"
+
+// Badge renders an SVG status badge with label and value into w,
+// suitable for embedding in README files and catalogs.
+// Text widths are estimated since fonts are not available to measure.
+func Badge(w io.Writer, label, value, color string) error {
+	lw := textWidth(label)
+	vw := textWidth(value)
+	return badgeTemplate.Execute(w, struct {
+		Label, Value, Color           string
+		Width, LabelWidth, ValueWidth int
+		LabelX, ValueX                float64
+	}{
+		Label:      label,
+		Value:      value,
+		Color:      color,
+		Width:      lw + vw,
+		LabelWidth: lw,
+		ValueWidth: vw,
+		LabelX:     float64(lw) / 2,
+		ValueX:     float64(lw) + float64(vw)/2,
+	})
+}
+
+// textWidth estimates width in pixels of s rendered in an 11px Verdana,
+// including horizontal padding.
+func textWidth(s string) int {
+	return utf8.RuneCountInString(s)*7 + 10
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestBadge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Badge(&buf, "duration", "<45 min>", BadgeColor); err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		XMLName xml.Name
+		Width   int    `xml:"width,attr"`
+		Title   string `xml:"title"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatalf("xml.Unmarshal: %v\n%s", err, buf.Bytes())
+	}
+	if svg.XMLName.Local != "svg" || svg.Title != "duration: <45 min>" {
+		t.Errorf("svg = %+v", svg)
+	}
+	if want := textWidth("duration") + textWidth("<45 min>"); svg.Width != want {
+		t.Errorf("svg.Width = %d; want %d", svg.Width, want)
+	}
+	if !strings.Contains(buf.String(), BadgeColor) {
+		t.Errorf("badge does not contain color %s", BadgeColor)
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("docx", docxRenderer{})
+}
+
+// docxRenderer renders a codelab as a Word document for reviewers.
+//
+// Node types are mapped to paragraph styles, e.g. "Code" or "InfoNegative",
+// which reviewers can restyle in Word without affecting the content.
+// Steps of other environments than the context's are skipped.
+// Each step title and each top-level block of a step is bookmarked
+// with a stable ID, see DocxBookmark, so that comments can be mapped back
+// to the source regions they refer to.
+type docxRenderer struct{}
+
+func (docxRenderer) Ext() string { return "docx" }
+
+func (docxRenderer) Render(w io.Writer, ctx *Context) error {
+	dw := &docxWriter{env: ctx.Env}
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	dw.paragraph("Title", func() { dw.run(m.Title, "") })
+	if m.Summary != "" {
+		dw.paragraph("Subtitle", func() { dw.run(m.Summary, "") })
+	}
+	var num int // number of the step, among those of the environment
+	for i, st := range ctx.Steps {
+		if !dw.matchEnv(st.Tags) {
+			continue
+		}
+		num++
+		// bookmarks keep the position of the step in the source
+		dw.bookmark = DocxBookmark(i+1, 0)
+		dw.paragraph("Heading1", func() { dw.run(fmt.Sprintf("%d. %s", num, st.Title), "") })
+		for j, n := range st.Content.Nodes {
+			dw.bookmark = DocxBookmark(i+1, j+1)
+			dw.write(n)
+			dw.closePara()
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		body []byte
+	}{
+		{"[Content_Types].xml", []byte(docxContentTypes)},
+		{"_rels/.rels", []byte(docxRels)},
+		{"docProps/core.xml", docxCore(m)},
+		{"word/styles.xml", []byte(docxStyles)},
+		{"word/_rels/document.xml.rels", dw.rels()},
+		{"word/document.xml", dw.document()},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// DocxBookmark returns the name of a docx bookmark of block n of step s,
+// both 1-based, e.g. "s2_b3". Block 0 is the step title, e.g. "s2_b0".
+func DocxBookmark(s, n int) string {
+	return fmt.Sprintf("s%d_b%d", s, n)
+}
+
+type docxWriter struct {
+	env      string       // target environment
+	body     bytes.Buffer // content of the document body element
+	open     bool         // whether a paragraph is open
+	style    string       // paragraph style of block content
+	bookmark string       // bookmark of the next paragraph, if not empty
+	nmarks   int          // number of written bookmarks
+	links    []string     // hyperlink targets; relationship IDs are rIdN+2
+	lists    int          // depth of the lists being written
+}
+
+func (dw *docxWriter) matchEnv(v []string) bool {
+	if len(v) == 0 || dw.env == "" {
+		return true
+	}
+	i := sort.SearchStrings(v, dw.env)
+	return i < len(v) && v[i] == dw.env
+}
+
+// openPara opens a new paragraph of style, unless one is already open.
+func (dw *docxWriter) openPara(style string) {
+	if dw.open {
+		return
+	}
+	dw.open = true
+	dw.body.WriteString("<w:p>")
+	if style == "" {
+		style = dw.style
+	}
+	if style != "" && dw.lists > 1 {
+		// nested lists are indented by the indent of list styles per level
+		fmt.Fprintf(&dw.body, `<w:pPr><w:pStyle w:val="%s"/><w:ind w:left="%d" w:hanging="360"/></w:pPr>`, style, 720*dw.lists)
+	} else if style != "" {
+		fmt.Fprintf(&dw.body, `<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	}
+	if dw.bookmark != "" {
+		fmt.Fprintf(&dw.body, `<w:bookmarkStart w:id="%d" w:name="%s"/><w:bookmarkEnd w:id="%d"/>`, dw.nmarks, dw.bookmark, dw.nmarks)
+		dw.nmarks++
+		dw.bookmark = ""
+	}
+}
+
+func (dw *docxWriter) closePara() {
+	if dw.open {
+		dw.body.WriteString("</w:p>")
+		dw.open = false
+	}
+}
+
+// paragraph writes a paragraph of style with content written by f.
+func (dw *docxWriter) paragraph(style string, f func()) {
+	dw.closePara()
+	dw.openPara(style)
+	f()
+	dw.closePara()
+}
+
+// run writes text s with run properties rpr, e.g. "<w:b/>".
+// Line breaks of s are preserved.
+func (dw *docxWriter) run(s, rpr string) {
+	dw.openPara("")
+	dw.body.WriteString("<w:r>")
+	if rpr != "" {
+		dw.body.WriteString("<w:rPr>" + rpr + "</w:rPr>")
+	}
+	for i, l := range strings.Split(s, "\n") {
+		if i > 0 {
+			dw.body.WriteString("<w:br/>")
+		}
+		if l == "" {
+			continue
+		}
+		dw.body.WriteString(`<w:t xml:space="preserve">`)
+		xml.EscapeText(&dw.body, []byte(l))
+		dw.body.WriteString("</w:t>")
+	}
+	dw.body.WriteString("</w:r>")
+}
+
+func (dw *docxWriter) write(nodes ...types.Node) {
+	for _, n := range nodes {
+		if !dw.matchEnv(n.Env()) {
+			continue
+		}
+		switch n := n.(type) {
+		case *types.TextNode:
+			dw.text(n)
+		case *types.ImageNode:
+			dw.image(n)
+		case *types.URLNode:
+			dw.url(n)
+		case *types.ButtonNode:
+			dw.write(n.Content.Nodes...)
+		case *types.CodeNode:
+			dw.code(n)
+		case *types.ListNode:
+			dw.list(n)
+		case *types.ImportNode:
+			dw.write(n.Content.Nodes...)
+		case *types.ItemsListNode:
+			dw.itemsList(n)
+		case *types.GridNode:
+			dw.table(n)
+		case *types.InfoboxNode:
+			dw.infobox(n)
+		case *types.SurveyNode:
+			dw.survey(n)
+		case *types.HeaderNode:
+			dw.header(n)
+		case *types.YouTubeNode:
+			dw.paragraph("Caption", func() {
+				dw.hyperlink("https://www.youtube.com/watch?v="+n.VideoID, "YouTube video")
+			})
+		case *types.IframeNode:
+			dw.paragraph("Caption", func() { dw.hyperlink(n.URL, n.URL) })
+		case *types.TermNode:
+			dw.run(n.Term, `<w:u w:val="dotted"/>`)
+			if n.Definition != "" {
+				dw.run(" ("+n.Definition+")", "<w:i/>")
+			}
+		}
+	}
+}
+
+func (dw *docxWriter) text(n *types.TextNode) {
+	var rpr string
+	if n.Bold {
+		rpr += "<w:b/>"
+	}
+	if n.Italic {
+		rpr += "<w:i/>"
+	}
+	if n.Code {
+		rpr = `<w:rStyle w:val="CodeChar"/>` + rpr
+	}
+	dw.run(n.Value, rpr)
+}
+
+func (dw *docxWriter) image(n *types.ImageNode) {
+	if n.Placeholder != "" {
+		dw.run("[Placeholder: "+n.Placeholder+"]", `<w:rStyle w:val="CaptionChar"/><w:highlight w:val="lightGray"/>`)
+		return
+	}
+	alt := n.Alt
+	if alt == "" {
+		alt = path.Base(n.Src)
+	}
+	dw.run("[Image: "+alt+"]", `<w:rStyle w:val="CaptionChar"/>`)
+}
+
+func (dw *docxWriter) url(n *types.URLNode) {
+	if n.URL == "" || strings.HasPrefix(n.URL, "#") {
+		dw.write(n.Content.Nodes...)
+		return
+	}
+	dw.hyperlink(n.URL, PlainText(n.Content.Nodes))
+}
+
+// hyperlink writes an external link to url with text.
+func (dw *docxWriter) hyperlink(url, text string) {
+	dw.openPara("")
+	dw.links = append(dw.links, url)
+	fmt.Fprintf(&dw.body, `<w:hyperlink r:id="rId%d">`, len(dw.links)+1)
+	dw.body.WriteString(`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t xml:space="preserve">`)
+	xml.EscapeText(&dw.body, []byte(text))
+	dw.body.WriteString("</w:t></w:r></w:hyperlink>")
+}
+
+func (dw *docxWriter) code(n *types.CodeNode) {
+	if n.Empty() {
+		return
+	}
+	style := "Code"
+	if n.Term {
+		style = "Console"
+	}
+	if n.Title != "" {
+		dw.paragraph("Caption", func() { dw.run(n.Title, "") })
+	}
+	dw.paragraph(style, func() { dw.run(strings.TrimRight(n.Value, "\n"), "") })
+}
+
+func (dw *docxWriter) list(n *types.ListNode) {
+	if n.Block() == true {
+		dw.closePara()
+	}
+	dw.write(n.Nodes...)
+	if n.Block() == true {
+		dw.closePara()
+	}
+}
+
+func (dw *docxWriter) itemsList(n *types.ItemsListNode) {
+	style := "ListBullet"
+	if n.ListType != "" || n.Start > 0 {
+		style = "ListNumber"
+	}
+	start := n.Start
+	if start == 0 {
+		start = 1
+	}
+	dw.lists++
+	defer func() { dw.lists-- }()
+	for i, item := range n.Items {
+		marker := "•\t"
+		if style == "ListNumber" {
+			marker = strconv.Itoa(i+start) + ".\t"
+		}
+		dw.closePara()
+		dw.openPara(style)
+		dw.run(marker, "")
+		for _, cn := range item.Nodes {
+			cn.MutateBlock(false)
+		}
+		dw.write(item.Nodes...)
+		dw.closePara()
+	}
+}
+
+// docxTextWidth is the width of the page text area in twips,
+// i.e. the page width less margins, see document.
+const docxTextWidth = 12240 - 2*1440
+
+func (dw *docxWriter) table(n *types.GridNode) {
+	dw.closePara()
+	dw.body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/></w:tblPr>`)
+	// the grid has a column for each cell of the widest row,
+	// which are spread evenly over the text area
+	var cols int
+	for _, row := range n.Rows {
+		var c int
+		for _, cell := range row {
+			if cell.Colspan > 1 {
+				c += cell.Colspan
+			} else {
+				c++
+			}
+		}
+		if c > cols {
+			cols = c
+		}
+	}
+	dw.body.WriteString("<w:tblGrid>")
+	for i := 0; i < cols; i++ {
+		fmt.Fprintf(&dw.body, `<w:gridCol w:w="%d"/>`, docxTextWidth/cols)
+	}
+	dw.body.WriteString("</w:tblGrid>")
+	for _, row := range n.Rows {
+		dw.body.WriteString("<w:tr>")
+		for _, cell := range row {
+			dw.body.WriteString("<w:tc>")
+			if cell.Colspan > 1 {
+				fmt.Fprintf(&dw.body, `<w:tcPr><w:gridSpan w:val="%d"/></w:tcPr>`, cell.Colspan)
+			}
+			// every cell needs at least one paragraph
+			dw.openPara("")
+			for _, cn := range cell.Content.Nodes {
+				cn.MutateBlock(false)
+				dw.write(cn)
+			}
+			dw.closePara()
+			dw.body.WriteString("</w:tc>")
+		}
+		dw.body.WriteString("</w:tr>")
+	}
+	dw.body.WriteString("</w:tbl>")
+}
+
+func (dw *docxWriter) infobox(n *types.InfoboxNode) {
+	dw.closePara()
+	dw.style = "InfoPositive"
+	if n.Kind == types.InfoboxNegative {
+		dw.style = "InfoNegative"
+	}
+	dw.write(n.Content.Nodes...)
+	dw.closePara()
+	dw.style = ""
+}
+
+func (dw *docxWriter) survey(n *types.SurveyNode) {
+	for _, g := range n.Picked() {
+		dw.paragraph("Question", func() { dw.run(g.Name, "") })
+		for _, o := range g.Options {
+			dw.paragraph("ListBullet", func() { dw.run("○\t"+o, "") })
+		}
+	}
+}
+
+func (dw *docxWriter) header(n *types.HeaderNode) {
+	level := n.Level
+	if level < 2 {
+		level = 2
+	}
+	if level > 6 {
+		level = 6
+	}
+	dw.closePara()
+	dw.openPara("Heading" + strconv.Itoa(level))
+	dw.write(n.Content.Nodes...)
+	dw.closePara()
+}
+
+func (dw *docxWriter) document() []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>`)
+	b.Write(dw.body.Bytes())
+	b.WriteString(`<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr>`)
+	b.WriteString("</w:body></w:document>")
+	return b.Bytes()
+}
+
+// rels returns relationships of the document part: styles and hyperlinks.
+func (dw *docxWriter) rels() []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	b.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	for i, l := range dw.links {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="`, i+2)
+		xml.EscapeText(&b, []byte(l))
+		b.WriteString(`" TargetMode="External"/>`)
+	}
+	b.WriteString("</Relationships>")
+	return b.Bytes()
+}
+
+// docxCore returns document properties of codelab m.
+func docxCore(m *types.Meta) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">`)
+	for _, p := range []struct{ tag, val string }{
+		{"dc:title", m.Title},
+		{"dc:identifier", m.ID},
+		{"dc:creator", m.Authors},
+		{"dc:description", m.Summary},
+	} {
+		if p.val == "" {
+			continue
+		}
+		b.WriteString("<" + p.tag + ">")
+		xml.EscapeText(&b, []byte(p.val))
+		b.WriteString("</" + p.tag + ">")
+	}
+	b.WriteString("</cp:coreProperties>")
+	return b.Bytes()
+}
+
+const docxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// docxStyles are paragraph and character styles of node types.
+const docxStyles = xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="120"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:rPr><w:sz w:val="52"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:rPr><w:i/><w:color w:val="5A5A5A"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="360"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="240"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading4"><w:name w:val="heading 4"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:outlineLvl w:val="3"/></w:pPr><w:rPr><w:b/><w:i/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading5"><w:name w:val="heading 5"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:outlineLvl w:val="4"/></w:pPr><w:rPr><w:b/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading6"><w:name w:val="heading 6"/><w:basedOn w:val="Normal"/><w:pPr><w:keepNext/><w:outlineLvl w:val="5"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F1F3F4"/><w:spacing w:after="0"/></w:pPr><w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/><w:sz w:val="20"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Console"><w:name w:val="Console"/><w:basedOn w:val="Code"/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="263238"/></w:pPr><w:rPr><w:color w:val="FFFFFF"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="InfoPositive"><w:name w:val="Info Positive"/><w:basedOn w:val="Normal"/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="E6F4EA"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="InfoNegative"><w:name w:val="Info Negative"/><w:basedOn w:val="Normal"/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="FEF7E0"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/><w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListNumber"><w:name w:val="List Number"/><w:basedOn w:val="Normal"/><w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Question"><w:name w:val="Question"/><w:basedOn w:val="Normal"/><w:rPr><w:b/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Caption"><w:name w:val="caption"/><w:basedOn w:val="Normal"/><w:rPr><w:i/><w:color w:val="5A5A5A"/><w:sz w:val="18"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="CodeChar"><w:name w:val="Code Char"/><w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New" w:cs="Courier New"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="CaptionChar"><w:name w:val="Caption Char"/><w:rPr><w:i/><w:color w:val="5A5A5A"/></w:rPr></w:style>` +
+	`<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="1A73E8"/><w:u w:val="single"/></w:rPr></w:style>` +
+	`<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders>` +
+	`<w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`</w:tblBorders></w:tblPr></w:style>` +
+	`</w:styles>`
@@ -0,0 +1,146 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestDocx(t *testing.T) {
+	p := types.NewListNode(
+		types.NewTextNode("See "),
+		types.NewURLNode("https://example.com/?a=1&b=2", types.NewTextNode("docs")),
+	)
+	p.MutateBlock(true)
+	ib := &types.InfoboxNode{Kind: types.InfoboxNegative, Content: types.NewListNode(types.NewTextNode("Careful <3"))}
+	cell := func(s string, colspan int) *types.GridCell {
+		return &types.GridCell{Colspan: colspan, Rowspan: 1, Content: types.NewListNode(types.NewTextNode(s))}
+	}
+	grid := types.NewGridNode(
+		[]*types.GridCell{cell("wide", 2)},
+		[]*types.GridCell{cell("a", 1), cell("b", 1)},
+	)
+	data := &Context{
+		Meta: &types.Meta{ID: "lab", Title: "Lab & Co"},
+		Steps: []*types.Step{{
+			Title:   "Intro",
+			Content: types.NewListNode(p, types.NewCodeNode("a\nb", false, "go"), ib, grid),
+		}},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "docx", data); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+		// every part must be well-formed
+		d := xml.NewDecoder(bytes.NewReader(b))
+		for {
+			if _, err := d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+		}
+	}
+
+	doc := files["word/document.xml"]
+	for _, want := range []string{
+		`<w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">Lab &amp; Co</w:t>`,
+		`w:name="s1_b0"/>`,
+		`w:name="s1_b1"/>`,
+		`<w:hyperlink r:id="rId2">`,
+		`<w:pStyle w:val="Code"/></w:pPr><w:bookmarkStart w:id="2" w:name="s1_b2"/><w:bookmarkEnd w:id="2"/><w:r><w:t xml:space="preserve">a</w:t><w:br/><w:t xml:space="preserve">b</w:t></w:r>`,
+		`<w:pStyle w:val="InfoNegative"/>`,
+		`Careful &lt;3`,
+		`<w:tblGrid><w:gridCol w:w="4680"/><w:gridCol w:w="4680"/></w:tblGrid>`,
+		`<w:tcPr><w:gridSpan w:val="2"/></w:tcPr>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document.xml missing %s:\n%s", want, doc)
+		}
+	}
+	if rels := files["word/_rels/document.xml.rels"]; !strings.Contains(rels, `Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com/?a=1&amp;b=2"`) {
+		t.Errorf("document.xml.rels: %s", rels)
+	}
+	if core := files["docProps/core.xml"]; !strings.Contains(core, "<dc:identifier>lab</dc:identifier>") {
+		t.Errorf("core.xml: %s", core)
+	}
+}
+
+func TestDocxEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "docx", data); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range zr.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := string(b)
+		if strings.Contains(doc, "Android") || strings.Contains(doc, "Gradle") {
+			t.Errorf("document.xml has a step of another environment:\n%s", doc)
+		}
+		// the bookmark of the step keeps its position in the source
+		if !strings.Contains(doc, `w:name="s2_b0"/>`) || !strings.Contains(doc, ">1. Web<") {
+			t.Errorf("document.xml has no step 1. Web bookmarked s2_b0:\n%s", doc)
+		}
+		return
+	}
+	t.Error("no word/document.xml")
+}
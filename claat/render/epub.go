@@ -0,0 +1,377 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("epub", epubRenderer{})
+}
+
+// epubRenderer renders a codelab as an EPUB 3 book for e-readers.
+//
+// Each step is a chapter, listed in the navigation document and, for older
+// readers, in an NCX table of contents. Chapters are written with the Lite
+// markup. Local images, relative to Context.Dir, are embedded in the book;
+// remote images are left as is and require a connected reader.
+type epubRenderer struct{}
+
+func (epubRenderer) Ext() string { return "epub" }
+
+func (epubRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	book := &epubBook{meta: m, modified: epubModified(ctx.Updated, m)}
+	book.add("title.xhtml", "application/xhtml+xml", epubTitlePage(m))
+	for i, st := range envSteps(ctx.Steps, ctx.Env) {
+		var body bytes.Buffer
+		fmt.Fprintf(&body, "<h1>%d. ", i+1)
+		xml.EscapeText(&body, []byte(st.Title))
+		body.WriteString("</h1>\n")
+		if err := WriteLite(&body, ctx.Env, st.Content.Nodes...); err != nil {
+			return err
+		}
+		b := epubStepLink.ReplaceAllFunc(body.Bytes(), func(l []byte) []byte {
+			n, _ := strconv.Atoi(string(epubStepLink.FindSubmatch(l)[1]))
+			return []byte(`href="` + epubChapter(n) + `"`)
+		})
+		f := book.add(epubChapter(i), "application/xhtml+xml", epubPage(m.Lang, st.Title, b))
+		f.step = st.Title
+		book.images(ctx, f, st.Content.Nodes)
+	}
+
+	zw := zip.NewWriter(w)
+	// the mimetype file must be first and stored uncompressed
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, "application/epub+zip"); err != nil {
+		return err
+	}
+	files := []struct {
+		name string
+		body []byte
+	}{
+		{"META-INF/container.xml", []byte(epubContainer)},
+		{"OEBPS/content.opf", book.opf()},
+		{"OEBPS/nav.xhtml", book.nav()},
+		{"OEBPS/toc.ncx", book.ncx()},
+		{"OEBPS/style.css", []byte(epubStyle)},
+	}
+	for _, f := range book.files {
+		files = append(files, struct {
+			name string
+			body []byte
+		}{"OEBPS/" + f.name, f.body})
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// epubStepLink matches links to codelab steps in chapter markup, e.g. href="#2",
+// along with the target attribute, which links get by default.
+var epubStepLink = regexp.MustCompile(`href="#(\d+)"( target="_blank")?`)
+
+// epubChapter returns the file name of 0-based step n.
+func epubChapter(n int) string {
+	return fmt.Sprintf("step-%d.xhtml", n+1)
+}
+
+// epubModified returns the book modification time from updated,
+// an RFC 3339 timestamp, in the format required by EPUB metadata.
+// If updated is empty, the time is the update date of codelab m,
+// or that of the SOURCE_DATE_EPOCH environment variable, in Unix seconds,
+// so that books of the same codelab are identical. It is the Unix epoch
+// if neither is set.
+func epubModified(updated string, m *types.Meta) string {
+	t, err := time.Parse(time.RFC3339, updated)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, m.LastUpdated)
+	}
+	if err != nil {
+		t, err = time.Parse("2006-01-02", m.LastUpdated)
+	}
+	if err != nil {
+		sec, _ := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64)
+		t = time.Unix(sec, 0)
+	}
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// epubFile is a publication resource of the book.
+type epubFile struct {
+	name   string // path relative to the package document
+	media  string // media type
+	body   []byte
+	step   string // step title of chapters
+	remote bool   // whether the chapter refers to remote resources
+}
+
+type epubBook struct {
+	meta     *types.Meta
+	modified string
+	files    []*epubFile
+	names    map[string]bool // names of added files
+}
+
+// add appends a resource to the book and returns it.
+func (b *epubBook) add(name, media string, body []byte) *epubFile {
+	if b.names == nil {
+		b.names = make(map[string]bool)
+	}
+	b.names[name] = true
+	f := &epubFile{name: name, media: media, body: body}
+	b.files = append(b.files, f)
+	return f
+}
+
+// epubImageTypes are media types of image file extensions supported by EPUB.
+var epubImageTypes = map[string]string{
+	".gif":  "image/gif",
+	".jpeg": "image/jpeg",
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// images embeds local images of nodes into the book, keeping their relative
+// paths, so that chapter markup need not change. Chapter ch is marked
+// as referring to remote resources if any images are remote.
+func (b *epubBook) images(ctx *Context, ch *epubFile, nodes []types.Node) {
+	types.Walk(nodes, func(n types.Node) bool {
+		img, ok := n.(*types.ImageNode)
+		if !ok || img.Placeholder != "" || img.Src == "" {
+			return true
+		}
+		if strings.Contains(img.Src, "://") {
+			ch.remote = true
+			return true
+		}
+		name, ok := localImagePath(img.Src)
+		media := epubImageTypes[strings.ToLower(path.Ext(name))]
+		if !ok || b.names[name] || media == "" {
+			return true
+		}
+		data, err := ioutil.ReadFile(filepath.Join(ctx.Dir, filepath.FromSlash(name)))
+		if err != nil {
+			return true
+		}
+		b.add(name, media, data)
+		return true
+	})
+}
+
+// localImagePath returns the cleaned path of image src relative to
+// the codelab output directory, or false if src is remote or out of it.
+func localImagePath(src string) (string, bool) {
+	if src == "" || strings.Contains(src, "://") || strings.HasPrefix(src, "data:") {
+		return "", false
+	}
+	name := path.Clean(src)
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
+
+// id returns a manifest item ID of the i-th file.
+func (f *epubFile) id(i int) string {
+	if f.step != "" {
+		return strings.TrimSuffix(f.name, ".xhtml")
+	}
+	return fmt.Sprintf("res%d", i)
+}
+
+// opf returns the package document, listing all resources of the book.
+func (b *epubBook) opf() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="bookid">`)
+	buf.WriteString(`<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">`)
+	buf.WriteString(`<dc:identifier id="bookid">`)
+	xml.EscapeText(&buf, []byte("urn:claat:"+b.meta.ID))
+	buf.WriteString("</dc:identifier>")
+	for _, p := range []struct{ tag, val string }{
+		{"dc:title", b.meta.Title},
+		{"dc:language", epubLang(b.meta.Lang)},
+		{"dc:creator", b.meta.Authors},
+		{"dc:description", b.meta.Summary},
+	} {
+		if p.val == "" {
+			continue
+		}
+		buf.WriteString("<" + p.tag + ">")
+		xml.EscapeText(&buf, []byte(p.val))
+		buf.WriteString("</" + p.tag + ">")
+	}
+	fmt.Fprintf(&buf, `<meta property="dcterms:modified">%s</meta>`, b.modified)
+	buf.WriteString("</metadata><manifest>")
+	buf.WriteString(`<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>`)
+	buf.WriteString(`<item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>`)
+	buf.WriteString(`<item id="style" href="style.css" media-type="text/css"/>`)
+	for i, f := range b.files {
+		fmt.Fprintf(&buf, `<item id="%s" href="`, f.id(i))
+		xml.EscapeText(&buf, []byte(f.name))
+		fmt.Fprintf(&buf, `" media-type="%s"`, f.media)
+		if f.remote {
+			buf.WriteString(` properties="remote-resources"`)
+		}
+		buf.WriteString("/>")
+	}
+	buf.WriteString(`</manifest><spine toc="ncx">`)
+	for i, f := range b.files {
+		if f.media == "application/xhtml+xml" {
+			fmt.Fprintf(&buf, `<itemref idref="%s"/>`, f.id(i))
+		}
+	}
+	buf.WriteString("</spine></package>")
+	return buf.Bytes()
+}
+
+// nav returns the navigation document, linking to all chapters.
+func (b *epubBook) nav() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<nav epub:type="toc" id="toc"><h1>Contents</h1><ol>`)
+	var i int
+	for _, f := range b.files {
+		if f.step == "" {
+			continue
+		}
+		i++
+		fmt.Fprintf(&buf, `<li><a href="%s">%d. `, f.name, i)
+		xml.EscapeText(&buf, []byte(f.step))
+		buf.WriteString("</a></li>")
+	}
+	buf.WriteString("</ol></nav>")
+	return epubPage(b.meta.Lang, "Contents", buf.Bytes())
+}
+
+// ncx returns the EPUB 2 table of contents, for readers without
+// support of the navigation document.
+func (b *epubBook) ncx() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1"><head>`)
+	buf.WriteString(`<meta name="dtb:uid" content="`)
+	xml.EscapeText(&buf, []byte("urn:claat:"+b.meta.ID))
+	buf.WriteString(`"/></head><docTitle><text>`)
+	xml.EscapeText(&buf, []byte(b.meta.Title))
+	buf.WriteString("</text></docTitle><navMap>")
+	var i int
+	for _, f := range b.files {
+		if f.step == "" {
+			continue
+		}
+		i++
+		fmt.Fprintf(&buf, `<navPoint id="nav%d" playOrder="%d"><navLabel><text>%d. `, i, i, i)
+		xml.EscapeText(&buf, []byte(f.step))
+		fmt.Fprintf(&buf, `</text></navLabel><content src="%s"/></navPoint>`, f.name)
+	}
+	buf.WriteString("</navMap></ncx>")
+	return buf.Bytes()
+}
+
+// epubTitlePage returns the first page of the book with codelab metadata.
+func epubTitlePage(m *types.Meta) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<h1 class="title">`)
+	xml.EscapeText(&buf, []byte(m.Title))
+	buf.WriteString("</h1>")
+	for _, p := range []struct{ class, val string }{
+		{"summary", m.Summary},
+		{"authors", m.Authors},
+	} {
+		if p.val == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, `<p class="%s">`, p.class)
+		xml.EscapeText(&buf, []byte(p.val))
+		buf.WriteString("</p>")
+	}
+	if m.Duration > 0 {
+		fmt.Fprintf(&buf, `<p class="duration">Duration: %d min</p>`, m.Duration)
+	}
+	return epubPage(m.Lang, m.Title, buf.Bytes())
+}
+
+// epubPage returns an XHTML content document of body markup.
+func epubPage(lang, title string, body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&buf, `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="%s" lang="%s">`,
+		epubLang(lang), epubLang(lang))
+	buf.WriteString("\n<head><meta charset=\"utf-8\"/><title>")
+	xml.EscapeText(&buf, []byte(title))
+	buf.WriteString("</title><link rel=\"stylesheet\" type=\"text/css\" href=\"style.css\"/></head>\n<body>\n")
+	buf.Write(body)
+	buf.WriteString("\n</body>\n</html>\n")
+	return buf.Bytes()
+}
+
+// epubLang returns the book language, defaulting to English.
+func epubLang(lang string) string {
+	if lang == "" {
+		return "en"
+	}
+	return lang
+}
+
+const epubContainer = xml.Header + `<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">` +
+	`<rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>` +
+	`</container>`
+
+// epubStyle styles the Lite markup of chapters.
+const epubStyle = `body { font-family: serif; line-height: 1.4; }
+h1.title { margin-top: 30%; }
+p.summary { font-style: italic; }
+p.authors, p.duration { color: #5a5a5a; }
+pre { font-family: monospace; font-size: 0.85em; white-space: pre-wrap; background: #f1f3f4; padding: 0.5em; }
+img { max-width: 100%; }
+table { border-collapse: collapse; }
+td { border: 1px solid #ccc; padding: 0.3em; }
+.step__note { padding: 0.5em; margin: 1em 0; }
+.note--special { background: #e6f4ea; }
+.note--warning { background: #fef7e0; }
+.placeholder-image { display: block; }
+`
@@ -0,0 +1,196 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestEPUB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-epub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "img"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "img", "a.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := types.NewListNode(
+		types.NewTextNode("Go to "),
+		types.NewURLNode("#1", types.NewTextNode("next")),
+		types.NewTextNode(" & read <docs>"),
+	)
+	p.MutateBlock(true)
+	data := &Context{
+		Dir:     dir,
+		Updated: "2019-05-01T10:00:00+02:00",
+		Meta:    &types.Meta{ID: "lab", Title: "Lab & Co", Authors: "Jo", Lang: "fr"},
+		Steps: []*types.Step{
+			{Title: "Intro", Content: types.NewListNode(p, types.NewImageNode("img/a.png"))},
+			{Title: "Code", Content: types.NewListNode(
+				types.NewCodeNode("a < b", false, "go"),
+				types.NewImageNode("https://example.com/b.png"),
+			)},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "epub", data); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := zr.File[0]; f.Name != "mimetype" || f.Method != zip.Store {
+		t.Errorf("first file is %q, method %d; want stored mimetype", f.Name, f.Method)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+		if !strings.HasSuffix(f.Name, ".xhtml") && !strings.HasSuffix(f.Name, ".opf") && !strings.HasSuffix(f.Name, ".ncx") {
+			continue
+		}
+		// markup must be well-formed XML
+		d := xml.NewDecoder(bytes.NewReader(b))
+		for {
+			if _, err := d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+		}
+	}
+
+	if v := files["mimetype"]; v != "application/epub+zip" {
+		t.Errorf("mimetype = %q", v)
+	}
+	if v := files["OEBPS/img/a.png"]; v != "png" {
+		t.Errorf("embedded image = %q; want png", v)
+	}
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"META-INF/container.xml", []string{`full-path="OEBPS/content.opf"`}},
+		{"OEBPS/content.opf", []string{
+			`<dc:identifier id="bookid">urn:claat:lab</dc:identifier>`,
+			`<dc:title>Lab &amp; Co</dc:title>`,
+			`<dc:language>fr</dc:language>`,
+			`<meta property="dcterms:modified">2019-05-01T08:00:00Z</meta>`,
+			`<item id="res2" href="img/a.png" media-type="image/png"/>`,
+			`<item id="step-2" href="step-2.xhtml" media-type="application/xhtml+xml" properties="remote-resources"/>`,
+			`<itemref idref="res0"/><itemref idref="step-1"/><itemref idref="step-2"/></spine>`,
+		}},
+		{"OEBPS/nav.xhtml", []string{`<li><a href="step-1.xhtml">1. Intro</a></li><li><a href="step-2.xhtml">2. Code</a></li>`}},
+		{"OEBPS/toc.ncx", []string{`<content src="step-2.xhtml"/>`}},
+		{"OEBPS/title.xhtml", []string{`<h1 class="title">Lab &amp; Co</h1><p class="authors">Jo</p>`}},
+		{"OEBPS/step-1.xhtml", []string{
+			`<h1>1. Intro</h1>`,
+			`<a href="step-2.xhtml">next</a> &amp; read &lt;docs&gt;`,
+			`<img src="img/a.png"/>`,
+		}},
+		{"OEBPS/step-2.xhtml", []string{`a &lt; b`, `xml:lang="fr"`}},
+	}
+	for _, test := range tests {
+		for _, want := range test.want {
+			if !strings.Contains(files[test.file], want) {
+				t.Errorf("%s does not contain %q:\n%s", test.file, want, files[test.file])
+			}
+		}
+	}
+}
+
+func TestEPUBModified(t *testing.T) {
+	defer os.Setenv("SOURCE_DATE_EPOCH", os.Getenv("SOURCE_DATE_EPOCH"))
+	tests := []struct {
+		updated, lastUpdated, epoch string
+		want                        string
+	}{
+		{"2019-05-01T10:00:00+02:00", "2020-01-01", "1600000000", "2019-05-01T08:00:00Z"},
+		{"", "2020-01-02", "1600000000", "2020-01-02T00:00:00Z"},
+		{"", "2020-01-02T03:04:05Z", "", "2020-01-02T03:04:05Z"},
+		{"", "", "1600000000", "2020-09-13T12:26:40Z"},
+		{"", "", "", "1970-01-01T00:00:00Z"},
+	}
+	for i, test := range tests {
+		os.Setenv("SOURCE_DATE_EPOCH", test.epoch)
+		if v := epubModified(test.updated, &types.Meta{LastUpdated: test.lastUpdated}); v != test.want {
+			t.Errorf("%d: epubModified = %q; want %q", i, v, test.want)
+		}
+	}
+}
+
+func TestEPUBEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{ID: "lab", Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "epub", data); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chapters int
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), "Android") || strings.Contains(string(b), "Gradle") {
+			t.Errorf("%s has a step of another environment:\n%s", f.Name, b)
+		}
+		if strings.Contains(string(b), "<h1>1. Web</h1>") {
+			chapters++
+		}
+	}
+	if chapters != 1 {
+		t.Errorf("%d chapters of step 1. Web; want 1", chapters)
+	}
+}
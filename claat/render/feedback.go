@@ -0,0 +1,156 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	htmlTemplate "html/template"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// feedbackMailto reports whether the codelab feedback link is an email address.
+func feedbackMailto(meta *types.Meta) bool {
+	return strings.HasPrefix(strings.ToLower(meta.Feedback), "mailto:")
+}
+
+// feedbackURL returns the codelab feedback link with codelab_id and
+// codelab_title query parameters, unless already present.
+// Email addresses are never exposed in the link: the returned value is empty
+// so that the address is only rendered obfuscated by feedbackCard.
+func feedbackURL(meta *types.Meta) string {
+	if meta.Feedback == "" || feedbackMailto(meta) {
+		return ""
+	}
+	u, err := url.Parse(meta.Feedback)
+	if err != nil || !u.IsAbs() {
+		return meta.Feedback
+	}
+	q := u.Query()
+	for k, v := range map[string]string{"codelab_id": meta.ID, "codelab_title": meta.Title} {
+		if q.Get(k) == "" && v != "" {
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// feedbackCard renders a contact card of the codelab step n titled title,
+// if the codelab feedback link is an email address.
+// To reduce scraping, the address is split and reversed, and assembled back
+// into a mailto link by feedbackScript only when the card is clicked.
+// The email subject is pre-filled with the codelab ID and step.
+// The card is in locale lang, if any, English otherwise.
+func feedbackCard(meta *types.Meta, n int, title string, lang ...string) htmlTemplate.HTML {
+	if !feedbackMailto(meta) {
+		return ""
+	}
+	addr := meta.Feedback[len("mailto:"):]
+	if i := strings.Index(addr, "?"); i >= 0 {
+		addr = addr[:i]
+	}
+	i := strings.LastIndex(addr, "@")
+	if i < 0 {
+		return ""
+	}
+	var l string
+	if len(lang) > 0 {
+		l = lang[0]
+	}
+	subj := fmt.Sprintf("[%s] %s %d: %s", meta.ID, Msg(l, MsgStep), n, title)
+	return htmlTemplate.HTML(fmt.Sprintf(`<aside class="feedback-card">`+
+		`%s <a class="feedback-contact" href="#" data-u="%s" data-d="%s" data-s="%s">%s</a>`+
+		`</aside>`,
+		htmlTemplate.HTMLEscapeString(Msg(l, MsgFeedbackQuestion)),
+		htmlTemplate.HTMLEscapeString(reverse(addr[:i])),
+		htmlTemplate.HTMLEscapeString(reverse(addr[i+1:])),
+		htmlTemplate.HTMLEscapeString(subj),
+		htmlTemplate.HTMLEscapeString(Msg(l, MsgFeedbackContact))))
+}
+
+// feedbackScript returns a script assembling mailto links of contact cards
+// on click, if the codelab feedback link is an email address.
+func feedbackScript(meta *types.Meta) htmlTemplate.HTML {
+	if !feedbackMailto(meta) {
+		return ""
+	}
+	return feedbackJS
+}
+
+const feedbackJS = htmlTemplate.HTML(`<script>
+document.addEventListener('click', function(e) {
+  var a = e.target.closest && e.target.closest('a.feedback-contact');
+  if (!a) return;
+  e.preventDefault();
+  var rev = function(s) { return s.split('').reverse().join(''); };
+  window.location.href = 'mailto:' + rev(a.dataset.u) + '@' + rev(a.dataset.d) +
+      '?subject=' + encodeURIComponent(a.dataset.s);
+});
+</script>`)
+
+// ValidateFeedbackWidget returns an error if backend is not a backend
+// of the feedback widget: an http or https endpoint which votes and
+// comments are posted to as JSON, or "github:owner/repo" to open a
+// pre-filled issue of the GitHub repository instead.
+func ValidateFeedbackWidget(backend string) error {
+	if repo := strings.TrimPrefix(backend, "github:"); repo != backend {
+		if !githubRepoRegexp.MatchString(repo) {
+			return fmt.Errorf("feedback widget %q: want github:owner/repo", backend)
+		}
+		return nil
+	}
+	u, err := url.Parse(backend)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("feedback widget %q: want an http(s) URL or github:owner/repo", backend)
+	}
+	return nil
+}
+
+var githubRepoRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+// feedbackWidget renders the thumbs up and down and comment form of
+// step n titled title, in locale lang, if the codelab has a feedback
+// widget backend.
+func feedbackWidget(backend string, n int, title, lang string) htmlTemplate.HTML {
+	if backend == "" {
+		return ""
+	}
+	esc := htmlTemplate.HTMLEscapeString
+	return htmlTemplate.HTML(fmt.Sprintf(`<aside class="feedback-widget" data-step="%d" data-title="%s">`+
+		`<span class="feedback-widget-question">%s</span>`+
+		`<button class="feedback-vote" type="button" data-vote="up" title="%[4]s" aria-label="%[4]s" aria-pressed="false"><i class="material-icons">thumb_up</i></button>`+
+		`<button class="feedback-vote" type="button" data-vote="down" title="%[5]s" aria-label="%[5]s" aria-pressed="false"><i class="material-icons">thumb_down</i></button>`+
+		`<form class="feedback-widget-form" hidden>`+
+		`<textarea name="comment" rows="3" placeholder="%[6]s" aria-label="%[6]s"></textarea>`+
+		`<button type="submit">%s</button></form>`+
+		`<span class="feedback-widget-thanks" role="status" hidden>%s</span>`+
+		`</aside>`,
+		n, esc(title), esc(Msg(lang, MsgFeedbackHelpful)),
+		esc(Msg(lang, MsgFeedbackYes)), esc(Msg(lang, MsgFeedbackNo)), esc(Msg(lang, MsgFeedbackComment)),
+		esc(Msg(lang, MsgFeedbackSend)), esc(Msg(lang, MsgFeedbackThanks))))
+}
+
+// reverse returns s with its runes in reverse order.
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestFeedbackURL(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"", ""},
+		{"mailto:team@example.com", ""},
+		{"https://example.com/issues", "https://example.com/issues?codelab_id=my-codelab&codelab_title=My+Codelab"},
+		{"https://example.com/new?codelab_id=x", "https://example.com/new?codelab_id=x&codelab_title=My+Codelab"},
+	}
+	for i, test := range tests {
+		meta := &types.Meta{ID: "my-codelab", Title: "My Codelab", Feedback: test.in}
+		if out := feedbackURL(meta); out != test.out {
+			t.Errorf("%d: feedbackURL(%q) = %q; want %q", i, test.in, out, test.out)
+		}
+	}
+}
+
+func TestFeedbackCard(t *testing.T) {
+	meta := &types.Meta{ID: "my-codelab", Feedback: "mailto:team@example.com?subject=hi"}
+	card := string(feedbackCard(meta, 2, "Setup"))
+	if strings.Contains(card, "team@example.com") {
+		t.Errorf("card exposes the email address: %s", card)
+	}
+	for _, want := range []string{`data-u="maet"`, `data-d="moc.elpmaxe"`, `data-s="[my-codelab] Step 2: Setup"`} {
+		if !strings.Contains(card, want) {
+			t.Errorf("card does not contain %s: %s", want, card)
+		}
+	}
+	card = string(feedbackCard(meta, 2, "Setup", "fr"))
+	for _, want := range []string{`Un problème dans cette étape ?`, `data-s="[my-codelab] Étape 2: Setup"`, `>Contacter les auteurs</a>`} {
+		if !strings.Contains(card, want) {
+			t.Errorf("fr card does not contain %s: %s", want, card)
+		}
+	}
+	meta.Feedback = "https://example.com"
+	if card := feedbackCard(meta, 1, "Intro"); card != "" {
+		t.Errorf("feedbackCard for a URL = %q; want empty", card)
+	}
+}
+
+func TestValidateFeedbackWidget(t *testing.T) {
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"https://example.com/feedback", true},
+		{"http://localhost:8080/votes", true},
+		{"github:googlecodelabs/tools", true},
+		{"github:googlecodelabs", false},
+		{"github:a/b/c", false},
+		{"/feedback", false},
+		{"javascript:alert(1)", false},
+	}
+	for _, test := range tests {
+		if err := ValidateFeedbackWidget(test.in); (err == nil) != test.ok {
+			t.Errorf("ValidateFeedbackWidget(%q) = %v; want ok %v", test.in, err, test.ok)
+		}
+	}
+}
+
+func TestFeedbackWidget(t *testing.T) {
+	if w := feedbackWidget("", 1, "Setup", "fr"); w != "" {
+		t.Errorf("feedbackWidget with no backend = %q; want empty", w)
+	}
+	w := string(feedbackWidget("github:owner/repo", 2, "Set <up>", "fr"))
+	for _, want := range []string{
+		`<aside class="feedback-widget" data-step="2" data-title="Set &lt;up&gt;">`,
+		`<span class="feedback-widget-question">Cette étape vous a-t-elle été utile ?</span>`,
+		`data-vote="up" title="Oui" aria-label="Oui"`,
+		`data-vote="down" title="Non" aria-label="Non"`,
+		`placeholder="Dites-nous en plus (facultatif)"`,
+		`<button type="submit">Envoyer</button>`,
+		`hidden>Merci pour vos commentaires !</span>`,
+	} {
+		if !strings.Contains(w, want) {
+			t.Errorf("feedbackWidget does not contain %q:\n%s", want, w)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	extMu    sync.Mutex // guards extFuncs and partials
+	extFuncs = map[string]interface{}{}
+	partials = map[string]string{}
+)
+
+// RegisterFuncs adds functions fm to those available in templates
+// executed with Execute, built-in and local file templates alike,
+// e.g. for asset fingerprinting or translation lookups.
+// Functions passed to Execute with WithFuncMap take precedence.
+//
+// Since templates refer to functions when parsed, RegisterFuncs is meant
+// to be called before any export, typically from an init function.
+// It panics if a function of the same name is built in or already registered.
+func RegisterFuncs(fm map[string]interface{}) {
+	extMu.Lock()
+	defer extMu.Unlock()
+	for name := range fm {
+		if _, exists := funcMap[name]; exists {
+			panic(fmt.Sprintf("template func %q already registered", name))
+		}
+		if _, exists := extFuncs[name]; exists {
+			panic(fmt.Sprintf("template func %q already registered", name))
+		}
+	}
+	for name, fn := range fm {
+		extFuncs[name] = fn
+	}
+}
+
+// RegisterPartial registers a named template, which templates executed
+// with Execute can include with {{template "name" .}}.
+// A partial named after a {{block "name" .}} of a template replaces
+// the block's default content.
+//
+// Partials are parsed along with each template, with the same functions,
+// so that errors are reported by Execute.
+// It panics if name is empty or a partial of the same name is already registered.
+func RegisterPartial(name, text string) {
+	extMu.Lock()
+	defer extMu.Unlock()
+	if _, exists := partials[name]; exists || name == "" {
+		panic(fmt.Sprintf("template partial %q already registered", name))
+	}
+	partials[name] = text
+}
+
+// templateFuncs returns built-in and registered template functions,
+// overridden by fmap.
+func templateFuncs(fmap map[string]interface{}) map[string]interface{} {
+	extMu.Lock()
+	defer extMu.Unlock()
+	funcs := make(map[string]interface{}, len(funcMap)+len(extFuncs)+len(fmap))
+	for k, v := range funcMap {
+		funcs[k] = v
+	}
+	for k, v := range extFuncs {
+		funcs[k] = v
+	}
+	for k, v := range fmap {
+		funcs[k] = v
+	}
+	return funcs
+}
+
+// builtinFuncs returns built-in template functions, overridden by fmap.
+func builtinFuncs(fmap map[string]interface{}) map[string]interface{} {
+	funcs := make(map[string]interface{}, len(funcMap)+len(fmap))
+	for k, v := range funcMap {
+		funcs[k] = v
+	}
+	for k, v := range fmap {
+		funcs[k] = v
+	}
+	return funcs
+}
+
+// templatePartials returns a copy of registered partials.
+func templatePartials() map[string]string {
+	extMu.Lock()
+	defer extMu.Unlock()
+	p := make(map[string]string, len(partials))
+	for k, v := range partials {
+		p[k] = v
+	}
+	return p
+}
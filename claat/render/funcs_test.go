@@ -0,0 +1,89 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestRegisterFuncs(t *testing.T) {
+	RegisterFuncs(map[string]interface{}{
+		"testAsset": func(s string) string { return s + "?v=1" },
+	})
+	RegisterPartial("test-footer", `<footer>{{testAsset "app.js"}} {{.Meta.Title}}</footer>`)
+
+	dir, err := ioutil.TempDir("", "TestRegisterFuncs-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tmpl := filepath.Join(dir, "page.html")
+	text := `<h1>{{.Meta.Title}}</h1>{{template "test-footer" .}}`
+	if err := ioutil.WriteFile(tmpl, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data := &struct {
+		Context
+	}{Context: Context{Meta: &types.Meta{Title: "A & B"}}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, tmpl, data); err != nil {
+		t.Fatal(err)
+	}
+	want := "<h1>A &amp; B</h1><footer>app.js?v=1 A &amp; B</footer>"
+	if buf.String() != want {
+		t.Errorf("Execute = %q; want %q", buf.String(), want)
+	}
+
+	// Built-in templates are parsed with registered funcs and partials too.
+	buf.Reset()
+	data.Steps = []*types.Step{{Title: "One", Content: types.NewListNode()}}
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "A &amp; B") {
+		t.Errorf("Execute(html) = %q; want title", buf.String())
+	}
+
+	for _, fm := range []map[string]interface{}{
+		{"testAsset": strings.ToUpper},
+		{"renderHTML": strings.ToUpper},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterFuncs(%v) did not panic", fm)
+				}
+			}()
+			RegisterFuncs(fm)
+		}()
+	}
+	for _, name := range []string{"test-footer", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterPartial(%q) did not panic", name)
+				}
+			}()
+			RegisterPartial(name, "")
+		}()
+	}
+}
@@ -13,6 +13,7 @@
 // limitations under the License.
 
 // This program generates tmpldata.go
+//go:build ignore
 // +build ignore
 
 package main
@@ -32,10 +33,11 @@ var files = map[string]struct {
 	file string
 	html bool
 }{
-	"html":    {"template.html", true},
-	"devsite": {"template-devsite.html", true},
-	"md":      {"template.md", false},
-	"offline": {"template-offline.html", true},
+	"html":     {"template.html", true},
+	"devsite":  {"template-devsite.html", true},
+	"md":       {"template.md", false},
+	"offline":  {"template-offline.html", true},
+	"obsidian": {"template-obsidian.md", false},
 }
 
 func main() {
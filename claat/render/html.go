@@ -35,16 +35,19 @@ var (
 	newLine     = []byte{'\n'}
 )
 
-// HTML renders nodes as the markup for the target env.
+// HTML renders nodes as the markup for the target env,
+// with labels in the locale of ctx.
 func HTML(ctx Context, nodes ...types.Node) (htmlTemplate.HTML, error) {
 	var buf bytes.Buffer
-	if err := WriteHTML(&buf, ctx.Env, ctx.Format, nodes...); err != nil {
+	hw := htmlWriter{w: &buf, env: ctx.Env, format: ctx.Format, lang: ctx.Locale}
+	if err := hw.write(nodes...); err != nil {
 		return "", err
 	}
 	return htmlTemplate.HTML(buf.String()), nil
 }
 
-// WriteHTML does the same as HTML but outputs rendered markup to w.
+// WriteHTML does the same as HTML but outputs rendered markup to w,
+// with labels in English.
 func WriteHTML(w io.Writer, env string, fmt string, nodes ...types.Node) error {
 	hw := htmlWriter{w: w, env: env, format: fmt}
 	return hw.write(nodes...)
@@ -67,6 +70,7 @@ type htmlWriter struct {
 	w      io.Writer // output writer
 	env    string    // target environment
 	format string    // target template
+	lang   string    // locale of labels, see Msg
 	err    error     // error during any writeXxx methods
 }
 
@@ -125,6 +129,8 @@ func (hw *htmlWriter) write(nodes ...types.Node) error {
 		case *types.IframeNode:
 			hw.iframe(n)
 			hw.writeBytes(newLine)
+		case *types.TermNode:
+			hw.term(n)
 		}
 		if hw.err != nil {
 			return hw.err
@@ -179,6 +185,42 @@ func (hw *htmlWriter) text(n *types.TextNode) {
 }
 
 func (hw *htmlWriter) image(n *types.ImageNode) {
+	if n.Placeholder != "" {
+		hw.writeString(`<span class="placeholder-image" role="img" style="`)
+		hw.writeString(placeholderStyle)
+		if n.Width > 0 {
+			hw.writeFmt("width: %.2fpx;", n.Width)
+		}
+		hw.writeString(`">Placeholder: `)
+		hw.writeEscape(n.Placeholder)
+		hw.writeString("</span>")
+		return
+	}
+	// variants in other formats are picture sources,
+	// and sizes of the image format its srcset
+	var typ string
+	if v := srcVariant(n); v != nil {
+		typ = v.Type
+	}
+	var formats []string
+	var sizes int
+	seen := map[string]bool{typ: true}
+	for _, v := range n.Variants {
+		if v.Type == typ {
+			sizes++
+		} else if !seen[v.Type] {
+			seen[v.Type] = true
+			formats = append(formats, v.Type)
+		}
+	}
+	if len(formats) > 0 {
+		hw.writeString("<picture>")
+		for _, t := range formats {
+			hw.writeFmt(`<source type=%q`, t)
+			hw.srcset(n, t)
+			hw.writeBytes(greaterThan)
+		}
+	}
 	hw.writeString("<img")
 	if n.Alt != "" {
 		hw.writeFmt(" alt=%q", n.Alt)
@@ -192,9 +234,54 @@ func (hw *htmlWriter) image(n *types.ImageNode) {
 	hw.writeString(` src="`)
 	hw.writeString(n.Src)
 	hw.writeBytes(doubleQuote)
+	if sizes > 1 {
+		hw.srcset(n, typ)
+	}
 	hw.writeBytes(greaterThan)
+	if len(formats) > 0 {
+		hw.writeString("</picture>")
+	}
+}
+
+// srcset writes srcset and sizes attributes of the variants of image n
+// of MIME type typ. Several sizes are described by their width,
+// displayed at most as wide as the image itself.
+func (hw *htmlWriter) srcset(n *types.ImageNode, typ string) {
+	var vs []*types.ImageVariant
+	for _, v := range n.Variants {
+		if v.Type == typ {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 1 {
+		hw.writeFmt(` srcset="%s"`, vs[0].Src)
+		return
+	}
+	var set []string
+	for _, v := range vs {
+		set = append(set, fmt.Sprintf("%s %dw", v.Src, v.Width))
+	}
+	hw.writeFmt(` srcset="%s"`, strings.Join(set, ", "))
+	if v := srcVariant(n); v != nil && v.Width > 0 {
+		hw.writeFmt(` sizes="(max-width: %dpx) 100vw, %dpx"`, v.Width, v.Width)
+	}
+}
+
+// srcVariant returns the variant of image n which is its Src, if any.
+func srcVariant(n *types.ImageNode) *types.ImageVariant {
+	for _, v := range n.Variants {
+		if v.Src == n.Src {
+			return v
+		}
+	}
+	return nil
 }
 
+// placeholderStyle is the inline style of placeholder images, a grey box
+// which stands out in previews.
+const placeholderStyle = "display: inline-block; box-sizing: border-box; min-width: 240px; padding: 48px 16px; " +
+	"background: #e0e0e0; border: 2px dashed #9e9e9e; color: #616161; text-align: center;"
+
 func (hw *htmlWriter) url(n *types.URLNode) {
 	hw.writeString("<a")
 	if n.URL != "" {
@@ -212,6 +299,11 @@ func (hw *htmlWriter) url(n *types.URLNode) {
 		hw.writeEscape(n.Target)
 		hw.writeBytes(doubleQuote)
 	}
+	if n.Rel != "" {
+		hw.writeString(` rel="`)
+		hw.writeEscape(n.Rel)
+		hw.writeBytes(doubleQuote)
+	}
 	hw.writeBytes(greaterThan)
 	hw.write(n.Content.Nodes...)
 	hw.writeString("</a>")
@@ -234,6 +326,14 @@ func (hw *htmlWriter) button(n *types.ButtonNode) {
 }
 
 func (hw *htmlWriter) code(n *types.CodeNode) {
+	if n.Title != "" {
+		hw.writeString(`<div class="code-header"><span class="code-filename">`)
+		hw.writeEscape(n.Title)
+		label := htmlTemplate.HTMLEscapeString(Msg(hw.lang, MsgCopy))
+		hw.writeFmt(`</span><button class="code-copy" type="button" title="%s" `, label)
+		hw.writeString(`onclick="navigator.clipboard.writeText(this.parentNode.nextElementSibling.textContent)">`)
+		hw.writeString(label + `</button></div>`)
+	}
 	hw.writeString("<pre>")
 	if !n.Term {
 		hw.writeString("<code")
@@ -245,7 +345,11 @@ func (hw *htmlWriter) code(n *types.CodeNode) {
 	if hw.format == "devsite" {
 		hw.writeString("{% verbatim %}")
 	}
-	hw.writeEscape(n.Value)
+	if len(n.Added) > 0 || len(n.Removed) > 0 {
+		hw.diffLines(n)
+	} else {
+		hw.writeEscape(n.Value)
+	}
 	if hw.format == "devsite" {
 		hw.writeString("{% endverbatim %}")
 	}
@@ -255,6 +359,35 @@ func (hw *htmlWriter) code(n *types.CodeNode) {
 	hw.writeString("</pre>")
 }
 
+// diffLines writes code n value, wrapping added and removed lines
+// in spans with code-added and code-removed classes respectively.
+func (hw *htmlWriter) diffLines(n *types.CodeNode) {
+	kind := make(map[int]string)
+	for _, l := range n.Added {
+		kind[l] = "code-added"
+	}
+	for _, l := range n.Removed {
+		kind[l] = "code-removed"
+	}
+	v := n.Value
+	if strings.HasPrefix(v, "\n") {
+		hw.writeBytes(newLine)
+		v = v[1:]
+	}
+	for i, l := range strings.Split(v, "\n") {
+		if i > 0 {
+			hw.writeBytes(newLine)
+		}
+		if k, ok := kind[i+1]; ok {
+			hw.writeFmt(`<span class="%s">`, k)
+			hw.writeEscape(l)
+			hw.writeString("</span>")
+		} else {
+			hw.writeEscape(l)
+		}
+	}
+}
+
 func (hw *htmlWriter) list(n *types.ListNode) {
 	wrap := n.Block() == true
 	if wrap {
@@ -350,6 +483,9 @@ func (hw *htmlWriter) survey(n *types.SurveyNode) {
 	hw.writeString(`<google-codelab-survey survey-id="`)
 	hw.writeString(n.ID)
 	hw.writeBytes(doubleQuote)
+	if n.Pick > 0 {
+		hw.writeString(fmt.Sprintf(` pick="%d"`, n.Pick))
+	}
 	hw.writeString(">\n")
 	for _, g := range n.Groups {
 		hw.writeString("<h4>")
@@ -388,10 +524,38 @@ func (hw *htmlWriter) youtube(n *types.YouTubeNode) {
 	hw.writeFmt(`<iframe class="youtube-video" `+
 		`src="https://www.youtube.com/embed/%s?rel=0" allow="accelerometer; `+
 		`autoplay; encrypted-media; gyroscope; picture-in-picture" `+
-		`allowfullscreen></iframe>`, n.VideoID)
+		`allowfullscreen title="YouTube video"></iframe>`, n.VideoID)
 }
 
 func (hw *htmlWriter) iframe(n *types.IframeNode) {
-	hw.writeFmt(`<iframe class="embedded-iframe" src="%s"></iframe>`,
-		n.URL)
+	hw.writeFmt(`<iframe class="embedded-iframe" src="%s"`, n.URL)
+	if n.Width != "" {
+		hw.writeString(` width="`)
+		hw.writeEscape(n.Width)
+		hw.writeString(`"`)
+	}
+	if n.Height != "" {
+		hw.writeString(` height="`)
+		hw.writeEscape(n.Height)
+		hw.writeString(`"`)
+	}
+	if n.Sandboxed || n.Sandbox != "" {
+		hw.writeString(` sandbox="`)
+		hw.writeEscape(n.Sandbox)
+		hw.writeString(`"`)
+	}
+	if n.Title != "" {
+		hw.writeString(` title="`)
+		hw.writeEscape(n.Title)
+		hw.writeString(`"`)
+	}
+	hw.writeString("></iframe>")
+}
+
+func (hw *htmlWriter) term(n *types.TermNode) {
+	hw.writeString(`<span class="glossary-term" title="`)
+	hw.writeEscape(n.Definition)
+	hw.writeString(`" style="text-decoration: underline dotted; cursor: help">`)
+	hw.writeEscape(n.Term)
+	hw.writeString("</span>")
 }
@@ -51,3 +51,77 @@ func TestHTMLEnv(t *testing.T) {
 		}
 	}
 }
+
+func TestHTMLImageVariants(t *testing.T) {
+	tests := []struct {
+		src      string
+		variants []*types.ImageVariant
+		output   string
+	}{
+		{"img/a.png", nil, `<img src="img/a.png">`},
+		{
+			"img/a.png",
+			[]*types.ImageVariant{
+				{Src: "img/a.webp", Type: "image/webp", Width: 400},
+				{Src: "img/a.png", Type: "image/png", Width: 400},
+			},
+			`<picture><source type="image/webp" srcset="img/a.webp"><img src="img/a.png"></picture>`,
+		},
+		{
+			"img/a@1x.png",
+			[]*types.ImageVariant{
+				{Src: "img/a@1x.png", Type: "image/png", Width: 800},
+				{Src: "img/a@2x.png", Type: "image/png", Width: 1600},
+			},
+			`<img src="img/a@1x.png" srcset="img/a@1x.png 800w, img/a@2x.png 1600w" sizes="(max-width: 800px) 100vw, 800px">`,
+		},
+		{
+			"img/a@1x.jpeg",
+			[]*types.ImageVariant{
+				{Src: "img/a@1x.avif", Type: "image/avif", Width: 800},
+				{Src: "img/a@2x.avif", Type: "image/avif", Width: 1000},
+				{Src: "img/a@1x.jpeg", Type: "image/jpeg", Width: 800},
+				{Src: "img/a@2x.jpeg", Type: "image/jpeg", Width: 1000},
+			},
+			`<picture><source type="image/avif" srcset="img/a@1x.avif 800w, img/a@2x.avif 1000w" sizes="(max-width: 800px) 100vw, 800px">` +
+				`<img src="img/a@1x.jpeg" srcset="img/a@1x.jpeg 800w, img/a@2x.jpeg 1000w" sizes="(max-width: 800px) 100vw, 800px"></picture>`,
+		},
+	}
+	for i, test := range tests {
+		n := types.NewImageNode(test.src)
+		n.Variants = test.variants
+		h, err := HTML(Context{}, n)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if v := string(h); v != test.output {
+			t.Errorf("%d: v = %q; want %q", i, v, test.output)
+		}
+	}
+}
+
+func TestHTMLIframeSandbox(t *testing.T) {
+	tests := []struct {
+		sandbox   string
+		sandboxed bool
+		output    string
+	}{
+		{"", false, "<iframe class=\"embedded-iframe\" src=\"https://example.com/\"></iframe>\n"},
+		{"", true, "<iframe class=\"embedded-iframe\" src=\"https://example.com/\" sandbox=\"\"></iframe>\n"},
+		{"allow-scripts", true, "<iframe class=\"embedded-iframe\" src=\"https://example.com/\" sandbox=\"allow-scripts\"></iframe>\n"},
+	}
+	for i, test := range tests {
+		n := types.NewIframeNode("https://example.com/")
+		n.Sandbox = test.sandbox
+		n.Sandboxed = test.sandboxed
+		h, err := HTML(Context{}, n)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if v := string(h); v != test.output {
+			t.Errorf("%d: v = %q; want %q", i, v, test.output)
+		}
+	}
+}
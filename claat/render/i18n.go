@@ -0,0 +1,572 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Messages are localized strings of the viewer chrome of the html format,
+// such as button labels, keyed by message ID.
+// Messages of a locale missing a string fall back to English.
+type Messages map[string]string
+
+// Message IDs of the viewer chrome.
+const (
+	MsgBack             = "back"              // previous step button
+	MsgNext             = "next"              // next step button
+	MsgDone             = "done"              // last step button
+	MsgMinutesRemaining = "minutes-remaining" // codelab time left; {n} is the number of minutes
+	MsgCopy             = "copy"              // code block copy button
+	MsgLanguage         = "language"          // locale variants menu
+	MsgToggleDarkMode   = "toggle-dark-mode"  // color scheme toggle
+	MsgFeedbackQuestion = "feedback-question" // feedback card text
+	MsgFeedbackContact  = "feedback-contact"  // feedback card link
+	MsgStep             = "step"              // step number in feedback email subjects
+	MsgSkipToContent    = "skip-to-content"   // skip link to the current step
+	MsgPrerequisites    = "prerequisites"     // codelabs to take first, in the first step
+	MsgRelated          = "related"           // codelabs to take next, in the last step
+	MsgFeedbackHelpful  = "feedback-helpful"  // feedback widget question
+	MsgFeedbackYes      = "feedback-yes"      // feedback widget thumbs up
+	MsgFeedbackNo       = "feedback-no"       // feedback widget thumbs down
+	MsgFeedbackComment  = "feedback-comment"  // feedback widget comment placeholder
+	MsgFeedbackSend     = "feedback-send"     // feedback widget submit button
+	MsgFeedbackThanks   = "feedback-thanks"   // feedback widget confirmation
+	MsgLastUpdated      = "last-updated"      // source stamp date; {date} is the YYYY-MM-DD date
+	MsgRevision         = "revision"          // source stamp revision label
+	MsgCourse           = "course"            // course link of course codelabs; {course} is the course title
+	MsgPreviousCodelab  = "previous-codelab"  // link to the previous codelab of a course
+	MsgNextCodelab      = "next-codelab"      // link to the next codelab of a course
+)
+
+var (
+	localesMu sync.Mutex // guards locales
+	locales   = map[string]Messages{
+		"en": {
+			MsgBack:             "Back",
+			MsgNext:             "Next",
+			MsgDone:             "Done",
+			MsgMinutesRemaining: "{n} min remaining",
+			MsgCopy:             "Copy",
+			MsgLanguage:         "Language",
+			MsgToggleDarkMode:   "Toggle dark mode",
+			MsgSkipToContent:    "Skip to content",
+			MsgPrerequisites:    "Prerequisites",
+			MsgRelated:          "Related codelabs",
+			MsgFeedbackHelpful:  "Was this step helpful?",
+			MsgFeedbackYes:      "Yes",
+			MsgFeedbackNo:       "No",
+			MsgFeedbackComment:  "Tell us more (optional)",
+			MsgFeedbackSend:     "Send",
+			MsgFeedbackThanks:   "Thanks for your feedback!",
+			MsgFeedbackQuestion: "Found an issue in this step?",
+			MsgFeedbackContact:  "Contact the authors",
+			MsgStep:             "Step",
+			MsgLastUpdated:      "Last updated {date}",
+			MsgRevision:         "Revision",
+			MsgCourse:           "This codelab is part of the course {course}.",
+			MsgPreviousCodelab:  "Previous codelab",
+			MsgNextCodelab:      "Next codelab",
+		},
+		"ar": {
+			MsgBack:             "رجوع",
+			MsgNext:             "التالي",
+			MsgDone:             "تم",
+			MsgMinutesRemaining: "متبقٍ {n} دقيقة",
+			MsgCopy:             "نسخ",
+			MsgLanguage:         "اللغة",
+			MsgToggleDarkMode:   "تبديل الوضع الداكن",
+			MsgSkipToContent:    "تخطَّ إلى المحتوى",
+			MsgPrerequisites:    "المتطلبات المسبقة",
+			MsgRelated:          "دروس تطبيقية ذات صلة",
+			MsgFeedbackHelpful:  "هل كانت هذه الخطوة مفيدة؟",
+			MsgFeedbackYes:      "نعم",
+			MsgFeedbackNo:       "لا",
+			MsgFeedbackComment:  "أخبرنا بالمزيد (اختياري)",
+			MsgFeedbackSend:     "إرسال",
+			MsgFeedbackThanks:   "شكرًا على ملاحظاتك!",
+			MsgFeedbackQuestion: "هل وجدت مشكلة في هذه الخطوة؟",
+			MsgFeedbackContact:  "التواصل مع المؤلفين",
+			MsgStep:             "الخطوة",
+			MsgLastUpdated:      "آخر تحديث {date}",
+			MsgRevision:         "المراجعة",
+			MsgCourse:           "هذا الدرس التطبيقي جزء من الدورة {course}.",
+			MsgPreviousCodelab:  "الدرس التطبيقي السابق",
+			MsgNextCodelab:      "الدرس التطبيقي التالي",
+		},
+		"de": {
+			MsgBack:             "Zurück",
+			MsgNext:             "Weiter",
+			MsgDone:             "Fertig",
+			MsgMinutesRemaining: "Noch {n} Min.",
+			MsgCopy:             "Kopieren",
+			MsgLanguage:         "Sprache",
+			MsgToggleDarkMode:   "Dunkelmodus umschalten",
+			MsgSkipToContent:    "Zum Inhalt springen",
+			MsgPrerequisites:    "Voraussetzungen",
+			MsgRelated:          "Ähnliche Codelabs",
+			MsgFeedbackHelpful:  "War dieser Schritt hilfreich?",
+			MsgFeedbackYes:      "Ja",
+			MsgFeedbackNo:       "Nein",
+			MsgFeedbackComment:  "Mehr erzählen (optional)",
+			MsgFeedbackSend:     "Senden",
+			MsgFeedbackThanks:   "Danke für Ihr Feedback!",
+			MsgFeedbackQuestion: "Problem in diesem Schritt gefunden?",
+			MsgFeedbackContact:  "Autoren kontaktieren",
+			MsgStep:             "Schritt",
+			MsgLastUpdated:      "Zuletzt aktualisiert: {date}",
+			MsgRevision:         "Revision",
+			MsgCourse:           "Dieses Codelab ist Teil des Kurses {course}.",
+			MsgPreviousCodelab:  "Vorheriges Codelab",
+			MsgNextCodelab:      "Nächstes Codelab",
+		},
+		"es": {
+			MsgBack:             "Atrás",
+			MsgNext:             "Siguiente",
+			MsgDone:             "Listo",
+			MsgMinutesRemaining: "Quedan {n} min",
+			MsgCopy:             "Copiar",
+			MsgLanguage:         "Idioma",
+			MsgToggleDarkMode:   "Cambiar modo oscuro",
+			MsgSkipToContent:    "Saltar al contenido",
+			MsgPrerequisites:    "Requisitos previos",
+			MsgRelated:          "Codelabs relacionados",
+			MsgFeedbackHelpful:  "¿Te resultó útil este paso?",
+			MsgFeedbackYes:      "Sí",
+			MsgFeedbackNo:       "No",
+			MsgFeedbackComment:  "Cuéntanos más (opcional)",
+			MsgFeedbackSend:     "Enviar",
+			MsgFeedbackThanks:   "¡Gracias por tus comentarios!",
+			MsgFeedbackQuestion: "¿Encontraste un problema en este paso?",
+			MsgFeedbackContact:  "Contacta a los autores",
+			MsgStep:             "Paso",
+			MsgLastUpdated:      "Última actualización: {date}",
+			MsgRevision:         "Revisión",
+			MsgCourse:           "Este codelab forma parte del curso {course}.",
+			MsgPreviousCodelab:  "Codelab anterior",
+			MsgNextCodelab:      "Siguiente codelab",
+		},
+		"fa": {
+			MsgBack:             "بازگشت",
+			MsgNext:             "بعدی",
+			MsgDone:             "پایان",
+			MsgMinutesRemaining: "{n} دقیقه باقی‌مانده",
+			MsgCopy:             "کپی",
+			MsgLanguage:         "زبان",
+			MsgToggleDarkMode:   "تغییر حالت تاریک",
+			MsgSkipToContent:    "پرش به محتوا",
+			MsgPrerequisites:    "پیش‌نیازها",
+			MsgRelated:          "کدلب‌های مرتبط",
+			MsgFeedbackHelpful:  "آیا این مرحله مفید بود؟",
+			MsgFeedbackYes:      "بله",
+			MsgFeedbackNo:       "خیر",
+			MsgFeedbackComment:  "بیشتر بگویید (اختیاری)",
+			MsgFeedbackSend:     "ارسال",
+			MsgFeedbackThanks:   "از بازخورد شما سپاسگزاریم!",
+			MsgFeedbackQuestion: "در این مرحله مشکلی پیدا کردید؟",
+			MsgFeedbackContact:  "تماس با نویسندگان",
+			MsgStep:             "مرحله",
+			MsgLastUpdated:      "آخرین به‌روزرسانی {date}",
+			MsgRevision:         "نسخه",
+			MsgCourse:           "این کدلب بخشی از دوره {course} است.",
+			MsgPreviousCodelab:  "کدلب قبلی",
+			MsgNextCodelab:      "کدلب بعدی",
+		},
+		"fr": {
+			MsgBack:             "Retour",
+			MsgNext:             "Suivant",
+			MsgDone:             "Terminé",
+			MsgMinutesRemaining: "{n} min restantes",
+			MsgCopy:             "Copier",
+			MsgLanguage:         "Langue",
+			MsgToggleDarkMode:   "Activer/désactiver le mode sombre",
+			MsgSkipToContent:    "Aller au contenu",
+			MsgPrerequisites:    "Prérequis",
+			MsgRelated:          "Ateliers associés",
+			MsgFeedbackHelpful:  "Cette étape vous a-t-elle été utile ?",
+			MsgFeedbackYes:      "Oui",
+			MsgFeedbackNo:       "Non",
+			MsgFeedbackComment:  "Dites-nous en plus (facultatif)",
+			MsgFeedbackSend:     "Envoyer",
+			MsgFeedbackThanks:   "Merci pour vos commentaires !",
+			MsgFeedbackQuestion: "Un problème dans cette étape ?",
+			MsgFeedbackContact:  "Contacter les auteurs",
+			MsgStep:             "Étape",
+			MsgLastUpdated:      "Dernière mise à jour : {date}",
+			MsgRevision:         "Révision",
+			MsgCourse:           "Cet atelier fait partie du cours {course}.",
+			MsgPreviousCodelab:  "Atelier précédent",
+			MsgNextCodelab:      "Atelier suivant",
+		},
+		"he": {
+			MsgBack:             "הקודם",
+			MsgNext:             "הבא",
+			MsgDone:             "סיום",
+			MsgMinutesRemaining: "נותרו {n} דקות",
+			MsgCopy:             "העתקה",
+			MsgLanguage:         "שפה",
+			MsgToggleDarkMode:   "החלפת מצב כהה",
+			MsgSkipToContent:    "דילוג לתוכן",
+			MsgPrerequisites:    "דרישות מוקדמות",
+			MsgRelated:          "Codelabs קשורים",
+			MsgFeedbackHelpful:  "האם השלב הזה עזר לך?",
+			MsgFeedbackYes:      "כן",
+			MsgFeedbackNo:       "לא",
+			MsgFeedbackComment:  "ספרו לנו עוד (אופציונלי)",
+			MsgFeedbackSend:     "שליחה",
+			MsgFeedbackThanks:   "תודה על המשוב!",
+			MsgFeedbackQuestion: "מצאת בעיה בשלב הזה?",
+			MsgFeedbackContact:  "יצירת קשר עם המחברים",
+			MsgStep:             "שלב",
+			MsgLastUpdated:      "עודכן לאחרונה {date}",
+			MsgRevision:         "גרסה",
+			MsgCourse:           "ה-Codelab הזה הוא חלק מהקורס {course}.",
+			MsgPreviousCodelab:  "ה-Codelab הקודם",
+			MsgNextCodelab:      "ה-Codelab הבא",
+		},
+		"it": {
+			MsgBack:             "Indietro",
+			MsgNext:             "Avanti",
+			MsgDone:             "Fine",
+			MsgMinutesRemaining: "{n} min rimanenti",
+			MsgCopy:             "Copia",
+			MsgLanguage:         "Lingua",
+			MsgToggleDarkMode:   "Attiva/disattiva modalità scura",
+			MsgSkipToContent:    "Vai al contenuto",
+			MsgPrerequisites:    "Prerequisiti",
+			MsgRelated:          "Codelab correlati",
+			MsgFeedbackHelpful:  "Questo passaggio ti è stato utile?",
+			MsgFeedbackYes:      "Sì",
+			MsgFeedbackNo:       "No",
+			MsgFeedbackComment:  "Dicci di più (facoltativo)",
+			MsgFeedbackSend:     "Invia",
+			MsgFeedbackThanks:   "Grazie per il tuo feedback!",
+			MsgFeedbackQuestion: "Hai trovato un problema in questo passaggio?",
+			MsgFeedbackContact:  "Contatta gli autori",
+			MsgStep:             "Passaggio",
+			MsgLastUpdated:      "Ultimo aggiornamento: {date}",
+			MsgRevision:         "Revisione",
+			MsgCourse:           "Questo codelab fa parte del corso {course}.",
+			MsgPreviousCodelab:  "Codelab precedente",
+			MsgNextCodelab:      "Codelab successivo",
+		},
+		"ja": {
+			MsgBack:             "戻る",
+			MsgNext:             "次へ",
+			MsgDone:             "完了",
+			MsgMinutesRemaining: "残り {n} 分",
+			MsgCopy:             "コピー",
+			MsgLanguage:         "言語",
+			MsgToggleDarkMode:   "ダークモードの切り替え",
+			MsgSkipToContent:    "コンテンツにスキップ",
+			MsgPrerequisites:    "前提条件",
+			MsgRelated:          "関連する Codelab",
+			MsgFeedbackHelpful:  "このステップは役に立ちましたか？",
+			MsgFeedbackYes:      "はい",
+			MsgFeedbackNo:       "いいえ",
+			MsgFeedbackComment:  "詳しく教えてください（任意）",
+			MsgFeedbackSend:     "送信",
+			MsgFeedbackThanks:   "フィードバックをありがとうございます",
+			MsgFeedbackQuestion: "このステップで問題が見つかりましたか？",
+			MsgFeedbackContact:  "作成者に連絡",
+			MsgStep:             "ステップ",
+			MsgLastUpdated:      "最終更新日 {date}",
+			MsgRevision:         "リビジョン",
+			MsgCourse:           "この Codelab はコース「{course}」の一部です。",
+			MsgPreviousCodelab:  "前の Codelab",
+			MsgNextCodelab:      "次の Codelab",
+		},
+		"ko": {
+			MsgBack:             "뒤로",
+			MsgNext:             "다음",
+			MsgDone:             "완료",
+			MsgMinutesRemaining: "{n}분 남음",
+			MsgCopy:             "복사",
+			MsgLanguage:         "언어",
+			MsgToggleDarkMode:   "다크 모드 전환",
+			MsgSkipToContent:    "콘텐츠로 건너뛰기",
+			MsgPrerequisites:    "기본 요건",
+			MsgRelated:          "관련 Codelab",
+			MsgFeedbackHelpful:  "이 단계가 도움이 되었나요?",
+			MsgFeedbackYes:      "예",
+			MsgFeedbackNo:       "아니요",
+			MsgFeedbackComment:  "자세히 알려주세요(선택사항)",
+			MsgFeedbackSend:     "보내기",
+			MsgFeedbackThanks:   "의견을 보내 주셔서 감사합니다.",
+			MsgFeedbackQuestion: "이 단계에서 문제를 발견하셨나요?",
+			MsgFeedbackContact:  "작성자에게 문의",
+			MsgStep:             "단계",
+			MsgLastUpdated:      "최종 업데이트: {date}",
+			MsgRevision:         "버전",
+			MsgCourse:           "이 Codelab은 {course} 과정의 일부입니다.",
+			MsgPreviousCodelab:  "이전 Codelab",
+			MsgNextCodelab:      "다음 Codelab",
+		},
+		"pt": {
+			MsgBack:             "Voltar",
+			MsgNext:             "Próximo",
+			MsgDone:             "Concluído",
+			MsgMinutesRemaining: "{n} min restantes",
+			MsgCopy:             "Copiar",
+			MsgLanguage:         "Idioma",
+			MsgToggleDarkMode:   "Alternar modo escuro",
+			MsgSkipToContent:    "Pular para o conteúdo",
+			MsgPrerequisites:    "Pré-requisitos",
+			MsgRelated:          "Codelabs relacionados",
+			MsgFeedbackHelpful:  "Esta etapa foi útil?",
+			MsgFeedbackYes:      "Sim",
+			MsgFeedbackNo:       "Não",
+			MsgFeedbackComment:  "Conte mais (opcional)",
+			MsgFeedbackSend:     "Enviar",
+			MsgFeedbackThanks:   "Agradecemos seu feedback!",
+			MsgFeedbackQuestion: "Encontrou um problema nesta etapa?",
+			MsgFeedbackContact:  "Fale com os autores",
+			MsgStep:             "Etapa",
+			MsgLastUpdated:      "Última atualização: {date}",
+			MsgRevision:         "Revisão",
+			MsgCourse:           "Este codelab faz parte do curso {course}.",
+			MsgPreviousCodelab:  "Codelab anterior",
+			MsgNextCodelab:      "Próximo codelab",
+		},
+		"zh": {
+			MsgBack:             "上一步",
+			MsgNext:             "下一步",
+			MsgDone:             "完成",
+			MsgMinutesRemaining: "剩余 {n} 分钟",
+			MsgCopy:             "复制",
+			MsgLanguage:         "语言",
+			MsgToggleDarkMode:   "切换深色模式",
+			MsgSkipToContent:    "跳至内容",
+			MsgPrerequisites:    "前提条件",
+			MsgRelated:          "相关 Codelab",
+			MsgFeedbackHelpful:  "这一步对您有帮助吗？",
+			MsgFeedbackYes:      "是",
+			MsgFeedbackNo:       "否",
+			MsgFeedbackComment:  "详细说明（可选）",
+			MsgFeedbackSend:     "发送",
+			MsgFeedbackThanks:   "感谢您的反馈！",
+			MsgFeedbackQuestion: "在此步骤中发现问题？",
+			MsgFeedbackContact:  "联系作者",
+			MsgStep:             "步骤",
+			MsgLastUpdated:      "最后更新时间：{date}",
+			MsgRevision:         "修订版本",
+			MsgCourse:           "此 Codelab 是课程“{course}”的一部分。",
+			MsgPreviousCodelab:  "上一个 Codelab",
+			MsgNextCodelab:      "下一个 Codelab",
+		},
+	}
+)
+
+// Validate returns an error if m has an unknown message ID.
+func (m Messages) Validate() error {
+	for id := range m {
+		switch id {
+		case MsgBack, MsgNext, MsgDone, MsgMinutesRemaining, MsgCopy,
+			MsgLanguage, MsgToggleDarkMode, MsgFeedbackQuestion, MsgFeedbackContact, MsgStep,
+			MsgSkipToContent, MsgPrerequisites, MsgRelated, MsgFeedbackHelpful, MsgFeedbackYes,
+			MsgFeedbackNo, MsgFeedbackComment, MsgFeedbackSend, MsgFeedbackThanks, MsgLastUpdated,
+			MsgRevision, MsgCourse, MsgPreviousCodelab, MsgNextCodelab:
+		default:
+			return fmt.Errorf("unknown message %q", id)
+		}
+	}
+	return nil
+}
+
+// RegisterLocale adds messages m to the bundle of locale lang,
+// creating it if needed and replacing existing messages of the same ID.
+// It returns an error if m has an unknown message ID.
+func RegisterLocale(lang string, m Messages) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	lang = normLocale(lang)
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	b := locales[lang]
+	if b == nil {
+		b = make(Messages, len(m))
+		locales[lang] = b
+	}
+	for id, s := range m {
+		b[id] = s
+	}
+	return nil
+}
+
+// Locales returns sorted names of all locale bundles.
+func Locales() []string {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	l := make([]string, 0, len(locales))
+	for k := range locales {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// HasLocale reports whether there is a bundle of locale lang,
+// or of its base language, e.g. "pt" for "pt-BR".
+func HasLocale(lang string) bool {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	lang = normLocale(lang)
+	_, ok := locales[lang]
+	if !ok {
+		_, ok = locales[baseLocale(lang)]
+	}
+	return ok
+}
+
+// Msg returns message id in locale lang, falling back to the base
+// language of lang and then English.
+func Msg(lang, id string) string {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	lang = normLocale(lang)
+	for _, l := range []string{lang, baseLocale(lang)} {
+		if s := locales[l][id]; s != "" {
+			return s
+		}
+	}
+	return locales["en"][id]
+}
+
+// langNames are names of languages in themselves, keyed by normalized locale,
+// shown in the language switcher of locale variants.
+var langNames = map[string]string{
+	"ar":    "العربية",
+	"de":    "Deutsch",
+	"en":    "English",
+	"es":    "Español",
+	"fa":    "فارسی",
+	"fr":    "Français",
+	"he":    "עברית",
+	"hi":    "हिन्दी",
+	"id":    "Bahasa Indonesia",
+	"it":    "Italiano",
+	"ja":    "日本語",
+	"ko":    "한국어",
+	"nl":    "Nederlands",
+	"pl":    "Polski",
+	"pt":    "Português",
+	"pt-br": "Português (Brasil)",
+	"pt-pt": "Português (Portugal)",
+	"ru":    "Русский",
+	"th":    "ไทย",
+	"tr":    "Türkçe",
+	"uk":    "Українська",
+	"vi":    "Tiếng Việt",
+	"zh":    "中文",
+	"zh-cn": "简体中文",
+	"zh-tw": "繁體中文",
+}
+
+// langName returns the name of the language of locale lang in itself,
+// followed by its region if unknown, e.g. "Français (CA)",
+// or lang if the language is unknown.
+func langName(lang string) string {
+	l := normLocale(lang)
+	if n, ok := langNames[l]; ok {
+		return n
+	}
+	b := baseLocale(l)
+	if n, ok := langNames[b]; ok {
+		return n + " (" + strings.ToUpper(l[len(b)+1:]) + ")"
+	}
+	return lang
+}
+
+// langTag returns locale lang as a BCP 47 language tag, such as
+// the hreflang of a link, e.g. "pt-BR" for "pt_BR".
+func langTag(lang string) string {
+	return strings.Replace(strings.TrimSpace(lang), "_", "-", -1)
+}
+
+// rtlLocales are languages written right to left.
+var rtlLocales = map[string]bool{
+	"ar": true, // Arabic
+	"fa": true, // Persian
+	"he": true, // Hebrew
+	"iw": true, // Hebrew, legacy code
+	"ur": true, // Urdu
+}
+
+// localeDir returns the text direction of locale lang, "rtl" or "ltr".
+func localeDir(lang string) string {
+	if rtlLocales[baseLocale(normLocale(lang))] {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// normLocale returns lowercase locale lang with dashes, e.g. "pt-br".
+func normLocale(lang string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(lang), "_", "-", -1))
+}
+
+// baseLocale returns the language of normalized locale lang, e.g. "pt".
+func baseLocale(lang string) string {
+	if i := strings.Index(lang, "-"); i > 0 {
+		return lang[:i]
+	}
+	return lang
+}
+
+// chromeScript returns a script translating labels rendered by the codelab
+// elements in locale lang, or nothing if lang is English.
+func chromeScript(lang string) htmlTemplate.HTML {
+	if lang == "" || baseLocale(normLocale(lang)) == "en" {
+		return ""
+	}
+	msgs := map[string]string{}
+	for _, id := range []string{MsgBack, MsgNext, MsgDone, MsgMinutesRemaining} {
+		msgs[id] = Msg(lang, id)
+	}
+	b, err := json.Marshal(msgs) // escapes <, > and & for script elements
+	if err != nil {
+		return ""
+	}
+	return htmlTemplate.HTML(`<script>
+(function(msgs) {
+  var labels = {'previous-step': msgs.back, 'next-step': msgs.next, 'done': msgs.done};
+  var translate = function() {
+    var codelab = document.querySelector('google-codelab');
+    if (!codelab) return;
+    Object.keys(labels).forEach(function(id) {
+      var el = codelab.querySelector('#' + id);
+      if (el && el.textContent.trim() !== labels[id] && el.children.length === 0) {
+        el.textContent = labels[id];
+      }
+    });
+    // only the text is replaced, keeping the access_time icon
+    codelab.querySelectorAll('.time-remaining').forEach(function(el) {
+      el.childNodes.forEach(function(node) {
+        var m = node.nodeType === Node.TEXT_NODE && /(\d+)\s*mins?\s+remaining/.exec(node.nodeValue);
+        if (m) node.nodeValue = node.nodeValue.replace(m[0], msgs['minutes-remaining'].replace('{n}', m[1]));
+      });
+    });
+  };
+  new MutationObserver(translate).observe(document.body, {childList: true, subtree: true, characterData: true});
+  translate();
+})(` + string(b) + `);
+</script>`)
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestMsg(t *testing.T) {
+	if err := RegisterLocale("test_NL", Messages{MsgNext: "Volgende"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		lang, id, want string
+	}{
+		{"", MsgNext, "Next"},
+		{"fr", MsgNext, "Suivant"},
+		{"pt-BR", MsgCopy, "Copiar"},
+		{"pt_br", MsgCopy, "Copiar"},
+		{"xx", MsgDone, "Done"},
+		{"test-nl", MsgNext, "Volgende"},
+		{"test-nl", MsgBack, "Back"},
+	}
+	for _, test := range tests {
+		if got := Msg(test.lang, test.id); got != test.want {
+			t.Errorf("Msg(%q, %q) = %q; want %q", test.lang, test.id, got, test.want)
+		}
+	}
+	if !HasLocale("fr-CA") || HasLocale("xx") {
+		t.Errorf("HasLocale(fr-CA) = %v, HasLocale(xx) = %v; want true, false", HasLocale("fr-CA"), HasLocale("xx"))
+	}
+	if err := RegisterLocale("nl", Messages{"start": "Start"}); err == nil {
+		t.Error("RegisterLocale with an unknown message = nil error; want an error")
+	}
+}
+
+func TestExecuteLocale(t *testing.T) {
+	code := types.NewCodeNode("x := 1", false, "go")
+	code.Title = "main.go"
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:   &types.Meta{},
+		Steps:  []*types.Step{{Title: "One", Content: types.NewListNode(code)}},
+		Locale: "fr",
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`title="Activer/désactiver le mode sombre"`,
+		`<button class="code-copy" type="button" title="Copier"`,
+		`"next":"Suivant"`,
+		`"minutes-remaining":"{n} min restantes"`,
+		`querySelectorAll('.time-remaining')`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+
+	buf.Reset()
+	data.Locale = ""
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "MutationObserver") || !strings.Contains(out, `title="Copy"`) {
+		t.Errorf("Execute(html) in English:\n%s", out)
+	}
+}
+
+func TestExecuteRTL(t *testing.T) {
+	for lang, want := range map[string]string{"ar": "rtl", "he-IL": "rtl", "fa": "rtl", "en": "ltr", "": "ltr"} {
+		if got := localeDir(lang); got != want {
+			t.Errorf("localeDir(%q) = %q; want %q", lang, got, want)
+		}
+	}
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:   &types.Meta{Lang: "ar"},
+		Steps:  []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		Locale: "ar",
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<html lang="ar" dir="rtl">`, `"next":"التالي"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+}
+
+func TestExecuteTranslations(t *testing.T) {
+	for lang, want := range map[string]string{"fr": "Français", "pt_BR": "Português (Brasil)", "fr-CA": "Français (CA)", "xx": "xx"} {
+		if got := langName(lang); got != want {
+			t.Errorf("langName(%q) = %q; want %q", lang, got, want)
+		}
+	}
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta: &types.Meta{
+			ID:    "lab-pt_br",
+			Lang:  "pt_BR",
+			Group: "lab",
+			Translations: []*types.Translation{
+				{Lang: "en", ID: "lab"},
+				{Lang: "pt_BR", ID: "lab-pt_br"},
+			},
+		},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<link rel="alternate" hreflang="en" href="../lab/">`,
+		`<link rel="alternate" hreflang="pt-BR" href="../lab-pt_br/">`,
+		`<link rel="alternate" hreflang="x-default" href="../lab/">`,
+		`<option value="../lab-pt_br/" selected lang="pt-BR">Português (Brasil)</option>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+	"github.com/googlecodelabs/tools/claat/util"
+)
+
+// jsonLD returns schema.org structured data of codelab meta, updated
+// at RFC 3339 time updated, in locale lang, for search engines.
+// The html format writes it as JSON into a script of type
+// application/ld+json, which html/template escapes.
+func jsonLD(meta *types.Meta, updated, lang string) map[string]interface{} {
+	ld := map[string]interface{}{
+		"@context":             "https://schema.org",
+		"@type":                []string{"Course", "LearningResource"},
+		"name":                 meta.Title,
+		"identifier":           meta.ID,
+		"learningResourceType": "Codelab",
+		"isAccessibleForFree":  true,
+	}
+	if meta.Summary != "" {
+		ld["description"] = meta.Summary
+	}
+	var authors []map[string]string
+	for _, a := range strings.Split(meta.Authors, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			authors = append(authors, map[string]string{"@type": "Person", "name": a})
+		}
+	}
+	if len(authors) > 0 {
+		ld["author"] = authors
+	}
+	if meta.Duration > 0 {
+		ld["timeRequired"] = fmt.Sprintf("PT%dM", meta.Duration)
+	}
+	if kw := util.Unique(append(append([]string(nil), meta.Categories...), meta.Tags...)); len(kw) > 0 {
+		ld["keywords"] = strings.Join(kw, ", ")
+	}
+	if meta.Status != nil && len(*meta.Status) > 0 {
+		ld["creativeWorkStatus"] = strings.Join(*meta.Status, ", ")
+	}
+	if updated != "" {
+		ld["dateModified"] = updated
+	}
+	if meta.Lang != "" {
+		lang = meta.Lang
+	}
+	if lang != "" {
+		ld["inLanguage"] = langTag(lang)
+	}
+	if len(meta.Prerequisites) > 0 {
+		ld["coursePrerequisites"] = meta.Prerequisites
+	}
+	return ld
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestExecuteJSONLD(t *testing.T) {
+	status := types.LegacyStatus{"published"}
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta: &types.Meta{
+			ID:            "go-basics",
+			Title:         "Go </script> basics",
+			Summary:       "Learn Go.",
+			Authors:       "Ada Lovelace, Alan Turing",
+			Duration:      35,
+			Categories:    []string{"Go"},
+			Tags:          []string{"web", "Go"},
+			Status:        &status,
+			Prerequisites: []string{"setup"},
+		},
+		Steps:   []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		Updated: "2019-06-01T10:00:00Z",
+		Locale:  "pt_BR",
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	const start = `<script type="application/ld+json">`
+	i := strings.Index(out, start)
+	if i < 0 {
+		t.Fatalf("Execute(html) has no JSON-LD:\n%s", out)
+	}
+	ld := out[i+len(start):]
+	ld = ld[:strings.Index(ld, "</script>")]
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(ld), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", ld, err)
+	}
+	want := map[string]interface{}{
+		"@context":             "https://schema.org",
+		"@type":                []interface{}{"Course", "LearningResource"},
+		"name":                 "Go </script> basics",
+		"identifier":           "go-basics",
+		"description":          "Learn Go.",
+		"learningResourceType": "Codelab",
+		"isAccessibleForFree":  true,
+		"author": []interface{}{
+			map[string]interface{}{"@type": "Person", "name": "Ada Lovelace"},
+			map[string]interface{}{"@type": "Person", "name": "Alan Turing"},
+		},
+		"timeRequired":        "PT35M",
+		"keywords":            "Go, web",
+		"creativeWorkStatus":  "published",
+		"dateModified":        "2019-06-01T10:00:00Z",
+		"inLanguage":          "pt-BR",
+		"coursePrerequisites": []interface{}{"setup"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON-LD = %v\nwant %v", got, want)
+	}
+}
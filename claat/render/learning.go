@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// codelabRef returns the link and label of ref, a codelab ID or a URL.
+// Codelab IDs link to sibling export directories, like locale variants.
+func codelabRef(ref string) (href, label string) {
+	if strings.Contains(ref, "/") || strings.Contains(ref, ":") {
+		return ref, ref
+	}
+	return "../" + ref + "/", ref
+}
+
+// prerequisitesSection renders a list of the codelabs to take before
+// the codelab of meta, in locale lang, or nothing if there are none.
+func prerequisitesSection(meta *types.Meta, lang string) htmlTemplate.HTML {
+	return codelabsSection("codelab-prerequisites", Msg(lang, MsgPrerequisites), meta.Prerequisites)
+}
+
+// relatedSection renders a list of the codelabs to take after
+// the codelab of meta, in locale lang, or nothing if there are none.
+func relatedSection(meta *types.Meta, lang string) htmlTemplate.HTML {
+	return codelabsSection("codelab-related", Msg(lang, MsgRelated), meta.Related)
+}
+
+// codelabsSection renders refs as a list titled title, of class class.
+func codelabsSection(class, title string, refs []string) htmlTemplate.HTML {
+	if len(refs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<aside class="%s"><h3>%s</h3><ul>`, class, htmlTemplate.HTMLEscapeString(title))
+	for _, ref := range refs {
+		href, label := codelabRef(ref)
+		fmt.Fprintf(&b, `<li><a href="%s">%s</a></li>`,
+			htmlTemplate.HTMLEscapeString(safeRefURL(href)), htmlTemplate.HTMLEscapeString(label))
+	}
+	b.WriteString("</ul></aside>")
+	return htmlTemplate.HTML(b.String())
+}
+
+// safeRefURL returns href, or "#" if it has a scheme other than http(s),
+// such as javascript:.
+func safeRefURL(href string) string {
+	if i := strings.Index(href, ":"); i >= 0 && !strings.Contains(href[:i], "/") {
+		s := strings.ToLower(href[:i])
+		if s != "http" && s != "https" {
+			return "#"
+		}
+	}
+	return href
+}
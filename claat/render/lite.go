@@ -45,9 +45,10 @@ func WriteLite(w io.Writer, env string, nodes ...types.Node) error {
 }
 
 type liteWriter struct {
-	w   io.Writer // output writer
-	env string    // target environment
-	err error     // error during any writeXxx methods
+	w     io.Writer         // output writer
+	env   string            // target environment
+	err   error             // error during any writeXxx methods
+	terms []*types.TermNode // glossary terms to write as footnotes
 }
 
 func (lw *liteWriter) matchEnv(v []string) bool {
@@ -65,6 +66,9 @@ func (lw *liteWriter) write(nodes ...types.Node) error {
 			doc.AppendChild(hn)
 		}
 	}
+	if fn := lw.footnotes(); fn != nil {
+		doc.AppendChild(fn)
+	}
 	return html.Render(lw.w, doc)
 }
 
@@ -102,6 +106,8 @@ func (lw *liteWriter) htmlnode(n types.Node) *html.Node {
 		hn = lw.header(n)
 	case *types.YouTubeNode:
 		hn = lw.youtube(n)
+	case *types.TermNode:
+		hn = lw.term(n)
 	}
 	return hn
 }
@@ -127,6 +133,23 @@ func (lw *liteWriter) text(n *types.TextNode) *html.Node {
 }
 
 func (lw *liteWriter) image(n *types.ImageNode) *html.Node {
+	if n.Placeholder != "" {
+		style := placeholderStyle
+		if n.Width > 0 {
+			style += fmt.Sprintf("width: %.2fpx;", n.Width)
+		}
+		hn := &html.Node{
+			Type: html.ElementNode,
+			Data: atom.Span.String(),
+			Attr: []html.Attribute{
+				{Key: "class", Val: "placeholder-image"},
+				{Key: "role", Val: "img"},
+				{Key: "style", Val: style},
+			},
+		}
+		hn.AppendChild(&html.Node{Type: html.TextNode, Data: "Placeholder: " + n.Placeholder})
+		return hn
+	}
 	hn := &html.Node{
 		Type: html.ElementNode,
 		Data: atom.Img.String(),
@@ -152,6 +175,9 @@ func (lw *liteWriter) alink(n *types.URLNode) *html.Node {
 	if n.Target != "" {
 		top.Attr = append(top.Attr, html.Attribute{Key: "target", Val: n.Target})
 	}
+	if n.Rel != "" {
+		top.Attr = append(top.Attr, html.Attribute{Key: "rel", Val: n.Rel})
+	}
 	for _, cn := range n.Content.Nodes {
 		if hn := lw.htmlnode(cn); hn != nil {
 			top.AppendChild(hn)
@@ -324,7 +350,8 @@ func (lw *liteWriter) survey(n *types.SurveyNode) *html.Node {
 			{Key: "data-survey-id", Val: n.ID},
 		},
 	}
-	for i, g := range n.Groups {
+	// lite output is also used for print, so selection must be stable
+	for i, g := range n.Picked() {
 		h4 := &html.Node{
 			Type: html.ElementNode,
 			Data: atom.H4.String(),
@@ -404,3 +431,52 @@ func (lw *liteWriter) youtube(n *types.YouTubeNode) *html.Node {
 	pad.AppendChild(box)
 	return top
 }
+
+// term renders n as a reference to a footnote, which is written
+// at the end of the content by footnotes.
+func (lw *liteWriter) term(n *types.TermNode) *html.Node {
+	lw.terms = append(lw.terms, n)
+	id := len(lw.terms)
+	top := &html.Node{
+		Type: html.ElementNode,
+		Data: atom.Span.String(),
+		Attr: []html.Attribute{{Key: "class", Val: "step__term"}},
+	}
+	top.AppendChild(&html.Node{Type: html.TextNode, Data: n.Term})
+	sup := &html.Node{Type: html.ElementNode, Data: atom.Sup.String()}
+	a := &html.Node{
+		Type: html.ElementNode,
+		Data: atom.A.String(),
+		Attr: []html.Attribute{{Key: "href", Val: fmt.Sprintf("#term-fn-%d", id)}},
+	}
+	a.AppendChild(&html.Node{Type: html.TextNode, Data: strconv.Itoa(id)})
+	sup.AppendChild(a)
+	top.AppendChild(sup)
+	return top
+}
+
+// footnotes returns a list of all glossary terms definitions
+// rendered so far, or nil if there are none.
+func (lw *liteWriter) footnotes() *html.Node {
+	if len(lw.terms) == 0 {
+		return nil
+	}
+	top := &html.Node{
+		Type: html.ElementNode,
+		Data: atom.Ol.String(),
+		Attr: []html.Attribute{{Key: "class", Val: "step__footnotes"}},
+	}
+	for i, n := range lw.terms {
+		li := &html.Node{
+			Type: html.ElementNode,
+			Data: atom.Li.String(),
+			Attr: []html.Attribute{{Key: "id", Val: fmt.Sprintf("term-fn-%d", i+1)}},
+		}
+		t := &html.Node{Type: html.ElementNode, Data: atom.Strong.String()}
+		t.AppendChild(&html.Node{Type: html.TextNode, Data: n.Term})
+		li.AppendChild(t)
+		li.AppendChild(&html.Node{Type: html.TextNode, Data: ": " + n.Definition})
+		top.AppendChild(li)
+	}
+	return top
+}
@@ -0,0 +1,333 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("scorm", lmsRenderer{version: "1.2"})
+	register("scorm2004", lmsRenderer{version: "2004"})
+	register("xapi", lmsRenderer{version: "xapi"})
+}
+
+// lmsRenderer renders a codelab as a package for learning management
+// systems, such as Moodle or Cornerstone: a zip archive of the html format
+// output, its local images and a tracking script.
+//
+// The version is "1.2" or "2004" for SCORM packages, described by
+// an imsmanifest.xml file, or "xapi" for a package with a tincan.xml file.
+// The script reports each visited step, as an objective in SCORM or
+// an "experienced" statement in xAPI, and completes the codelab once
+// all of its steps are visited. Codelab elements are still loaded
+// from Context.Prefix.
+type lmsRenderer struct {
+	version string
+}
+
+func (lmsRenderer) Ext() string { return "zip" }
+
+func (r lmsRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	var page bytes.Buffer
+	// the template refers to the context as a field, like writeCodelab data
+	data := &struct{ Context }{*ctx}
+	var opts []Option
+	if ctx.builtin {
+		opts = append(opts, Builtin())
+	}
+	if err := Execute(&page, "html", data, opts...); err != nil {
+		return err
+	}
+	cfg, err := json.Marshal(map[string]string{
+		"version":  r.version,
+		"activity": "urn:claat:" + m.ID,
+		"title":    m.Title,
+	})
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf("<script>window.claatTracking = %s;</script>\n<script src=\"lms.js\"></script>\n", cfg)
+	index := page.Bytes()
+	if i := bytes.LastIndex(index, []byte("</body>")); i >= 0 {
+		index = append(index[:i:i], append([]byte(script), index[i:]...)...)
+	} else {
+		index = append(index, script...)
+	}
+
+	files := map[string][]byte{
+		"index.html": index,
+		"lms.js":     []byte(lmsScript),
+	}
+	for _, st := range ctx.Steps {
+		types.Walk(st.Content.Nodes, func(n types.Node) bool {
+			img, ok := n.(*types.ImageNode)
+			if !ok {
+				return true
+			}
+			name, ok := localImagePath(img.Src)
+			if !ok || files[name] != nil {
+				return true
+			}
+			if b, err := ioutil.ReadFile(filepath.Join(ctx.Dir, filepath.FromSlash(name))); err == nil {
+				files[name] = b
+			}
+			return true
+		})
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if r.version == "xapi" {
+		files["tincan.xml"] = lmsTincan(m)
+	} else {
+		files["imsmanifest.xml"] = lmsManifest(m, r.version, names)
+	}
+
+	zw := zip.NewWriter(w)
+	for _, name := range append([]string{"imsmanifest.xml", "tincan.xml"}, names...) {
+		b, ok := files[name]
+		if !ok {
+			continue
+		}
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(b); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// lmsIDRegexp matches characters not allowed in XML IDs of manifest elements.
+var lmsIDRegexp = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// lmsManifest returns a SCORM manifest of codelab m, declaring a single SCO
+// made of files, in the SCORM version "1.2" or "2004".
+func lmsManifest(m *types.Meta, version string, files []string) []byte {
+	id := "claat-" + lmsIDRegexp.ReplaceAllString(m.ID, "_")
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	scormType := "scormType"
+	if version == "1.2" {
+		scormType = "scormtype"
+		fmt.Fprintf(&b, `<manifest identifier="%s" version="1" xmlns="http://www.imsproject.org/xsd/imscp_rootv1p1p2" xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_rootv1p2">`, id)
+		b.WriteString("<metadata><schema>ADL SCORM</schema><schemaversion>1.2</schemaversion></metadata>")
+	} else {
+		fmt.Fprintf(&b, `<manifest identifier="%s" version="1" xmlns="http://www.imsglobal.org/xsd/imscp_v1p1" xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_v1p3">`, id)
+		b.WriteString("<metadata><schema>ADL SCORM</schema><schemaversion>2004 4th Edition</schemaversion></metadata>")
+	}
+	var title bytes.Buffer
+	xml.EscapeText(&title, []byte(m.Title))
+	fmt.Fprintf(&b, `<organizations default="%[1]s-org"><organization identifier="%[1]s-org"><title>%[2]s</title>`, id, title.Bytes())
+	fmt.Fprintf(&b, `<item identifier="%[1]s-item" identifierref="%[1]s-sco"><title>%[2]s</title></item>`, id, title.Bytes())
+	b.WriteString("</organization></organizations>")
+	fmt.Fprintf(&b, `<resources><resource identifier="%s-sco" type="webcontent" adlcp:%s="sco" href="index.html">`, id, scormType)
+	for _, f := range files {
+		b.WriteString(`<file href="`)
+		xml.EscapeText(&b, []byte(f))
+		b.WriteString(`"/>`)
+	}
+	b.WriteString("</resource></resources></manifest>")
+	return b.Bytes()
+}
+
+// lmsTincan returns an xAPI package descriptor of codelab m.
+func lmsTincan(m *types.Meta) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<tincan xmlns="http://projecttincan.com/tincan.xsd"><activities>`)
+	b.WriteString(`<activity id="`)
+	xml.EscapeText(&b, []byte("urn:claat:"+m.ID))
+	b.WriteString(`" type="http://adlnet.gov/expapi/activities/course"><name>`)
+	xml.EscapeText(&b, []byte(m.Title))
+	b.WriteString(`</name><description lang="en-US">`)
+	xml.EscapeText(&b, []byte(m.Summary))
+	b.WriteString(`</description><launch lang="en-US">index.html</launch></activity>`)
+	b.WriteString("</activities></tincan>")
+	return b.Bytes()
+}
+
+// lmsScript tracks progress of the codelab viewer, which selects step N
+// with a google-codelab-pageview event, and reports it to the LMS.
+const lmsScript = `(function() {
+  var cfg = window.claatTracking || {};
+  var visited = {};
+  var total = 0;
+  var lms = null;
+
+  function findAPI(name) {
+    var w = window;
+    for (var i = 0; w && i < 10; i++) {
+      if (w[name]) return w[name];
+      if (w.parent === w) break;
+      w = w.parent;
+    }
+    return window.opener && window.opener[name] || null;
+  }
+
+  function scorm(api, v12) {
+    var call = function(m12, m2004) {
+      var args = Array.prototype.slice.call(arguments, 2);
+      return api[v12 ? m12 : m2004].apply(api, args);
+    };
+    var objectives = {};
+    return {
+      init: function() {
+        call('LMSInitialize', 'Initialize', '');
+        var n = parseInt(call('LMSGetValue', 'GetValue', 'cmi.objectives._count'), 10) || 0;
+        for (var i = 0; i < n; i++) {
+          objectives[call('LMSGetValue', 'GetValue', 'cmi.objectives.' + i + '.id')] = i;
+        }
+        var data = call('LMSGetValue', 'GetValue', 'cmi.suspend_data');
+        return {location: call('LMSGetValue', 'GetValue', v12 ? 'cmi.core.lesson_location' : 'cmi.location'), data: data};
+      },
+      visit: function(step, data) {
+        var id = 'step-' + (step + 1);
+        if (!(id in objectives)) {
+          objectives[id] = Object.keys(objectives).length;
+          call('LMSSetValue', 'SetValue', 'cmi.objectives.' + objectives[id] + '.id', id);
+        }
+        var o = 'cmi.objectives.' + objectives[id];
+        if (v12) {
+          call('LMSSetValue', 'SetValue', o + '.status', 'completed');
+        } else {
+          call('LMSSetValue', 'SetValue', o + '.completion_status', 'completed');
+        }
+        call('LMSSetValue', 'SetValue', v12 ? 'cmi.core.lesson_location' : 'cmi.location', String(step));
+        call('LMSSetValue', 'SetValue', 'cmi.suspend_data', data);
+      },
+      progress: function(done, measure) {
+        if (v12) {
+          call('LMSSetValue', 'SetValue', 'cmi.core.lesson_status', done ? 'completed' : 'incomplete');
+        } else {
+          call('LMSSetValue', 'SetValue', 'cmi.completion_status', done ? 'completed' : 'incomplete');
+          call('LMSSetValue', 'SetValue', 'cmi.progress_measure', measure.toFixed(2));
+        }
+        call('LMSCommit', 'Commit', '');
+      },
+      finish: function() {
+        if (!v12) call('', 'SetValue', 'cmi.exit', 'suspend');
+        call('LMSFinish', 'Terminate', '');
+      }
+    };
+  }
+
+  function xapi() {
+    var q = {};
+    location.search.substr(1).split('&').forEach(function(kv) {
+      var i = kv.indexOf('=');
+      if (i > 0) q[decodeURIComponent(kv.substr(0, i))] = decodeURIComponent(kv.substr(i + 1).replace(/\+/g, ' '));
+    });
+    if (!q.endpoint || !q.actor) return null;
+    var endpoint = q.endpoint.replace(/\/?$/, '/');
+    var activity = q.activity_id || cfg.activity;
+    var actor;
+    try {
+      actor = JSON.parse(q.actor);
+    } catch (e) {
+      return null;
+    }
+    var send = function(verb, object) {
+      var st = {
+        actor: actor,
+        verb: {id: 'http://adlnet.gov/expapi/verbs/' + verb, display: {'en-US': verb}},
+        object: object,
+        context: {contextActivities: {parent: [{id: activity}]}}
+      };
+      if (q.registration) st.context.registration = q.registration;
+      var xhr = new XMLHttpRequest();
+      xhr.open('POST', endpoint + 'statements');
+      xhr.setRequestHeader('Content-Type', 'application/json');
+      xhr.setRequestHeader('X-Experience-API-Version', '1.0.3');
+      if (q.auth) xhr.setRequestHeader('Authorization', q.auth);
+      xhr.send(JSON.stringify(st));
+    };
+    var course = {id: activity, definition: {name: {'en-US': cfg.title}}};
+    var completed = false;
+    return {
+      init: function() {
+        send('attempted', course);
+        return {};
+      },
+      visit: function(step) {
+        send('experienced', {id: activity + '/step-' + (step + 1)});
+      },
+      progress: function(done) {
+        if (done && !completed) {
+          completed = true;
+          send('completed', course);
+        }
+      },
+      finish: function() {}
+    };
+  }
+
+  function update(step) {
+    step = step || 0;
+    if (!lms || visited[step]) return;
+    visited[step] = true;
+    var keys = Object.keys(visited);
+    lms.visit(step, keys.join(','));
+    lms.progress(keys.length >= total, keys.length / total);
+  }
+
+  window.addEventListener('load', function() {
+    total = document.querySelectorAll('google-codelab-step').length || 1;
+    if (cfg.version === 'xapi') {
+      lms = xapi();
+    } else {
+      var v12 = cfg.version === '1.2';
+      var api = findAPI(v12 ? 'API' : 'API_1484_11');
+      lms = api && scorm(api, v12);
+    }
+    if (!lms) return;
+    var state = lms.init();
+    (state.data || '').split(',').forEach(function(s) {
+      if (s !== '') visited[s] = true;
+    });
+    var codelab = document.querySelector('google-codelab');
+    if (!codelab) return;
+    if (state.location && !location.hash) codelab.setAttribute('selected', state.location);
+    codelab.addEventListener('google-codelab-pageview', function(e) {
+      update(parseInt(e.detail.page.split('#').pop(), 10));
+    });
+    update(parseInt(codelab.getAttribute('selected'), 10));
+  });
+  window.addEventListener('beforeunload', function() {
+    if (lms) lms.finish();
+    lms = null;
+  });
+})();
+`
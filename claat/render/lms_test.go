@@ -0,0 +1,126 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestLMS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-lms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "img"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "img", "a.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format string
+		desc   string // package descriptor file
+		want   []string
+	}{
+		{"scorm", "imsmanifest.xml", []string{
+			`<manifest identifier="claat-my_lab" version="1" xmlns="http://www.imsproject.org/xsd/imscp_rootv1p1p2"`,
+			`<schemaversion>1.2</schemaversion>`,
+			`<title>Lab &amp; Co</title>`,
+			`<resource identifier="claat-my_lab-sco" type="webcontent" adlcp:scormtype="sco" href="index.html">` +
+				`<file href="img/a.png"/><file href="index.html"/><file href="lms.js"/></resource>`,
+		}},
+		{"scorm2004", "imsmanifest.xml", []string{
+			`<schemaversion>2004 4th Edition</schemaversion>`,
+			`adlcp:scormType="sco"`,
+		}},
+		{"xapi", "tincan.xml", []string{
+			`<activity id="urn:claat:my lab" type="http://adlnet.gov/expapi/activities/course"><name>Lab &amp; Co</name>`,
+			`<launch lang="en-US">index.html</launch>`,
+		}},
+	}
+	for _, test := range tests {
+		data := &Context{
+			Dir:  dir,
+			Meta: &types.Meta{ID: "my lab", Title: "Lab & Co"},
+			Steps: []*types.Step{
+				{Title: "Intro", Content: types.NewListNode(types.NewImageNode("img/a.png"))},
+				{Title: "End", Content: types.NewListNode(types.NewImageNode("https://example.com/b.png"))},
+			},
+		}
+		var buf bytes.Buffer
+		if err := Execute(&buf, test.format, data); err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+		files := map[string]string{}
+		var names []string
+		for _, f := range zr.File {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadAll(r)
+			r.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[f.Name] = string(b)
+			names = append(names, f.Name)
+		}
+		if want := []string{test.desc, "img/a.png", "index.html", "lms.js"}; strings.Join(names, " ") != strings.Join(want, " ") {
+			t.Errorf("%s: files = %v; want %v", test.format, names, want)
+		}
+		d := xml.NewDecoder(strings.NewReader(files[test.desc]))
+		for {
+			if _, err := d.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %s: %v", test.format, test.desc, err)
+			}
+		}
+		for _, want := range test.want {
+			if !strings.Contains(files[test.desc], want) {
+				t.Errorf("%s: %s does not contain %q:\n%s", test.format, test.desc, want, files[test.desc])
+			}
+		}
+		index := files["index.html"]
+		i := strings.Index(index, `<script src="lms.js"></script>`)
+		if i < 0 || i > strings.LastIndex(index, "</body>") {
+			t.Errorf("%s: index.html does not load lms.js in body:\n%s", test.format, index)
+		}
+		if !strings.Contains(index, `"version":"`) || !strings.Contains(index, "<google-codelab-step") {
+			t.Errorf("%s: index.html is missing tracking config or steps:\n%s", test.format, index)
+		}
+		// google-codelab selects steps with the history API, not the hash
+		if js := files["lms.js"]; !strings.Contains(js, "'google-codelab-pageview'") || strings.Contains(js, "hashchange") {
+			t.Errorf("%s: lms.js does not track google-codelab-pageview events", test.format)
+		}
+	}
+}
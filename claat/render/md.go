@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/googlecodelabs/tools/claat/types"
 )
@@ -39,7 +40,11 @@ func MD(ctx Context, nodes ...types.Node) (string, error) {
 // WriteMD does the same as MD but outputs rendered markup to w.
 func WriteMD(w io.Writer, env string, nodes ...types.Node) error {
 	mw := mdWriter{w: w, env: env, Prefix: ""}
-	return mw.write(nodes...)
+	if err := mw.write(nodes...); err != nil {
+		return err
+	}
+	mw.footnotes()
+	return mw.err
 }
 
 type mdWriter struct {
@@ -50,17 +55,23 @@ type mdWriter struct {
 	isWritingTableCell bool   // used to override lineStart for correct cell formatting
 	isWritingList      bool  // used for override newblock when needed
 	Prefix             string // prefix for e.g. blockquote content
+	terms              []*types.TermNode // glossary terms to write as footnotes
+	embeds             bool              // write images as Obsidian ![[src]] embeds
+	fidelity           bool              // write all nodes in syntax the md parser reads back, see mdFullRenderer
+	trailing           string            // trailing spaces of text, dropped at line ends in fidelity mode
 }
 
 func (mw *mdWriter) writeBytes(b []byte) {
 	if mw.err != nil {
 		return
 	}
+	mw.trailing = ""
 	mw.lineStart = len(b) > 0 && b[len(b)-1] == '\n'
 	_, mw.err = mw.w.Write(b)
 }
 
 func (mw *mdWriter) writeString(s string) {
+	s, mw.trailing = mw.trailing+s, ""
 	if mw.lineStart {
 		s = mw.Prefix + s
 	}
@@ -112,6 +123,12 @@ func (mw *mdWriter) write(nodes ...types.Node) error {
 		case *types.ListNode:
 			mw.list(n)
 		case *types.ImportNode:
+			if mw.fidelity && mdImport(n.URL) {
+				mw.newBlock()
+				mw.writeString("<<" + n.URL + ">>")
+				mw.writeBytes(newLine)
+				break
+			}
 			if len(n.Content.Nodes) == 0 {
 				break
 			}
@@ -128,6 +145,12 @@ func (mw *mdWriter) write(nodes ...types.Node) error {
 			mw.header(n)
 		case *types.YouTubeNode:
 			mw.youtube(n)
+		case *types.TermNode:
+			mw.term(n)
+		case *types.IframeNode:
+			if mw.fidelity {
+				mw.iframe(n)
+			}
 		}
 		if mw.err != nil {
 			return mw.err
@@ -166,11 +189,31 @@ func (mw *mdWriter) text(n *types.TextNode) {
 		mw.writeString("**")
 	}
 
+	if mw.fidelity && strings.Trim(right, " ") == "" {
+		// the md parser reads spaces before a line end as part of the line break
+		mw.trailing = right
+		return
+	}
 	mw.writeString(right)
 }
 
 func (mw *mdWriter) image(n *types.ImageNode) {
-	mw.space()
+	if !mw.fidelity {
+		// text nodes keep their spaces in fidelity mode
+		mw.space()
+	}
+	if n.Placeholder != "" {
+		mw.writeString(fmt.Sprintf("![](placeholder: %q)", n.Placeholder))
+		return
+	}
+	if mw.embeds {
+		mw.writeString("![[" + n.Src)
+		if n.Width > 0 {
+			mw.writeString(fmt.Sprintf("|%.0f", n.Width))
+		}
+		mw.writeString("]]")
+		return
+	}
 	mw.writeString("<img ")
 	mw.writeString(fmt.Sprintf("src=%q ", n.Src))
 
@@ -193,7 +236,10 @@ func (mw *mdWriter) image(n *types.ImageNode) {
 }
 
 func (mw *mdWriter) url(n *types.URLNode) {
-	mw.space()
+	if !mw.fidelity {
+		// text nodes keep their spaces in fidelity mode
+		mw.space()
+	}
 	if n.URL != "" {
 		// Look-ahead for button syntax.
 		if _, ok := n.Content.Nodes[0].(*types.ButtonNode); ok {
@@ -222,9 +268,31 @@ func (mw *mdWriter) code(n *types.CodeNode) {
 	mw.writeString("```")
 	if n.Term {
 		mw.writeString("console")
+	} else if mw.fidelity {
+		mw.writeString(strings.TrimPrefix(n.Lang, "language-"))
 	} else {
 		mw.writeString(n.Lang)
 	}
+	if n.Title != "" {
+		mw.writeString(" title=")
+		if strings.ContainsAny(n.Title, " \t") {
+			mw.writeString(strconv.Quote(n.Title))
+		} else {
+			mw.writeString(n.Title)
+		}
+	}
+	if mw.fidelity && n.Src != "" {
+		mw.writeString(" src=" + n.Src)
+	}
+	// diff line highlights are computed by the parser
+	if strings.TrimPrefix(n.Lang, "language-") != "diff" {
+		if len(n.Added) > 0 {
+			mw.writeString(" added=" + lineRanges(n.Added))
+		}
+		if len(n.Removed) > 0 {
+			mw.writeString(" removed=" + lineRanges(n.Removed))
+		}
+	}
 	mw.writeBytes(newLine)
 	mw.writeString(n.Value)
 	if !mw.lineStart {
@@ -233,6 +301,25 @@ func (mw *mdWriter) code(n *types.CodeNode) {
 	mw.writeString("```")
 }
 
+// lineRanges formats sorted line numbers as a comma-separated list
+// of numbers and ranges, e.g. "1,3-5".
+func lineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); i++ {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		}
+		i = j
+	}
+	return strings.Join(parts, ",")
+}
+
 func (mw *mdWriter) list(n *types.ListNode) {
 	if n.Block() == true {
 		mw.newBlock()
@@ -244,22 +331,34 @@ func (mw *mdWriter) list(n *types.ListNode) {
 }
 
 func (mw *mdWriter) itemsList(n *types.ItemsListNode) {
+	nested := mw.isWritingList
 	mw.isWritingList = true
-	if n.Block() == true {
+	if nested {
+		// a blank line would make the parent list loose
+		if !mw.lineStart {
+			mw.writeBytes(newLine)
+		}
+	} else if n.Block() == true {
 		mw.newBlock()
 	}
+	prefix := mw.Prefix
 	for i, item := range n.Items {
 		s := "* "
 		if n.Type() == types.NodeItemsList && n.Start > 0 {
 			s = strconv.Itoa(i+n.Start) + ". "
+		} else if mw.fidelity && n.Type() == types.NodeItemsList && n.ListType != "" {
+			s = strconv.Itoa(i+1) + ". "
 		}
 		mw.writeString(s)
+		// indent nested blocks to the item content
+		mw.Prefix = prefix + strings.Repeat(" ", len(s))
 		mw.write(item.Nodes...)
+		mw.Prefix = prefix
 		if !mw.lineStart {
 			mw.writeBytes(newLine)
 		}
 	}
-	mw.isWritingList = false
+	mw.isWritingList = nested
 }
 
 func (mw *mdWriter) infobox(n *types.InfoboxNode) {
@@ -274,7 +373,9 @@ func (mw *mdWriter) infobox(n *types.InfoboxNode) {
 	}
 	mw.Prefix = "> "
 	mw.writeString(k)
-	mw.writeString("\n")
+	if !mw.fidelity || !mdSpaceFirst(n.Content) {
+		mw.writeString("\n")
+	}
 
 	for _, cn := range n.Content.Nodes {
 		cn.MutateBlock(false)
@@ -286,7 +387,16 @@ func (mw *mdWriter) infobox(n *types.InfoboxNode) {
 
 func (mw *mdWriter) survey(n *types.SurveyNode) {
 	mw.newBlock()
-	mw.writeString("<form>")
+	mw.writeString("<form")
+	if mw.fidelity && n.Bank != "" {
+		mw.writeString(" bank=\"")
+		mw.writeEscape(n.Bank)
+		mw.writeString("\"")
+	}
+	if n.Pick > 0 {
+		mw.writeString(fmt.Sprintf(" pick=\"%d\"", n.Pick))
+	}
+	mw.writeString(">")
 	mw.writeBytes(newLine)
 	for _, g := range n.Groups {
 		mw.writeString("<name>")
@@ -320,6 +430,28 @@ func (mw *mdWriter) youtube(n *types.YouTubeNode) {
 	mw.writeString(fmt.Sprintf(`<video id="%s"></video>`, n.VideoID))
 }
 
+// iframe writes n as an image with the frame URL and attributes
+// as alt text, which the md parser reads as an iframe of allowed hosts.
+func (mw *mdWriter) iframe(n *types.IframeNode) {
+	mw.newBlock()
+	alt := n.URL
+	if n.Width != "" {
+		alt += " width=" + n.Width
+	}
+	if n.Height != "" {
+		alt += " height=" + n.Height
+	}
+	if n.Sandboxed || n.Sandbox != "" {
+		alt += " sandbox=" + strings.Join(strings.Fields(n.Sandbox), ",")
+	}
+	if n.Title != "" {
+		mw.writeString(fmt.Sprintf("![%s](%s %q)", alt, n.URL, n.Title))
+	} else {
+		mw.writeString(fmt.Sprintf("![%s](%s)", alt, n.URL))
+	}
+	mw.writeBytes(newLine)
+}
+
 func (mw *mdWriter) table(n *types.GridNode) {
 	mw.writeBytes(newLine)
 	for rowIndex, row := range n.Rows {
@@ -347,3 +479,63 @@ func (mw *mdWriter) table(n *types.GridNode) {
 		mw.isWritingTableCell = false
 	}
 }
+
+// term writes n as a footnote reference.
+// Definitions are written at the end of the content by footnotes.
+func (mw *mdWriter) term(n *types.TermNode) {
+	if mw.fidelity {
+		mw.writeString(fmt.Sprintf("[[%s|%s]]", n.Term, n.Definition))
+		return
+	}
+	mw.writeString(n.Term)
+	mw.writeString(fmt.Sprintf("[^%s]", termLabel(n)))
+	for _, t := range mw.terms {
+		if termLabel(t) == termLabel(n) {
+			return
+		}
+	}
+	mw.terms = append(mw.terms, n)
+}
+
+// footnotes writes definitions of all glossary terms written so far.
+func (mw *mdWriter) footnotes() {
+	if len(mw.terms) == 0 {
+		return
+	}
+	mw.newBlock()
+	for _, n := range mw.terms {
+		mw.writeString(fmt.Sprintf("[^%s]: %s", termLabel(n), n.Definition))
+		mw.writeBytes(newLine)
+	}
+}
+
+// termLabel returns a footnote label of glossary term n.
+func termLabel(n *types.TermNode) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(n.Term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// mdImport reports whether url is a Markdown fragment the md parser
+// imports with the <<url>> syntax.
+func mdImport(url string) bool {
+	if i := strings.Index(url, "#"); i >= 0 {
+		url = url[:i]
+	}
+	return strings.HasSuffix(url, ".md")
+}
+
+// mdSpaceFirst reports whether content l starts with a space in a paragraph.
+// The md parser reads the line break after an infobox kind as such a space,
+// so the paragraph is written on the kind line in fidelity mode.
+func mdSpaceFirst(l *types.ListNode) bool {
+	if len(l.Nodes) == 0 {
+		return false
+	}
+	p, ok := l.Nodes[0].(*types.ListNode)
+	if !ok || len(p.Nodes) == 0 {
+		return false
+	}
+	t, ok := p.Nodes[0].(*types.TextNode)
+	return ok && strings.HasPrefix(t.Value, " ")
+}
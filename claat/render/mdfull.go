@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("md-full", mdFullRenderer{})
+}
+
+// mdFullRenderer renders a codelab as high-fidelity Markdown.
+//
+// Unlike the md format, every node is written in the claat Markdown syntax
+// the md parser reads back, including claat extensions for surveys, iframes,
+// glossary terms and imports, and "Environment:" lines for nodes limited
+// to environments. Parsing the output results in the same codelab,
+// which makes the format suitable for migrating codelabs to Markdown,
+// e.g. from Google Docs.
+type mdFullRenderer struct{}
+
+func (mdFullRenderer) Ext() string { return "md" }
+
+func (mdFullRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "---\n%s\n---\n\n# %s\n", metaHeaderYaml(m, true), m.Title)
+	mw := mdWriter{w: &b, env: ctx.Env, fidelity: true}
+	for _, st := range ctx.Steps {
+		if !mw.matchEnv(st.Tags) {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n", st.Title)
+		// estimated durations are estimated again when parsed
+		if st.Duration > 0 && !st.Estimated {
+			fmt.Fprintf(&b, "Duration: %.0f\n", math.Ceil(st.Duration.Minutes()))
+		}
+		mw.lineStart = true
+		if err := mw.writeTop(st.Content.Nodes); err != nil {
+			return err
+		}
+		if !mw.lineStart {
+			mw.writeBytes(newLine)
+		}
+	}
+	if mw.err != nil {
+		return mw.err
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeTop writes top level nodes of a step, preceded by "Environment:"
+// lines where environments of the nodes change.
+// The md parser applies environments to all following nodes
+// until the next header or environment line, and to the header preceding
+// the line.
+func (mw *mdWriter) writeTop(nodes []types.Node) error {
+	var env []string // environments of the following nodes
+	for _, n := range nodes {
+		if !mw.matchEnv(n.Env()) {
+			continue
+		}
+		if _, ok := n.(*types.HeaderNode); ok {
+			if err := mw.write(n); err != nil {
+				return err
+			}
+			env = nil
+			if len(n.Env()) > 0 {
+				env = n.Env()
+				mw.envLine(env)
+			}
+			continue
+		}
+		if strings.Join(n.Env(), ",") != strings.Join(env, ",") {
+			env = n.Env()
+			mw.envLine(env)
+		}
+		if err := mw.write(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// envLine writes an "Environment:" line of environments env,
+// none meaning all environments.
+func (mw *mdWriter) envLine(env []string) {
+	mw.newBlock()
+	mw.writeString("Environment: " + strings.Join(env, ", "))
+	mw.writeBytes(newLine)
+}
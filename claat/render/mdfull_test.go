@@ -0,0 +1,198 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googlecodelabs/tools/claat/parser"
+	_ "github.com/googlecodelabs/tools/claat/parser/md"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+const mdFullSource = `id: round-trip
+summary: All the nodes
+status: draft,published
+authors: Jane Doe
+categories: web,testing
+tags: kiosk,web
+feedback link: https://example.com/feedback
+
+# Round Trip
+
+## Overview
+Duration: 5
+
+Text with **bold**, *italic*, ` + "`code`" + ` and a [link](https://example.com/a).
+Glossary [[CLI|command line interface]] terms.
+
+<button>[Download SDK](https://example.com/sdk.zip)</button>
+
+![An image](img/pic.png "Caption")
+
+![](placeholder: "login screen")
+
+### What you'll learn
+
+* one
+* two
+
+### Frequently asked questions
+
+* [How?](https://example.com/faq)
+
+## Details
+Duration: 3
+
+1. first
+2. second
+
+3. third
+
+5. five
+6. six
+
+- a
+- b
+  - nested
+  - list
+- c
+
+<img src="img/wide.png" alt="Wide" width="200">
+
+<<code: src/main.go#setup>>
+
+` + "```go title=main.go added=2\npackage main\n\nfunc main() {}\n```" + `
+
+` + "```console\n$ go run .\n```" + `
+
+> aside positive
+> Positive **note**.
+
+Between infoboxes.
+
+> aside negative
+> Careful.
+
+| Name | Value |
+| --- | --- |
+| a | ` + "`1`" + ` |
+
+<form pick="1">
+<name>Question?</name>
+<input value="Yes">
+<input value="No">
+</form>
+
+<form bank="questions.md" pick="2">
+</form>
+
+<video id="dQw4w9WgXcQ"></video>
+
+![https://www.google.com/maps/embed width=600 height=100% sandbox=allow-scripts](https://www.google.com/maps/embed)
+
+Environment: web
+
+Web only paragraph.
+
+### Kiosk header
+Environment: kiosk
+
+Kiosk only.
+
+Environment:
+
+Everywhere again.
+
+<<fragment.md>>
+`
+
+// TestMDFullRoundTrip makes sure parsing md-full output results
+// in the parsed codelab.
+func TestMDFullRoundTrip(t *testing.T) {
+	// block parents are HTML nodes of the parsed source,
+	// and positions are lines of the source, which output reformats
+	ignoreBlock := cmp.FilterPath(func(p cmp.Path) bool {
+		f, ok := p.Last().(cmp.StructField)
+		return ok && (f.Name() == "block" || f.Name() == "pos" || f.Name() == "Pos" || f.Name() == "KeyPos")
+	}, cmp.Ignore())
+	allFields := cmp.Exporter(func(reflect.Type) bool { return true })
+
+	for _, mdp := range []parser.MarkdownParser{parser.Blackfriday, parser.Goldmark} {
+		for _, wpm := range []int{0, parser.DefaultReadingWPM} {
+			mdFullRoundTrip(t, mdp, wpm, ignoreBlock, allFields)
+		}
+	}
+}
+
+// mdFullRoundTrip parses mdFullSource with mdp, estimating durations at wpm,
+// and compares the codelab with its md-full output parsed back.
+func mdFullRoundTrip(t *testing.T, mdp parser.MarkdownParser, wpm int, cmpOpts ...cmp.Option) {
+	t.Helper()
+	opts := *parser.NewOptions(mdp)
+	opts.ReadingWPM = wpm
+	want, err := parser.Parse("md", strings.NewReader(mdFullSource), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := Execute(&b, "md-full", &Context{Meta: &want.Meta, Steps: want.Steps}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parser.Parse("md", bytes.NewReader(b.Bytes()), opts)
+	if err != nil {
+		t.Fatalf("%v: %v:\n%s", mdp, err, b.String())
+	}
+	if diff := cmp.Diff(want, got, cmpOpts...); diff != "" {
+		t.Errorf("%v, %d wpm: parsed output differs (-want +got):\n%s\noutput:\n%s", mdp, wpm, diff, b.String())
+	}
+}
+
+// TestMetaHeaderYaml makes sure the md formats keep their header,
+// while md-full writes the status and badge path the md parser reads back.
+func TestMetaHeaderYaml(t *testing.T) {
+	status := types.LegacyStatus{"draft", "published"}
+	m := &types.Meta{ID: "id", Status: &status, BadgePath: "badges/a"}
+	if h, want := metaHeaderYaml(m, false), "id: id\nstatus: [draft,published]\n"; h != want {
+		t.Errorf("metaHeaderYaml(m, false) = %q; want %q", h, want)
+	}
+	if h, want := metaHeaderYaml(m, true), "id: id\nstatus: draft,published\nbadge path: badges/a\n"; h != want {
+		t.Errorf("metaHeaderYaml(m, true) = %q; want %q", h, want)
+	}
+}
+
+// TestMDEstimatedDuration makes sure estimated step durations are not
+// written, so that steps with no duration are read back as such.
+func TestMDEstimatedDuration(t *testing.T) {
+	steps := []*types.Step{
+		{Title: "Set", Duration: 2 * time.Minute, Content: types.NewListNode()},
+		{Title: "Estimated", Duration: time.Minute, Estimated: true, Content: types.NewListNode()},
+	}
+	for _, f := range []string{"md", "md-full"} {
+		var b bytes.Buffer
+		data := &struct{ Context }{Context{Meta: &types.Meta{}, Steps: steps}}
+		if err := Execute(&b, f, data); err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(b.String(), "Duration:"); n != 1 {
+			t.Errorf("%s: %d durations; want 1:\n%s", f, n, b.String())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Obsidian renders nodes as markdown suitable for an Obsidian vault.
+// It is the same as MD except images are written as ![[src]] embeds.
+func Obsidian(ctx Context, nodes ...types.Node) (string, error) {
+	var buf bytes.Buffer
+	mw := mdWriter{w: &buf, env: ctx.Env, embeds: true}
+	if err := mw.write(nodes...); err != nil {
+		return "", err
+	}
+	mw.footnotes()
+	return buf.String(), mw.err
+}
+
+// ObsidianIndexNote returns the name of the note linking to all steps
+// of codelab m, without the ".md" extension.
+func ObsidianIndexNote(m *types.Meta) string {
+	name := noteName(m.Title)
+	if name == "" {
+		name = noteName(m.ID)
+	}
+	return name
+}
+
+// ObsidianStepNote returns the name of the note of step n, 1-based,
+// without the ".md" extension.
+// Step notes are prefixed with the index note name to avoid clashes
+// across codelabs sharing a vault.
+func ObsidianStepNote(m *types.Meta, steps []*types.Step, n int) string {
+	var title string
+	if n > 0 && n <= len(steps) {
+		title = steps[n-1].Title
+	}
+	return noteName(fmt.Sprintf("%s %02d %s", ObsidianIndexNote(m), n, title))
+}
+
+// noteNameReplacer strips characters not allowed in note names or wikilinks.
+var noteNameReplacer = strings.NewReplacer(
+	"*", "", "\"", "", "\\", "", "/", "", "<", "", ">", "",
+	":", "", "|", "", "?", "", "#", "", "^", "", "[", "", "]", "",
+)
+
+// noteName returns s usable as an Obsidian note file name.
+func noteName(s string) string {
+	return strings.Join(strings.Fields(noteNameReplacer.Replace(s)), " ")
+}
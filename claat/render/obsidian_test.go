@@ -0,0 +1,48 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestObsidianNotes(t *testing.T) {
+	meta := &types.Meta{ID: "my-lab", Title: "My Lab: Part 1/2"}
+	steps := []*types.Step{{Title: "Intro"}, {Title: "What's [next]?"}}
+	if v := ObsidianIndexNote(meta); v != "My Lab Part 12" {
+		t.Errorf("ObsidianIndexNote = %q", v)
+	}
+	if v := ObsidianStepNote(meta, steps, 2); v != "My Lab Part 12 02 What's next" {
+		t.Errorf("ObsidianStepNote = %q", v)
+	}
+	if v := ObsidianIndexNote(&types.Meta{ID: "my-lab"}); v != "my-lab" {
+		t.Errorf("ObsidianIndexNote(no title) = %q", v)
+	}
+}
+
+func TestObsidianImageEmbed(t *testing.T) {
+	img := types.NewImageNode("img/abc.png")
+	img.Width = 300
+	out, err := Obsidian(Context{}, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "![[img/abc.png|300]]"; !strings.Contains(out, want) {
+		t.Errorf("Obsidian(img) = %q; want %q", out, want)
+	}
+}
@@ -0,0 +1,996 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register gif decoder
+	_ "image/jpeg" // register jpeg decoder
+	_ "image/png"  // register png decoder
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/googlecodelabs/tools/claat/qr"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("pdf", pdfRenderer{})
+}
+
+// pdfRenderer renders a codelab as a paginated PDF document
+// for offline workshops and printed handouts.
+//
+// The document is set in the standard PDF fonts, Helvetica and Courier,
+// which cover the characters of the Windows-1252 code page; other characters
+// are printed as "?", and reported once with Context.Warn. The first page lists the codelab steps, each of which
+// starts on a new page. With Context.QRURL, the first page has a QR code
+// linking to the published codelab. Images are embedded from local files, relative to
+// Context.Dir; remote images are replaced with their alt text.
+type pdfRenderer struct{}
+
+func (pdfRenderer) Ext() string { return "pdf" }
+
+func (pdfRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	pw := &pdfWriter{env: ctx.Env, dir: ctx.Dir, size: pdfTextSize}
+	steps := envSteps(ctx.Steps, ctx.Env)
+	pw.titlePage(m, steps)
+	if ctx.QRURL != "" {
+		if err := pw.qrCode(pw.pages[0], ctx.QRURL); err != nil {
+			return err
+		}
+	}
+	for i, st := range steps {
+		pw.newPage()
+		pw.stepPages = append(pw.stepPages, len(pw.pages)-1)
+		pw.heading(fmt.Sprintf("%d. %s", i+1, st.Title), 18)
+		pw.write(st.Content.Nodes...)
+		pw.flush()
+	}
+	if err := pw.output(w, m); err != nil {
+		return err
+	}
+	if len(pw.missing) > 0 && ctx.Warn != nil {
+		var chars []string
+		for _, r := range pw.missing {
+			chars = append(chars, strconv.QuoteRune(r))
+		}
+		ctx.Warn("pdf: %d characters printed as \"?\", which the standard fonts lack: %s",
+			len(chars), strings.Join(chars, " "))
+	}
+	return nil
+}
+
+const (
+	pdfPageWidth  = 595 // A4, in points
+	pdfPageHeight = 842
+	pdfMargin     = 56
+	pdfTextSize   = 10.5
+	pdfCodeSize   = 9
+	pdfLineHeight = 1.4      // relative to font size
+	pdfPixel      = 0.75     // points per CSS pixel
+	pdfMaxPixels  = 50000000 // of embedded images, bounding decoding memory
+)
+
+// pdfFont is one of the standard fonts used by the document.
+type pdfFont int
+
+const (
+	pdfRegular pdfFont = iota
+	pdfBold
+	pdfItalic
+	pdfBoldItalic
+	pdfMono
+)
+
+// pdfFontNames are base font names, in the order of pdfFont values.
+var pdfFontNames = [...]string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique", "Courier"}
+
+// pdfColor is an RGB color with components in [0, 1].
+type pdfColor [3]float64
+
+var (
+	pdfBlack       = pdfColor{0, 0, 0}
+	pdfGrey        = pdfColor{0.4, 0.4, 0.4}
+	pdfLinkColor   = pdfColor{0.1, 0.35, 0.8}
+	pdfCodeBg      = pdfColor{0.95, 0.95, 0.95}
+	pdfConsoleBg   = pdfColor{0.9, 0.92, 0.95}
+	pdfPositiveBg  = pdfColor{0.9, 0.96, 0.9}
+	pdfNegativeBg  = pdfColor{1, 0.95, 0.88}
+	pdfRuleColor   = pdfColor{0.8, 0.8, 0.8}
+	pdfPlaceholder = pdfColor{0.88, 0.88, 0.88}
+)
+
+// fill returns the content stream operator setting c as the fill color.
+func (c pdfColor) fill() string {
+	return fmt.Sprintf("%.2f %.2f %.2f rg", c[0], c[1], c[2])
+}
+
+// stroke returns the content stream operator setting c as the stroke color.
+func (c pdfColor) stroke() string {
+	return fmt.Sprintf("%.2f %.2f %.2f RG", c[0], c[1], c[2])
+}
+
+// pdfSpan is a run of inline text of the same style.
+type pdfSpan struct {
+	text  string
+	font  pdfFont
+	size  float64
+	color pdfColor
+	link  string // link target URL, if any
+}
+
+// pdfWord is a word or a space of a line, measured in points.
+type pdfWord struct {
+	span  pdfSpan
+	text  []byte // Windows-1252 encoded
+	width float64
+}
+
+type pdfPage struct {
+	content bytes.Buffer // content stream
+	links   []pdfLink
+}
+
+// pdfLink is a link annotation of a page.
+type pdfLink struct {
+	rect [4]float64
+	url  string
+}
+
+// pdfImage is an embedded image XObject.
+type pdfImage struct {
+	name string // resource name, e.g. "Im1"
+	w, h int
+	data []byte // zlib-compressed RGB samples
+}
+
+type pdfWriter struct {
+	env       string  // target environment
+	dir       string  // directory of relative image sources
+	size      float64 // font size of inline text
+	pages     []*pdfPage
+	y         float64   // top of the next line on the current page
+	indent    float64   // left indent of block content
+	bg        *pdfColor // background of block content lines, if any
+	marker    string    // list item marker of the next line, if any
+	link      string    // target of inline text being written, if any
+	spans     []pdfSpan // inline content of the current block
+	images    []*pdfImage
+	imageSrc  map[string]*pdfImage // images by file path
+	stepPages []int                // page index of each step
+	missing   []rune               // characters out of the code page, in order of appearance
+}
+
+func (pw *pdfWriter) matchEnv(v []string) bool {
+	if len(v) == 0 || pw.env == "" {
+		return true
+	}
+	i := sort.SearchStrings(v, pw.env)
+	return i < len(v) && v[i] == pw.env
+}
+
+func (pw *pdfWriter) page() *pdfPage {
+	return pw.pages[len(pw.pages)-1]
+}
+
+func (pw *pdfWriter) newPage() {
+	pw.pages = append(pw.pages, &pdfPage{})
+	pw.y = pdfPageHeight - pdfMargin
+}
+
+// left returns x coordinate of the current block content.
+func (pw *pdfWriter) left() float64 {
+	return pdfMargin + pw.indent
+}
+
+// ensure starts a new page if less than h points are left on the current one.
+func (pw *pdfWriter) ensure(h float64) {
+	if pw.y-h < pdfMargin {
+		pw.newPage()
+	}
+}
+
+// gap adds vertical space of h points, unless at the top of a page.
+func (pw *pdfWriter) gap(h float64) {
+	if pw.y >= pdfPageHeight-pdfMargin {
+		return
+	}
+	pw.ensure(h)
+	pw.background(h)
+	pw.y -= h
+}
+
+// background fills the next h points of the current block with pw.bg, if set.
+func (pw *pdfWriter) background(h float64) {
+	if pw.bg == nil {
+		return
+	}
+	fmt.Fprintf(&pw.page().content, "%s %.2f %.2f %.2f %.2f re f\n",
+		pw.bg.fill(), pw.left()-6, pw.y-h, pdfPageWidth-pdfMargin-pw.left()+12, h)
+}
+
+// block writes content of f indented by indent points, on background bg if not nil.
+func (pw *pdfWriter) block(bg *pdfColor, indent float64, f func()) {
+	pw.flush()
+	obg, oindent := pw.bg, pw.indent
+	pw.bg = bg
+	pw.indent += indent
+	f()
+	pw.flush()
+	pw.bg, pw.indent = obg, oindent
+}
+
+// add appends inline text s in font to the current block.
+func (pw *pdfWriter) add(s string, font pdfFont) {
+	sp := pdfSpan{text: s, font: font, size: pw.size, color: pdfBlack, link: pw.link}
+	if font == pdfMono {
+		sp.size = pw.size * 0.95
+	}
+	if sp.link != "" {
+		sp.color = pdfLinkColor
+	}
+	pw.spans = append(pw.spans, sp)
+}
+
+// flush lays out inline content of the current block in lines,
+// wrapped at word boundaries.
+func (pw *pdfWriter) flush() {
+	spans := pw.spans
+	pw.spans = nil
+	if len(spans) == 0 {
+		return
+	}
+	width := pdfPageWidth - pdfMargin - pw.left()
+	var line []pdfWord
+	var lw float64
+	emit := func(size float64) {
+		for len(line) > 0 && isPDFSpace(line[len(line)-1].text) {
+			line = line[:len(line)-1]
+		}
+		pw.line(line, size)
+		line, lw = nil, 0
+	}
+	for _, sp := range spans {
+		for _, tok := range splitPDFWords(sp.text) {
+			if tok == "\n" {
+				emit(sp.size)
+				continue
+			}
+			b := pw.encode(tok)
+			if len(line) == 0 && isPDFSpace(b) {
+				continue
+			}
+			wd := pdfTextWidth(sp.font, sp.size, b)
+			if lw+wd > width && len(line) > 0 && !isPDFSpace(b) {
+				emit(sp.size)
+			}
+			// break words longer than a line
+			for wd > width && len(b) > 1 {
+				n := len(b) - 1
+				for n > 1 && pdfTextWidth(sp.font, sp.size, b[:n]) > width {
+					n--
+				}
+				pw.line([]pdfWord{{sp, b[:n], pdfTextWidth(sp.font, sp.size, b[:n])}}, sp.size)
+				b = b[n:]
+				wd = pdfTextWidth(sp.font, sp.size, b)
+			}
+			line = append(line, pdfWord{sp, b, wd})
+			lw += wd
+		}
+	}
+	if len(line) > 0 {
+		emit(spans[len(spans)-1].size)
+	}
+}
+
+// line writes a single line of words at the current position.
+// Empty lines are size points high, times pdfLineHeight.
+func (pw *pdfWriter) line(words []pdfWord, size float64) {
+	for _, w := range words {
+		if w.span.size > size {
+			size = w.span.size
+		}
+	}
+	lh := size * pdfLineHeight
+	pw.ensure(lh)
+	pw.background(lh)
+	p := pw.page()
+	base := pw.y - lh + (lh-size)/2 + size*0.2
+	x := pw.left()
+	if pw.marker != "" {
+		b := pw.encode(pw.marker)
+		fmt.Fprintf(&p.content, "BT /F%d %.2f Tf %s %.2f %.2f Td (%s) Tj ET\n",
+			pdfRegular+1, pw.size, pdfBlack.fill(), x-pdfTextWidth(pdfRegular, pw.size, b)-4, base, pdfEscape(b))
+		pw.marker = ""
+	}
+	for i := 0; i < len(words); {
+		// merge words of the same span into a single text operator
+		sp := words[i].span
+		var text []byte
+		var w float64
+		for ; i < len(words) && words[i].span == sp; i++ {
+			text = append(text, words[i].text...)
+			w += words[i].width
+		}
+		fmt.Fprintf(&p.content, "BT /F%d %.2f Tf %s %.2f %.2f Td (%s) Tj ET\n",
+			sp.font+1, sp.size, sp.color.fill(), x, base, pdfEscape(text))
+		if sp.link != "" {
+			p.links = append(p.links, pdfLink{[4]float64{x, base - 2, x + w, base + sp.size}, sp.link})
+		}
+		x += w
+	}
+	pw.y -= lh
+}
+
+// rule draws a horizontal line across the current block.
+func (pw *pdfWriter) rule() {
+	fmt.Fprintf(&pw.page().content, "%s 0.5 w %.2f %.2f m %.2f %.2f l S\n",
+		pdfRuleColor.stroke(), pw.left(), pw.y, float64(pdfPageWidth-pdfMargin), pw.y)
+}
+
+// heading writes a bold line of text s in size points.
+func (pw *pdfWriter) heading(s string, size float64) {
+	pw.flush()
+	pw.gap(size * 0.6)
+	pw.spans = append(pw.spans, pdfSpan{text: s, font: pdfBold, size: size, color: pdfBlack})
+	pw.flush()
+	pw.gap(size * 0.3)
+}
+
+// titlePage writes codelab metadata and a table of contents linking to steps.
+func (pw *pdfWriter) titlePage(m *types.Meta, steps []*types.Step) {
+	pw.newPage()
+	pw.y -= 120
+	pw.spans = append(pw.spans, pdfSpan{text: m.Title, font: pdfBold, size: 26, color: pdfBlack})
+	pw.flush()
+	if m.Summary != "" {
+		pw.gap(10)
+		pw.spans = append(pw.spans, pdfSpan{text: m.Summary, font: pdfRegular, size: 13, color: pdfGrey})
+		pw.flush()
+	}
+	pw.gap(16)
+	if m.Authors != "" {
+		pw.spans = append(pw.spans, pdfSpan{text: "Authors: " + m.Authors, font: pdfRegular, size: pdfTextSize, color: pdfGrey})
+		pw.flush()
+	}
+	if m.Duration > 0 {
+		pw.spans = append(pw.spans, pdfSpan{text: fmt.Sprintf("Duration: %d min", m.Duration), font: pdfRegular, size: pdfTextSize, color: pdfGrey})
+		pw.flush()
+	}
+	if len(steps) == 0 {
+		return
+	}
+	pw.gap(24)
+	pw.heading("Contents", 14)
+	for i, st := range steps {
+		pw.spans = append(pw.spans, pdfSpan{
+			text:  fmt.Sprintf("%d. %s", i+1, st.Title),
+			font:  pdfRegular,
+			size:  pdfTextSize,
+			color: pdfLinkColor,
+			link:  "#" + strconv.Itoa(i),
+		})
+		pw.flush()
+	}
+}
+
+// pdfQRSize is the width of QR codes, including their quiet zone, in points.
+const pdfQRSize = 108
+
+// qrCode draws a QR code of url, linking to it, in the top right corner
+// of page p, above the title.
+func (pw *pdfWriter) qrCode(p *pdfPage, url string) error {
+	c, err := qr.Encode(url)
+	if err != nil {
+		return fmt.Errorf("QR code of %s: %v", url, err)
+	}
+	x0, y0 := float64(pdfPageWidth-pdfMargin-pdfQRSize), float64(pdfPageHeight-pdfMargin)
+	unit := float64(pdfQRSize) / float64(c.Size+2*qr.QuietZone)
+	p.content.WriteString(pdfBlack.fill() + "\n")
+	for y := 0; y < c.Size; y++ {
+		// one rectangle per run of dark modules
+		for x := 0; x < c.Size; x++ {
+			if !c.Black(x, y) {
+				continue
+			}
+			n := 1
+			for c.Black(x+n, y) {
+				n++
+			}
+			fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re\n",
+				x0+float64(x+qr.QuietZone)*unit, y0-float64(y+qr.QuietZone+1)*unit, float64(n)*unit, unit)
+			x += n
+		}
+	}
+	p.content.WriteString("f\n")
+	p.links = append(p.links, pdfLink{[4]float64{x0, y0 - pdfQRSize, x0 + pdfQRSize, y0}, url})
+	return nil
+}
+
+func (pw *pdfWriter) write(nodes ...types.Node) {
+	for _, n := range nodes {
+		if !pw.matchEnv(n.Env()) {
+			continue
+		}
+		switch n := n.(type) {
+		case *types.TextNode:
+			pw.text(n)
+		case *types.ImageNode:
+			pw.image(n)
+		case *types.URLNode:
+			pw.url(n)
+		case *types.ButtonNode:
+			pw.write(n.Content.Nodes...)
+		case *types.CodeNode:
+			pw.code(n)
+		case *types.ListNode:
+			pw.list(n)
+		case *types.ImportNode:
+			pw.write(n.Content.Nodes...)
+		case *types.ItemsListNode:
+			pw.itemsList(n)
+		case *types.GridNode:
+			pw.table(n)
+		case *types.InfoboxNode:
+			pw.infobox(n)
+		case *types.SurveyNode:
+			pw.survey(n)
+		case *types.HeaderNode:
+			size := 16 - 1.5*float64(n.Level)
+			if size < pdfTextSize+1 {
+				size = pdfTextSize + 1
+			}
+			pw.heading(PlainText(n.Content.Nodes), size)
+		case *types.YouTubeNode:
+			pw.embed("https://www.youtube.com/watch?v="+n.VideoID, "YouTube video")
+		case *types.IframeNode:
+			pw.embed(n.URL, "Embedded content")
+		case *types.TermNode:
+			pw.add(n.Term, pdfRegular)
+			if n.Definition != "" {
+				pw.add(" ("+n.Definition+")", pdfItalic)
+			}
+		}
+	}
+}
+
+func (pw *pdfWriter) text(n *types.TextNode) {
+	font := pdfRegular
+	switch {
+	case n.Code:
+		font = pdfMono
+	case n.Bold && n.Italic:
+		font = pdfBoldItalic
+	case n.Bold:
+		font = pdfBold
+	case n.Italic:
+		font = pdfItalic
+	}
+	pw.add(n.Value, font)
+}
+
+func (pw *pdfWriter) url(n *types.URLNode) {
+	if n.URL == "" || strings.HasPrefix(n.URL, "#") && !isStepAnchor(n.URL) {
+		pw.write(n.Content.Nodes...)
+		return
+	}
+	olink := pw.link
+	pw.link = n.URL
+	pw.write(n.Content.Nodes...)
+	pw.link = olink
+}
+
+// isStepAnchor reports whether url is an in-codelab link to a step, e.g. "#2".
+func isStepAnchor(url string) bool {
+	if !strings.HasPrefix(url, "#") {
+		return false
+	}
+	_, err := strconv.Atoi(url[1:])
+	return err == nil
+}
+
+// embed writes a link to an embedded resource, such as a video.
+func (pw *pdfWriter) embed(url, text string) {
+	pw.flush()
+	pw.gap(4)
+	pw.add(text+": ", pdfItalic)
+	olink := pw.link
+	pw.link = url
+	pw.add(url, pdfRegular)
+	pw.link = olink
+	pw.flush()
+}
+
+func (pw *pdfWriter) list(n *types.ListNode) {
+	if n.Block() == true {
+		pw.flush()
+		pw.gap(pw.size * 0.5)
+	}
+	pw.write(n.Nodes...)
+	if n.Block() == true {
+		pw.flush()
+	}
+}
+
+func (pw *pdfWriter) itemsList(n *types.ItemsListNode) {
+	pw.flush()
+	pw.gap(pw.size * 0.3)
+	for i, item := range n.Items {
+		marker := "•"
+		if n.ListType != "" || n.Start > 0 {
+			start := n.Start
+			if start == 0 {
+				start = 1
+			}
+			marker = strconv.Itoa(i+start) + "."
+		}
+		pw.block(pw.bg, 16, func() {
+			pw.marker = marker
+			for _, cn := range item.Nodes {
+				cn.MutateBlock(false)
+			}
+			pw.write(item.Nodes...)
+		})
+	}
+}
+
+func (pw *pdfWriter) code(n *types.CodeNode) {
+	if n.Empty() {
+		return
+	}
+	pw.flush()
+	pw.gap(6)
+	if n.Title != "" {
+		pw.spans = append(pw.spans, pdfSpan{text: n.Title, font: pdfBold, size: pdfCodeSize, color: pdfGrey})
+		pw.flush()
+	}
+	bg := pdfCodeBg
+	if n.Term {
+		bg = pdfConsoleBg
+	}
+	pw.block(&bg, 6, func() {
+		width := pdfPageWidth - pdfMargin - pw.left()
+		chars := int(width / pdfTextWidth(pdfMono, pdfCodeSize, []byte{' '}))
+		sp := pdfSpan{font: pdfMono, size: pdfCodeSize, color: pdfBlack}
+		pw.gap(4)
+		for _, l := range strings.Split(strings.TrimRight(n.Value, "\n"), "\n") {
+			b := pw.encode(strings.Replace(l, "\t", "    ", -1))
+			// code lines are not wrapped at words, keeping indentation
+			for {
+				n := len(b)
+				if n > chars {
+					n = chars
+				}
+				pw.line([]pdfWord{{sp, b[:n], pdfTextWidth(pdfMono, pdfCodeSize, b[:n])}}, pdfCodeSize)
+				if b = b[n:]; len(b) == 0 {
+					break
+				}
+			}
+		}
+		pw.gap(4)
+	})
+	pw.gap(6)
+}
+
+func (pw *pdfWriter) infobox(n *types.InfoboxNode) {
+	pw.flush()
+	pw.gap(6)
+	bg := pdfPositiveBg
+	if n.Kind == types.InfoboxNegative {
+		bg = pdfNegativeBg
+	}
+	pw.block(&bg, 8, func() {
+		pw.gap(4)
+		pw.write(n.Content.Nodes...)
+		pw.flush()
+		pw.gap(4)
+	})
+	pw.gap(6)
+}
+
+func (pw *pdfWriter) table(n *types.GridNode) {
+	pw.flush()
+	pw.gap(6)
+	for _, row := range n.Rows {
+		pw.rule()
+		for j, cell := range row {
+			if j > 0 {
+				pw.add("  |  ", pdfRegular)
+			}
+			for _, cn := range cell.Content.Nodes {
+				cn.MutateBlock(false)
+				pw.write(cn)
+			}
+		}
+		pw.flush()
+	}
+	pw.rule()
+	pw.gap(6)
+}
+
+func (pw *pdfWriter) survey(n *types.SurveyNode) {
+	for _, g := range n.Picked() {
+		pw.flush()
+		pw.gap(6)
+		pw.add(g.Name, pdfBold)
+		pw.flush()
+		for _, o := range g.Options {
+			pw.add("( )  "+o, pdfRegular)
+			pw.flush()
+		}
+	}
+}
+
+func (pw *pdfWriter) image(n *types.ImageNode) {
+	if n.Placeholder != "" {
+		pw.placeholder(n.Placeholder)
+		return
+	}
+	img, err := pw.loadImage(n.Src)
+	if err != nil {
+		alt := n.Alt
+		if alt == "" {
+			alt = path.Base(n.Src)
+		}
+		pw.add("[Image: "+alt+"]", pdfItalic)
+		return
+	}
+	pw.flush()
+	w, h := float64(img.w)*pdfPixel, float64(img.h)*pdfPixel
+	if n.Width > 0 {
+		h *= float64(n.Width) * pdfPixel / w
+		w = float64(n.Width) * pdfPixel
+	}
+	maxW := pdfPageWidth - pdfMargin - pw.left()
+	maxH := float64(pdfPageHeight - 3*pdfMargin)
+	if w > maxW {
+		w, h = maxW, h*maxW/w
+	}
+	if h > maxH {
+		w, h = w*maxH/h, maxH
+	}
+	pw.ensure(h + 4)
+	pw.background(h + 4)
+	fmt.Fprintf(&pw.page().content, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q\n", w, h, pw.left(), pw.y-h-2, img.name)
+	pw.y -= h + 4
+}
+
+// placeholder draws a box in place of an image described by desc.
+func (pw *pdfWriter) placeholder(desc string) {
+	pw.flush()
+	const w, h = 240, 60
+	pw.ensure(h + 4)
+	x, y := pw.left(), pw.y-h-2
+	fmt.Fprintf(&pw.page().content, "%s %s [4 2] 0 d 1 w %.2f %.2f %.2f %.2f re B [] 0 d\n",
+		pdfPlaceholder.fill(), pdfGrey.stroke(), x, y, float64(w), float64(h))
+	pw.y -= h/2 - pdfCodeSize
+	oindent := pw.indent
+	pw.indent += 8
+	pw.spans = append(pw.spans, pdfSpan{text: "Placeholder: " + desc, font: pdfItalic, size: pdfCodeSize, color: pdfGrey})
+	pw.flush()
+	pw.indent = oindent
+	pw.y = y - 2
+}
+
+// loadImage decodes a local image file src, relative to pw.dir,
+// and embeds its samples. Remote images are not supported.
+func (pw *pdfWriter) loadImage(src string) (*pdfImage, error) {
+	if src == "" || strings.Contains(src, "://") || strings.HasPrefix(src, "data:") {
+		return nil, errors.New("not a local image")
+	}
+	p := filepath.FromSlash(src)
+	if !filepath.IsAbs(p) && pw.dir != "" {
+		p = filepath.Join(pw.dir, p)
+	}
+	if img, ok := pw.imageSrc[p]; ok {
+		return img, nil
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return nil, err
+	}
+	if int64(c.Width)*int64(c.Height) > pdfMaxPixels {
+		return nil, fmt.Errorf("%dx%d image is too large", c.Width, c.Height)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	m, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	b := m.Bounds()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	row := make([]byte, 0, 3*b.Dx())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row = row[:0]
+		for x := b.Min.X; x < b.Max.X; x++ {
+			// composite premultiplied colors on a white page
+			r, g, bl, a := m.At(x, y).RGBA()
+			row = append(row, byte((r+0xffff-a)>>8), byte((g+0xffff-a)>>8), byte((bl+0xffff-a)>>8))
+		}
+		zw.Write(row)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	img := &pdfImage{
+		name: "Im" + strconv.Itoa(len(pw.images)+1),
+		w:    b.Dx(),
+		h:    b.Dy(),
+		data: buf.Bytes(),
+	}
+	if pw.imageSrc == nil {
+		pw.imageSrc = make(map[string]*pdfImage)
+	}
+	pw.imageSrc[p] = img
+	pw.images = append(pw.images, img)
+	return img, nil
+}
+
+// footers writes the codelab title and page numbers at the bottom
+// of every page except the title page.
+func (pw *pdfWriter) footers(title string) {
+	for i, p := range pw.pages {
+		if i == 0 {
+			continue
+		}
+		t := pw.encode(title)
+		n := pw.encode(strconv.Itoa(i + 1))
+		fmt.Fprintf(&p.content, "BT /F%d 8 Tf %s %.2f %.2f Td (%s) Tj ET\n",
+			pdfRegular+1, pdfGrey.fill(), float64(pdfMargin), pdfMargin/2.0, pdfEscape(t))
+		fmt.Fprintf(&p.content, "BT /F%d 8 Tf %s %.2f %.2f Td (%s) Tj ET\n",
+			pdfRegular+1, pdfGrey.fill(), pdfPageWidth-pdfMargin-pdfTextWidth(pdfRegular, 8, n), pdfMargin/2.0, pdfEscape(n))
+	}
+}
+
+// output writes the document of all pages to w.
+//
+// Objects are numbered as follows: 1 is the catalog, 2 the page tree,
+// 3 the document info, followed by fonts, images, and a page object
+// and its content stream for each page.
+func (pw *pdfWriter) output(w io.Writer, m *types.Meta) error {
+	pw.footers(m.Title)
+	firstFont := 4
+	firstImage := firstFont + len(pdfFontNames)
+	firstPage := firstImage + len(pw.images)
+	pageObj := func(i int) int { return firstPage + 2*i }
+
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string, stream []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+	}
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	kids := make([]string, len(pw.pages))
+	for i := range pw.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj(i))
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pw.pages)), nil)
+	info := fmt.Sprintf("<< /Title (%s) /Producer (claat)", pdfEscape(pw.encode(m.Title)))
+	if m.Authors != "" {
+		info += fmt.Sprintf(" /Author (%s)", pdfEscape(pw.encode(m.Authors)))
+	}
+	obj(info+" >>", nil)
+
+	var fonts, images strings.Builder
+	for i, name := range pdfFontNames {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name), nil)
+		fmt.Fprintf(&fonts, " /F%d %d 0 R", i+1, firstFont+i)
+	}
+	for i, img := range pw.images {
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+			img.w, img.h, len(img.data)), img.data)
+		fmt.Fprintf(&images, " /%s %d 0 R", img.name, firstImage+i)
+	}
+	res := fmt.Sprintf("/Resources << /Font <<%s >> /XObject <<%s >> >>", fonts.String(), images.String())
+
+	for i, p := range pw.pages {
+		var annots strings.Builder
+		for _, l := range p.links {
+			r := l.rect
+			fmt.Fprintf(&annots, "<< /Type /Annot /Subtype /Link /Rect [%.2f %.2f %.2f %.2f] /Border [0 0 0] ", r[0], r[1], r[2], r[3])
+			if isStepAnchor(l.url) {
+				s, _ := strconv.Atoi(l.url[1:])
+				if s < 0 || s >= len(pw.stepPages) {
+					annots.WriteString(">> ")
+					continue
+				}
+				fmt.Fprintf(&annots, "/Dest [%d 0 R /XYZ null null null] >> ", pageObj(pw.stepPages[s]))
+				continue
+			}
+			fmt.Fprintf(&annots, "/A << /Type /Action /S /URI /URI (%s) >> >> ", pdfEscape([]byte(l.url)))
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] %s /Contents %d 0 R /Annots [%s] >>",
+			pdfPageWidth, pdfPageHeight, res, pageObj(i)+1, annots.String()), nil)
+		obj(fmt.Sprintf("<< /Length %d >>", p.content.Len()), p.content.Bytes())
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// splitPDFWords splits s into words, runs of spaces and line breaks.
+func splitPDFWords(s string) []string {
+	var words []string
+	start := 0
+	for i, r := range s {
+		if r == '\n' || r == ' ' || i > start && s[i-1] == ' ' {
+			if i > start {
+				words = append(words, s[start:i])
+			}
+			start = i
+		}
+		if r == '\n' {
+			words = append(words, "\n")
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+func isPDFSpace(b []byte) bool {
+	return len(bytes.TrimSpace(b)) == 0
+}
+
+// pdfCP1252 maps characters of Windows-1252 code page which differ from Latin-1.
+var pdfCP1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// encode converts s to Windows-1252, the encoding of document fonts.
+// Characters out of the code page are replaced with '?', and recorded
+// in pw.missing.
+func (pw *pdfWriter) encode(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			b = append(b, ' ')
+		case r < 0x20 || r == utf8.RuneError:
+			// skip control characters
+		case r < 0x80 || r >= 0xa0 && r <= 0xff:
+			b = append(b, byte(r))
+		default:
+			c, ok := pdfCP1252[r]
+			if !ok {
+				c = '?'
+				pw.markMissing(r)
+			}
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+func (pw *pdfWriter) markMissing(r rune) {
+	for _, m := range pw.missing {
+		if m == r {
+			return
+		}
+	}
+	pw.missing = append(pw.missing, r)
+}
+
+// pdfEscape escapes b for use in a PDF literal string.
+func pdfEscape(b []byte) []byte {
+	var out []byte
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			out = append(out, '\\')
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// pdfTextWidth returns the width of encoded text b in font of size points.
+func pdfTextWidth(font pdfFont, size float64, b []byte) float64 {
+	var w int
+	for _, c := range b {
+		w += pdfCharWidth(font, c)
+	}
+	return float64(w) * size / 1000
+}
+
+// pdfCharWidth returns the width of character c of font, in 1/1000 of font size.
+func pdfCharWidth(font pdfFont, c byte) int {
+	if font == pdfMono {
+		return 600
+	}
+	if c >= 32 && c <= 126 {
+		if font == pdfBold || font == pdfBoldItalic {
+			return helveticaBoldWidths[c-32]
+		}
+		return helveticaWidths[c-32]
+	}
+	switch c {
+	case 0x91, 0x92:
+		return 222
+	case 0x93, 0x94:
+		return 333
+	case 0x95:
+		return 350
+	case 0x85, 0x97:
+		return 1000
+	case 0xa0:
+		return 278
+	}
+	return 556
+}
+
+// helveticaWidths are widths of ASCII characters 32-126 of Helvetica
+// and Helvetica-Oblique, from the font metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBoldWidths are widths of ASCII characters 32-126 of Helvetica-Bold
+// and Helvetica-BoldOblique, from the font metrics.
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,230 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestPDF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	m := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	m.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	var img bytes.Buffer
+	if err := png.Encode(&img, m); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "dot.png"), img.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := types.NewListNode(
+		types.NewTextNode("See “docs” (at "),
+		types.NewURLNode("https://example.com/", types.NewTextNode("example")),
+		types.NewTextNode(") or "),
+		types.NewURLNode("#1", types.NewTextNode("next step")),
+	)
+	p.MutateBlock(true)
+	ib := &types.InfoboxNode{Kind: types.InfoboxPositive, Content: types.NewListNode(types.NewTextNode("Good to know"))}
+	remote := types.NewImageNode("https://example.com/remote.png")
+	remote.Alt = "diagram"
+	data := &Context{
+		Dir:  dir,
+		Meta: &types.Meta{Title: "Lab", Authors: "Jo"},
+		Steps: []*types.Step{
+			{Title: "Intro", Content: types.NewListNode(p, ib, types.NewImageNode("dot.png"), remote)},
+			{Title: "Code", Content: types.NewListNode(types.NewCodeNode("func main() {\n\tfmt.Println(1)\n}", false, "go"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "pdf", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("not a PDF document:\n%s", out)
+	}
+	for _, want := range []string{
+		"/Type /Pages /Kids [10 0 R 12 0 R 14 0 R] /Count 3",
+		"/Title (Lab) /Producer (claat) /Author (Jo)",
+		"(1. Intro) Tj",
+		"(2. Code) Tj",
+		"(See \x93docs\x94 \\(at ) Tj",
+		"(example) Tj",
+		"/URI (https://example.com/)",
+		"/Dest [14 0 R /XYZ null null null]",
+		"(Good to know) Tj",
+		"/Im1 Do",
+		"/Width 4 /Height 2 /ColorSpace /DeviceRGB",
+		"([Image: diagram]) Tj",
+		"(func main\\(\\) {) Tj",
+		"(    fmt.Println\\(1\\)) Tj",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+
+	// cross-reference table must point at objects
+	i := strings.LastIndex(out, "startxref\n")
+	xref, err := strconv.Atoi(strings.Fields(out[i+len("startxref\n"):])[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[xref:], -1)
+	if len(entries) != 15 {
+		t.Errorf("xref has %d objects; want 15", len(entries))
+	}
+	for n, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		if want := fmt.Sprintf("%d 0 obj\n", n+1); !strings.HasPrefix(out[off:], want) {
+			t.Errorf("xref entry %d: offset %d does not start %q", n+1, off, want)
+		}
+	}
+}
+
+func TestPDFEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "pdf", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "Android") || strings.Contains(out, "Gradle") {
+		t.Error("output contains a step of another environment")
+	}
+	if !strings.Contains(out, "(1. Web) Tj") || !strings.Contains(out, "/Count 2") {
+		t.Errorf("output has no contents entry and page of step 1. Web")
+	}
+}
+
+func TestPDFWarnMissing(t *testing.T) {
+	var warnings []string
+	data := &Context{
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Café ✓", Content: types.NewListNode(types.NewTextNode("“Done” ✓ → next"))},
+		},
+		Warn: func(format string, args ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "pdf", data); err != nil {
+		t.Fatal(err)
+	}
+	want := `pdf: 2 characters printed as "?", which the standard fonts lack: '✓' '→'`
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("warnings = %q; want [%q]", warnings, want)
+	}
+}
+
+func TestPDFImageTooLarge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "claat-pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// PNG header of a 100000x100000 image
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	b := img.Bytes()
+	binary.BigEndian.PutUint32(b[16:], 100000)
+	binary.BigEndian.PutUint32(b[20:], 100000)
+	binary.BigEndian.PutUint32(b[29:], crc32.ChecksumIEEE(b[12:29]))
+	if err := ioutil.WriteFile(filepath.Join(dir, "huge.png"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	pw := &pdfWriter{dir: dir, size: pdfTextSize}
+	if _, err := pw.loadImage("huge.png"); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("loadImage(huge.png) err = %v; want too large", err)
+	}
+}
+
+func TestPDFWrap(t *testing.T) {
+	long := strings.Repeat("word ", 200)
+	data := &Context{
+		Meta:  &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode(types.NewTextNode(long))}},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "pdf", data); err != nil {
+		t.Fatal(err)
+	}
+	lines := regexp.MustCompile(`\(((?:word ?)+)\) Tj`).FindAllStringSubmatch(buf.String(), -1)
+	if len(lines) < 5 {
+		t.Fatalf("text is wrapped in %d lines; want at least 5", len(lines))
+	}
+	var words int
+	for _, l := range lines {
+		if w := pdfTextWidth(pdfRegular, pdfTextSize, []byte(l[1])); w > pdfPageWidth-2*pdfMargin {
+			t.Errorf("line %q is %.2f points wide", l[1], w)
+		}
+		words += len(strings.Fields(l[1]))
+	}
+	if words != 200 {
+		t.Errorf("got %d words; want 200", words)
+	}
+}
+
+func TestPDFQRCode(t *testing.T) {
+	data := &Context{
+		Meta:  &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode(types.NewTextNode("text"))}},
+		QRURL: "https://codelabs.example.com/lab/",
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "pdf", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "/URI (https://codelabs.example.com/lab/)") {
+		t.Error("title page does not link to the QR code URL")
+	}
+	// top left module of the top left finder pattern, after the quiet zone
+	unit := float64(pdfQRSize) / float64(29+8)
+	x, y := float64(pdfPageWidth-pdfMargin-pdfQRSize)+4*unit, float64(pdfPageHeight-pdfMargin)-5*unit
+	if want := fmt.Sprintf("%.2f %.2f %.2f %.2f re\n", x, y, 7*unit, unit); !strings.Contains(out, want) {
+		t.Errorf("output does not contain finder pattern %q", want)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"io"
+
+	"github.com/googlecodelabs/tools/claat/schema"
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("proto", protoRenderer{})
+}
+
+// protoRenderer renders a codelab as a binary claat.schema.v2.Codelab
+// Protocol Buffers message, defined in schema/codelab.proto.
+// Content is converted as in the v2 JSON export schema.
+type protoRenderer struct{}
+
+func (protoRenderer) Ext() string { return "pb" }
+
+func (protoRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	b, err := schema.MarshalProto(schema.Convert(m, ctx.Steps, ctx.Env, ctx.Updated))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
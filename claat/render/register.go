@@ -0,0 +1,105 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Renderer renders a codelab in a custom output format.
+// Each renderer needs to call Register to become a known format.
+type Renderer interface {
+	// Render writes the codelab of ctx to w.
+	Render(w io.Writer, ctx *Context) error
+	// Ext is the file extension of rendered output, without a dot.
+	Ext() string
+}
+
+var (
+	renderersMu sync.Mutex // guards renderers and builtins
+	renderers   = map[string]Renderer{}
+	builtins    = map[string]bool{} // names of renderers of this package
+)
+
+// Register registers a new renderer r under specified format name.
+// It panics if another renderer or a built-in template is already
+// registered under the same name.
+func Register(name string, r Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if _, exists := renderers[name]; exists || tmpldata[name] != nil {
+		panic(fmt.Sprintf("renderer %q already registered", name))
+	}
+	renderers[name] = r
+}
+
+// register registers built-in renderer r under name, see Register.
+func register(name string, r Renderer) {
+	Register(name, r)
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	builtins[name] = true
+}
+
+// Renderers returns a slice of all registered renderer names.
+func Renderers() []string {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	r := make([]string, 0, len(renderers))
+	for k := range renderers {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Formats returns sorted names of all output formats,
+// built-in templates and registered renderers.
+func Formats() []string {
+	f := Renderers()
+	for k := range tmpldata {
+		f = append(f, k)
+	}
+	sort.Strings(f)
+	return f
+}
+
+// Lookup returns a renderer registered under the format name, or nil.
+func Lookup(name string) Renderer {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	return renderers[name]
+}
+
+// lookup is like Lookup, returning only built-in renderers if builtin is true.
+func lookup(name string, builtin bool) Renderer {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if builtin && !builtins[name] {
+		return nil
+	}
+	return renderers[name]
+}
+
+// contexter is satisfied by *Context and structs embedding Context.
+type contexter interface {
+	context() *Context
+}
+
+func (c *Context) context() *Context {
+	return c
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+type titleRenderer struct{}
+
+func (titleRenderer) Render(w io.Writer, ctx *Context) error {
+	_, err := fmt.Fprintf(w, "%s (%d steps)", ctx.Meta.Title, len(ctx.Steps))
+	return err
+}
+
+func (titleRenderer) Ext() string { return "txt" }
+
+func TestRegister(t *testing.T) {
+	Register("test-title", titleRenderer{})
+	data := &struct {
+		Context
+		Current *types.Step
+	}{Context: Context{
+		Meta:  &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{{Title: "One"}},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "test-title", data); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Lab (1 steps)"; buf.String() != want {
+		t.Errorf("Execute = %q; want %q", buf.String(), want)
+	}
+	if err := Execute(&buf, "test-title", struct{}{}); err == nil {
+		t.Errorf("Execute(struct{}{}) err = nil; want error")
+	}
+
+	for _, name := range []string{"test-title", "html"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			Register(name, titleRenderer{})
+		}()
+	}
+}
+
+func TestFormats(t *testing.T) {
+	f := Formats()
+	if !sort.StringsAreSorted(f) {
+		t.Errorf("Formats = %v; want sorted", f)
+	}
+	for _, name := range []string{"html", "md", "text", "docx"} {
+		if i := sort.SearchStrings(f, name); i == len(f) || f[i] != name {
+			t.Errorf("Formats = %v; want to include %q", f, name)
+		}
+	}
+}
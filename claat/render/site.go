@@ -0,0 +1,128 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("hugo", siteRenderer{generator: "hugo"})
+	register("jekyll", siteRenderer{generator: "jekyll"})
+}
+
+// siteCollection is the Jekyll collection codelab entries are written for.
+// Entries are expected in the _codelabs directory of a site, one directory
+// per codelab, with the collection output enabled.
+const siteCollection = "codelabs"
+
+// siteRenderer renders a codelab as a Markdown page of a static site
+// generator, "hugo" or "jekyll", so that codelabs can be hosted without
+// the codelab viewer.
+//
+// The page has a YAML front matter of codelab metadata, followed by
+// all steps as second level sections with "step-N" IDs. Together with its
+// images directory, the page is a Hugo leaf bundle, or an entry of
+// the Jekyll siteCollection with a permalink keeping relative image paths.
+type siteRenderer struct {
+	generator string
+}
+
+func (siteRenderer) Ext() string { return "md" }
+
+func (r siteRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	if r.generator == "jekyll" {
+		b.WriteString("layout: codelab\n")
+	}
+	siteField(&b, "title", m.Title)
+	siteField(&b, "summary", m.Summary)
+	siteField(&b, "author", m.Authors)
+	if ctx.Updated != "" {
+		fmt.Fprintf(&b, "date: %s\n", ctx.Updated)
+	}
+	if m.Duration > 0 {
+		fmt.Fprintf(&b, "duration: %d\n", m.Duration)
+	}
+	if len(m.Categories) > 0 {
+		b.WriteString("categories:\n")
+		for _, c := range m.Categories {
+			b.WriteString("  - " + strconv.Quote(c) + "\n")
+		}
+	}
+	siteField(&b, "codelab_id", m.ID)
+	siteField(&b, "feedback", m.Feedback)
+	if r.generator == "jekyll" && m.ID != "" {
+		fmt.Fprintf(&b, "permalink: /%s/%s/\n", siteCollection, m.ID)
+	}
+	b.WriteString("---\n")
+
+	// sections are numbered among steps of the environment
+	num := make(map[int]int)
+	for i, st := range ctx.Steps {
+		if e := st.Tags; len(e) > 0 && ctx.Env != "" {
+			if j := sort.SearchStrings(e, ctx.Env); j == len(e) || e[j] != ctx.Env {
+				continue
+			}
+		}
+		num[i] = len(num) + 1
+	}
+	for i, st := range ctx.Steps {
+		n, ok := num[i]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %d. %s {#step-%d}\n\n", n, st.Title, n)
+		var content bytes.Buffer
+		if err := WriteMD(&content, ctx.Env, st.Content.Nodes...); err != nil {
+			return err
+		}
+		b.Write(siteStepLink.ReplaceAllFunc(bytes.TrimSpace(content.Bytes()), func(l []byte) []byte {
+			i, _ := strconv.Atoi(string(siteStepLink.FindSubmatch(l)[1]))
+			if n, ok := num[i]; ok {
+				return []byte(fmt.Sprintf("](#step-%d)", n))
+			}
+			return l
+		}))
+		b.WriteString("\n")
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// siteStepLink matches Markdown links to codelab steps, e.g. "](#2)".
+var siteStepLink = regexp.MustCompile(`\]\(#(\d+)\)`)
+
+// siteField writes a front matter field of a string value, unless it is empty.
+func siteField(b *bytes.Buffer, name, value string) {
+	if value = strings.TrimSpace(value); value == "" {
+		return
+	}
+	// YAML double-quoted strings accept Go escape sequences
+	fmt.Fprintf(b, "%s: %s\n", name, strconv.Quote(value))
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestSite(t *testing.T) {
+	link := types.NewListNode(types.NewTextNode("See "), types.NewURLNode("#2", types.NewTextNode("last")))
+	link.MutateBlock(true)
+	data := &Context{
+		Env:     "web",
+		Updated: "2019-05-01T10:00:00Z",
+		Meta: &types.Meta{
+			ID:         "lab",
+			Title:      `Lab "One"`,
+			Duration:   5,
+			Categories: []string{"Web"},
+		},
+		Steps: []*types.Step{
+			{Title: "Intro", Content: types.NewListNode(link)},
+			{Title: "Kiosk", Tags: []string{"kiosk"}, Content: types.NewListNode()},
+			{Title: "End", Content: types.NewListNode(types.NewImageNode("img/a.png"))},
+		},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"hugo", "---\n" +
+			"title: \"Lab \\\"One\\\"\"\n" +
+			"date: 2019-05-01T10:00:00Z\n" +
+			"duration: 5\n" +
+			"categories:\n  - \"Web\"\n" +
+			"codelab_id: \"lab\"\n" +
+			"---\n\n" +
+			"## 1. Intro {#step-1}\n\n" +
+			"See  [last](#step-2)\n\n" +
+			"## 2. End {#step-2}\n\n" +
+			"<img src=\"img/a.png\" alt=\"a.png\" />\n"},
+		{"jekyll", "---\nlayout: codelab\ntitle: \"Lab \\\"One\\\"\"\n"},
+		{"jekyll", "codelab_id: \"lab\"\npermalink: /codelabs/lab/\n---\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := Execute(&buf, test.format, data); err != nil {
+			t.Fatalf("%s: %v", test.format, err)
+		}
+		if !strings.Contains(buf.String(), test.want) {
+			t.Errorf("%s: output does not contain %q:\n%s", test.format, test.want, buf.String())
+		}
+	}
+}
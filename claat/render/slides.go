@@ -0,0 +1,157 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("slides", slidesRenderer{})
+}
+
+// slidesRenderer renders a codelab as a reveal.js slide deck,
+// so that instructors can present the content they publish.
+//
+// Each step is a slide titled with the step title. Headers of a step
+// start vertical sub-slides titled with the header text. Infoboxes are
+// not shown on slides but become speaker notes of the slide they are on.
+// Slide content is written with the Lite markup.
+type slidesRenderer struct{}
+
+func (slidesRenderer) Ext() string { return "html" }
+
+// slidesRevealURL is the location of the reveal.js package,
+// pinned to a release so that the files loaded by decks do not change.
+const slidesRevealURL = "https://cdn.jsdelivr.net/npm/reveal.js@4.6.1/"
+
+func (slidesRenderer) Render(w io.Writer, ctx *Context) error {
+	m := ctx.Meta
+	if m == nil {
+		m = &types.Meta{}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<!doctype html>\n<html lang=%q>\n<head>\n<meta charset=\"utf-8\">\n", epubLang(m.Lang))
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(m.Title))
+	fmt.Fprintf(&b, "<link rel=\"stylesheet\" href=\"%sdist/reveal.css\" crossorigin=\"anonymous\">\n", slidesRevealURL)
+	fmt.Fprintf(&b, "<link rel=\"stylesheet\" href=\"%sdist/theme/white.css\" crossorigin=\"anonymous\">\n", slidesRevealURL)
+	b.WriteString(slidesStyle)
+	b.WriteString("</head>\n<body>\n<div class=\"reveal\"><div class=\"slides\">\n")
+
+	b.WriteString("<section class=\"title-slide\">\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(m.Title))
+	if m.Summary != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(m.Summary))
+	}
+	if m.Authors != "" {
+		fmt.Fprintf(&b, "<p><small>%s</small></p>\n", html.EscapeString(m.Authors))
+	}
+	b.WriteString("</section>\n")
+
+	for i, st := range envSteps(ctx.Steps, ctx.Env) {
+		slides := splitSlides(ctx.Env, st.Content.Nodes)
+		if len(slides) > 1 {
+			b.WriteString("<section>\n")
+		}
+		for j, sl := range slides {
+			b.WriteString("<section>\n")
+			if j == 0 {
+				fmt.Fprintf(&b, "<h2>%d. %s</h2>\n", i+1, html.EscapeString(st.Title))
+			}
+			if sl.title != nil {
+				b.WriteString("<h3>")
+				if err := WriteLite(&b, ctx.Env, sl.title.Content.Nodes...); err != nil {
+					return err
+				}
+				b.WriteString("</h3>\n")
+			}
+			if err := WriteLite(&b, ctx.Env, sl.nodes...); err != nil {
+				return err
+			}
+			if len(sl.notes) > 0 {
+				b.WriteString("\n<aside class=\"notes\">")
+				for _, n := range sl.notes {
+					if err := WriteLite(&b, ctx.Env, n.Content.Nodes...); err != nil {
+						return err
+					}
+				}
+				b.WriteString("</aside>")
+			}
+			b.WriteString("\n</section>\n")
+		}
+		if len(slides) > 1 {
+			b.WriteString("</section>\n")
+		}
+	}
+
+	b.WriteString("</div></div>\n")
+	fmt.Fprintf(&b, "<script src=\"%sdist/reveal.js\" crossorigin=\"anonymous\"></script>\n", slidesRevealURL)
+	fmt.Fprintf(&b, "<script src=\"%splugin/notes/notes.js\" crossorigin=\"anonymous\"></script>\n", slidesRevealURL)
+	b.WriteString("<script>Reveal.initialize({hash: true, plugins: [RevealNotes]});</script>\n")
+	b.WriteString("</body>\n</html>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// slide is a part of a step shown on a single slide.
+type slide struct {
+	title *types.HeaderNode // nil for content preceding headers of a step
+	nodes []types.Node      // slide content
+	notes []*types.InfoboxNode
+}
+
+// splitSlides splits top-level nodes of a step at headers into slides.
+// Nodes excluded from the env environment are skipped.
+func splitSlides(env string, nodes []types.Node) []*slide {
+	sl := &slide{}
+	slides := []*slide{sl}
+	for _, n := range nodes {
+		if e := n.Env(); len(e) > 0 && env != "" {
+			if i := sort.SearchStrings(e, env); i == len(e) || e[i] != env {
+				continue
+			}
+		}
+		switch n := n.(type) {
+		case *types.HeaderNode:
+			sl = &slide{title: n}
+			slides = append(slides, sl)
+		case *types.InfoboxNode:
+			sl.notes = append(sl.notes, n)
+		default:
+			sl.nodes = append(sl.nodes, n)
+		}
+	}
+	// a step starting with a header needs no slide without one
+	if len(slides) > 1 && len(slides[0].nodes) == 0 && len(slides[0].notes) == 0 {
+		slides = slides[1:]
+	}
+	return slides
+}
+
+// slidesStyle adjusts the reveal.js theme for codelab content.
+const slidesStyle = `<style>
+.reveal .slides section { text-align: left; }
+.reveal .slides section.title-slide { text-align: center; }
+.reveal pre { width: 100%; font-size: 0.5em; }
+.reveal img { max-height: 60vh; }
+.reveal .placeholder-image { display: inline-block; }
+</style>
+`
@@ -0,0 +1,89 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestSlides(t *testing.T) {
+	para := func(s string) types.Node {
+		n := types.NewListNode(types.NewTextNode(s))
+		n.MutateBlock(true)
+		return n
+	}
+	tip := &types.InfoboxNode{Kind: types.InfoboxPositive, Content: types.NewListNode(types.NewTextNode("Mention the demo"))}
+	web := para("Web only")
+	web.MutateEnv([]string{"web"})
+	data := &Context{
+		Env:  "kiosk",
+		Meta: &types.Meta{Title: "Lab & Co", Authors: "Jo"},
+		Steps: []*types.Step{
+			{Title: "Intro", Content: types.NewListNode(para("Welcome"), tip)},
+			{Title: "Setup", Content: types.NewListNode(
+				para("Overview"),
+				types.NewHeaderNode(2, types.NewTextNode("Install")),
+				para("Run it"),
+				web,
+			)},
+			{Title: "Deploy", Content: types.NewListNode(
+				types.NewHeaderNode(2, types.NewTextNode("Build")),
+				para("Make it"),
+			)},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "slides", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<section class=\"title-slide\">\n<h1>Lab &amp; Co</h1>\n<p><small>Jo</small></p>\n</section>",
+		"<section>\n<h2>1. Intro</h2>\n<p>Welcome</p>\n<aside class=\"notes\">Mention the demo</aside>\n</section>",
+		"<section>\n<section>\n<h2>2. Setup</h2>\n<p>Overview</p>\n</section>\n<section>\n<h3>Install</h3>\n<p>Run it</p>\n</section>\n</section>",
+		"<section>\n<h2>3. Deploy</h2>\n<h3>Build</h3>\n<p>Make it</p>\n</section>",
+		"Reveal.initialize(",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Web only") {
+		t.Errorf("output contains content of another environment:\n%s", out)
+	}
+}
+
+func TestSlidesEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "slides", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "Android") || strings.Contains(out, "Gradle") || !strings.Contains(out, "<h2>1. Web</h2>") {
+		t.Errorf("slides of other environments rendered:\n%s", out)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// sourceStamp renders the last update date, an RFC 3339 time, and the
+// source revision of the codelab of meta, in locale lang, or nothing
+// if the codelab has no revision.
+func sourceStamp(meta *types.Meta, updated, lang string) htmlTemplate.HTML {
+	if meta.Revision == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(`<footer class="codelab-stamp">`)
+	if t, err := time.Parse(time.RFC3339, updated); err == nil {
+		date := strings.Replace(Msg(lang, MsgLastUpdated), "{date}", t.Format("2006-01-02"), 1)
+		fmt.Fprintf(&b, `<time datetime="%s">%s</time> · `,
+			htmlTemplate.HTMLEscapeString(updated), htmlTemplate.HTMLEscapeString(date))
+	}
+	fmt.Fprintf(&b, `%s <code>%s</code></footer>`,
+		htmlTemplate.HTMLEscapeString(Msg(lang, MsgRevision)), htmlTemplate.HTMLEscapeString(meta.Revision))
+	return htmlTemplate.HTML(b.String())
+}
@@ -0,0 +1,40 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestSourceStamp(t *testing.T) {
+	tests := []struct {
+		rev, updated, lang string
+		out                string
+	}{
+		{"", "2019-05-01T10:00:00Z", "", ""},
+		{"abc1234", "2019-05-01T10:00:00Z", "",
+			`<footer class="codelab-stamp"><time datetime="2019-05-01T10:00:00Z">Last updated 2019-05-01</time> · Revision <code>abc1234</code></footer>`},
+		{"<42>", "", "fr",
+			`<footer class="codelab-stamp">Révision <code>&lt;42&gt;</code></footer>`},
+	}
+	for i, test := range tests {
+		out := sourceStamp(&types.Meta{Revision: test.rev}, test.updated, test.lang)
+		if string(out) != test.out {
+			t.Errorf("%d: sourceStamp(%q, %q, %q) = %q; want %q", i, test.rev, test.updated, test.lang, out, test.out)
+		}
+	}
+}
@@ -15,10 +15,14 @@
 package render
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	htmlTemplate "html/template"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"path/filepath"
 	"sort"
 	textTemplate "text/template"
@@ -41,6 +45,50 @@ type Context struct {
 	Steps    []*types.Step
 	Updated  string
 	Extra    map[string]string // Extra variables passed from the command line.
+	Dir      string            // Output directory, if any; local images are relative to it.
+	Schema   string            // JSON export schema version of the json format.
+	Theme    *Theme            // Theme bundle of the html format, if any.
+	Locale   string            // Locale of the viewer chrome, see Msg; English if empty.
+	// A11yNav adds a skip link, aria-current step navigation, focus
+	// management and n/p keyboard shortcuts to the html format.
+	A11yNav bool
+	// ProgressURL is the endpoint the html format posts reading progress
+	// to, if any. Progress is stored in localStorage either way.
+	ProgressURL string
+	// Analytics are providers which the html format sends step view,
+	// duration and completion events to, see ParseAnalytics.
+	Analytics []*Analytics
+	// FeedbackWidget is the backend of thumbs up and down and comment
+	// widgets of each step of the html format, see ValidateFeedbackWidget.
+	// There are no widgets if empty.
+	FeedbackWidget string
+	// PWA links a web app manifest and registers a service worker,
+	// manifest.webmanifest and sw.js of the codelab directory,
+	// making the html format installable and readable offline.
+	PWA bool
+	// PWARoot is the URL of the directory of the web app manifest and
+	// service worker of PWA, relative to the page, such as "../" for
+	// a single app of all codelabs of a directory. It ends with a slash,
+	// and is empty for the codelab directory.
+	PWARoot string
+	// URL is the published URL of the codelab, if known, which the html
+	// format gives as og:url and resolves relative hero images against.
+	// Link previews have no relative hero images if empty.
+	URL string
+	// QRURL is the published URL of the codelab, which a QR code on the
+	// title page of the pdf format links to. There is none if empty.
+	QRURL string
+	// Asset stores the content of an inline style or script of the html
+	// format, whose extension ext is ".css" or ".js", and returns the URL
+	// which the page then links to instead, e.g. of a content-hashed file.
+	// Styles and scripts stay inline if nil.
+	Asset func(content, ext string) (string, error)
+	// Warn reports issues of the rendered output, such as characters
+	// the pdf format cannot print. They are discarded if nil.
+	Warn func(format string, args ...interface{})
+
+	builtin bool     // executed with the Builtin option
+	tmpl    executer // template being executed, see asset
 }
 
 // Execute renders a template of the fmt format into w.
@@ -50,68 +98,150 @@ type Context struct {
 // Template execution context data is expected to be of type *Context
 // but can be an arbitrary struct, as long as it contains at least Context's fields
 // for the built-in templates to be successfully executed.
+//
+// If fmt is a format registered with Register, its renderer is used instead
+// and data must be a *Context or a pointer to a struct embedding Context.
+//
+// Options Builtin and WithTemplate restrict the formats and templates
+// which fmt refers to.
 func Execute(w io.Writer, fmt string, data interface{}, opt ...Option) error {
-	var funcs map[string]interface{}
-	for _, o := range opt {
-		switch o := o.(type) {
+	return ExecuteContext(context.Background(), w, fmt, data, opt...)
+}
+
+// ExecuteContext is like Execute but stops writing to w, and returns
+// the error of ctx, once ctx is done.
+func ExecuteContext(ctx context.Context, w io.Writer, fmt string, data interface{}, opt ...Option) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w = &contextWriter{ctx: ctx, w: w}
+	var o execOptions
+	for _, opt := range opt {
+		switch opt := opt.(type) {
 		case optFuncMap:
-			funcs = o
+			o.funcs = opt
+		case optBuiltin:
+			o.builtin = true
+		case optTemplate:
+			o.text = opt.r
+			o.html = opt.html
 		}
 	}
-	t, err := parseTemplate(fmt, funcs)
+	if r := lookup(fmt, o.builtin); r != nil && o.text == nil {
+		c, ok := data.(contexter)
+		if !ok {
+			return &TemplateError{Name: fmt, Err: errors.New("data does not embed render.Context")}
+		}
+		sort.Strings(c.context().Meta.Tags)
+		c.context().builtin = o.builtin
+		if err := r.Render(w, c.context()); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		return nil
+	}
+	t, err := parseTemplate(fmt, o)
 	if err != nil {
 		return err
 	}
-	if ctx, ok := data.(*Context); ok {
-		sort.Strings(ctx.Meta.Tags)
+	if c, ok := data.(*Context); ok {
+		sort.Strings(c.Meta.Tags)
+	}
+	if c, ok := data.(contexter); ok {
+		c.context().tmpl = t
+	}
+	if err := t.Execute(w, data); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// contextWriter writes to w until ctx is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w *contextWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
 	}
-	return t.Execute(w, data)
+	return w.w.Write(p)
+}
+
+// CheckTemplate reports whether the fmt format, as given to Execute,
+// is available: a registered or built-in format, or a local template
+// file which parses.
+func CheckTemplate(fmt string) error {
+	if Lookup(fmt) != nil {
+		return nil
+	}
+	_, err := parseTemplate(fmt, execOptions{})
+	return err
 }
 
 // executer satisfies both html/template and text/template.
 type executer interface {
 	Execute(io.Writer, interface{}) error
+	ExecuteTemplate(io.Writer, string, interface{}) error
+}
+
+// matchEnv reports whether sorted tags, of a step or node, include
+// environment env. Everything matches if either is empty.
+func matchEnv(tags []string, env string) bool {
+	if len(tags) == 0 || env == "" {
+		return true
+	}
+	i := sort.SearchStrings(tags, env)
+	return i < len(tags) && tags[i] == env
+}
+
+// envSteps returns steps whose tags match environment env,
+// which the built-in templates render, see matchEnv.
+func envSteps(steps []*types.Step, env string) []*types.Step {
+	var res []*types.Step
+	for _, st := range steps {
+		if matchEnv(st.Tags, env) {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+// absURL returns ref resolved against base, or "" if ref is relative
+// and base empty, e.g. for link previews, whose URLs must be absolute.
+func absURL(base, ref string) string {
+	r, err := url.Parse(ref)
+	if err != nil || ref == "" {
+		return ""
+	}
+	if r.IsAbs() || r.Host != "" {
+		return ref
+	}
+	b, err := url.Parse(base)
+	if err != nil || base == "" {
+		return ""
+	}
+	return b.ResolveReference(r).String()
 }
 
 // funcMap are exposted to the templates.
 var funcMap = map[string]interface{}{
-	"renderLite": Lite,
-	"renderHTML": HTML,
-	"renderMD":   MD,
+	"renderLite":     Lite,
+	"renderHTML":     HTML,
+	"renderMD":       MD,
+	"renderObsidian": Obsidian,
 	"durationStr": func(d time.Duration) string {
 		m := d / time.Minute
 		return fmt.Sprintf("%02d:00", m)
 	},
-	"metaHeaderYaml": func(meta *types.Meta) string {
-		kvLine := func(k string, v string) string {
-			if tv := strings.TrimSpace(v); tv != "" {
-				return fmt.Sprintf("%s: %s\n", k, tv)
-			}
-
-			return ""
-		}
-
-		res := ""
-		res += kvLine(mdParse.MetaID, meta.ID)
-		res += kvLine(mdParse.MetaSummary, meta.Summary)
-		if meta.Status != nil {
-			res += kvLine(mdParse.MetaStatus, meta.Status.String())
-		}
-		res += kvLine(mdParse.MetaAuthors, meta.Authors)
-		res += kvLine(mdParse.MetaCategories, strings.Join(meta.Categories, ","))
-		res += kvLine(mdParse.MetaTags, strings.Join(meta.Tags, ","))
-		res += kvLine(mdParse.MetaFeedbackLink, meta.Feedback)
-		res += kvLine(mdParse.MetaAnalyticsAccount, meta.GA)
-
-		return res
-	},
-	"matchEnv": func(tags []string, t string) bool {
-		if len(tags) == 0 || t == "" {
-			return true
-		}
-		i := sort.SearchStrings(tags, t)
-		return i < len(tags) && tags[i] == t
-	},
+	"metaHeaderYaml": func(meta *types.Meta) string { return metaHeaderYaml(meta, false) },
+	"matchEnv":       matchEnv,
 	// lite/offline versions; multiple step files
 	"inc": func(n int) int {
 		return n + 1
@@ -128,6 +258,25 @@ var funcMap = map[string]interface{}{
 		}
 		return a
 	},
+	"absURL":         absURL,
+	"asset":          asset,
+	"feedbackURL":    feedbackURL,
+	"feedbackCard":   feedbackCard,
+	"feedbackScript": feedbackScript,
+	"feedbackWidget": feedbackWidget,
+	"jsonLD":         jsonLD,
+	"indexNote":      ObsidianIndexNote,
+	"stepNote":       ObsidianStepNote,
+	"msg":            Msg,
+	"localeDir":      localeDir,
+	"langTag":        langTag,
+	"langName":       langName,
+	"chromeScript":   chromeScript,
+	"themeColor":     ThemeColor,
+	"themeStyle":     themeStyle,
+	"prerequisites":  prerequisitesSection,
+	"related":        relatedSection,
+	"sourceStamp":    sourceStamp,
 	"stepLink": func(n int) string {
 		if n <= 1 {
 			return "index.html"
@@ -143,37 +292,104 @@ type template struct {
 	html  bool
 }
 
+// execOptions are the options of an Execute call.
+type execOptions struct {
+	funcs   map[string]interface{} // of WithFuncMap
+	builtin bool                   // see Builtin
+	text    io.Reader              // template of WithTemplate, if any
+	html    bool                   // whether text is an HTML template
+}
+
 // parseTemplate parses template name defined either in tmpldata
-// or a local file.
+// or a local file, or the template text of opts.
 //
 // A local file template is parsed as HTML if file extension is ".html",
 // text otherwise.
-func parseTemplate(name string, fmap map[string]interface{}) (executer, error) {
+func parseTemplate(name string, opts execOptions) (executer, error) {
 	tmpl := tmpldata[name] // defined in pre-generated tmpldata.go
-	if tmpl == nil {
+	if opts.text != nil {
+		b, err := ioutil.ReadAll(opts.text)
+		if err != nil {
+			return nil, &TemplateError{Name: name, Err: err}
+		}
+		tmpl = &template{bytes: b, html: opts.html}
+	} else if tmpl == nil {
+		if opts.builtin {
+			return nil, &TemplateError{Name: name, Err: errors.New("not a built-in format")}
+		}
 		// TODO: add templates in-mem caching
 		var err error
 		if tmpl, err = readTemplate(name); err != nil {
-			return nil, err
+			return nil, &TemplateError{Name: name, Err: err}
 		}
 	}
 
-	funcs := make(map[string]interface{}, len(funcMap))
-	for k, v := range funcMap {
-		funcs[k] = v
-	}
-	for k, v := range fmap {
-		funcs[k] = v
+	funcs := builtinFuncs(opts.funcs)
+	var parts map[string]string
+	if !opts.builtin {
+		funcs = templateFuncs(opts.funcs)
+		parts = templatePartials()
 	}
 
+	var (
+		t   executer
+		err error
+	)
 	if tmpl.html {
-		return htmlTemplate.New(name).
-			Funcs(funcs).
-			Parse(string(tmpl.bytes))
+		t, err = parseHTML(name, string(tmpl.bytes), funcs, parts)
+	} else {
+		t, err = parseText(name, string(tmpl.bytes), funcs, parts)
+	}
+	if err != nil {
+		return nil, &TemplateError{Name: name, Err: err}
 	}
-	return textTemplate.New(name).
-		Funcs(funcs).
-		Parse(string(tmpl.bytes))
+	return t, nil
+}
+
+// parseHTML parses an HTML template and registered partials parts,
+// which are parsed last so that they replace blocks of the same name.
+func parseHTML(name, text string, funcs map[string]interface{}, parts map[string]string) (executer, error) {
+	t, err := htmlTemplate.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for n, p := range parts {
+		if _, err := t.New(n).Parse(p); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// parseText is like parseHTML for text templates.
+func parseText(name, text string, funcs map[string]interface{}, parts map[string]string) (executer, error) {
+	t, err := textTemplate.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for n, p := range parts {
+		if _, err := t.New(n).Parse(p); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// TemplateError means template Name is neither a built-in format
+// nor a readable and valid local template file.
+// The cause is available with errors.Unwrap.
+type TemplateError struct {
+	Name string
+	Err  error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("template %s: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TemplateError) Unwrap() error {
+	return e.Err
 }
 
 func readTemplate(name string) (*template, error) {
@@ -200,3 +416,118 @@ func WithFuncMap(fm map[string]interface{}) Option {
 type optFuncMap map[string]interface{}
 
 func (o optFuncMap) option() {}
+
+// Builtin restricts Execute to the built-in formats and template
+// functions of this package: formats registered with Register, functions
+// and partials registered with RegisterFuncs and RegisterPartial, and
+// local template files are not used. Functions of WithFuncMap still are.
+func Builtin() Option {
+	return optBuiltin{}
+}
+
+type optBuiltin struct{}
+
+func (optBuiltin) option() {}
+
+// WithTemplate executes the template read from r in place of the format
+// given to Execute, which names it. The template is parsed as HTML
+// if html is true, text otherwise.
+func WithTemplate(r io.Reader, html bool) Option {
+	return optTemplate{r: r, html: html}
+}
+
+type optTemplate struct {
+	r    io.Reader
+	html bool
+}
+
+func (optTemplate) option() {}
+
+// asset renders template name, defining a single style or script element,
+// or nothing, of the template being executed with root, with data.
+// If the Asset of the Context of root is set, the content of the element
+// is stored with it, and asset returns a link to the content instead.
+func asset(root interface{}, name string, data interface{}) (htmlTemplate.HTML, error) {
+	c, ok := root.(contexter)
+	if !ok || c.context().tmpl == nil {
+		return "", fmt.Errorf("asset %s: data does not embed render.Context", name)
+	}
+	ctx := c.context()
+	var buf bytes.Buffer
+	if err := ctx.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	s := strings.TrimSpace(buf.String())
+	if s == "" || ctx.Asset == nil {
+		return htmlTemplate.HTML(buf.String()), nil
+	}
+	tag, ext := "script", ".js"
+	if strings.HasPrefix(s, "<style") {
+		tag, ext = "style", ".css"
+	}
+	end := "</" + tag + ">"
+	i := strings.IndexByte(s, '>')
+	if !strings.HasPrefix(s, "<"+tag) || i < 0 || !strings.HasSuffix(s, end) || strings.Count(s, end) > 1 {
+		return "", fmt.Errorf("asset %s: not a single style or script element", name)
+	}
+	attrs := s[len(tag)+1 : i]
+	if strings.Contains(attrs, "src=") {
+		return htmlTemplate.HTML(buf.String()), nil
+	}
+	url, err := ctx.Asset(s[i+1:len(s)-len(end)], ext)
+	if err != nil {
+		return "", fmt.Errorf("asset %s: %v", name, err)
+	}
+	if tag == "style" {
+		return htmlTemplate.HTML(fmt.Sprintf(`<link rel="stylesheet" href="%s"%s>`, htmlTemplate.HTMLEscapeString(url), attrs)), nil
+	}
+	return htmlTemplate.HTML(fmt.Sprintf(`<script src="%s"%s></script>`, htmlTemplate.HTMLEscapeString(url), attrs)), nil
+}
+
+// metaHeaderYaml returns codelab metadata m as YAML header lines
+// of the Markdown format, which the md parser reads back.
+// The md-full format sets full to also write the badge path
+// and the status in a form the md parser reads back unchanged.
+func metaHeaderYaml(meta *types.Meta, full bool) string {
+	kvLine := func(k string, v string) string {
+		if tv := strings.TrimSpace(v); tv != "" {
+			return fmt.Sprintf("%s: %s\n", k, tv)
+		}
+
+		return ""
+	}
+
+	res := ""
+	res += kvLine(mdParse.MetaID, meta.ID)
+	res += kvLine(mdParse.MetaSummary, meta.Summary)
+	if meta.Status != nil && full {
+		res += kvLine(mdParse.MetaStatus, strings.Join(*meta.Status, ","))
+	} else if meta.Status != nil {
+		res += kvLine(mdParse.MetaStatus, meta.Status.String())
+	}
+	res += kvLine(mdParse.MetaAuthors, meta.Authors)
+	if full {
+		res += kvLine(mdParse.MetaBadgePath, meta.BadgePath)
+	}
+	res += kvLine(mdParse.MetaCategories, strings.Join(meta.Categories, ","))
+	res += kvLine(mdParse.MetaTags, strings.Join(meta.Tags, ","))
+	res += kvLine(mdParse.MetaFeedbackLink, meta.Feedback)
+	res += kvLine(mdParse.MetaAnalyticsAccount, meta.GA)
+	res += kvLine(mdParse.MetaAnalytics, strings.Join(meta.Analytics, ","))
+	res += kvLine(mdParse.MetaRequires, meta.Requires)
+	res += kvLine(mdParse.MetaFeatures, strings.Join(meta.Features, ","))
+	res += kvLine(mdParse.MetaTemplate, meta.Template)
+	res += kvLine(mdParse.MetaTheme, meta.Theme)
+	res += kvLine(mdParse.MetaPrerequisites, strings.Join(meta.Prerequisites, ","))
+	res += kvLine(mdParse.MetaRelated, strings.Join(meta.Related, ","))
+	res += kvLine(mdParse.MetaDescription, meta.Description)
+	res += kvLine(mdParse.MetaHeroImage, meta.HeroImage)
+	res += kvLine(mdParse.MetaPublished, meta.Published)
+	res += kvLine(mdParse.MetaUpdated, meta.LastUpdated)
+	res += kvLine(mdParse.MetaRevision, meta.Revision)
+	if meta.GateSteps {
+		res += kvLine(mdParse.MetaGateSteps, "true")
+	}
+
+	return res
+}
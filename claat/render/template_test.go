@@ -16,6 +16,8 @@ package render
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/googlecodelabs/tools/claat/types"
@@ -39,3 +41,283 @@ func TestExecuteBuiltin(t *testing.T) {
 		}
 	}
 }
+
+func TestExecuteDarkMode(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:  &types.Meta{},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<style id="codelab-dark" media="(prefers-color-scheme: dark)">`,
+		`google-codelab-step aside.warning {`,
+		`<button class="codelab-color-scheme" type="button"`,
+		`codelabColorScheme(localStorage.getItem('claat-color-scheme'));`,
+		`localStorage.setItem('claat-color-scheme', scheme);`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+}
+
+func TestExecuteAsset(t *testing.T) {
+	assets := map[string]string{}
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:      &types.Meta{ID: "go-basics"},
+		Steps:     []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		Analytics: []*Analytics{{AnalyticsGA4, "G-ABC123"}},
+		Asset: func(content, ext string) (string, error) {
+			name := fmt.Sprintf("%d%s", len(assets), ext)
+			assets[name] = content
+			return name, nil
+		},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<style") || strings.Contains(out, "<script>") {
+		t.Errorf("Execute(html) has inline styles or scripts:\n%s", out)
+	}
+	if !strings.Contains(out, `<link rel="stylesheet" href="1.css" id="codelab-dark" media="(prefers-color-scheme: dark)">`) {
+		t.Errorf("Execute(html) does not link the dark style with its attributes:\n%s", out)
+	}
+	var scripts []string
+	for name, content := range assets {
+		ref := `<script src="` + name + `"></script>`
+		if strings.HasSuffix(name, ".css") {
+			ref = `<link rel="stylesheet" href="` + name + `"`
+		}
+		if !strings.Contains(out, ref) {
+			t.Errorf("Execute(html) does not contain %q", ref)
+		}
+		scripts = append(scripts, content)
+	}
+	all := strings.Join(scripts, "\n")
+	for _, want := range []string{
+		`gtag('config', "G-ABC123");`,
+		`})("go-basics", "");`,
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("assets do not contain %q", want)
+		}
+	}
+}
+
+func TestExecuteLearningPath(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta: &types.Meta{
+			Prerequisites: []string{"go-basics", "https://example.com/setup?a=1&b=2", "javascript:alert(1)"},
+			Related:       []string{"go-testing"},
+		},
+		Steps: []*types.Step{
+			{Title: "One", Content: types.NewListNode()},
+			{Title: "Two", Content: types.NewListNode()},
+		},
+		Locale: "fr",
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<aside class="codelab-prerequisites"><h3>Prérequis</h3><ul>` +
+			`<li><a href="../go-basics/">go-basics</a></li>` +
+			`<li><a href="https://example.com/setup?a=1&amp;b=2">https://example.com/setup?a=1&amp;b=2</a></li>` +
+			`<li><a href="#">javascript:alert(1)</a></li></ul></aside>`,
+		`<aside class="codelab-related"><h3>Ateliers associés</h3><ul><li><a href="../go-testing/">go-testing</a></li></ul></aside>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+	pre := strings.Index(out, `class="codelab-prerequisites"`)
+	rel := strings.Index(out, `class="codelab-related"`)
+	two := strings.Index(out, `label="Two"`)
+	if pre > two || rel < two {
+		t.Errorf("prerequisites at %d and related at %d; want them in the first and last steps, around %d", pre, rel, two)
+	}
+}
+
+func TestExecuteA11yNav(t *testing.T) {
+	wants := []string{
+		`<a class="codelab-skip-link" href="#steps">Zum Inhalt springen</a>`,
+		`a.setAttribute('aria-current', 'step');`,
+		`if (selected) step.focus({preventScroll: true});`,
+		`var id = {n: 'next-step', p: 'previous-step'}[e.key];`,
+	}
+	for _, nav := range []bool{false, true} {
+		data := &struct {
+			Context
+		}{Context: Context{
+			Meta:    &types.Meta{},
+			Steps:   []*types.Step{{Title: "One", Content: types.NewListNode()}},
+			Locale:  "de",
+			A11yNav: nav,
+		}}
+		var buf bytes.Buffer
+		if err := Execute(&buf, "html", data); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, want := range wants {
+			if strings.Contains(out, want) != nav {
+				t.Errorf("A11yNav %v: Execute(html) contains %q: %v", nav, want, !nav)
+			}
+		}
+	}
+}
+
+func TestExecuteProgress(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:        &types.Meta{ID: "go-basics"},
+		Steps:       []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		ProgressURL: "https://example.com/progress",
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`var key = 'claat-progress-' + id;`,
+		`navigator.sendBeacon(endpoint, body);`,
+		`codelab.setAttribute('selected', progress.step);`,
+		`}, 250);`,
+		`})("go-basics", "https://example.com/progress");`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+}
+
+func TestExecuteFeedbackWidget(t *testing.T) {
+	for _, backend := range []string{"", "https://example.com/feedback"} {
+		data := &struct {
+			Context
+		}{Context: Context{
+			Meta:           &types.Meta{ID: "go-basics"},
+			Steps:          []*types.Step{{Title: "One", Content: types.NewListNode()}},
+			FeedbackWidget: backend,
+		}}
+		var buf bytes.Buffer
+		if err := Execute(&buf, "html", data); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			`<aside class="feedback-widget" data-step="1" data-title="One">`,
+			`vote: w.dataset.vote, url: location.href});`, // on click
+			`})("go-basics", "https://example.com/feedback");`,
+		} {
+			if strings.Contains(out, want) != (backend != "") {
+				t.Errorf("backend %q: Execute(html) contains %q: %v", backend, want, backend == "")
+			}
+		}
+	}
+}
+
+func TestExecuteFeedbackMailto(t *testing.T) {
+	for _, tmpl := range []string{"html", "devsite"} {
+		data := &struct {
+			Context
+		}{Context: Context{
+			Meta:  &types.Meta{ID: "go-basics", Feedback: "mailto:team@example.com"},
+			Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		}}
+		var buf bytes.Buffer
+		if err := Execute(&buf, tmpl, data); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			`<a class="feedback-contact" href="#" data-u="maet" data-d="moc.elpmaxe"`,
+			`e.target.closest('a.feedback-contact')`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Execute(%s) does not contain %q", tmpl, want)
+			}
+		}
+		if strings.Contains(out, "team@example.com") {
+			t.Errorf("Execute(%s) exposes the feedback address", tmpl)
+		}
+	}
+}
+
+func TestExecuteSocialMeta(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta: &types.Meta{
+			Title:     "Go & you",
+			Summary:   "Learn Go.",
+			HeroImage: "https://example.com/hero.png",
+		},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<meta name="description" content="Learn Go.">`,
+		`<meta property="og:title" content="Go &amp; you">`,
+		`<meta property="og:description" content="Learn Go.">`,
+		`<meta property="og:image" content="https://example.com/hero.png">`,
+		`<meta name="twitter:card" content="summary_large_image">`,
+		`<meta name="twitter:image" content="https://example.com/hero.png">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q", want)
+		}
+	}
+
+	data.Meta.Description = "Share me"
+	data.Meta.HeroImage = ""
+	buf.Reset()
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out = buf.String()
+	if !strings.Contains(out, `<meta property="og:description" content="Share me">`) ||
+		!strings.Contains(out, `<meta name="twitter:card" content="summary">`) || strings.Contains(out, "og:image") {
+		t.Errorf("Execute(html) with a description and no hero image:\n%s", out)
+	}
+
+	// relative hero images are resolved against the published URL
+	data.Meta.HeroImage = "img/hero.png"
+	for url, want := range map[string]string{
+		"":                            "",
+		"https://example.com/my-lab/": `<meta property="og:image" content="https://example.com/my-lab/img/hero.png">`,
+	} {
+		data.URL = url
+		buf.Reset()
+		if err := Execute(&buf, "html", data); err != nil {
+			t.Fatal(err)
+		}
+		out = buf.String()
+		if want == "" && (strings.Contains(out, "og:image") || strings.Contains(out, "og:url")) {
+			t.Errorf("Execute(html) without a URL has a relative og:image or an og:url:\n%s", out)
+		}
+		if want != "" && (!strings.Contains(out, want) || !strings.Contains(out, `<meta property="og:url" content="`+url+`">`)) {
+			t.Errorf("Execute(html) with URL %s does not contain %s and og:url", url, want)
+		}
+	}
+}
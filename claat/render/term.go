@@ -0,0 +1,467 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("term", termRenderer{})
+}
+
+// ANSI escape sequences used by the term format.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiItalic    = "\x1b[3m"
+	ansiUnderline = "\x1b[4m"
+	ansiGreen     = "\x1b[32m"
+	ansiYellow    = "\x1b[33m"
+	ansiBlue      = "\x1b[34m"
+	ansiMagenta   = "\x1b[35m"
+	ansiCyan      = "\x1b[36m"
+)
+
+// termWidth is the width of step separators.
+const termWidth = 72
+
+// termRenderer renders a codelab for a terminal, using ANSI colors.
+type termRenderer struct{}
+
+func (termRenderer) Ext() string { return "txt" }
+
+func (termRenderer) Render(w io.Writer, ctx *Context) error {
+	tw := termWriter{w: w, env: ctx.Env, lineStart: true}
+	if m := ctx.Meta; m != nil {
+		tw.writeStyled(ansiBold+ansiCyan, m.Title)
+		tw.writeBytes(newLine)
+		for _, s := range []string{m.Summary, m.Authors} {
+			if s != "" {
+				tw.writeStyled(ansiDim, s)
+				tw.writeBytes(newLine)
+			}
+		}
+	}
+	for i, st := range envSteps(ctx.Steps, ctx.Env) {
+		tw.separator(i+1, st.Title)
+		if err := tw.write(st.Content.Nodes...); err != nil {
+			return err
+		}
+		if !tw.lineStart {
+			tw.writeBytes(newLine)
+		}
+	}
+	return tw.err
+}
+
+type termWriter struct {
+	w         io.Writer // output writer
+	env       string    // target environment
+	err       error     // error during any writeXxx methods
+	lineStart bool
+	prefix    string // prefix of each line, e.g. infobox border
+	lists     int    // depth of the lists being written
+}
+
+func (tw *termWriter) writeBytes(b []byte) {
+	if tw.err != nil {
+		return
+	}
+	tw.lineStart = len(b) > 0 && b[len(b)-1] == '\n'
+	_, tw.err = tw.w.Write(b)
+}
+
+// writeString writes s, prefixing each line with tw.prefix.
+func (tw *termWriter) writeString(s string) {
+	lines := strings.SplitAfter(s, "\n")
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+		if tw.lineStart && tw.prefix != "" {
+			tw.writeBytes([]byte(tw.prefix))
+		}
+		tw.writeBytes([]byte(l))
+	}
+}
+
+// writeStyled writes s wrapped in ANSI style sequence and a reset.
+func (tw *termWriter) writeStyled(style, s string) {
+	if tw.lineStart && tw.prefix != "" {
+		tw.writeBytes([]byte(tw.prefix))
+	}
+	tw.writeBytes([]byte(style + s + ansiReset))
+}
+
+func (tw *termWriter) space() {
+	if !tw.lineStart {
+		tw.writeString(" ")
+	}
+}
+
+func (tw *termWriter) newBlock() {
+	if !tw.lineStart {
+		tw.writeBytes(newLine)
+	}
+	tw.writeString("\n")
+}
+
+// separator writes a rule with step number n and its title.
+func (tw *termWriter) separator(n int, title string) {
+	tw.newBlock()
+	s := fmt.Sprintf("━━ %d. %s ", n, title)
+	if k := termWidth - len([]rune(s)); k > 0 {
+		s += strings.Repeat("━", k)
+	}
+	tw.writeStyled(ansiBold+ansiBlue, s)
+	tw.writeBytes(newLine)
+}
+
+func (tw *termWriter) matchEnv(v []string) bool {
+	if len(v) == 0 || tw.env == "" {
+		return true
+	}
+	i := sort.SearchStrings(v, tw.env)
+	return i < len(v) && v[i] == tw.env
+}
+
+func (tw *termWriter) write(nodes ...types.Node) error {
+	for _, n := range nodes {
+		if !tw.matchEnv(n.Env()) {
+			continue
+		}
+		switch n := n.(type) {
+		case *types.TextNode:
+			tw.text(n)
+		case *types.ImageNode:
+			tw.image(n)
+		case *types.URLNode:
+			tw.url(n)
+		case *types.ButtonNode:
+			tw.button(n)
+		case *types.CodeNode:
+			tw.code(n)
+		case *types.ListNode:
+			tw.list(n)
+		case *types.ImportNode:
+			tw.write(n.Content.Nodes...)
+		case *types.ItemsListNode:
+			tw.itemsList(n)
+		case *types.GridNode:
+			tw.table(n)
+		case *types.InfoboxNode:
+			tw.infobox(n)
+		case *types.SurveyNode:
+			tw.survey(n)
+		case *types.HeaderNode:
+			tw.header(n)
+		case *types.YouTubeNode:
+			tw.embed("video", "https://www.youtube.com/watch?v="+n.VideoID)
+		case *types.IframeNode:
+			tw.embed("embed", n.URL)
+		case *types.TermNode:
+			tw.term(n)
+		}
+		if tw.err != nil {
+			return tw.err
+		}
+	}
+	return nil
+}
+
+func (tw *termWriter) text(n *types.TextNode) {
+	var style string
+	if n.Bold {
+		style += ansiBold
+	}
+	if n.Italic {
+		style += ansiItalic
+	}
+	if n.Code {
+		style += ansiYellow
+	}
+	if style == "" {
+		tw.writeString(n.Value)
+		return
+	}
+	tw.writeStyled(style, n.Value)
+}
+
+func (tw *termWriter) image(n *types.ImageNode) {
+	tw.space()
+	if n.Placeholder != "" {
+		tw.writeStyled(ansiYellow, "[placeholder: "+n.Placeholder+"]")
+		return
+	}
+	alt := n.Alt
+	if alt == "" {
+		alt = path.Base(n.Src)
+	}
+	tw.writeStyled(ansiDim, "[image: "+alt+"]")
+}
+
+func (tw *termWriter) url(n *types.URLNode) {
+	tw.writeBytes([]byte(ansiUnderline))
+	tw.write(n.Content.Nodes...)
+	tw.writeBytes([]byte(ansiReset))
+	if n.URL != "" && !strings.HasPrefix(n.URL, "#") {
+		tw.writeStyled(ansiDim, " <"+n.URL+">")
+	}
+}
+
+func (tw *termWriter) button(n *types.ButtonNode) {
+	tw.writeStyled(ansiBold, "[ ")
+	tw.write(n.Content.Nodes...)
+	tw.writeStyled(ansiBold, " ]")
+}
+
+func (tw *termWriter) code(n *types.CodeNode) {
+	if n.Empty() {
+		return
+	}
+	tw.newBlock()
+	if n.Title != "" {
+		tw.writeStyled(ansiDim, "  "+n.Title)
+		tw.writeBytes(newLine)
+	}
+	lang := strings.TrimPrefix(n.Lang, "language-")
+	for _, l := range strings.Split(strings.TrimRight(n.Value, "\n"), "\n") {
+		tw.writeStyled(ansiDim, "  │ ")
+		if n.Term {
+			tw.writeString(highlightConsole(l))
+		} else {
+			tw.writeString(highlightCode(lang, l))
+		}
+		tw.writeBytes(newLine)
+	}
+}
+
+func (tw *termWriter) list(n *types.ListNode) {
+	if n.Block() == true {
+		tw.newBlock()
+	}
+	tw.write(n.Nodes...)
+	if !tw.lineStart {
+		tw.writeBytes(newLine)
+	}
+}
+
+func (tw *termWriter) itemsList(n *types.ItemsListNode) {
+	if tw.lists > 0 {
+		// nested lists start on a line of their own
+		if !tw.lineStart {
+			tw.writeBytes(newLine)
+		}
+	} else if n.Block() == true {
+		tw.newBlock()
+	}
+	tw.lists++
+	defer func() { tw.lists-- }()
+	prefix := tw.prefix
+	for i, item := range n.Items {
+		s := "  • "
+		switch {
+		case n.Type() == types.NodeItemsCheck:
+			s = "  ☐ "
+		case n.ListType != "" || n.Start > 0:
+			start := n.Start
+			if start == 0 {
+				start = 1
+			}
+			s = "  " + strconv.Itoa(i+start) + ". "
+		}
+		tw.writeString(s)
+		tw.prefix = prefix + strings.Repeat(" ", len([]rune(s)))
+		for _, cn := range item.Nodes {
+			cn.MutateBlock(false)
+		}
+		tw.write(item.Nodes...)
+		tw.prefix = prefix
+		if !tw.lineStart {
+			tw.writeBytes(newLine)
+		}
+	}
+}
+
+func (tw *termWriter) table(n *types.GridNode) {
+	tw.newBlock()
+	for i, row := range n.Rows {
+		for j, cell := range row {
+			if j > 0 {
+				tw.writeStyled(ansiDim, " │ ")
+			}
+			if i == 0 {
+				tw.writeBytes([]byte(ansiBold))
+			}
+			for _, cn := range cell.Content.Nodes {
+				cn.MutateBlock(false)
+				tw.write(cn)
+			}
+			if i == 0 {
+				tw.writeBytes([]byte(ansiReset))
+			}
+		}
+		tw.writeBytes(newLine)
+	}
+}
+
+func (tw *termWriter) infobox(n *types.InfoboxNode) {
+	tw.newBlock()
+	color := ansiGreen
+	if n.Kind == types.InfoboxNegative {
+		color = ansiYellow
+	}
+	prefix := tw.prefix
+	tw.prefix = prefix + color + "┃" + ansiReset + " "
+	for _, cn := range n.Content.Nodes {
+		cn.MutateBlock(false)
+		tw.write(cn)
+	}
+	if !tw.lineStart {
+		tw.writeBytes(newLine)
+	}
+	tw.prefix = prefix
+}
+
+func (tw *termWriter) survey(n *types.SurveyNode) {
+	tw.newBlock()
+	for _, g := range n.Picked() {
+		tw.writeStyled(ansiBold, g.Name)
+		tw.writeBytes(newLine)
+		for _, o := range g.Options {
+			tw.writeString("  ○ " + o + "\n")
+		}
+	}
+}
+
+func (tw *termWriter) header(n *types.HeaderNode) {
+	tw.newBlock()
+	tw.writeBytes([]byte(ansiBold + ansiCyan))
+	tw.write(n.Content.Nodes...)
+	tw.writeBytes([]byte(ansiReset))
+	if !tw.lineStart {
+		tw.writeBytes(newLine)
+	}
+}
+
+// embed writes a reference to an embedded resource of kind, e.g. a video.
+func (tw *termWriter) embed(kind, url string) {
+	tw.newBlock()
+	tw.writeStyled(ansiDim, "["+kind+": "+url+"]")
+	tw.writeBytes(newLine)
+}
+
+func (tw *termWriter) term(n *types.TermNode) {
+	tw.writeStyled(ansiUnderline, n.Term)
+	if n.Definition != "" {
+		tw.writeStyled(ansiDim, " ("+n.Definition+")")
+	}
+}
+
+// highlightConsole colors the prompt of a console command line.
+func highlightConsole(line string) string {
+	for _, p := range []string{"$ ", "# ", "> "} {
+		if strings.HasPrefix(line, p) {
+			return ansiBold + ansiGreen + p + ansiReset + line[len(p):]
+		}
+	}
+	return line
+}
+
+// codeKeywords are highlighted in code snippets of any language.
+var codeKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "case": true,
+	"catch": true, "class": true, "const": true, "continue": true, "def": true,
+	"default": true, "defer": true, "elif": true, "else": true, "except": true,
+	"export": true, "extends": true, "false": true, "False": true, "finally": true,
+	"fn": true, "for": true, "from": true, "func": true, "function": true,
+	"go": true, "if": true, "impl": true, "import": true, "in": true,
+	"interface": true, "lambda": true, "let": true, "match": true, "new": true,
+	"nil": true, "None": true, "null": true, "package": true, "private": true,
+	"pub": true, "public": true, "raise": true, "range": true, "return": true,
+	"self": true, "static": true, "struct": true, "switch": true, "this": true,
+	"throw": true, "true": true, "True": true, "try": true, "type": true,
+	"use": true, "var": true, "while": true, "with": true, "yield": true,
+}
+
+// hashCommentLangs are languages with "#" line comments.
+var hashCommentLangs = map[string]bool{
+	"bash": true, "dockerfile": true, "makefile": true, "perl": true,
+	"py": true, "python": true, "r": true, "rb": true, "ruby": true,
+	"sh": true, "shell": true, "toml": true, "yaml": true, "yml": true,
+}
+
+// highlightCode colors keywords, strings, numbers and comments of a single
+// line of code written in lang.
+// It is a simple lexer which is good enough for a preview, not a parser
+// of any particular language.
+func highlightCode(lang, line string) string {
+	var b strings.Builder
+	r := []rune(line)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case c == '/' && i+1 < len(r) && r[i+1] == '/',
+			c == '#' && hashCommentLangs[strings.ToLower(lang)]:
+			b.WriteString(ansiDim + string(r[i:]) + ansiReset)
+			return b.String()
+		case c == '"' || c == '\'' || c == '`':
+			j := i + 1
+			for j < len(r) && r[j] != c {
+				if r[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(r) {
+				j = len(r) - 1
+			}
+			b.WriteString(ansiGreen + string(r[i:j+1]) + ansiReset)
+			i = j + 1
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(r) && (unicode.IsDigit(r[j]) || r[j] == '.' || r[j] == 'x' || r[j] == '_') {
+				j++
+			}
+			b.WriteString(ansiMagenta + string(r[i:j]) + ansiReset)
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(r) && (unicode.IsLetter(r[j]) || unicode.IsDigit(r[j]) || r[j] == '_') {
+				j++
+			}
+			if w := string(r[i:j]); codeKeywords[w] {
+				b.WriteString(ansiBold + ansiBlue + w + ansiReset)
+			} else {
+				b.WriteString(w)
+			}
+			i = j
+		default:
+			b.WriteRune(c)
+			i++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestTerm(t *testing.T) {
+	ib := &types.InfoboxNode{Kind: types.InfoboxNegative, Content: types.NewListNode(types.NewTextNode("Careful"))}
+	data := &Context{
+		Meta: &types.Meta{Title: "Lab", Summary: "A lab"},
+		Steps: []*types.Step{
+			{Title: "Intro", Content: types.NewListNode(
+				types.NewHeaderNode(2, types.NewTextNode("Setup")),
+				types.NewCodeNode("x := 1 // one", false, "go"),
+				ib,
+			)},
+			{Title: "Done", Content: types.NewListNode(types.NewTextNode("Bye"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "term", data); err != nil {
+		t.Fatal(err)
+	}
+	got := ansiRegexp.ReplaceAllString(buf.String(), "")
+	for _, want := range []string{
+		"Lab\nA lab\n",
+		"━━ 1. Intro ━━",
+		"\nSetup\n",
+		"  │ x := 1 // one\n",
+		"┃ Careful\n",
+		"━━ 2. Done ━━",
+		"Bye\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestTermEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"android", "web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "term", data); err != nil {
+		t.Fatal(err)
+	}
+	got := ansiRegexp.ReplaceAllString(buf.String(), "")
+	if strings.Contains(got, "Android") || strings.Contains(got, "Gradle") || !strings.Contains(got, "━━ 1. Web ━━") {
+		t.Errorf("step of other environments rendered:\n%s", got)
+	}
+}
+
+func TestHighlightCode(t *testing.T) {
+	tests := []struct{ lang, in, out string }{
+		{"go", `return "a" // b`, ansiBold + ansiBlue + "return" + ansiReset + " " + ansiGreen + `"a"` + ansiReset + " " + ansiDim + "// b" + ansiReset},
+		{"python", "x = 42 # y", "x = " + ansiMagenta + "42" + ansiReset + " " + ansiDim + "# y" + ansiReset},
+		{"go", "x #y", "x #y"},
+		{"js", `'open`, ansiGreen + "'open" + ansiReset},
+	}
+	for _, test := range tests {
+		if v := highlightCode(test.lang, test.in); v != test.out {
+			t.Errorf("highlightCode(%q, %q) = %q; want %q", test.lang, test.in, v, test.out)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func init() {
+	register("text", textRenderer{})
+	register("gemtext", textRenderer{gem: true})
+}
+
+// textRenderer renders a codelab as plain text, or as Gemini gemtext
+// if gem is true.
+// Both keep the structure of steps, lists and code blocks but no inline
+// formatting.
+type textRenderer struct {
+	gem bool
+}
+
+func (r textRenderer) Ext() string {
+	if r.gem {
+		return "gmi"
+	}
+	return "txt"
+}
+
+func (r textRenderer) Render(w io.Writer, ctx *Context) error {
+	tw := textWriter{w: w, env: ctx.Env, gem: r.gem, lineStart: true}
+	if m := ctx.Meta; m != nil {
+		tw.title(1, m.Title)
+		if m.Summary != "" {
+			tw.newBlock()
+			tw.text(m.Summary + "\n")
+		}
+	}
+	for i, st := range envSteps(ctx.Steps, ctx.Env) {
+		tw.newBlock()
+		tw.title(2, fmt.Sprintf("%d. %s", i+1, st.Title))
+		if err := tw.write(st.Content.Nodes...); err != nil {
+			return err
+		}
+		tw.flushLinks()
+	}
+	return tw.err
+}
+
+// textLink is a link written on its own line by gemtext.
+type textLink struct {
+	url, text string
+}
+
+type textWriter struct {
+	w         io.Writer // output writer
+	env       string    // target environment
+	err       error     // error during any writeXxx methods
+	gem       bool      // write gemtext instead of plain text
+	lineStart bool
+	prefix    string     // prefix of each line, e.g. quote marker
+	links     []textLink // gemtext links of the current block
+	lists     int        // depth of the lists being written
+}
+
+func (tw *textWriter) writeBytes(b []byte) {
+	if tw.err != nil {
+		return
+	}
+	tw.lineStart = len(b) > 0 && b[len(b)-1] == '\n'
+	_, tw.err = tw.w.Write(b)
+}
+
+// writeString writes s, prefixing each line with tw.prefix.
+func (tw *textWriter) writeString(s string) {
+	for _, l := range strings.SplitAfter(s, "\n") {
+		if l == "" {
+			continue
+		}
+		if tw.lineStart {
+			l = tw.prefix + l
+		}
+		tw.writeBytes([]byte(l))
+	}
+}
+
+// gemLineTypes are prefixes of gemtext lines other than text lines.
+var gemLineTypes = []string{"=>", "#", "*", ">", "```"}
+
+// text writes content text s. Gemtext lines of s starting like
+// links, headings, list items, quotes or preformatted toggles
+// are written with a leading space, which makes them text lines.
+func (tw *textWriter) text(s string) {
+	if !tw.gem || tw.prefix != "" {
+		tw.writeString(s)
+		return
+	}
+	for _, l := range strings.SplitAfter(s, "\n") {
+		if tw.lineStart {
+			for _, p := range gemLineTypes {
+				if strings.HasPrefix(l, p) {
+					l = " " + l
+					break
+				}
+			}
+		}
+		tw.writeString(l)
+	}
+}
+
+func (tw *textWriter) endLine() {
+	if !tw.lineStart {
+		tw.writeBytes(newLine)
+	}
+}
+
+func (tw *textWriter) newBlock() {
+	tw.endLine()
+	tw.flushLinks()
+	tw.writeBytes(newLine)
+}
+
+// flushLinks writes gemtext link lines collected since the last block.
+// Gemtext has no inline links; they go right after the text block.
+func (tw *textWriter) flushLinks() {
+	if len(tw.links) == 0 {
+		return
+	}
+	tw.endLine()
+	for _, l := range tw.links {
+		tw.writeBytes([]byte(strings.TrimSpace("=> " + l.url + " " + l.text)))
+		tw.writeBytes(newLine)
+	}
+	tw.links = nil
+}
+
+// title writes a codelab or step title of level 1 or 2.
+func (tw *textWriter) title(level int, s string) {
+	if tw.gem {
+		tw.writeString(strings.Repeat("#", level) + " " + s + "\n")
+		return
+	}
+	u := "="
+	if level > 1 {
+		u = "-"
+	}
+	tw.writeString(s + "\n" + strings.Repeat(u, len([]rune(s))) + "\n")
+}
+
+func (tw *textWriter) matchEnv(v []string) bool {
+	if len(v) == 0 || tw.env == "" {
+		return true
+	}
+	i := sort.SearchStrings(v, tw.env)
+	return i < len(v) && v[i] == tw.env
+}
+
+func (tw *textWriter) write(nodes ...types.Node) error {
+	for _, n := range nodes {
+		if !tw.matchEnv(n.Env()) {
+			continue
+		}
+		switch n := n.(type) {
+		case *types.TextNode:
+			tw.text(n.Value)
+		case *types.ImageNode:
+			tw.image(n)
+		case *types.URLNode:
+			tw.url(n)
+		case *types.ButtonNode:
+			tw.write(n.Content.Nodes...)
+		case *types.CodeNode:
+			tw.code(n)
+		case *types.ListNode:
+			tw.list(n)
+		case *types.ImportNode:
+			tw.write(n.Content.Nodes...)
+		case *types.ItemsListNode:
+			tw.itemsList(n)
+		case *types.GridNode:
+			tw.table(n)
+		case *types.InfoboxNode:
+			tw.infobox(n)
+		case *types.SurveyNode:
+			tw.survey(n)
+		case *types.HeaderNode:
+			tw.header(n)
+		case *types.YouTubeNode:
+			tw.embed("https://www.youtube.com/watch?v="+n.VideoID, "YouTube video")
+		case *types.IframeNode:
+			tw.embed(n.URL, "Embedded content")
+		case *types.TermNode:
+			tw.text(n.Term)
+			if n.Definition != "" {
+				tw.text(" (" + n.Definition + ")")
+			}
+		}
+		if tw.err != nil {
+			return tw.err
+		}
+	}
+	return nil
+}
+
+func (tw *textWriter) image(n *types.ImageNode) {
+	if n.Placeholder != "" {
+		tw.writeString("[Placeholder: " + n.Placeholder + "]")
+		return
+	}
+	alt := n.Alt
+	if alt == "" {
+		alt = path.Base(n.Src)
+	}
+	if tw.gem {
+		tw.links = append(tw.links, textLink{n.Src, alt})
+		return
+	}
+	tw.writeString("[" + alt + "]")
+}
+
+func (tw *textWriter) url(n *types.URLNode) {
+	tw.write(n.Content.Nodes...)
+	if n.URL == "" || strings.HasPrefix(n.URL, "#") {
+		return
+	}
+	if tw.gem {
+		tw.links = append(tw.links, textLink{n.URL, PlainText(n.Content.Nodes)})
+		return
+	}
+	tw.writeString(" <" + n.URL + ">")
+}
+
+// embed writes a reference to an embedded resource at url.
+func (tw *textWriter) embed(url, text string) {
+	tw.newBlock()
+	if tw.gem {
+		tw.links = append(tw.links, textLink{url, text})
+		tw.flushLinks()
+		return
+	}
+	tw.writeString(text + ": " + url + "\n")
+}
+
+func (tw *textWriter) code(n *types.CodeNode) {
+	if n.Empty() {
+		return
+	}
+	tw.newBlock()
+	lang := strings.TrimPrefix(n.Lang, "language-")
+	if n.Term {
+		lang = "console"
+	}
+	tw.writeString("```" + lang + "\n")
+	v := n.Value
+	if tw.gem {
+		// a line starting with ``` would close the preformatted block
+		lines := strings.SplitAfter(v, "\n")
+		for i, l := range lines {
+			if strings.HasPrefix(l, "```") {
+				lines[i] = " " + l
+			}
+		}
+		v = strings.Join(lines, "")
+	}
+	tw.writeString(v)
+	tw.endLine()
+	tw.writeString("```\n")
+}
+
+func (tw *textWriter) list(n *types.ListNode) {
+	if n.Block() == true {
+		tw.newBlock()
+	}
+	tw.write(n.Nodes...)
+	tw.endLine()
+}
+
+func (tw *textWriter) itemsList(n *types.ItemsListNode) {
+	if tw.lists > 0 {
+		// nested lists start on a line of their own
+		tw.endLine()
+	} else if n.Block() == true {
+		tw.newBlock()
+	}
+	tw.lists++
+	defer func() { tw.lists-- }()
+	prefix := tw.prefix
+	for i, item := range n.Items {
+		s := "* "
+		if !tw.gem && (n.ListType != "" || n.Start > 0) {
+			start := n.Start
+			if start == 0 {
+				start = 1
+			}
+			s = strconv.Itoa(i+start) + ". "
+		}
+		tw.writeString(s)
+		if !tw.gem {
+			// gemtext has no nested lists
+			tw.prefix = prefix + strings.Repeat(" ", len(s))
+		}
+		for _, cn := range item.Nodes {
+			cn.MutateBlock(false)
+		}
+		tw.write(item.Nodes...)
+		tw.prefix = prefix
+		tw.endLine()
+	}
+}
+
+func (tw *textWriter) table(n *types.GridNode) {
+	tw.newBlock()
+	for _, row := range n.Rows {
+		for j, cell := range row {
+			if j > 0 {
+				tw.writeString(" | ")
+			}
+			for _, cn := range cell.Content.Nodes {
+				cn.MutateBlock(false)
+				tw.write(cn)
+			}
+		}
+		tw.endLine()
+	}
+}
+
+func (tw *textWriter) infobox(n *types.InfoboxNode) {
+	tw.newBlock()
+	tw.prefix = "> "
+	for _, cn := range n.Content.Nodes {
+		cn.MutateBlock(false)
+		tw.write(cn)
+	}
+	tw.endLine()
+	tw.prefix = ""
+}
+
+func (tw *textWriter) survey(n *types.SurveyNode) {
+	for _, g := range n.Picked() {
+		tw.newBlock()
+		tw.writeString(g.Name + "\n")
+		for _, o := range g.Options {
+			tw.writeString("* " + o + "\n")
+		}
+	}
+}
+
+func (tw *textWriter) header(n *types.HeaderNode) {
+	tw.newBlock()
+	if tw.gem {
+		tw.writeString("### ")
+	}
+	tw.write(n.Content.Nodes...)
+	tw.endLine()
+}
+
+// PlainText returns concatenated values of all text nodes in nodes.
+func PlainText(nodes []types.Node) string {
+	var b strings.Builder
+	types.Walk(nodes, func(n types.Node) bool {
+		if t, ok := n.(*types.TextNode); ok {
+			b.WriteString(t.Value)
+		}
+		return true
+	})
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,152 @@
+// Copyright 2016 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func textTestContext() *Context {
+	p := types.NewListNode(
+		types.NewTextNode("See "),
+		types.NewURLNode("https://example.com", types.NewTextNode("docs")),
+		types.NewTextNode("."),
+	)
+	p.MutateBlock(true)
+	list := &types.ItemsListNode{ListType: "1"}
+	list.MutateBlock(true)
+	list.NewItem(types.NewTextNode("one"))
+	list.NewItem(types.NewTextNode("two"))
+	return &Context{
+		Meta: &types.Meta{Title: "Lab", Summary: "A lab"},
+		Steps: []*types.Step{{
+			Title: "Intro",
+			Content: types.NewListNode(
+				p,
+				list,
+				types.NewCodeNode("echo hi", true, ""),
+			),
+		}},
+	}
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Execute(&buf, "text", textTestContext()); err != nil {
+		t.Fatal(err)
+	}
+	want := "Lab\n===\n\nA lab\n\n1. Intro\n--------\n\n" +
+		"See docs <https://example.com>.\n\n" +
+		"1. one\n2. two\n\n" +
+		"```console\necho hi\n```\n"
+	if buf.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestGemtext(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Execute(&buf, "gemtext", textTestContext()); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Lab\n\nA lab\n\n## 1. Intro\n\n" +
+		"See docs.\n=> https://example.com docs\n\n" +
+		"* one\n* two\n\n" +
+		"```console\necho hi\n```\n"
+	if buf.String() != want {
+		t.Errorf("gemtext:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestTextEnvSteps(t *testing.T) {
+	data := &Context{
+		Env:  "web",
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{
+			{Title: "Android", Tags: []string{"android"}, Content: types.NewListNode(types.NewTextNode("Gradle"))},
+			{Title: "Web", Tags: []string{"web"}, Content: types.NewListNode(types.NewTextNode("npm"))},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "text", data); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Lab\n===\n\n1. Web\n------\nnpm"; buf.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestTextNestedList(t *testing.T) {
+	inner := types.NewItemsListNode("", 0)
+	inner.NewItem(types.NewTextNode("three"))
+	list := types.NewItemsListNode("", 0)
+	list.NewItem(types.NewTextNode("one"), inner)
+	list.NewItem(types.NewTextNode("two"))
+	ctx := &Context{
+		Meta:  &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{{Title: "Intro", Content: types.NewListNode(list)}},
+	}
+	tests := map[string]string{
+		"text":    "* one\n  * three\n* two\n",
+		"gemtext": "* one\n* three\n* two\n",
+	}
+	for format, want := range tests {
+		var buf bytes.Buffer
+		if err := Execute(&buf, format, ctx); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n\n"+want)) {
+			t.Errorf("%s:\n%s\nwant suffix:\n%s", format, buf.String(), want)
+		}
+	}
+}
+
+func TestGemtextCodeFence(t *testing.T) {
+	ctx := &Context{
+		Meta: &types.Meta{Title: "Lab"},
+		Steps: []*types.Step{{Title: "Intro", Content: types.NewListNode(
+			types.NewCodeNode("Fence code with:\n```go\nx := 1\n```\n", false, "language-md"),
+		)}},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "gemtext", ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Lab\n\n## 1. Intro\n\n```md\nFence code with:\n ```go\nx := 1\n ```\n```\n"
+	if buf.String() != want {
+		t.Errorf("gemtext:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestGemtextLineTypes(t *testing.T) {
+	p := types.NewListNode(types.NewTextNode("=> not a link\n# not a heading\n* not an item\n> not a quote\n```not a toggle\n#1 and a # are text"))
+	p.MutateBlock(true)
+	ctx := &Context{
+		Meta:  &types.Meta{Title: "Lab", Summary: "# not a heading"},
+		Steps: []*types.Step{{Title: "Intro", Content: types.NewListNode(p)}},
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "gemtext", ctx); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Lab\n\n # not a heading\n\n## 1. Intro\n\n" +
+		" => not a link\n # not a heading\n * not an item\n > not a quote\n ```not a toggle\n #1 and a # are text\n"
+	if buf.String() != want {
+		t.Errorf("gemtext:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	htmlTemplate "html/template"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Theme is a named bundle of colors, logo and typography of the html format,
+// emitted as CSS custom properties, e.g. --claat-color-primary.
+type Theme struct {
+	// Colors are CSS colors keyed by role, such as "primary",
+	// the title bar, or "link". Each is emitted as --claat-color-<role>.
+	Colors map[string]string `yaml:"colors,omitempty" json:"colors,omitempty"`
+	// Logo is the URL of an image shown on each page, emitted as --claat-logo.
+	Logo string `yaml:"logo,omitempty" json:"logo,omitempty"`
+	// Font and CodeFont are CSS font-family values of text and code,
+	// emitted as --claat-font and --claat-code-font.
+	Font     string `yaml:"font,omitempty" json:"font,omitempty"`
+	CodeFont string `yaml:"code_font,omitempty" json:"code_font,omitempty"`
+	// FontURL is a stylesheet loading the fonts, such as Google Fonts.
+	FontURL string `yaml:"font_url,omitempty" json:"font_url,omitempty"`
+}
+
+var (
+	// themeRoleRegexp matches valid color roles.
+	themeRoleRegexp = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// themeValueRegexp matches CSS values which cannot escape a declaration.
+	themeValueRegexp = regexp.MustCompile(`^[^;{}<>\\]*$`)
+)
+
+// Validate returns an error if t has invalid color roles, or values
+// which could escape their CSS declarations.
+func (t *Theme) Validate() error {
+	for role, c := range t.Colors {
+		if !themeRoleRegexp.MatchString(role) {
+			return fmt.Errorf("invalid color role %q", role)
+		}
+		if c == "" || !themeValueRegexp.MatchString(c) {
+			return fmt.Errorf("color %s: invalid value %q", role, c)
+		}
+	}
+	for _, v := range []string{t.Font, t.CodeFont} {
+		if !themeValueRegexp.MatchString(v) {
+			return fmt.Errorf("invalid font %q", v)
+		}
+	}
+	if strings.ContainsAny(t.Logo, "\"\\\n<>") {
+		return fmt.Errorf("invalid logo URL %q", t.Logo)
+	}
+	return nil
+}
+
+// ThemeColor returns the primary color of t, or the default theme color.
+func ThemeColor(t *Theme) string {
+	if t != nil && t.Colors["primary"] != "" {
+		return t.Colors["primary"]
+	}
+	return "#4F7DC9"
+}
+
+// themeStyle returns a style element declaring custom properties of t
+// and applying them to the page, or nothing if t is nil.
+func themeStyle(t *Theme) htmlTemplate.HTML {
+	if t == nil {
+		return ""
+	}
+	var vars, rules []string
+	roles := make([]string, 0, len(t.Colors))
+	for role := range t.Colors {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		vars = append(vars, fmt.Sprintf("--claat-color-%s: %s;", role, t.Colors[role]))
+	}
+	if c := t.Colors["primary"]; c != "" {
+		rules = append(rules, "google-codelab #codelab-title { background-color: var(--claat-color-primary); }")
+	}
+	if c := t.Colors["link"]; c != "" {
+		rules = append(rules, "google-codelab-step a { color: var(--claat-color-link); }")
+	}
+	if t.Logo != "" {
+		vars = append(vars, fmt.Sprintf("--claat-logo: url(%q);", t.Logo))
+	}
+	if t.Font != "" {
+		vars = append(vars, "--claat-font: "+t.Font+";")
+		rules = append(rules, "body, google-codelab { font-family: var(--claat-font); }")
+	}
+	if t.CodeFont != "" {
+		vars = append(vars, "--claat-code-font: "+t.CodeFont+";")
+		rules = append(rules, "code, pre { font-family: var(--claat-code-font); }")
+	}
+	if len(vars) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("<style>\n    :root {\n")
+	for _, v := range vars {
+		fmt.Fprintf(&b, "      %s\n", v)
+	}
+	b.WriteString("    }\n")
+	for _, r := range rules {
+		fmt.Fprintf(&b, "    %s\n", r)
+	}
+	b.WriteString("  </style>")
+	return htmlTemplate.HTML(b.String())
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestThemeValidate(t *testing.T) {
+	valid := &Theme{
+		Colors:   map[string]string{"primary": "#1a73e8", "link-hover": "rgb(0, 0, 0)"},
+		Logo:     "https://example.com/logo.svg",
+		Font:     "'Google Sans', sans-serif",
+		CodeFont: "monospace",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate(%+v) = %v", valid, err)
+	}
+	for _, th := range []*Theme{
+		{Colors: map[string]string{"Primary": "red"}},
+		{Colors: map[string]string{"primary": ""}},
+		{Colors: map[string]string{"primary": "red; } body { display: none"}},
+		{Font: "serif</style>"},
+		{Logo: `logo.svg") ; x: url("`},
+	} {
+		if err := th.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil; want error", th)
+		}
+	}
+}
+
+func TestExecuteTheme(t *testing.T) {
+	data := &struct {
+		Context
+	}{Context: Context{
+		Meta:  &types.Meta{},
+		Steps: []*types.Step{{Title: "One", Content: types.NewListNode()}},
+		Theme: &Theme{
+			Colors:  map[string]string{"primary": "#1a73e8", "accent": "#fbbc04"},
+			Logo:    "https://example.com/logo.svg",
+			Font:    "'Google Sans', sans-serif",
+			FontURL: "https://fonts.example.com/css",
+		},
+	}}
+	var buf bytes.Buffer
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<meta name="theme-color" content="#1a73e8">`,
+		`<link rel="stylesheet" href="https://fonts.example.com/css">`,
+		"--claat-color-accent: #fbbc04;\n      --claat-color-primary: #1a73e8;",
+		`--claat-logo: url("https://example.com/logo.svg");`,
+		"--claat-font: 'Google Sans', sans-serif;",
+		"google-codelab #codelab-title { background-color: var(--claat-color-primary); }",
+		`<img class="codelab-logo" src="https://example.com/logo.svg" alt="">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Execute(html) does not contain %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	data.Theme = nil
+	if err := Execute(&buf, "html", data); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "--claat-") || !strings.Contains(out, `content="#4F7DC9"`) {
+		t.Errorf("Execute(html) with no theme:\n%s", out)
+	}
+}
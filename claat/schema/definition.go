@@ -0,0 +1,117 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+// definitionV2 is the JSON Schema of V2 codelabs. It must be kept in sync
+// with the Codelab type; additional properties are allowed so that
+// validation keeps passing as optional fields are added.
+const definitionV2 = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://github.com/googlecodelabs/tools/claat/schema/v2.json",
+  "title": "Codelab",
+  "type": "object",
+  "required": ["schema", "id", "title", "steps"],
+  "properties": {
+    "schema": {"const": "v2"},
+    "id": {"type": "string"},
+    "title": {"type": "string"},
+    "summary": {"type": "string"},
+    "authors": {"type": "string"},
+    "categories": {"type": "array", "items": {"type": "string"}},
+    "tags": {"type": "array", "items": {"type": "string"}, "description": "Environments of the codelab"},
+    "duration": {"type": "integer", "minimum": 0, "description": "Minutes"},
+    "lang": {"type": "string"},
+    "source": {"type": "string"},
+    "feedback": {"type": "string"},
+    "updated": {"type": "string", "format": "date-time"},
+    "steps": {"type": "array", "items": {"$ref": "#/definitions/step"}},
+    "prerequisites": {"type": "array", "items": {"type": "string"}, "description": "IDs or URLs of codelabs to take before this one"},
+    "related": {"type": "array", "items": {"type": "string"}, "description": "IDs or URLs of codelabs to take next"},
+    "description": {"type": "string", "description": "Link preview description; summary if empty"},
+    "hero_image": {"type": "string", "description": "Link preview image URL"},
+    "revision": {"type": "string", "description": "Source revision, e.g. a git commit"}
+  },
+  "definitions": {
+    "step": {
+      "type": "object",
+      "required": ["title", "content"],
+      "properties": {
+        "title": {"type": "string"},
+        "tags": {"type": "array", "items": {"type": "string"}, "description": "Environments of the step"},
+        "duration": {"type": "integer", "minimum": 0, "description": "Minutes"},
+        "content": {"$ref": "#/definitions/nodes"}
+      }
+    },
+    "nodes": {"type": "array", "items": {"$ref": "#/definitions/node"}},
+    "node": {
+      "type": "object",
+      "required": ["type"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "description": "Node type; consumers should skip nodes of unknown types",
+          "examples": ["text", "paragraph", "group", "link", "button", "image", "code", "list",
+            "header", "infobox", "table", "survey", "youtube", "iframe", "term"]
+        },
+        "env": {"type": "array", "items": {"type": "string"}},
+        "value": {"type": "string"},
+        "bold": {"type": "boolean"},
+        "italic": {"type": "boolean"},
+        "code": {"type": "boolean"},
+        "url": {"type": "string"},
+        "alt": {"type": "string"},
+        "title": {"type": "string"},
+        "width": {"type": "number", "description": "Pixels"},
+        "placeholder": {"type": "string"},
+        "lang": {"type": "string"},
+        "console": {"type": "boolean"},
+        "added": {"type": "array", "items": {"type": "integer", "minimum": 1}},
+        "removed": {"type": "array", "items": {"type": "integer", "minimum": 1}},
+        "download": {"type": "boolean"},
+        "level": {"type": "integer", "minimum": 1},
+        "style": {"enum": ["checklist", "faq"]},
+        "ordered": {"type": "boolean"},
+        "start": {"type": "integer"},
+        "items": {"type": "array", "items": {"$ref": "#/definitions/nodes"}},
+        "kind": {"enum": ["positive", "negative"]},
+        "rows": {"type": "array", "items": {"type": "array", "items": {"$ref": "#/definitions/cell"}}},
+        "id": {"type": "string"},
+        "pick": {"type": "integer", "minimum": 0},
+        "groups": {"type": "array", "items": {"$ref": "#/definitions/surveyGroup"}},
+        "video_id": {"type": "string"},
+        "definition": {"type": "string"},
+        "children": {"$ref": "#/definitions/nodes"}
+      }
+    },
+    "cell": {
+      "type": "object",
+      "required": ["colspan", "rowspan", "content"],
+      "properties": {
+        "colspan": {"type": "integer"},
+        "rowspan": {"type": "integer"},
+        "content": {"$ref": "#/definitions/nodes"}
+      }
+    },
+    "surveyGroup": {
+      "type": "object",
+      "required": ["name", "options"],
+      "properties": {
+        "name": {"type": "string"},
+        "options": {"type": "array", "items": {"type": "string"}}
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,334 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Changes of a step or node in a Diff.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Renamed = "renamed" // step only, title changed but content is similar
+	Changed = "changed"
+)
+
+// Diff is a structural comparison of two versions of a codelab.
+// It is empty if the versions are the same.
+type Diff struct {
+	Meta  []*FieldChange `json:"meta,omitempty"`
+	Steps []*StepChange  `json:"steps,omitempty"`
+}
+
+// Empty reports whether d has no changes.
+func (d *Diff) Empty() bool {
+	return len(d.Meta) == 0 && len(d.Steps) == 0
+}
+
+// FieldChange is a changed metadata field, by its JSON name.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// StepChange is an added, removed, renamed or changed step.
+// Indexes are 1-based, or 0 in the version the step is not in.
+type StepChange struct {
+	Change      string        `json:"change"`
+	OldIndex    int           `json:"old_index,omitempty"`
+	NewIndex    int           `json:"new_index,omitempty"`
+	OldTitle    string        `json:"old_title,omitempty"`
+	NewTitle    string        `json:"new_title,omitempty"`
+	OldDuration int           `json:"old_duration,omitempty"` // Minutes
+	NewDuration int           `json:"new_duration,omitempty"` // Minutes
+	Nodes       []*NodeChange `json:"nodes,omitempty"`        // Of renamed and changed steps
+}
+
+// NodeChange is an added, removed or changed top-level content node
+// of a step. Old and New are short summaries of the node content.
+type NodeChange struct {
+	Change string `json:"change"`
+	Type   string `json:"type"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// renameSimilarity is the minimum share of content nodes two steps have
+// in common for a step with a new title to be considered renamed.
+const renameSimilarity = 0.5
+
+// Compare returns the changes from codelab a to codelab b.
+// Steps are matched by title, in order, and steps of different titles
+// with mostly the same content are reported as renamed.
+// The updated time of the codelabs is not compared.
+func Compare(a, b *Codelab) *Diff {
+	d := &Diff{Meta: compareMeta(a, b)}
+	ta, tb := make([]string, len(a.Steps)), make([]string, len(b.Steps))
+	for i, s := range a.Steps {
+		ta[i] = s.Title
+	}
+	for i, s := range b.Steps {
+		tb[i] = s.Title
+	}
+	i, j := 0, 0
+	for _, p := range append(lcs(ta, tb), [2]int{len(ta), len(tb)}) {
+		d.Steps = append(d.Steps, compareUnmatched(a.Steps, b.Steps, i, p[0], j, p[1])...)
+		if p[0] < len(ta) {
+			if c := compareStep(a.Steps[p[0]], b.Steps[p[1]], p[0], p[1]); c != nil {
+				d.Steps = append(d.Steps, c)
+			}
+		}
+		i, j = p[0]+1, p[1]+1
+	}
+	return d
+}
+
+// compareMeta returns changed metadata fields of a and b.
+func compareMeta(a, b *Codelab) []*FieldChange {
+	fields := []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"id", a.ID, b.ID},
+		{"title", a.Title, b.Title},
+		{"summary", a.Summary, b.Summary},
+		{"authors", a.Authors, b.Authors},
+		{"categories", a.Categories, b.Categories},
+		{"tags", a.Tags, b.Tags},
+		{"duration", a.Duration, b.Duration},
+		{"lang", a.Lang, b.Lang},
+		{"source", a.Source, b.Source},
+		{"feedback", a.Feedback, b.Feedback},
+		{"prerequisites", a.Prerequisites, b.Prerequisites},
+		{"related", a.Related, b.Related},
+		{"description", a.Description, b.Description},
+		{"hero_image", a.HeroImage, b.HeroImage},
+		{"revision", a.Revision, b.Revision},
+	}
+	var res []*FieldChange
+	for _, f := range fields {
+		o, n := fieldString(f.old), fieldString(f.new)
+		if o != n {
+			res = append(res, &FieldChange{Field: f.name, Old: o, New: n})
+		}
+	}
+	return res
+}
+
+func fieldString(v interface{}) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case int:
+		if v == 0 {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// compareUnmatched returns changes of steps a[i:iend] and b[j:jend],
+// whose titles are not matched: renamed steps, paired in order,
+// and the rest removed and added.
+func compareUnmatched(a, b []*Step, i, iend, j, jend int) []*StepChange {
+	var res []*StepChange
+	for ; i < iend; i++ {
+		k := j
+		for k < jend && similarity(a[i], b[k]) < renameSimilarity {
+			k++
+		}
+		if k == jend {
+			res = append(res, &StepChange{Change: Removed, OldIndex: i + 1, OldTitle: a[i].Title, OldDuration: a[i].Duration})
+			continue
+		}
+		for ; j < k; j++ {
+			res = append(res, addedStep(b[j], j))
+		}
+		if c := compareStep(a[i], b[k], i, k); c != nil {
+			if c.OldTitle != c.NewTitle {
+				c.Change = Renamed
+			}
+			res = append(res, c)
+		}
+		j = k + 1
+	}
+	for ; j < jend; j++ {
+		res = append(res, addedStep(b[j], j))
+	}
+	return res
+}
+
+func addedStep(s *Step, j int) *StepChange {
+	return &StepChange{Change: Added, NewIndex: j + 1, NewTitle: s.Title, NewDuration: s.Duration}
+}
+
+// compareStep returns the changes of step a at index i to step b at
+// index j, or nil if only their indexes differ.
+func compareStep(a, b *Step, i, j int) *StepChange {
+	c := &StepChange{
+		Change:   Changed,
+		OldIndex: i + 1,
+		NewIndex: j + 1,
+		OldTitle: a.Title,
+		NewTitle: b.Title,
+		Nodes:    compareNodes(a.Content, b.Content),
+	}
+	if a.Duration != b.Duration {
+		c.OldDuration, c.NewDuration = a.Duration, b.Duration
+	}
+	if a.Title == b.Title && a.Duration == b.Duration && len(c.Nodes) == 0 {
+		return nil
+	}
+	return c
+}
+
+// compareNodes returns the changes of content nodes a to b.
+// Removed and added nodes of the same type in between unchanged ones
+// are reported as changed.
+func compareNodes(a, b []*Node) []*NodeChange {
+	ka, kb := nodeKeys(a), nodeKeys(b)
+	var res []*NodeChange
+	i, j := 0, 0
+	for _, p := range append(lcs(ka, kb), [2]int{len(ka), len(kb)}) {
+		for i < p[0] || j < p[1] {
+			switch {
+			case i < p[0] && j < p[1] && a[i].Type == b[j].Type:
+				res = append(res, &NodeChange{Change: Changed, Type: a[i].Type, Old: nodeSummary(a[i]), New: nodeSummary(b[j])})
+				i++
+				j++
+			case i < p[0]:
+				res = append(res, &NodeChange{Change: Removed, Type: a[i].Type, Old: nodeSummary(a[i])})
+				i++
+			default:
+				res = append(res, &NodeChange{Change: Added, Type: b[j].Type, New: nodeSummary(b[j])})
+				j++
+			}
+		}
+		i, j = p[0]+1, p[1]+1
+	}
+	return res
+}
+
+// similarity returns the share of content nodes steps a and b have
+// in common, from 0 to 1.
+func similarity(a, b *Step) float64 {
+	n := len(a.Content)
+	if len(b.Content) > n {
+		n = len(b.Content)
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(len(lcs(nodeKeys(a.Content), nodeKeys(b.Content)))) / float64(n)
+}
+
+// nodeKeys returns keys of nodes, equal for equal nodes.
+func nodeKeys(nodes []*Node) []string {
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		b, _ := json.Marshal(n)
+		keys[i] = string(b)
+	}
+	return keys
+}
+
+// lcs returns index pairs of a longest common subsequence of a and b,
+// in order.
+func lcs(a, b []string) [][2]int {
+	// n[i][j] is the length of the LCS of a[i:] and b[j:]
+	n := make([][]int, len(a)+1)
+	for i := range n {
+		n[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				n[i][j] = n[i+1][j+1] + 1
+			case n[i+1][j] >= n[i][j+1]:
+				n[i][j] = n[i+1][j]
+			default:
+				n[i][j] = n[i][j+1]
+			}
+		}
+	}
+	var res [][2]int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, [2]int{i, j})
+			i++
+			j++
+		case n[i+1][j] >= n[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return res
+}
+
+// maxSummary is the maximum length of a node summary, in runes.
+const maxSummary = 60
+
+// nodeSummary returns a short text of node n, e.g. its text content
+// or image URL, on a single line.
+func nodeSummary(n *Node) string {
+	var parts []string
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		switch n.Type {
+		case NodeText, NodeCode, NodeTerm:
+			parts = append(parts, n.Value)
+		case NodeImage, NodeIframe:
+			parts = append(parts, n.URL)
+		case NodeYouTube:
+			parts = append(parts, n.VideoID)
+		case NodeSurvey:
+			for _, g := range n.Groups {
+				parts = append(parts, g.Name)
+			}
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+		for _, it := range n.Items {
+			for _, c := range it {
+				walk(c)
+			}
+			parts = append(parts, " ")
+		}
+		for _, r := range n.Rows {
+			for _, cell := range r {
+				for _, c := range cell.Content {
+					walk(c)
+				}
+				parts = append(parts, " ")
+			}
+		}
+	}
+	walk(n)
+	s := strings.Join(strings.Fields(strings.Join(parts, "")), " ")
+	if utf8.RuneCountInString(s) > maxSummary {
+		s = string([]rune(s)[:maxSummary-1]) + "…"
+	}
+	return s
+}
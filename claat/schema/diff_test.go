@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	text := func(v string) *Node {
+		return &Node{Type: NodeParagraph, Children: []*Node{{Type: NodeText, Value: v}}}
+	}
+	a := &Codelab{
+		Schema:   V2,
+		ID:       "lab",
+		Title:    "Lab",
+		Duration: 12,
+		Updated:  "2019-05-01T10:00:00Z",
+		Steps: []*Step{
+			{Title: "Intro", Duration: 2, Content: []*Node{text("Hello")}},
+			{Title: "Set up", Duration: 5, Content: []*Node{text("Install"), {Type: NodeCode, Value: "go get"}, text("Done")}},
+			{Title: "Old", Duration: 3, Content: []*Node{text("Legacy")}},
+			{Title: "End", Duration: 2, Content: []*Node{text("Bye")}},
+		},
+	}
+	b := &Codelab{
+		Schema:   V2,
+		ID:       "lab",
+		Title:    "Lab",
+		Duration: 17,
+		Updated:  "2019-06-01T10:00:00Z",
+		Steps: []*Step{
+			{Title: "Intro", Duration: 2, Content: []*Node{text("Hello")}},
+			{Title: "Setup", Duration: 5, Content: []*Node{text("Install"), {Type: NodeCode, Value: "go install"}, text("Done")}},
+			{Title: "Deploy", Duration: 10, Content: []*Node{text("Ship it")}},
+			{Title: "End", Duration: 0, Content: []*Node{text("Bye"), {Type: NodeImage, URL: "img/end.png"}}},
+		},
+	}
+	got := Compare(a, b)
+	want := &Diff{
+		Meta: []*FieldChange{{Field: "duration", Old: "12", New: "17"}},
+		Steps: []*StepChange{
+			{Change: Renamed, OldIndex: 2, NewIndex: 2, OldTitle: "Set up", NewTitle: "Setup", Nodes: []*NodeChange{
+				{Change: Changed, Type: NodeCode, Old: "go get", New: "go install"},
+			}},
+			{Change: Removed, OldIndex: 3, OldTitle: "Old", OldDuration: 3},
+			{Change: Added, NewIndex: 3, NewTitle: "Deploy", NewDuration: 10},
+			{Change: Changed, OldIndex: 4, NewIndex: 4, OldTitle: "End", NewTitle: "End", OldDuration: 2, Nodes: []*NodeChange{
+				{Change: Added, Type: NodeImage, New: "img/end.png"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		g, _ := json.MarshalIndent(got, "", "  ")
+		w, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("Compare:\n%s\nwant:\n%s", g, w)
+	}
+	if d := Compare(a, a); !d.Empty() {
+		t.Errorf("Compare(a, a) = %+v; want empty", d)
+	}
+}
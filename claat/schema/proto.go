@@ -0,0 +1,138 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Protocol Buffers wire types.
+const (
+	wireVarint  = 0
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// MarshalProto returns the Protocol Buffers encoding of codelab c,
+// a claat.schema.v2.Codelab message of codelab.proto in this package.
+//
+// Fields of a message are the struct fields of its type, numbered
+// from 1 in order. Nested slices, such as Node.Items, are encoded
+// as repeated wrapper messages with a single repeated field.
+func MarshalProto(c *Codelab) ([]byte, error) {
+	return protoMessage(nil, reflect.ValueOf(c).Elem())
+}
+
+// protoMessage appends fields of struct v to b.
+// Zero values are omitted, as in proto3.
+func protoMessage(b []byte, v reflect.Value) ([]byte, error) {
+	var err error
+	for i := 0; i < v.NumField(); i++ {
+		if b, err = protoField(b, i+1, v.Field(i)); err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", v.Type().Name(), v.Type().Field(i).Name, err)
+		}
+	}
+	return b, nil
+}
+
+func protoField(b []byte, num int, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() > 0 {
+			b = protoBytes(b, num, []byte(v.String()))
+		}
+	case reflect.Bool:
+		if v.Bool() {
+			b = protoVarint(protoTag(b, num, wireVarint), 1)
+		}
+	case reflect.Int:
+		if v.Int() != 0 {
+			b = protoVarint(protoTag(b, num, wireVarint), uint64(v.Int()))
+		}
+	case reflect.Float32:
+		if v.Float() != 0 {
+			var p [4]byte
+			binary.LittleEndian.PutUint32(p[:], math.Float32bits(float32(v.Float())))
+			b = append(protoTag(b, num, wireFixed32), p[:]...)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		m, err := protoMessage(nil, v.Elem())
+		if err != nil {
+			return nil, err
+		}
+		b = protoBytes(b, num, m)
+	case reflect.Slice:
+		if v.Len() == 0 {
+			break
+		}
+		if v.Type().Elem().Kind() == reflect.Int {
+			// packed, the proto3 default of scalars
+			var p []byte
+			for i := 0; i < v.Len(); i++ {
+				p = protoVarint(p, uint64(v.Index(i).Int()))
+			}
+			return protoBytes(b, num, p), nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			var m []byte
+			var err error
+			switch e := v.Index(i); e.Kind() {
+			case reflect.String:
+				m = []byte(e.String())
+			case reflect.Ptr:
+				if e.IsNil() {
+					break
+				}
+				if m, err = protoMessage(nil, e.Elem()); err != nil {
+					return nil, err
+				}
+			case reflect.Slice:
+				// wrapper message of a single repeated field
+				if m, err = protoField(nil, 1, e); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("unsupported type %s", v.Type())
+			}
+			// repeated elements are never omitted
+			b = protoBytes(b, num, m)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return b, nil
+}
+
+func protoTag(b []byte, num, wire int) []byte {
+	return protoVarint(b, uint64(num<<3|wire))
+}
+
+func protoVarint(b []byte, v uint64) []byte {
+	var p [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(p[:], v)
+	return append(b, p[:n]...)
+}
+
+func protoBytes(b []byte, num int, p []byte) []byte {
+	b = protoTag(b, num, wireBytes)
+	b = protoVarint(b, uint64(len(p)))
+	return append(b, p...)
+}
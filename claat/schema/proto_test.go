@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMarshalProto(t *testing.T) {
+	c := &Codelab{
+		Schema: V2,
+		ID:     "a",
+		Steps: []*Step{{
+			Title:    "T",
+			Duration: 2,
+			Content: []*Node{{
+				Type:  NodeList,
+				Width: 1,
+				Added: []int{1, 300},
+				Items: [][]*Node{{{Type: NodeText, Value: "x"}}},
+			}},
+		}},
+	}
+	text := "\x0a\x04text\x1a\x01x"
+	item := "\x0a\x09" + text
+	node := "\x0a\x04list" +
+		"\x55\x00\x00\x80\x3f" + // width
+		"\x72\x03\x01\xac\x02" + // added, packed
+		"\xaa\x01\x0b" + item
+	step := "\x0a\x01T\x18\x02\x22\x1e" + node
+	want := "\x0a\x02v2\x12\x01a\x6a\x25" + step
+
+	b, err := MarshalProto(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != want {
+		t.Errorf("MarshalProto:\n% x\nwant:\n% x", b, want)
+	}
+}
+
+// TestProtoDefinition makes sure codelab.proto matches the v2 types.
+func TestProtoDefinition(t *testing.T) {
+	f, err := os.Open("codelab.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	msgRx := regexp.MustCompile(`^message (\w+) \{`)
+	fieldRx := regexp.MustCompile(`^\s+(?:repeated )?\w+ (\w+) = (\d+);`)
+	fields := map[string][]string{} // message name => field names by number
+	var msg string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if m := msgRx.FindStringSubmatch(s.Text()); m != nil {
+			msg = m[1]
+			continue
+		}
+		m := fieldRx.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		if n, _ := strconv.Atoi(m[2]); n != len(fields[msg])+1 {
+			t.Errorf("%s.%s = %d; want %d", msg, m[1], n, len(fields[msg])+1)
+		}
+		fields[msg] = append(fields[msg], m[1])
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typ := range []interface{}{Codelab{}, Step{}, Node{}, Cell{}, SurveyGroup{}} {
+		rt := reflect.TypeOf(typ)
+		var names []string
+		for i := 0; i < rt.NumField(); i++ {
+			names = append(names, strings.Split(rt.Field(i).Tag.Get("json"), ",")[0])
+		}
+		if !reflect.DeepEqual(fields[rt.Name()], names) {
+			t.Errorf("message %s fields = %v; want %v", rt.Name(), fields[rt.Name()], names)
+		}
+	}
+	for _, w := range []string{"Nodes", "Row"} {
+		if len(fields[w]) != 1 {
+			t.Errorf("wrapper message %s fields = %v; want 1 field", w, fields[w])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema defines versioned JSON export schemas of codelabs,
+// used by the "json" output format.
+//
+// Version v1 is the codelab metadata as stored in codelab.json files.
+// It mirrors types.Meta and changes whenever the type does.
+// It is the default of the json format, for existing consumers.
+//
+// Version v2 is a stable schema of codelab metadata and content,
+// converted from the types package, and described by a JSON Schema
+// definition, see Definition. Its compatibility guarantees are:
+//
+//   - fields and node types are never removed or renamed,
+//     and their meaning does not change;
+//   - new optional fields and node types may be added, so consumers
+//     should ignore unknown fields and skip nodes of unknown types;
+//   - any incompatible change is made in a new version, e.g. v3,
+//     while v2 remains available.
+//
+// Version v2 codelabs are also exported in the Protocol Buffers format,
+// see MarshalProto and the codelab.proto definition in this package.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Schema versions.
+const (
+	V1     = "v1"
+	V2     = "v2"
+	Latest = V2
+)
+
+// Versions are all known schema versions, oldest first.
+var Versions = []string{V1, V2}
+
+// Valid reports whether version is a known schema version.
+func Valid(version string) bool {
+	for _, v := range Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshal returns the JSON encoding of a codelab in the schema version,
+// V1 if version is empty. Content of steps is limited to the env
+// environment, if not empty, and updated is an RFC 3339 time of
+// the last codelab update.
+func Marshal(version string, m *types.Meta, steps []*types.Step, env, updated string) ([]byte, error) {
+	var v interface{}
+	switch version {
+	case "", V1:
+		v = m
+	case V2:
+		v = Convert(m, steps, env, updated)
+	default:
+		return nil, fmt.Errorf("unknown schema version %q", version)
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// Definition returns the JSON Schema definition of a schema version.
+// Only V2 has a definition.
+func Definition(version string) (string, error) {
+	if version != V2 {
+		return "", fmt.Errorf("schema version %q has no JSON Schema definition", version)
+	}
+	return definitionV2, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestConvert(t *testing.T) {
+	p := types.NewListNode(types.NewTextNode("Hi "), types.NewURLNode("https://example.com", types.NewTextNode("there")))
+	p.MutateBlock(true)
+	kiosk := types.NewTextNode("kiosk only")
+	kiosk.MutateEnv([]string{"kiosk"})
+	list := types.NewItemsListNode("", 1)
+	list.NewItem().Append(types.NewTextNode("one"))
+	ib := &types.InfoboxNode{Kind: types.InfoboxNegative, Content: types.NewListNode(types.NewTextNode("careful"))}
+	steps := []*types.Step{
+		{Title: "Intro", Duration: 90 * time.Second, Content: types.NewListNode(
+			p, kiosk, list, ib, types.NewCodeNode("ls", true, ""), types.NewCodeNode("x := 1", false, "language-go"),
+		)},
+		{Title: "Kiosk", Tags: []string{"kiosk"}, Content: types.NewListNode()},
+	}
+	m := &types.Meta{ID: "lab", Title: "Lab", Duration: 2}
+	got := Convert(m, steps, "web", "2019-05-01T10:00:00Z")
+	want := &Codelab{
+		Schema:   V2,
+		ID:       "lab",
+		Title:    "Lab",
+		Duration: 2,
+		Updated:  "2019-05-01T10:00:00Z",
+		Steps: []*Step{{
+			Title:    "Intro",
+			Duration: 2,
+			Content: []*Node{
+				{Type: NodeParagraph, Children: []*Node{
+					{Type: NodeText, Value: "Hi "},
+					{Type: NodeLink, URL: "https://example.com", Children: []*Node{{Type: NodeText, Value: "there"}}},
+				}},
+				{Type: NodeList, Ordered: true, Start: 1, Items: [][]*Node{{{Type: NodeText, Value: "one"}}}},
+				{Type: NodeInfobox, Kind: "negative", Children: []*Node{{Type: NodeText, Value: "careful"}}},
+				{Type: NodeCode, Value: "ls", Console: true},
+				{Type: NodeCode, Value: "x := 1", Lang: "go"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		g, _ := json.MarshalIndent(got, "", "  ")
+		w, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("Convert:\n%s\nwant:\n%s", g, w)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	m := &types.Meta{ID: "lab", Title: "Lab"}
+	b, err := Marshal("", m, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"id": "lab"`) || strings.Contains(string(b), `"schema"`) {
+		t.Errorf("v1 = %s; want metadata only", b)
+	}
+	b, err = Marshal(V2, m, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"schema": "v2"`) || !strings.Contains(string(b), `"steps": []`) {
+		t.Errorf("v2 = %s", b)
+	}
+	if _, err := Marshal("v0", m, nil, "", ""); err == nil {
+		t.Error("Marshal(v0): no error")
+	}
+}
+
+// TestDefinition makes sure the JSON Schema describes all fields.
+func TestDefinition(t *testing.T) {
+	d, err := Definition(V2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var def struct {
+		Properties  map[string]json.RawMessage
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Examples []string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(d), &def); err != nil {
+		t.Fatalf("definition is not valid JSON: %v", err)
+	}
+	tests := []struct {
+		name  string
+		typ   interface{}
+		props map[string]bool
+	}{
+		{"codelab", Codelab{}, nil},
+		{"step", Step{}, nil},
+		{"node", Node{}, nil},
+		{"cell", Cell{}, nil},
+		{"surveyGroup", SurveyGroup{}, nil},
+	}
+	for _, test := range tests {
+		props := map[string]bool{}
+		if test.name == "codelab" {
+			for k := range def.Properties {
+				props[k] = true
+			}
+		} else {
+			for k := range def.Definitions[test.name].Properties {
+				props[k] = true
+			}
+		}
+		rt := reflect.TypeOf(test.typ)
+		for i := 0; i < rt.NumField(); i++ {
+			name := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+			if !props[name] {
+				t.Errorf("%s: field %q is not defined", test.name, name)
+			}
+		}
+	}
+	if ex := def.Definitions["node"].Properties["type"].Examples; !reflect.DeepEqual(ex, NodeTypes) {
+		t.Errorf("node types = %v; want %v", ex, NodeTypes)
+	}
+	if _, err := Definition(V1); err == nil {
+		t.Error("Definition(v1): no error")
+	}
+}
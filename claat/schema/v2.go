@@ -0,0 +1,328 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Codelab is a codelab in the V2 schema.
+type Codelab struct {
+	Schema     string   `json:"schema"` // Always V2
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Summary    string   `json:"summary,omitempty"`
+	Authors    string   `json:"authors,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	Tags       []string `json:"tags,omitempty"`     // Environments of the codelab
+	Duration   int      `json:"duration,omitempty"` // Minutes
+	Lang       string   `json:"lang,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	Feedback   string   `json:"feedback,omitempty"`
+	Updated    string   `json:"updated,omitempty"` // RFC 3339 time
+	Steps      []*Step  `json:"steps"`
+	// Prerequisites and Related are IDs or URLs of codelabs to take before
+	// and after this one, e.g. to build learning paths.
+	Prerequisites []string `json:"prerequisites,omitempty"`
+	Related       []string `json:"related,omitempty"`
+	// Description and HeroImage are shown in link previews of the codelab.
+	Description string `json:"description,omitempty"`
+	HeroImage   string `json:"hero_image,omitempty"`
+	// Revision is the source revision of the codelab, e.g. a git commit.
+	Revision string `json:"revision,omitempty"`
+}
+
+// Step is a codelab step in the V2 schema.
+type Step struct {
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags,omitempty"`     // Environments of the step
+	Duration int      `json:"duration,omitempty"` // Minutes
+	Content  []*Node  `json:"content"`
+}
+
+// Node types of the V2 schema.
+const (
+	NodeText      = "text"
+	NodeParagraph = "paragraph" // Block of inline nodes
+	NodeGroup     = "group"     // Inline nodes, or imported content
+	NodeLink      = "link"
+	NodeButton    = "button"
+	NodeImage     = "image"
+	NodeCode      = "code"
+	NodeList      = "list"
+	NodeHeader    = "header"
+	NodeInfobox   = "infobox"
+	NodeTable     = "table"
+	NodeSurvey    = "survey"
+	NodeYouTube   = "youtube"
+	NodeIframe    = "iframe"
+	NodeTerm      = "term"
+)
+
+// NodeTypes are all node types of the V2 schema.
+var NodeTypes = []string{
+	NodeText, NodeParagraph, NodeGroup, NodeLink, NodeButton, NodeImage, NodeCode, NodeList,
+	NodeHeader, NodeInfobox, NodeTable, NodeSurvey, NodeYouTube, NodeIframe, NodeTerm,
+}
+
+// Node is a content node in the V2 schema.
+// Fields other than Type and Env are set depending on the node type.
+type Node struct {
+	Type string   `json:"type"`
+	Env  []string `json:"env,omitempty"` // Environments the node is limited to
+
+	// text, code and term
+	Value  string `json:"value,omitempty"`
+	Bold   bool   `json:"bold,omitempty"`
+	Italic bool   `json:"italic,omitempty"`
+	Code   bool   `json:"code,omitempty"`
+
+	// link, iframe and image
+	URL         string  `json:"url,omitempty"`
+	Alt         string  `json:"alt,omitempty"`
+	Title       string  `json:"title,omitempty"`       // Also a code file name
+	Width       float32 `json:"width,omitempty"`       // Pixels
+	Placeholder string  `json:"placeholder,omitempty"` // Description of a missing image
+
+	// code
+	Lang    string `json:"lang,omitempty"`
+	Console bool   `json:"console,omitempty"`
+	Added   []int  `json:"added,omitempty"`   // 1-based line numbers
+	Removed []int  `json:"removed,omitempty"` // 1-based line numbers
+
+	// button
+	Download bool `json:"download,omitempty"`
+
+	// header and list
+	Level int    `json:"level,omitempty"`
+	Style string `json:"style,omitempty"` // "checklist" or "faq"
+
+	// list
+	Ordered bool      `json:"ordered,omitempty"`
+	Start   int       `json:"start,omitempty"`
+	Items   [][]*Node `json:"items,omitempty"`
+
+	// infobox
+	Kind string `json:"kind,omitempty"` // "positive" or "negative"
+
+	// table
+	Rows [][]*Cell `json:"rows,omitempty"`
+
+	// survey
+	ID     string         `json:"id,omitempty"`
+	Pick   int            `json:"pick,omitempty"`
+	Groups []*SurveyGroup `json:"groups,omitempty"`
+
+	// youtube
+	VideoID string `json:"video_id,omitempty"`
+
+	// term
+	Definition string `json:"definition,omitempty"`
+
+	// paragraph, group, link, button, header and infobox
+	Children []*Node `json:"children,omitempty"`
+}
+
+// Cell is a table cell in the V2 schema.
+type Cell struct {
+	Colspan int     `json:"colspan"`
+	Rowspan int     `json:"rowspan"`
+	Content []*Node `json:"content"`
+}
+
+// SurveyGroup is a survey question in the V2 schema.
+type SurveyGroup struct {
+	Name    string   `json:"name"`
+	Options []string `json:"options"`
+}
+
+// Convert returns codelab m with steps in the V2 schema.
+// Nodes and steps excluded from the env environment are skipped,
+// unless env is empty. Updated is an RFC 3339 time of the last update.
+func Convert(m *types.Meta, steps []*types.Step, env, updated string) *Codelab {
+	c := &converter{env: env}
+	clab := &Codelab{
+		Schema:     V2,
+		ID:         m.ID,
+		Title:      m.Title,
+		Summary:    m.Summary,
+		Authors:    m.Authors,
+		Categories: m.Categories,
+		Tags:       m.Tags,
+		Duration:   m.Duration,
+		Lang:       m.Lang,
+		Source:     m.Source,
+		Feedback:   m.Feedback,
+		Updated:    updated,
+		Steps:      []*Step{},
+
+		Prerequisites: m.Prerequisites,
+		Related:       m.Related,
+		Description:   m.Description,
+		HeroImage:     m.HeroImage,
+		Revision:      m.Revision,
+	}
+	for _, st := range steps {
+		if !c.matchEnv(st.Tags) {
+			continue
+		}
+		s := &Step{
+			Title:    st.Title,
+			Tags:     st.Tags,
+			Duration: int(st.Duration.Minutes() + 0.5),
+			Content:  []*Node{},
+		}
+		if st.Content != nil {
+			s.Content = c.nodes(st.Content.Nodes)
+		}
+		clab.Steps = append(clab.Steps, s)
+	}
+	return clab
+}
+
+type converter struct {
+	env string // target environment
+}
+
+func (c *converter) matchEnv(v []string) bool {
+	if len(v) == 0 || c.env == "" {
+		return true
+	}
+	i := sort.SearchStrings(v, c.env)
+	return i < len(v) && v[i] == c.env
+}
+
+// nodes converts nodes of the env environment; the result is never nil.
+func (c *converter) nodes(nodes []types.Node) []*Node {
+	res := []*Node{}
+	for _, n := range nodes {
+		if !c.matchEnv(n.Env()) {
+			continue
+		}
+		if v := c.node(n); v != nil {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// children converts content l, which may be nil.
+func (c *converter) children(l *types.ListNode) []*Node {
+	if l == nil {
+		return nil
+	}
+	return c.nodes(l.Nodes)
+}
+
+func (c *converter) node(n types.Node) *Node {
+	v := &Node{Env: n.Env()}
+	switch n := n.(type) {
+	case *types.TextNode:
+		v.Type = NodeText
+		v.Value = n.Value
+		v.Bold, v.Italic, v.Code = n.Bold, n.Italic, n.Code
+	case *types.ListNode:
+		v.Type = NodeGroup
+		if n.Block() == true {
+			v.Type = NodeParagraph
+		}
+		v.Children = c.nodes(n.Nodes)
+	case *types.ImportNode:
+		v.Type = NodeGroup
+		v.Children = c.children(n.Content)
+	case *types.URLNode:
+		v.Type = NodeLink
+		v.URL = n.URL
+		v.Children = c.children(n.Content)
+	case *types.ButtonNode:
+		v.Type = NodeButton
+		v.Download = n.Download
+		v.Children = c.children(n.Content)
+	case *types.ImageNode:
+		v.Type = NodeImage
+		v.URL = n.Src
+		v.Alt, v.Title, v.Width, v.Placeholder = n.Alt, n.Title, n.Width, n.Placeholder
+	case *types.CodeNode:
+		v.Type = NodeCode
+		v.Value = n.Value
+		v.Lang = strings.TrimPrefix(n.Lang, "language-")
+		v.Console = n.Term
+		v.Title = n.Title
+		v.Added, v.Removed = n.Added, n.Removed
+	case *types.ItemsListNode:
+		v.Type = NodeList
+		v.Style = nodeStyle(n.Type())
+		v.Ordered = v.Style == "" && (n.Start > 0 || n.ListType != "")
+		v.Start = n.Start
+		for _, it := range n.Items {
+			v.Items = append(v.Items, c.nodes(it.Nodes))
+		}
+	case *types.HeaderNode:
+		v.Type = NodeHeader
+		v.Level = n.Level
+		v.Style = nodeStyle(n.Type())
+		v.Children = c.children(n.Content)
+	case *types.InfoboxNode:
+		v.Type = NodeInfobox
+		v.Kind = "positive"
+		if n.Kind == types.InfoboxNegative {
+			v.Kind = "negative"
+		}
+		v.Children = c.children(n.Content)
+	case *types.GridNode:
+		v.Type = NodeTable
+		for _, r := range n.Rows {
+			var row []*Cell
+			for _, cell := range r {
+				row = append(row, &Cell{Colspan: cell.Colspan, Rowspan: cell.Rowspan, Content: c.children(cell.Content)})
+			}
+			v.Rows = append(v.Rows, row)
+		}
+	case *types.SurveyNode:
+		v.Type = NodeSurvey
+		v.ID = n.ID
+		v.Pick = n.Pick
+		for _, g := range n.Groups {
+			v.Groups = append(v.Groups, &SurveyGroup{Name: g.Name, Options: g.Options})
+		}
+	case *types.YouTubeNode:
+		v.Type = NodeYouTube
+		v.VideoID = n.VideoID
+	case *types.IframeNode:
+		v.Type = NodeIframe
+		v.URL = n.URL
+	case *types.TermNode:
+		v.Type = NodeTerm
+		v.Value = n.Term
+		v.Definition = n.Definition
+	default:
+		return nil
+	}
+	return v
+}
+
+// nodeStyle returns the style of special lists and headers.
+func nodeStyle(t types.NodeType) string {
+	switch t {
+	case types.NodeItemsCheck, types.NodeHeaderCheck:
+		return "checklist"
+	case types.NodeItemsFAQ, types.NodeHeaderFAQ:
+		return "faq"
+	}
+	return ""
+}
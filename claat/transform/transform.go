@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform applies declarative rules to the nodes of a parsed codelab,
+// allowing restricted environments to strip or rewrite disallowed content.
+//
+// A rule selects nodes by type and applies one of the following actions:
+//
+//   - drop removes the nodes altogether
+//   - link replaces youtube, iframe and image nodes with a link to their URL
+//   - rewrite-host replaces host From with To in the URL of
+//     url, image and iframe nodes
+package transform
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+// Rule actions.
+const (
+	ActionDrop        = "drop"
+	ActionLink        = "link"
+	ActionRewriteHost = "rewrite-host"
+)
+
+// nodeTypes maps rule type names to node types.
+var nodeTypes = map[string]types.NodeType{
+	"button":  types.NodeButton,
+	"code":    types.NodeCode,
+	"grid":    types.NodeGrid,
+	"header":  types.NodeHeader | types.NodeHeaderCheck | types.NodeHeaderFAQ,
+	"iframe":  types.NodeIframe,
+	"image":   types.NodeImage,
+	"infobox": types.NodeInfobox,
+	"list":    types.NodeItemsList | types.NodeItemsCheck | types.NodeItemsFAQ,
+	"survey":  types.NodeSurvey,
+	"term":    types.NodeTerm,
+	"text":    types.NodeText,
+	"url":     types.NodeURL,
+	"youtube": types.NodeYouTube,
+}
+
+// Rule is a single transformation applied to all nodes of a type.
+type Rule struct {
+	Type   string `yaml:"type" json:"type"`                     // node type, e.g. "youtube"
+	Action string `yaml:"action" json:"action"`                 // drop, link or rewrite-host
+	From   string `yaml:"from,omitempty" json:"from,omitempty"` // rewrite-host: original host
+	To     string `yaml:"to,omitempty" json:"to,omitempty"`     // rewrite-host: replacement host
+}
+
+// String formats the rule for error messages.
+func (r *Rule) String() string {
+	return r.Action + " " + r.Type
+}
+
+// Validate checks whether the rule is well-formed.
+func (r *Rule) Validate() error {
+	t, ok := nodeTypes[r.Type]
+	if !ok {
+		return fmt.Errorf("%s: unknown node type %q", r, r.Type)
+	}
+	switch r.Action {
+	case ActionDrop:
+		return nil
+	case ActionLink:
+		if t&(types.NodeYouTube|types.NodeIframe|types.NodeImage) == 0 {
+			return fmt.Errorf("%s: only youtube, iframe and image nodes can be converted to links", r)
+		}
+		return nil
+	case ActionRewriteHost:
+		if t&(types.NodeURL|types.NodeImage|types.NodeIframe) == 0 {
+			return fmt.Errorf("%s: only url, image and iframe hosts can be rewritten", r)
+		}
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("%s: both from and to hosts are required", r)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: unknown action %q", r, r.Action)
+}
+
+// Apply applies rules to all steps of clab, in the order rules are specified.
+// It returns an error without modifying clab if any of the rules is invalid.
+func Apply(clab *types.Codelab, rules []*Rule) error {
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	fn := func(n types.Node) []types.Node {
+		for _, r := range rules {
+			if n.Type()&nodeTypes[r.Type] == 0 {
+				continue
+			}
+			if n = r.apply(n); n == nil {
+				return nil
+			}
+		}
+		return []types.Node{n}
+	}
+	for _, s := range clab.Steps {
+		s.Content.Nodes = types.Rewrite(s.Content.Nodes, fn)
+	}
+	return nil
+}
+
+// apply transforms n according to r. It returns nil if n is to be removed.
+func (r *Rule) apply(n types.Node) types.Node {
+	switch r.Action {
+	case ActionDrop:
+		return nil
+	case ActionLink:
+		var u string
+		switch n := n.(type) {
+		case *types.YouTubeNode:
+			u = "https://www.youtube.com/watch?v=" + n.VideoID
+		case *types.IframeNode:
+			u = n.URL
+		case *types.ImageNode:
+			u = n.Src
+		}
+		l := types.NewURLNode(u, types.NewTextNode(u))
+		l.MutateBlock(n.Block())
+		l.MutateEnv(n.Env())
+		return l
+	case ActionRewriteHost:
+		switch n := n.(type) {
+		case *types.URLNode:
+			n.URL = r.rewriteHost(n.URL)
+		case *types.ImageNode:
+			n.Src = r.rewriteHost(n.Src)
+		case *types.IframeNode:
+			n.URL = r.rewriteHost(n.URL)
+		}
+	}
+	return n
+}
+
+// rewriteHost replaces host of URL s if it matches r.From.
+// Unparsable URLs are returned unchanged.
+func (r *Rule) rewriteHost(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Host != r.From {
+		return s
+	}
+	u.Host = r.To
+	return u.String()
+}
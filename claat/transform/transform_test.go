@@ -0,0 +1,81 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/googlecodelabs/tools/claat/types"
+)
+
+func TestApply(t *testing.T) {
+	img := types.NewImageNode("https://a.example.com/img.png")
+	link := types.NewURLNode("https://a.example.com/docs", types.NewTextNode("docs"))
+	other := types.NewURLNode("https://c.example.com/", types.NewTextNode("other"))
+	info := types.NewInfoboxNode(types.InfoboxPositive, types.NewYouTubeNode("abc"))
+	clab := &types.Codelab{Steps: []*types.Step{{Content: types.NewListNode(
+		types.NewListNode(img, link, other),
+		types.NewIframeNode("https://codepen.io/pen"),
+		info,
+	)}}}
+	rules := []*Rule{
+		{Type: "youtube", Action: ActionDrop},
+		{Type: "iframe", Action: ActionLink},
+		{Type: "image", Action: ActionRewriteHost, From: "a.example.com", To: "b.example.com"},
+		{Type: "url", Action: ActionRewriteHost, From: "a.example.com", To: "b.example.com"},
+	}
+	if err := Apply(clab, rules); err != nil {
+		t.Fatal(err)
+	}
+	nodes := clab.Steps[0].Content.Nodes
+	if len(nodes) != 3 {
+		t.Fatalf("len(nodes) = %d; want 3", len(nodes))
+	}
+	if img.Src != "https://b.example.com/img.png" {
+		t.Errorf("img.Src = %q", img.Src)
+	}
+	if link.URL != "https://b.example.com/docs" {
+		t.Errorf("link.URL = %q", link.URL)
+	}
+	if other.URL != "https://c.example.com/" {
+		t.Errorf("other.URL = %q", other.URL)
+	}
+	if l, ok := nodes[1].(*types.URLNode); !ok || l.URL != "https://codepen.io/pen" {
+		t.Errorf("nodes[1] = %+v; want link to iframe URL", nodes[1])
+	}
+	if n := len(info.Content.Nodes); n != 0 {
+		t.Errorf("len(info.Content.Nodes) = %d; want 0", n)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		r  *Rule
+		ok bool
+	}{
+		{&Rule{Type: "youtube", Action: ActionDrop}, true},
+		{&Rule{Type: "image", Action: ActionLink}, true},
+		{&Rule{Type: "url", Action: ActionRewriteHost, From: "a", To: "b"}, true},
+		{&Rule{Type: "video", Action: ActionDrop}, false},
+		{&Rule{Type: "text", Action: ActionLink}, false},
+		{&Rule{Type: "url", Action: ActionRewriteHost, From: "a"}, false},
+		{&Rule{Type: "code", Action: "hide"}, false},
+	}
+	for i, test := range tests {
+		if err := test.r.Validate(); (err == nil) != test.ok {
+			t.Errorf("%d: Validate() = %v; want ok = %v", i, err, test.ok)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -38,9 +39,55 @@ type Meta struct {
 	Tags       []string          `json:"tags"`                 // All environments supported by the codelab
 	Feedback   string            `json:"feedback,omitempty"`   // Issues and bugs are sent here
 	GA         string            `json:"ga,omitempty"`         // Codelab-specific GA tracking ID
+	Analytics  []string          `json:"analytics,omitempty"`  // Analytics providers, e.g. "ga4:G-XXXX", see render.ParseAnalytics
 	Extra      map[string]string `json:"extra,omitempty"`      // Extra metadata specified in pass_metadata
+	GateSteps  bool              `json:"gate_steps,omitempty"` // Steps are locked until surveys of previous ones are answered
+	Requires   string            `json:"requires,omitempty"`   // Required claat version, e.g. "claat >= 2.3"
+	Features   []string          `json:"features,omitempty"`   // Optional claat features used by the codelab
+	Template   string            `json:"template,omitempty"`   // Custom HTML template file of the html format
+
+	Prerequisites []string `json:"prerequisites,omitempty"` // IDs or URLs of codelabs to take before this one
+	Related       []string `json:"related,omitempty"`       // IDs or URLs of codelabs to take next
+
+	Description string `json:"description,omitempty"` // Link preview description; Summary if empty
+	HeroImage   string `json:"hero_image,omitempty"`  // Link preview image, relative to the codelab dir once exported
+
+	Published string `json:"published,omitempty"` // Publication date, RFC 3339 or YYYY-MM-DD
+	// LastUpdated is the update date of the codelab, RFC 3339 or YYYY-MM-DD,
+	// which overrides the source modification time on export.
+	// It is exported in the "updated" field of the export context.
+	LastUpdated string `json:"-"`
+	// Revision is the source revision of the codelab, such as a git commit
+	// or a Google Doc version, used to tell exports apart.
+	Revision string `json:"revision,omitempty"`
+
+	Lang         string         `json:"lang,omitempty"`         // Locale of a codelab variant, e.g. "fr"
+	Group        string         `json:"group,omitempty"`        // ID shared by all locale variants
+	Translations []*Translation `json:"translations,omitempty"` // All locale variants, including this one
 
 	URL string `json:"url"` // Legacy ID; TODO: remove
+
+	// Other has values of metadata keys with no field of their own,
+	// as named in sources, whether passed through in Extra or not.
+	Other map[string]string `json:"-"`
+	// KeyPos locates metadata keys in the source, as named there, if known.
+	KeyPos map[string]Position `json:"-"`
+}
+
+// idRegexp matches valid codelab IDs: lowercase letters and digits,
+// in words separated by single dashes or underscores.
+var idRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[-_][a-z0-9]+)*$`)
+
+// ValidID reports whether id is a valid codelab ID, which is safe to use
+// as a URL path segment and a directory name on any file system.
+func ValidID(id string) bool {
+	return idRegexp.MatchString(id)
+}
+
+// Translation refers to a locale variant of a codelab.
+type Translation struct {
+	Lang string `json:"lang"` // Variant locale
+	ID   string `json:"id"`   // Variant codelab ID
 }
 
 // Context is an export context.
@@ -51,6 +98,7 @@ type Context struct {
 	Prefix  string       `json:"prefix,omitempty"`  // Assets URL prefix for HTML-based formats
 	MainGA  string       `json:"mainga,omitempty"`  // Global Google Analytics ID
 	Updated *ContextTime `json:"updated,omitempty"` // Last update timestamp
+	Schema  string       `json:"schema,omitempty"`  // JSON export schema version of the json format
 }
 
 // ContextMeta is a composition of export context and meta data.
@@ -85,6 +133,10 @@ type Step struct {
 	Tags     []string      // Step environments
 	Duration time.Duration // Duration
 	Content  *ListNode     // Root node of the step nodes tree
+	Pos      Position      // Position of the step title in the source, if known
+	// Estimated is true if Duration is estimated from the step length
+	// rather than given by the source.
+	Estimated bool
 }
 
 // ContextTime is codelab metadata timestamp.
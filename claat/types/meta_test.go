@@ -61,3 +61,26 @@ func TestLegacyStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"codelab", true},
+		{"my-codelab-2", true},
+		{"cloud_run", true},
+		{"", false},
+		{"My-Codelab", false},
+		{"-codelab", false},
+		{"codelab-", false},
+		{"my--codelab", false},
+		{"my codelab", false},
+		{"../codelab", false},
+	}
+	for _, test := range tests {
+		if v := ValidID(test.id); v != test.valid {
+			t.Errorf("ValidID(%q) = %v; want %v", test.id, v, test.valid)
+		}
+	}
+}
@@ -15,6 +15,9 @@
 package types
 
 import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
 	"sort"
 	"strings"
 )
@@ -43,6 +46,7 @@ const (
 	NodeYouTube              // YouTube video
 	NodeIframe               // Embedded iframe
 	NodeImport               // A node which holds content imported from another resource
+	NodeTerm                 // A glossary term with its definition
 )
 
 // Node is an interface common to all node types.
@@ -64,6 +68,105 @@ type Node interface {
 	Env() []string
 	// MutateEnv replaces current node environment tags with env.
 	MutateEnv(env []string)
+	// Pos returns position of the node in the codelab source, if known.
+	Pos() Position
+	// MutatePos updates position of the node in the codelab source.
+	MutatePos(Position)
+}
+
+// Position is a location in a codelab source.
+// Line and Column are 1-based; Column counts bytes.
+// The zero value means the position is unknown.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position as "line:column", or "-" if unknown.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	if p.Column == 0 {
+		return fmt.Sprintf("%d", p.Line)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Walk traverses nodes tree depth-first, calling fn for each node
+// in the order they appear. If fn returns false, children of the node
+// are not visited.
+func Walk(nodes []Node, fn func(Node) bool) {
+	for _, n := range nodes {
+		if !fn(n) {
+			continue
+		}
+		switch n := n.(type) {
+		case *ListNode:
+			Walk(n.Nodes, fn)
+		case *ImportNode:
+			Walk(n.Content.Nodes, fn)
+		case *ItemsListNode:
+			for _, i := range n.Items {
+				Walk(i.Nodes, fn)
+			}
+		case *HeaderNode:
+			Walk(n.Content.Nodes, fn)
+		case *URLNode:
+			Walk(n.Content.Nodes, fn)
+		case *ButtonNode:
+			Walk(n.Content.Nodes, fn)
+		case *InfoboxNode:
+			Walk(n.Content.Nodes, fn)
+		case *GridNode:
+			for _, r := range n.Rows {
+				for _, c := range r {
+					Walk(c.Content.Nodes, fn)
+				}
+			}
+		}
+	}
+}
+
+// Rewrite traverses nodes tree depth-first, replacing each node with
+// the nodes returned by fn. Children of a node are rewritten before the node
+// itself. If fn returns no nodes, the node is removed.
+// It returns the rewritten nodes slice.
+func Rewrite(nodes []Node, fn func(Node) []Node) []Node {
+	res := make([]Node, 0, len(nodes))
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *ListNode:
+			n.Nodes = Rewrite(n.Nodes, fn)
+		case *ImportNode:
+			n.Content.Nodes = Rewrite(n.Content.Nodes, fn)
+		case *ItemsListNode:
+			for _, i := range n.Items {
+				i.Nodes = Rewrite(i.Nodes, fn)
+			}
+		case *HeaderNode:
+			n.Content.Nodes = Rewrite(n.Content.Nodes, fn)
+		case *URLNode:
+			n.Content.Nodes = Rewrite(n.Content.Nodes, fn)
+		case *ButtonNode:
+			n.Content.Nodes = Rewrite(n.Content.Nodes, fn)
+		case *InfoboxNode:
+			n.Content.Nodes = Rewrite(n.Content.Nodes, fn)
+		case *GridNode:
+			for _, r := range n.Rows {
+				for _, c := range r {
+					c.Content.Nodes = Rewrite(c.Content.Nodes, fn)
+				}
+			}
+		}
+		res = append(res, fn(n)...)
+	}
+	return res
 }
 
 // IsItemsList returns true if t is one of ItemsListNode types.
@@ -78,7 +181,7 @@ func IsHeader(t NodeType) bool {
 
 // IsInline returns true if t is an inline node type.
 func IsInline(t NodeType) bool {
-	return t&(NodeText|NodeURL|NodeImage|NodeButton) != 0
+	return t&(NodeText|NodeURL|NodeImage|NodeButton|NodeTerm) != 0
 }
 
 // EmptyNodes returns true if all of nodes are empty.
@@ -95,6 +198,7 @@ type node struct {
 	typ   NodeType
 	block interface{}
 	env   []string
+	pos   Position
 }
 
 func (b *node) Type() NodeType {
@@ -125,6 +229,14 @@ func (b *node) MutateEnv(e []string) {
 	sort.Strings(b.env)
 }
 
+func (b *node) Pos() Position {
+	return b.pos
+}
+
+func (b *node) MutatePos(p Position) {
+	b.pos = p
+}
+
 // NewListNode creates a new Node of type NodeList.
 func NewListNode(nodes ...Node) *ListNode {
 	n := &ListNode{node: node{typ: NodeList}}
@@ -164,6 +276,8 @@ func NewImportNode(url string) *ImportNode {
 }
 
 // ImportNode indicates a remote resource available at ImportNode.URL.
+// A "#section" suffix of the URL selects only the content under
+// the heading with that anchor.
 type ImportNode struct {
 	node
 	URL     string
@@ -257,6 +371,11 @@ type ItemsListNode struct {
 	ListType string
 	Start    int
 	Items    []*ListNode
+	// Level is the nesting depth of the list in sources which write
+	// nested lists as flat sibling lists, such as Google Docs; 0 is
+	// top-level. Parsers nest a list following one of a lower level
+	// in the last item of the latter, see parser.CompactNodes.
+	Level int
 }
 
 // Empty returns true if every item has empty content.
@@ -315,6 +434,14 @@ type CodeNode struct {
 	Term  bool
 	Lang  string
 	Value string
+	Title string // Optional file name of the snippet, e.g. "path/to/file.go"
+	// Added and Removed are 1-based numbers of lines highlighted
+	// as added or removed, e.g. in a diff.
+	Added, Removed []int
+	// Src refers to a file the snippet is included from, optionally
+	// followed by a region name, e.g. "path/to/main.go#setup".
+	// Value is replaced with the file content at export time.
+	Src string
 }
 
 // Empty returns true if cn.Value is zero, exluding space runes.
@@ -322,6 +449,40 @@ func (cn *CodeNode) Empty() bool {
 	return strings.TrimSpace(cn.Value) == ""
 }
 
+// NewTermNode creates a new glossary term node.
+func NewTermNode(term, def string) *TermNode {
+	return &TermNode{
+		node:       node{typ: NodeTerm},
+		Term:       term,
+		Definition: def,
+	}
+}
+
+// TermNode is a glossary term and its definition.
+type TermNode struct {
+	node
+	Term       string
+	Definition string
+}
+
+// Empty returns true if tn.Term is zero, excluding space runes.
+func (tn *TermNode) Empty() bool {
+	return strings.TrimSpace(tn.Term) == ""
+}
+
+// TermNodes returns all NodeTerm nodes of nodes in document order,
+// including those nested in other nodes, as traversed by Walk.
+func TermNodes(nodes []Node) []*TermNode {
+	var terms []*TermNode
+	Walk(nodes, func(n Node) bool {
+		if tn, ok := n.(*TermNode); ok {
+			terms = append(terms, tn)
+		}
+		return true
+	})
+	return terms
+}
+
 // NewHeaderNode creates a new HeaderNode with optional content nodes n.
 func NewHeaderNode(level int, n ...Node) *HeaderNode {
 	return &HeaderNode{
@@ -359,6 +520,7 @@ type URLNode struct {
 	URL     string
 	Name    string
 	Target  string
+	Rel     string
 	Content *ListNode
 }
 
@@ -367,6 +529,41 @@ func (un *URLNode) Empty() bool {
 	return un.Content.Empty()
 }
 
+// URLNodes returns all NodeURL nodes of nodes, recursively, including
+// links nested in the content of other links and of container nodes,
+// such as lists, imports, headers, buttons, infoboxes and grid cells.
+func URLNodes(nodes []Node) []*URLNode {
+	var urls []*URLNode
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *URLNode:
+			urls = append(urls, n)
+			urls = append(urls, URLNodes(n.Content.Nodes)...)
+		case *ListNode:
+			urls = append(urls, URLNodes(n.Nodes)...)
+		case *ImportNode:
+			urls = append(urls, URLNodes(n.Content.Nodes)...)
+		case *ItemsListNode:
+			for _, i := range n.Items {
+				urls = append(urls, URLNodes(i.Nodes)...)
+			}
+		case *HeaderNode:
+			urls = append(urls, URLNodes(n.Content.Nodes)...)
+		case *ButtonNode:
+			urls = append(urls, URLNodes(n.Content.Nodes)...)
+		case *InfoboxNode:
+			urls = append(urls, URLNodes(n.Content.Nodes)...)
+		case *GridNode:
+			for _, r := range n.Rows {
+				for _, c := range r {
+					urls = append(urls, URLNodes(c.Content.Nodes)...)
+				}
+			}
+		}
+	}
+	return urls
+}
+
 // NewImageNode creates a new ImageNode  with the give src.
 func NewImageNode(src string) *ImageNode {
 	return &ImageNode{
@@ -375,6 +572,15 @@ func NewImageNode(src string) *ImageNode {
 	}
 }
 
+// NewPlaceholderNode creates a new placeholder of an image which does not
+// exist yet, e.g. a screenshot, described by desc.
+func NewPlaceholderNode(desc string) *ImageNode {
+	return &ImageNode{
+		node:        node{typ: NodeImage},
+		Placeholder: desc,
+	}
+}
+
 // ImageNode represents a single image.
 type ImageNode struct {
 	node
@@ -382,11 +588,36 @@ type ImageNode struct {
 	Width float32
 	Alt   string
 	Title string
+	// Placeholder, if not empty, describes an image to be added later,
+	// in which case Src is empty.
+	Placeholder string
+	// Variants are alternate files of the image in other sizes or formats,
+	// such as those of an optimized export. Src is one of them, if any.
+	Variants []*ImageVariant
 }
 
-// Empty returns true if its Src is zero, excluding space runes.
+// ImageVariant is an alternate file of an image.
+type ImageVariant struct {
+	Src   string // Path or URL of the file
+	Type  string // MIME type, e.g. "image/webp"
+	Width int    // Width in pixels
+}
+
+// Empty returns true if its Src is zero, excluding space runes,
+// and the image is not a placeholder.
 func (in *ImageNode) Empty() bool {
-	return strings.TrimSpace(in.Src) == ""
+	return strings.TrimSpace(in.Src) == "" && in.Placeholder == ""
+}
+
+// PlaceholderNodes returns all placeholder images in nodes, recursively.
+func PlaceholderNodes(nodes []Node) []*ImageNode {
+	var imgs []*ImageNode
+	for _, n := range ImageNodes(nodes) {
+		if n.Placeholder != "" {
+			imgs = append(imgs, n)
+		}
+	}
+	return imgs
 }
 
 // ImageNodes extracts everything except NodeImage nodes, recursively.
@@ -461,6 +692,29 @@ type SurveyNode struct {
 	node
 	ID     string
 	Groups []*SurveyGroup
+	// Bank is a question bank file whose questions are added to Groups
+	// when the codelab is fetched.
+	Bank string
+	// Pick, if positive, is the number of Groups shown to a learner.
+	Pick int
+}
+
+// Picked returns sn.Pick groups selected deterministically by sn.ID,
+// in their original order. All groups are returned if Pick is not positive.
+func (sn *SurveyNode) Picked() []*SurveyGroup {
+	if sn.Pick <= 0 || sn.Pick >= len(sn.Groups) {
+		return sn.Groups
+	}
+	h := fnv.New64a()
+	h.Write([]byte(sn.ID))
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+	idx := r.Perm(len(sn.Groups))[:sn.Pick]
+	sort.Ints(idx)
+	gg := make([]*SurveyGroup, len(idx))
+	for i, k := range idx {
+		gg[i] = sn.Groups[k]
+	}
+	return gg
 }
 
 // SurveyGroup contains group name/question and possible answers.
@@ -528,7 +782,8 @@ func (yt *YouTubeNode) Empty() bool {
 	return yt.VideoID != ""
 }
 
-// iframe whitelist - set of domains allow to embed iframes in a codelab.
+// iframe whitelist - default set of domains allowed to embed iframes in a codelab.
+// Parsers can be configured with a different set, see parser.Options.
 var IframeWhitelist = []string{
 	"google.com",
 	"google.dev",
@@ -553,6 +808,19 @@ func NewIframeNode(url string) *IframeNode {
 type IframeNode struct {
 	node
 	URL string
+	// Width and Height are optional frame dimensions,
+	// either in pixels or a percentage, e.g. "600" or "100%".
+	Width, Height string
+	// Sandbox is an optional space-separated list of sandbox restrictions
+	// to lift, e.g. "allow-scripts allow-forms".
+	Sandbox string
+	// Sandboxed is whether a sandbox is requested, lifting the
+	// restrictions of Sandbox, if any. Frames are not sandboxed if false
+	// and Sandbox is empty.
+	Sandboxed bool
+	// Title is an optional accessible name of the frame,
+	// e.g. "Interactive demo".
+	Title string
 }
 
 // Empty returns true if iframe's URL field is empty.
@@ -0,0 +1,45 @@
+// Copyright 2019 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSurveyPicked(t *testing.T) {
+	var gg []*SurveyGroup
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		gg = append(gg, &SurveyGroup{Name: name})
+	}
+	n := NewSurveyNode("lab-1", gg...)
+	if v := n.Picked(); !reflect.DeepEqual(v, gg) {
+		t.Errorf("Picked() with no Pick = %v; want all groups", v)
+	}
+
+	n.Pick = 3
+	picked := n.Picked()
+	if len(picked) != 3 {
+		t.Fatalf("len(Picked()) = %d; want 3", len(picked))
+	}
+	if again := n.Picked(); !reflect.DeepEqual(again, picked) {
+		t.Errorf("Picked() = %v; want same as before %v", again, picked)
+	}
+	for i := 1; i < len(picked); i++ {
+		if picked[i-1].Name >= picked[i].Name {
+			t.Errorf("Picked() = %v; want original order", picked)
+		}
+	}
+}
@@ -13,6 +13,12 @@
 // limitations under the License.
 package util
 
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
 // ImgDirname is where a codelab images are stored,
 // relative to the codelab dir.
 const ImgDirname = "img"
@@ -30,3 +36,24 @@ func Unique(a []string) []string {
 	}
 	return res
 }
+
+// ReadLines reads a list of values stored in a local file, one per line.
+// Empty lines and lines starting with "#" are skipped.
+// The result is empty but not nil if the file lists no values,
+// which tells an empty list from one which is not set.
+func ReadLines(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lines := []string{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		v := strings.TrimSpace(s.Text())
+		if v != "" && !strings.HasPrefix(v, "#") {
+			lines = append(lines, v)
+		}
+	}
+	return lines, s.Err()
+}
@@ -20,6 +20,8 @@ goog.module('googlecodelabs.CodelabSurvey');
 const EventHandler = goog.require('goog.events.EventHandler');
 const HTML5LocalStorage =
     goog.require('goog.storage.mechanism.HTML5LocalStorage');
+const HTML5SessionStorage =
+    goog.require('goog.storage.mechanism.HTML5SessionStorage');
 const Templates = goog.require('googlecodelabs.CodelabSurvey.Templates');
 const dom = goog.require('goog.dom');
 const events = goog.require('goog.events');
@@ -39,6 +41,13 @@ const STORAGE_KEY_PREFIX = 'codelab-survey-';
 const SURVEY_ID_ATTR = 'survey-id';
 
 
+/**
+ * The number of questions picked at random for each session.
+ * @const {string}
+ */
+const SURVEY_PICK_ATTR = 'pick';
+
+
 /**
  * The upgraded id (to prevent FUOC).
  * @const {string}
@@ -205,9 +214,11 @@ class CodelabSurvey extends HTMLElement {
         });
         dom.removeNode(questionEls[index]);
       });
+      const pick = parseInt(this.getAttribute(SURVEY_PICK_ATTR), 10);
       const updatedDom = soy.renderAsElement(Templates.survey, {
         surveyName: this.surveyName_,
-        surveyQuestions: surveyQuestions
+        surveyQuestions: pick > 0 ?
+            this.pickQuestions_(surveyQuestions, pick) : surveyQuestions
       });
       this.appendChild(updatedDom);
     }
@@ -215,6 +226,38 @@ class CodelabSurvey extends HTMLElement {
     this.setAttribute(SURVEY_UPGRADED_ATTR, '');
   }
 
+  /**
+   * Picks n questions at random, keeping the selection for the session
+   * so that a reload shows the same questions.
+   * @param {!Array<!Object>} questions
+   * @param {number} n
+   * @return {!Array<!Object>}
+   * @private
+   */
+  pickQuestions_(questions, n) {
+    if (n >= questions.length) {
+      return questions;
+    }
+    const session = new HTML5SessionStorage();
+    const key = this.storageKey_ + '-pick';
+    let picked = [];
+    const stored = session.get(key);
+    if (stored) {
+      picked = /** @type {!Array<number>} */ (JSON.parse(stored));
+    }
+    if (picked.length != n ||
+        picked.some((i) => i < 0 || i >= questions.length)) {
+      const all = questions.map((q, i) => i);
+      for (let i = all.length - 1; i > 0; i--) {
+        const j = Math.floor(Math.random() * (i + 1));
+        [all[i], all[j]] = [all[j], all[i]];
+      }
+      picked = all.slice(0, n).sort((a, b) => a - b);
+      session.set(key, JSON.stringify(picked));
+    }
+    return picked.map((i) => questions[i]);
+  }
+
   /** @private */
   setAnsweredQuestions_() {
     const surveyData = this.storedData_[this.surveyName_];
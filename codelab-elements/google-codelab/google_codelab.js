@@ -85,6 +85,15 @@ const NO_ARROWS_ATTR = 'no-arrows';
 /** @const {string} */
 const DISAPPEAR_ATTR = 'disappear';
 
+/**
+ * Locks steps until all surveys of previous steps are answered.
+ * @const {string}
+ */
+const GATE_STEPS_ATTR = 'gate-steps';
+
+/** @const {string} */
+const DISABLED_ATTR = 'disabled';
+
 /** @const {number} Page transition time in seconds */
 const ANIMATION_DURATION = .5;
 
@@ -395,6 +404,74 @@ class Codelab extends HTMLElement {
     this.eventHandler_.listen(document.body, events.EventType.KEYDOWN, (e) => {
       this.handleKeyDown_(e);
     });
+
+    this.eventHandler_.listen(document.body, CODELAB_ACTION_EVENT, (e) => {
+      const detail = e.getBrowserEvent().detail;
+      if (detail && detail['category'] === 'survey') {
+        this.updateGate_();
+      }
+    });
+  }
+
+  /**
+   * Reports whether all surveys of a step have been answered.
+   * @param {!Element} step
+   * @return {boolean}
+   * @private
+   */
+  isStepPassed_(step) {
+    const questions = new Set();
+    const answered = new Set();
+    step.querySelectorAll('google-codelab-survey input').forEach((input) => {
+      questions.add(input.name);
+      if (input.checked) {
+        answered.add(input.name);
+      }
+    });
+    return questions.size === answered.size;
+  }
+
+  /**
+   * Returns the last step which can be selected when steps are gated.
+   * @return {number}
+   * @private
+   */
+  lastAllowedStep_() {
+    if (!this.hasAttribute(GATE_STEPS_ATTR)) {
+      return this.steps_.length - 1;
+    }
+    for (let i = 0; i < this.steps_.length - 1; i++) {
+      if (!this.isStepPassed_(this.steps_[i])) {
+        return i;
+      }
+    }
+    return this.steps_.length - 1;
+  }
+
+  /**
+   * Disables navigation beyond the current step if it is not passed yet.
+   * @private
+   */
+  updateGate_() {
+    const locked = this.lastAllowedStep_() <= this.currentSelectedStep_ &&
+        this.currentSelectedStep_ < this.steps_.length - 1;
+    if (this.nextStepBtn_) {
+      if (locked) {
+        this.nextStepBtn_.setAttribute(DISABLED_ATTR, '');
+      } else {
+        this.nextStepBtn_.removeAttribute(DISABLED_ATTR);
+      }
+    }
+    if (this.drawer_) {
+      const last = this.lastAllowedStep_();
+      this.drawer_.querySelectorAll('li').forEach((step, i) => {
+        if (i > last) {
+          step.setAttribute(DISABLED_ATTR, '');
+        } else {
+          step.removeAttribute(DISABLED_ATTR);
+        }
+      });
+    }
   }
 
   /**
@@ -616,7 +693,7 @@ class Codelab extends HTMLElement {
     }
 
     selected = Math.min(Math.max(0, parseInt(selected, 10)),
-                        this.steps_.length - 1);
+                        this.lastAllowedStep_());
 
     if (this.currentSelectedStep_ === selected || isNaN(selected)) {
       // Either the current step is already selected or an invalid option was provided
@@ -743,6 +820,7 @@ class Codelab extends HTMLElement {
       });
     }
 
+    this.updateGate_();
     this.updateTimeRemaining_();
     if (!this.hasAttribute(DONT_SET_HISTORY_ATTR)) {
       this.updateHistoryState(`#${selected}`, true);